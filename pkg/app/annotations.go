@@ -0,0 +1,119 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/deploy"
+)
+
+// annotateFiles adds the annotations to the metadata of every object of the rendered YAML files, keeping the
+// annotations set by the templates. The documents are written again in their key order, without their comments.
+func annotateFiles(renderedFiles map[string]string, annotations map[string]string) (map[string]string, error) {
+	if len(annotations) == 0 {
+		return renderedFiles, nil
+	}
+
+	annotated := make(map[string]string, len(renderedFiles))
+	for name, content := range renderedFiles {
+		ext := strings.ToLower(filepath.Ext(name))
+		if ext != ".yaml" && ext != ".yml" {
+			annotated[name] = content
+			continue
+		}
+		var docs []string
+		for i, doc := range deploy.SplitYAMLDocuments(content) {
+			annotatedDoc, err := annotateDocument(doc, annotations)
+			if err != nil {
+				return nil, fmt.Errorf("failed to annotate %s: document %d: %w", name, i+1, err)
+			}
+			docs = append(docs, annotatedDoc)
+		}
+		annotated[name] = strings.Join(docs, "---\n")
+	}
+	return annotated, nil
+}
+
+// annotateDocument adds the annotations missing from the metadata of the object of a YAML document
+func annotateDocument(doc string, annotations map[string]string) (string, error) {
+	var obj yaml.MapSlice
+	if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+		return "", err
+	}
+	// Documents holding only comments are kept as they are
+	if len(obj) == 0 {
+		if !strings.HasSuffix(doc, "\n") {
+			doc += "\n"
+		}
+		return doc, nil
+	}
+
+	metadata, err := mappingItem(obj, "metadata")
+	if err != nil {
+		return "", err
+	}
+	existing, err := mappingItem(metadata, "annotations")
+	if err != nil {
+		return "", fmt.Errorf("metadata.%w", err)
+	}
+	for _, key := range slices.Sorted(maps.Keys(annotations)) {
+		if !slices.ContainsFunc(existing, func(item yaml.MapItem) bool { return item.Key == key }) {
+			existing = append(existing, yaml.MapItem{Key: key, Value: annotations[key]})
+		}
+	}
+	metadata = setMappingItem(metadata, "annotations", existing)
+	obj = setMappingItem(obj, "metadata", metadata)
+
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// mappingItem returns the mapping of the key, empty when the key is missing or null
+func mappingItem(mapping yaml.MapSlice, key string) (yaml.MapSlice, error) {
+	for _, item := range mapping {
+		if item.Key != key || item.Value == nil {
+			continue
+		}
+		value, ok := item.Value.(yaml.MapSlice)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a mapping", key)
+		}
+		return value, nil
+	}
+	return yaml.MapSlice{}, nil
+}
+
+// setMappingItem sets the value of the key, appending it when the key is missing
+func setMappingItem(mapping yaml.MapSlice, key string, value interface{}) yaml.MapSlice {
+	for i, item := range mapping {
+		if item.Key == key {
+			mapping[i].Value = value
+			return mapping
+		}
+	}
+	return append(mapping, yaml.MapItem{Key: key, Value: value})
+}
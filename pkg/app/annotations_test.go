@@ -0,0 +1,94 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/deploy"
+	"github.com/nvidia/k8s-launch-kit/pkg/networkoperatorplugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+)
+
+func TestAnnotateFiles(t *testing.T) {
+	annotations := map[string]string{"example.com/team": "networking", "example.com/cost-center": "cc-42"}
+
+	t.Run("annotate every object keeping the existing annotations", func(t *testing.T) {
+		files, err := annotateFiles(map[string]string{
+			"10-objects.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: first\n  annotations:\n    example.com/team: compute\n    note: kept\ndata:\n  key: value\n" +
+				"---\n# only a comment\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: second\n",
+			"20-unnamed.yaml": "apiVersion: v1\nkind: ConfigMap\n",
+			"README.md":       "# Not YAML\n",
+		}, annotations)
+		require.NoError(t, err)
+
+		assert.Equal(t, "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: first\n  annotations:\n    example.com/team: compute\n    note: kept\n    example.com/cost-center: cc-42\ndata:\n  key: value\n"+
+			"---\n# only a comment\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: second\n  annotations:\n    example.com/cost-center: cc-42\n    example.com/team: networking\n",
+			files["10-objects.yaml"])
+		assert.Equal(t, "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  annotations:\n    example.com/cost-center: cc-42\n    example.com/team: networking\n", files["20-unnamed.yaml"])
+		assert.Equal(t, "# Not YAML\n", files["README.md"])
+	})
+
+	t.Run("keep the files without annotations", func(t *testing.T) {
+		rendered := map[string]string{"10-config.yaml": "kind: ConfigMap # comment\n"}
+		files, err := annotateFiles(rendered, nil)
+		require.NoError(t, err)
+		assert.Equal(t, rendered, files)
+	})
+
+	t.Run("fail on metadata that is not a mapping", func(t *testing.T) {
+		_, err := annotateFiles(map[string]string{"10-config.yaml": "kind: ConfigMap\nmetadata: [a]\n"}, annotations)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to annotate 10-config.yaml: document 1: metadata is not a mapping")
+	})
+}
+
+func TestGenerateWithAnnotations(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n" +
+			"---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: pinned\n  annotations:\n    example.com/team: compute\n",
+	})
+
+	userConfig := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(userConfig, []byte(testClusterConfig+"annotations:\n  example.com/cost-center: cc-42\n  example.com/team: storage\n"), 0644))
+
+	outputDir := t.TempDir()
+	require.NoError(t, newTestLauncher(t, options.Options{
+		UserConfig:          userConfig,
+		Fabric:              "ethernet",
+		DeploymentType:      "sriov",
+		ProfilesDirs:        []string{profilesDir},
+		SaveDeploymentFiles: outputDir,
+		Annotations:         []string{"example.com/team=networking"},
+	}).executeWorkflow())
+
+	content, err := os.ReadFile(filepath.Join(outputDir, networkoperatorplugin.PluginName, "30-network.yaml"))
+	require.NoError(t, err)
+	objects, err := deploy.ParseManifests(content)
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+
+	assert.Equal(t, map[string]string{"example.com/cost-center": "cc-42", "example.com/team": "networking"}, objects[0].GetAnnotations())
+	assert.Equal(t, map[string]string{"example.com/cost-center": "cc-42", "example.com/team": "compute"}, objects[1].GetAnnotations(),
+		"annotations set by the templates are kept")
+}
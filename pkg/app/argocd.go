@@ -0,0 +1,120 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+)
+
+const (
+	// argoCDNamespace is the namespace of the generated Argo CD Applications
+	argoCDNamespace = "argocd"
+	// argoCDDestination is the API server the Applications deploy to, the cluster Argo CD runs in
+	argoCDDestination = "https://kubernetes.default.svc"
+	// DefaultArgoCDRevision is the revision of the repository the Applications track when none is given
+	DefaultArgoCDRevision = "HEAD"
+)
+
+// argoCDApplication is the subset of the Argo CD Application resource l8k generates
+type argoCDApplication struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"metadata"`
+	Spec struct {
+		Project string `json:"project"`
+		Source  struct {
+			RepoURL        string `json:"repoURL"`
+			TargetRevision string `json:"targetRevision"`
+			Path           string `json:"path"`
+		} `json:"source"`
+		Destination struct {
+			Server string `json:"server"`
+		} `json:"destination"`
+		SyncPolicy struct {
+			SyncOptions []string `json:"syncOptions"`
+		} `json:"syncPolicy"`
+	} `json:"spec"`
+}
+
+// argoCDApplicationName returns the name of the Application of the profile
+func argoCDApplicationName(profile *profiles.Profile) string {
+	return "l8k-" + filepath.Base(profile.Dir)
+}
+
+// argoCDSourcePath returns the path in the repository of the files of the profile saved to outputDir.
+// ArgoCDPath is the repository path of the --save-deployment-files directory, with the same placeholders.
+func (l *Launcher) argoCDSourcePath(profile *profiles.Profile, outputDir string) (string, error) {
+	baseDir := expandOutputDir(l.options.SaveDeploymentFiles, filepath.Base(profile.Dir), l.runStarted)
+	rel, err := filepath.Rel(baseDir, outputDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to find the repository path of %s: %w", outputDir, err)
+	}
+	repoPath := expandOutputDir(l.options.ArgoCDPath, filepath.Base(profile.Dir), l.runStarted)
+	return path.Join(repoPath, filepath.ToSlash(rel)), nil
+}
+
+// argoCDApplicationManifest returns the Application syncing the files of the profile saved to outputDir
+func (l *Launcher) argoCDApplicationManifest(profile *profiles.Profile, outputDir string) ([]byte, error) {
+	sourcePath, err := l.argoCDSourcePath(profile, outputDir)
+	if err != nil {
+		return nil, err
+	}
+
+	app := argoCDApplication{APIVersion: "argoproj.io/v1alpha1", Kind: "Application"}
+	app.Metadata.Name = argoCDApplicationName(profile)
+	app.Metadata.Namespace = argoCDNamespace
+	app.Spec.Project = "default"
+	app.Spec.Source.RepoURL = l.options.ArgoCDRepoURL
+	app.Spec.Source.TargetRevision = l.options.ArgoCDRevision
+	if app.Spec.Source.TargetRevision == "" {
+		app.Spec.Source.TargetRevision = DefaultArgoCDRevision
+	}
+	app.Spec.Source.Path = sourcePath
+	app.Spec.Destination.Server = argoCDDestination
+	// l8k applies the objects with server-side apply too
+	app.Spec.SyncPolicy.SyncOptions = []string{"ServerSideApply=true"}
+
+	return yaml.Marshal(app)
+}
+
+// saveArgoCDApplication writes the Application of the profile next to the directories of the saved files,
+// outside the source path so that Argo CD doesn't sync the Application itself
+func (l *Launcher) saveArgoCDApplication(profile *profiles.Profile, outputDir string) error {
+	manifest, err := l.argoCDApplicationManifest(profile, outputDir)
+	if err != nil {
+		return err
+	}
+
+	baseDir := expandOutputDir(l.options.SaveDeploymentFiles, filepath.Base(profile.Dir), l.runStarted)
+	applicationPath := filepath.Join(baseDir, argoCDApplicationName(profile)+"-application.yaml")
+	if err := l.files.WriteFile(applicationPath, manifest, 0644); err != nil {
+		return fmt.Errorf("failed to write Argo CD Application %s: %w", applicationPath, err)
+	}
+
+	l.ui.Success("Saved Argo CD Application to: %s", applicationPath)
+	l.logger.Info("Saved Argo CD Application", "file", applicationPath, "repoURL", l.options.ArgoCDRepoURL)
+	return nil
+}
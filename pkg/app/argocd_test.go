@@ -0,0 +1,92 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/networkoperatorplugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+)
+
+func TestGenerateArgoCDApplication(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n",
+	})
+	writeTestProfile(t, profilesDir, "host-device-rdma", hostdevProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Hostdev.NetworkName}}\n",
+	})
+
+	generate := func(t *testing.T, opts options.Options) {
+		opts.Fabric = "ethernet"
+		opts.ProfilesDirs = []string{profilesDir}
+		opts.OutputFormat = OutputFormatArgoCD
+		opts.ArgoCDRepoURL = "https://git.example.com/infra.git"
+		launcher := newTestLauncher(t, opts)
+		require.NoError(t, launcher.executeWorkflow())
+	}
+	readApplication := func(t *testing.T, path string) argoCDApplication {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		app := argoCDApplication{}
+		require.NoError(t, yaml.Unmarshal(data, &app))
+		return app
+	}
+
+	t.Run("reference the saved files in the repository", func(t *testing.T) {
+		outputDir := t.TempDir()
+		generate(t, options.Options{DeploymentType: "sriov", SaveDeploymentFiles: outputDir, ArgoCDPath: "clusters/prod"})
+
+		assert.FileExists(t, filepath.Join(outputDir, networkoperatorplugin.PluginName, "30-network.yaml"))
+		app := readApplication(t, filepath.Join(outputDir, "l8k-sriov-ethernet-rdma-application.yaml"))
+		assert.Equal(t, "Application", app.Kind)
+		assert.Equal(t, "argoproj.io/v1alpha1", app.APIVersion)
+		assert.Equal(t, "l8k-sriov-ethernet-rdma", app.Metadata.Name)
+		assert.Equal(t, "argocd", app.Metadata.Namespace)
+		assert.Equal(t, "https://git.example.com/infra.git", app.Spec.Source.RepoURL)
+		assert.Equal(t, "HEAD", app.Spec.Source.TargetRevision)
+		assert.Equal(t, "clusters/prod/network-operator", app.Spec.Source.Path)
+		assert.Equal(t, "https://kubernetes.default.svc", app.Spec.Destination.Server)
+		assert.Equal(t, []string{"ServerSideApply=true"}, app.Spec.SyncPolicy.SyncOptions)
+	})
+
+	t.Run("one application per profile", func(t *testing.T) {
+		outputDir := t.TempDir()
+		generate(t, options.Options{DeploymentType: "sriov,host_device", SaveDeploymentFiles: outputDir, ArgoCDPath: "clusters/prod", ArgoCDRevision: "v1.2.0"})
+
+		sriov := readApplication(t, filepath.Join(outputDir, "l8k-sriov-ethernet-rdma-application.yaml"))
+		assert.Equal(t, "clusters/prod/network-operator/sriov-ethernet-rdma", sriov.Spec.Source.Path)
+		assert.Equal(t, "v1.2.0", sriov.Spec.Source.TargetRevision)
+		hostdev := readApplication(t, filepath.Join(outputDir, "l8k-host-device-rdma-application.yaml"))
+		assert.Equal(t, "clusters/prod/network-operator/host-device-rdma", hostdev.Spec.Source.Path)
+	})
+
+	t.Run("expand the placeholders of the repository path", func(t *testing.T) {
+		outputDir := t.TempDir()
+		generate(t, options.Options{DeploymentType: "sriov", SaveDeploymentFiles: filepath.Join(outputDir, ProfilePlaceholder), ArgoCDPath: "clusters/" + ProfilePlaceholder})
+
+		app := readApplication(t, filepath.Join(outputDir, "sriov-ethernet-rdma", "l8k-sriov-ethernet-rdma-application.yaml"))
+		assert.Equal(t, "clusters/sriov-ethernet-rdma/network-operator", app.Spec.Source.Path)
+	})
+}
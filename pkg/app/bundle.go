@@ -0,0 +1,258 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"gopkg.in/yaml.v2"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	"github.com/nvidia/k8s-launch-kit/pkg/secrets"
+)
+
+// redactedValue replaces the secrets in the support bundle
+const redactedValue = config.RedactedValue
+
+// supportBundle collects the artifacts of a run for the SupportBundle tarball
+type supportBundle struct {
+	mu sync.Mutex
+
+	// discovery and config are redacted copies
+	discovery *config.LaunchKubernetesConfig
+	config    *config.LaunchKubernetesConfig
+	profiles  []profiles.Profile
+	// files holds the generated files by profile directory name
+	files map[string]map[string]string
+	logs  bytes.Buffer
+}
+
+// bundleProfile describes a selected profile in profiles.yaml
+type bundleProfile struct {
+	Name         string                       `yaml:"name"`
+	Plugin       string                       `yaml:"plugin"`
+	Dir          string                       `yaml:"dir"`
+	Requirements profiles.ProfileRequirements `yaml:"requirements"`
+	Templates    []string                     `yaml:"templates"`
+}
+
+// bundleRun describes the run in run.yaml
+type bundleRun struct {
+	Version  string          `yaml:"version"`
+	Started  string          `yaml:"started"`
+	Finished string          `yaml:"finished"`
+	Result   string          `yaml:"result"`
+	Options  options.Options `yaml:"options"`
+}
+
+func newSupportBundle() *supportBundle {
+	return &supportBundle{files: map[string]map[string]string{}}
+}
+
+// logSink returns a sink writing to the logs of the bundle
+func (b *supportBundle) logSink() logr.LogSink {
+	return funcr.New(func(prefix, args string) {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if prefix != "" {
+			fmt.Fprintf(&b.logs, "%s: %s\n", prefix, args)
+		} else {
+			fmt.Fprintln(&b.logs, args)
+		}
+	}, funcr.Options{LogTimestamp: true, Verbosity: 1}).GetSink()
+}
+
+// recordDiscovery records the discovered config
+func (b *supportBundle) recordDiscovery(cfg *config.LaunchKubernetesConfig) {
+	if b == nil {
+		return
+	}
+	redacted := config.Redact(cfg)
+	b.discovery = &redacted
+}
+
+// recordConfig records the config the profiles are selected with
+func (b *supportBundle) recordConfig(cfg *config.LaunchKubernetesConfig) {
+	if b == nil {
+		return
+	}
+	redacted := config.Redact(cfg)
+	b.config = &redacted
+}
+
+// recordProfiles records the selected profiles
+func (b *supportBundle) recordProfiles(selected []profiles.Profile) {
+	if b == nil {
+		return
+	}
+	b.profiles = selected
+}
+
+// recordFiles records the files generated for the profile
+func (b *supportBundle) recordFiles(profile *profiles.Profile, files map[string]string) {
+	if b == nil {
+		return
+	}
+	b.files[filepath.Base(profile.Dir)] = files
+}
+
+// entries returns the content of the bundle files by name, with the secrets redacted
+func (b *supportBundle) entries(opts options.Options, started time.Time, runErr error) (map[string][]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entries := map[string][]byte{}
+	add := func(name string, value interface{}) error {
+		data, err := yaml.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %w", name, err)
+		}
+		entries[name] = data
+		return nil
+	}
+
+	run := bundleRun{
+		Version:  opts.Version,
+		Started:  started.Format(time.RFC3339),
+		Finished: time.Now().Format(time.RFC3339),
+		Result:   "succeeded",
+		Options:  opts,
+	}
+	if runErr != nil {
+		run.Result = runErr.Error()
+	}
+	if err := add("run.yaml", run); err != nil {
+		return nil, err
+	}
+
+	if b.discovery != nil {
+		if err := add("discovery.yaml", b.discovery); err != nil {
+			return nil, err
+		}
+	}
+	if b.config != nil {
+		if err := add("config.yaml", b.config); err != nil {
+			return nil, err
+		}
+	}
+	if b.profiles != nil {
+		selected := make([]bundleProfile, 0, len(b.profiles))
+		for _, profile := range b.profiles {
+			selected = append(selected, bundleProfile{
+				Name:         profile.Name,
+				Plugin:       profile.Plugin,
+				Dir:          profile.Dir,
+				Requirements: profile.ProfileRequirements,
+				Templates:    profile.Templates,
+			})
+		}
+		if err := add("profiles.yaml", selected); err != nil {
+			return nil, err
+		}
+	}
+	for profile, files := range b.files {
+		for name, content := range files {
+			entries[path.Join("files", profile, name)] = []byte(content)
+		}
+	}
+	entries["l8k.log"] = bytes.Clone(b.logs.Bytes())
+
+	secretValues := redactedSecrets(opts)
+	for name, data := range entries {
+		entries[name] = redact(data, secretValues)
+	}
+	return entries, nil
+}
+
+// redactedSecrets returns the secret values to redact from the output: the LLM API key and the secrets read from
+// the environment
+func redactedSecrets(opts options.Options) []string {
+	var values []string
+	if opts.LLMApiKey != "" {
+		values = append(values, opts.LLMApiKey)
+	}
+	for _, env := range os.Environ() {
+		name, value, _ := strings.Cut(env, "=")
+		if strings.HasPrefix(name, secrets.EnvPrefix) && value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// redact replaces every secret value in data
+func redact(data []byte, secretValues []string) []byte {
+	for _, value := range secretValues {
+		data = bytes.ReplaceAll(data, []byte(value), []byte(redactedValue))
+	}
+	return data
+}
+
+// writeSupportBundle writes the artifacts of the run to the SupportBundle tarball
+func (l *Launcher) writeSupportBundle(started time.Time, runErr error) error {
+	entries, err := l.bundle.entries(l.options, started, runErr)
+	if err != nil {
+		return fmt.Errorf("failed to create support bundle: %w", err)
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, name := range slices.Sorted(maps.Keys(entries)) {
+		header := &tar.Header{Name: name, Mode: 0644, Size: int64(len(entries[name])), ModTime: time.Now()}
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to create support bundle: %w", err)
+		}
+		if _, err := tw.Write(entries[name]); err != nil {
+			return fmt.Errorf("failed to create support bundle: %w", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to create support bundle: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to create support bundle: %w", err)
+	}
+
+	if dir := filepath.Dir(l.options.SupportBundle); dir != "." {
+		if err := l.files.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create support bundle directory %s: %w", dir, err)
+		}
+	}
+	if err := l.files.WriteFile(l.options.SupportBundle, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write support bundle %s: %w", l.options.SupportBundle, err)
+	}
+
+	l.ui.Success("Support bundle saved: %s", l.options.SupportBundle)
+	l.logger.Info("Support bundle saved", "path", l.options.SupportBundle, "entries", len(entries))
+	return nil
+}
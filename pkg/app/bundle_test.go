@@ -0,0 +1,160 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	applog "github.com/nvidia/k8s-launch-kit/pkg/log"
+	"github.com/nvidia/k8s-launch-kit/pkg/networkoperatorplugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+)
+
+// readBundle returns the content of the entries of a support bundle by name
+func readBundle(t *testing.T, path string) map[string]string {
+	t.Helper()
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+
+	entries := map[string]string{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		data, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		entries[header.Name] = string(data)
+	}
+	return entries
+}
+
+func TestSupportBundle(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n",
+	})
+
+	// The bundle receives the logs through the global logger
+	applog.InitLog()
+
+	run := func(t *testing.T, opts options.Options) (map[string]string, error) {
+		opts.EnabledPlugins = []string{networkoperatorplugin.PluginName}
+		opts.SupportBundle = filepath.Join(t.TempDir(), "support", "bundle.tar.gz")
+		opts.ProfilesDirs = []string{profilesDir}
+		opts.LLMApiKey = "sk-test-api-key"
+		t.Setenv("L8K_REGISTRY_GHCR_IO_PASSWORD", "registry-password")
+
+		launcher := newTestLauncher(t, opts)
+		launcher.logger = log.Log
+		launcher.kubeClient = newAllowingClient(&[]string{})
+		runErr := launcher.Run()
+		return readBundle(t, opts.SupportBundle), runErr
+	}
+
+	t.Run("bundle the artifacts of the run", func(t *testing.T) {
+		entries, err := run(t, options.Options{
+			Fabric:              "ethernet",
+			DeploymentType:      "sriov",
+			SaveDeploymentFiles: t.TempDir(),
+		})
+		require.NoError(t, err)
+
+		names := make([]string, 0, len(entries))
+		for name := range entries {
+			names = append(names, name)
+		}
+		assert.ElementsMatch(t, []string{"run.yaml", "config.yaml", "profiles.yaml", "files/sriov-ethernet-rdma/30-network.yaml", "l8k.log"}, names)
+
+		assert.Contains(t, entries["run.yaml"], "result: succeeded")
+		assert.Contains(t, entries["config.yaml"], "networkName: sriov_network")
+		assert.Contains(t, entries["profiles.yaml"], "name: SR-IOV")
+		assert.Contains(t, entries["files/sriov-ethernet-rdma/30-network.yaml"], "name: sriov_network")
+		assert.Contains(t, entries["l8k.log"], "Generating deployment files")
+	})
+
+	t.Run("bundle the logs of the deploy package", func(t *testing.T) {
+		entries, err := run(t, options.Options{
+			Fabric:         "ethernet",
+			DeploymentType: "sriov",
+			Deploy:         true,
+		})
+		require.NoError(t, err)
+		assert.Contains(t, entries["l8k.log"], "Applying manifests")
+	})
+
+	t.Run("redact the secrets", func(t *testing.T) {
+		entries, err := run(t, options.Options{
+			Fabric:              "ethernet",
+			DeploymentType:      "sriov",
+			SaveDeploymentFiles: t.TempDir(),
+		})
+		require.NoError(t, err)
+
+		for name, content := range entries {
+			assert.NotContains(t, content, "sk-test-api-key", name)
+			assert.NotContains(t, content, "registry-password", name)
+		}
+		assert.Contains(t, entries["run.yaml"], "llmapikey: <redacted>")
+	})
+
+	t.Run("redact the sensitive config fields", func(t *testing.T) {
+		userConfig := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(userConfig, []byte(testClusterConfig+"  workerNodes:\n  - worker-1\n"), 0644))
+		entries, err := run(t, options.Options{
+			UserConfig:          userConfig,
+			Fabric:              "ethernet",
+			DeploymentType:      "sriov",
+			SaveDeploymentFiles: t.TempDir(),
+		})
+		require.NoError(t, err)
+
+		for name, content := range entries {
+			assert.NotContains(t, content, "worker-1", name)
+		}
+		assert.Contains(t, entries["config.yaml"], "- <redacted>")
+		assert.Contains(t, entries["config.yaml"], `pciAddress: "0000:08:00.0"`)
+	})
+
+	t.Run("bundle a failed run", func(t *testing.T) {
+		entries, err := run(t, options.Options{
+			Fabric:              "infiniband",
+			DeploymentType:      "host_device",
+			SaveDeploymentFiles: t.TempDir(),
+		})
+		require.Error(t, err)
+
+		assert.Contains(t, entries["run.yaml"], "no applicable profile found")
+		assert.Contains(t, entries, "config.yaml")
+		assert.NotContains(t, entries, "profiles.yaml")
+	})
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/deploy"
+)
+
+// canonicalizeFiles rewrites every document of the rendered YAML files with sorted keys and a consistent indentation,
+// so that semantically equal renderings are byte-identical. Comments and empty documents are dropped, other files are
+// kept as rendered.
+func canonicalizeFiles(renderedFiles map[string]string) (map[string]string, error) {
+	canonical := make(map[string]string, len(renderedFiles))
+	for name, content := range renderedFiles {
+		ext := strings.ToLower(filepath.Ext(name))
+		if ext != ".yaml" && ext != ".yml" {
+			canonical[name] = content
+			continue
+		}
+		normalized, err := canonicalYAML(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to canonicalize %s: %w", name, err)
+		}
+		canonical[name] = normalized
+	}
+	return canonical, nil
+}
+
+// canonicalYAML round-trips the documents of a YAML stream through JSON, which sorts the keys of the mappings
+func canonicalYAML(content string) (string, error) {
+	var docs []string
+	for i, doc := range deploy.SplitYAMLDocuments(content) {
+		data, err := yaml.YAMLToJSON([]byte(doc))
+		if err != nil {
+			return "", fmt.Errorf("document %d: %w", i+1, err)
+		}
+		if string(data) == "null" {
+			continue
+		}
+		data, err = yaml.JSONToYAML(data)
+		if err != nil {
+			return "", fmt.Errorf("document %d: %w", i+1, err)
+		}
+		docs = append(docs, string(data))
+	}
+	return strings.Join(docs, "---\n"), nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/networkoperatorplugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+)
+
+func TestCanonicalizeFiles(t *testing.T) {
+	t.Run("semantically equal renderings are byte-identical", func(t *testing.T) {
+		first, err := canonicalizeFiles(map[string]string{
+			"10-config.yaml": "kind: ConfigMap\napiVersion: v1\nmetadata:\n    name: config\n    namespace: nvidia-network-operator\ndata:\n    mtu: \"9000\"\n    mode: 'rdma'\n",
+		})
+		require.NoError(t, err)
+		second, err := canonicalizeFiles(map[string]string{
+			"10-config.yaml": "# Config of the network\napiVersion: v1\ndata: {mode: rdma, mtu: \"9000\"}\nkind: ConfigMap\nmetadata:\n  namespace: nvidia-network-operator\n  name: config\n",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, first, second)
+		assert.Equal(t, "apiVersion: v1\ndata:\n  mode: rdma\n  mtu: \"9000\"\nkind: ConfigMap\nmetadata:\n  name: config\n  namespace: nvidia-network-operator\n", first["10-config.yaml"])
+	})
+
+	t.Run("keep the documents and drop the empty ones", func(t *testing.T) {
+		files, err := canonicalizeFiles(map[string]string{
+			"20-objects.yml": "kind: A\napiVersion: v1\n---\n# only a comment\n---\nkind: B\napiVersion: v1\n",
+			"README.md":      "# Not YAML\n",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "apiVersion: v1\nkind: A\n---\napiVersion: v1\nkind: B\n", files["20-objects.yml"])
+		assert.Equal(t, "# Not YAML\n", files["README.md"])
+	})
+
+	t.Run("fail on invalid YAML", func(t *testing.T) {
+		_, err := canonicalizeFiles(map[string]string{"30-broken.yaml": "kind: A\n---\nkind: [B\n"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to canonicalize 30-broken.yaml: document 2")
+	})
+}
+
+func TestGenerateCanonicalOutput(t *testing.T) {
+	generate := func(t *testing.T, canonical bool, template string) string {
+		profilesDir := t.TempDir()
+		writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+			"30-network.yaml": template,
+		})
+		outputDir := t.TempDir()
+		require.NoError(t, newTestLauncher(t, options.Options{
+			DeploymentType:      "sriov",
+			Fabric:              "ethernet",
+			SaveDeploymentFiles: outputDir,
+			ProfilesDirs:        []string{profilesDir},
+			CanonicalOutput:     canonical,
+		}).executeWorkflow())
+
+		network, err := os.ReadFile(filepath.Join(outputDir, networkoperatorplugin.PluginName, "30-network.yaml"))
+		require.NoError(t, err)
+		return string(network)
+	}
+
+	sorted := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n"
+	unsorted := "metadata:\n    name: \"{{.Sriov.NetworkName}}\"\nkind: ConfigMap\napiVersion: v1\n"
+
+	t.Run("write the canonical documents", func(t *testing.T) {
+		assert.Equal(t, generate(t, true, sorted), generate(t, true, unsorted))
+		assert.Equal(t, "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: sriov_network\n", generate(t, true, unsorted))
+	})
+
+	t.Run("write the raw documents by default", func(t *testing.T) {
+		assert.Equal(t, "metadata:\n    name: \"sriov_network\"\nkind: ConfigMap\napiVersion: v1\n", generate(t, false, unsorted))
+	})
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+)
+
+// inputsIndex records the hash of the inputs the files of an output directory were generated from, so that
+// --changed can skip the profiles whose inputs are unchanged
+const inputsIndex = ".l8k-inputs"
+
+// inputsHash returns the sha256 of everything the files of the profile are rendered from: the config,
+// the prompt, the profile manifest and templates, and the options changing the output
+func (l *Launcher) inputsHash(profile *profiles.Profile, cfg *config.LaunchKubernetesConfig) (string, error) {
+	hash := sha256.New()
+	write := func(name string, data []byte) {
+		fmt.Fprintf(hash, "%s %d\n", name, len(data))
+		hash.Write(data)
+	}
+
+	cfgHash, err := configHash(cfg)
+	if err != nil {
+		return "", err
+	}
+	write("version", []byte(l.options.Version))
+	write("environment", []byte(l.options.Environment))
+	write("provenance", []byte(strconv.FormatBool(l.options.ProvenanceHeader)))
+	write("canonical", []byte(strconv.FormatBool(l.options.CanonicalOutput)))
+	write("config", []byte(cfgHash))
+
+	// Profile files are named relative to the profile, pulled profiles land in a new directory every run
+	inputs := map[string]string{}
+	for _, path := range append([]string{filepath.Join(profile.Dir, "profile.yaml")}, profile.Templates...) {
+		name, err := filepath.Rel(profile.Dir, path)
+		if err != nil {
+			name = path
+		}
+		inputs[path] = name
+	}
+	if l.options.Prompt != "" {
+		inputs[l.options.Prompt] = "prompt"
+	}
+	if l.options.RunSpec != "" {
+		inputs[l.options.RunSpec] = "runSpec"
+	}
+	for _, path := range sortedFileNames(inputs) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to hash input %s: %w", path, err)
+		}
+		write(inputs[path], data)
+	}
+
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// readInputsHash returns the inputs hash recorded in the output directory, empty if there is none
+func readInputsHash(fw FileWriter, outputDir string) (string, error) {
+	data, err := fw.ReadFile(filepath.Join(outputDir, inputsIndex))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read inputs index: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// writeInputsHash records the inputs hash of the files saved to the output directory
+func writeInputsHash(fw FileWriter, outputDir, hash string) error {
+	if err := fw.WriteFile(filepath.Join(outputDir, inputsIndex), []byte(hash+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write inputs index: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+)
+
+// What the deploy phase does when the cluster deployed to is not the one the config was discovered from
+const (
+	// ClusterMatchWarn reports the mismatch and deploys
+	ClusterMatchWarn = "warn"
+	// ClusterMatchFail stops the deployment on a mismatch, or when the cluster cannot be identified
+	ClusterMatchFail = "fail"
+	// ClusterMatchIgnore deploys without comparing the clusters
+	ClusterMatchIgnore = "ignore"
+)
+
+// ClusterMatchPolicies lists the supported cluster match policies
+var ClusterMatchPolicies = []string{ClusterMatchWarn, ClusterMatchFail, ClusterMatchIgnore}
+
+// recordClusterID stores the identity of the discovered cluster in the config. A cluster that cannot be identified is
+// left without one, and the deploy phase cannot check it.
+func (l *Launcher) recordClusterID(ctx context.Context, c client.Client, cfg *config.LaunchKubernetesConfig) {
+	if c == nil {
+		return
+	}
+	clusterID, err := kubeclient.ClusterID(ctx, c)
+	if err != nil {
+		l.logger.Info("Cannot identify the discovered cluster", "error", err.Error())
+		return
+	}
+	cfg.ClusterConfig.ClusterID = clusterID
+}
+
+// checkClusterMatch compares the cluster of c with the cluster the config was discovered from, following ClusterMatch.
+// Configs without a cluster identity, written by hand or by an older release, are not checked.
+func (l *Launcher) checkClusterMatch(ctx context.Context, c client.Client) error {
+	if l.options.ClusterMatch == ClusterMatchIgnore || l.configClusterID == "" {
+		return nil
+	}
+
+	clusterID, err := kubeclient.ClusterID(ctx, c)
+	if err != nil {
+		err = fmt.Errorf("cannot identify the cluster to deploy to: %w", err)
+		if l.options.ClusterMatch == ClusterMatchFail {
+			l.ui.Error("Cluster check failed: %v", err)
+			return err
+		}
+		l.ui.Warning("Cluster check skipped: %v", err)
+		l.logger.Info("Cluster check skipped", "error", err.Error())
+		return nil
+	}
+	if clusterID == l.configClusterID {
+		l.logger.Info("Cluster matches the config", "clusterID", clusterID)
+		return nil
+	}
+
+	err = fmt.Errorf("the cluster to deploy to (%s) is not the cluster the config was discovered from (%s)", clusterID, l.configClusterID)
+	if l.options.ClusterMatch == ClusterMatchFail {
+		l.ui.Error("Cluster mismatch: %v", err)
+		return err
+	}
+	l.ui.Warning("Cluster mismatch: %v, its capabilities may differ", err)
+	l.logger.Info("Cluster does not match the config", "clusterID", clusterID, "configClusterID", l.configClusterID)
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+	"github.com/nvidia/k8s-launch-kit/pkg/ui"
+)
+
+func TestDeployClusterMatch(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n  namespace: default\n",
+	})
+
+	// deployTo deploys the config discovered from configClusterID to the cluster identified by clusterID
+	deployTo := func(t *testing.T, policy, configClusterID, clusterID string) ([]string, *ui.RecordingOutput, error) {
+		userConfig := filepath.Join(t.TempDir(), "config.yaml")
+		content := testClusterConfig
+		if configClusterID != "" {
+			content += "  clusterID: " + configClusterID + "\n"
+		}
+		require.NoError(t, os.WriteFile(userConfig, []byte(content), 0644))
+
+		applied := []string{}
+		c := newAllowingClient(&applied)
+		if clusterID != "" {
+			require.NoError(t, c.Create(context.Background(), &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "kube-system", UID: types.UID(clusterID)},
+			}))
+		}
+
+		output := ui.NewRecording()
+		launcher := newTestLauncher(t, options.Options{
+			UserConfig:          userConfig,
+			Fabric:              "ethernet",
+			DeploymentType:      "sriov",
+			ProfilesDirs:        []string{profilesDir},
+			SaveDeploymentFiles: t.TempDir(),
+			Deploy:              true,
+			ClusterMatch:        policy,
+		})
+		launcher.ui = output
+		launcher.kubeClient = c
+		return applied, output, launcher.executeWorkflow()
+	}
+
+	t.Run("deploy to the cluster the config was discovered from", func(t *testing.T) {
+		applied, output, err := deployTo(t, ClusterMatchFail, "cluster-a", "cluster-a")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap/sriov_network"}, applied)
+		assert.Empty(t, output.Warnings)
+	})
+
+	t.Run("warn about another cluster", func(t *testing.T) {
+		applied, output, err := deployTo(t, ClusterMatchWarn, "cluster-a", "cluster-b")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap/sriov_network"}, applied)
+		assert.Contains(t, output.Warnings, "Cluster mismatch: the cluster to deploy to (cluster-b) is not the cluster the config was discovered from (cluster-a), its capabilities may differ")
+	})
+
+	t.Run("fail on another cluster", func(t *testing.T) {
+		applied, _, err := deployTo(t, ClusterMatchFail, "cluster-a", "cluster-b")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "the cluster to deploy to (cluster-b) is not the cluster the config was discovered from (cluster-a)")
+		assert.Empty(t, applied)
+	})
+
+	t.Run("fail when the cluster cannot be identified", func(t *testing.T) {
+		applied, _, err := deployTo(t, ClusterMatchFail, "cluster-a", "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot identify the cluster to deploy to")
+		assert.Empty(t, applied)
+	})
+
+	t.Run("ignore another cluster", func(t *testing.T) {
+		applied, output, err := deployTo(t, ClusterMatchIgnore, "cluster-a", "cluster-b")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap/sriov_network"}, applied)
+		assert.Empty(t, output.Warnings)
+	})
+
+	t.Run("skip configs without a cluster identity", func(t *testing.T) {
+		applied, output, err := deployTo(t, ClusterMatchFail, "", "cluster-b")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap/sriov_network"}, applied)
+		assert.Empty(t, output.Warnings)
+	})
+}
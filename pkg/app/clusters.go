@@ -0,0 +1,144 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/deploy"
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+)
+
+// deployTarget is a cluster of --kubeconfigs the deploy phase applies the rendered files to
+type deployTarget struct {
+	// Name identifies the cluster in the output, the path of its kubeconfig
+	Name   string
+	Client client.Client
+	// Err is the failure to connect to the cluster, which is reported when deploying to it
+	Err error
+}
+
+// newDeployTargets creates a client for every kubeconfig. A cluster that can't be reached doesn't stop the run,
+// its error is kept in the target instead.
+func newDeployTargets(kubeconfigs []string, validateConnectivity bool) []deployTarget {
+	targets := make([]deployTarget, 0, len(kubeconfigs))
+	for _, kubeconfig := range kubeconfigs {
+		target := deployTarget{Name: kubeconfig}
+		if validateConnectivity {
+			target.Err = kubeclient.CheckConnectivity(kubeconfig, kubeclient.ConnectivityTimeout)
+		}
+		if target.Err == nil {
+			k8sClient, err := kubeclient.New(kubeconfig)
+			if err != nil {
+				target.Err = fmt.Errorf("failed to create k8s client: %w", err)
+			}
+			target.Client = k8sClient
+		}
+		targets = append(targets, target)
+	}
+	return targets
+}
+
+// deployToClusters runs the deploy phase against every target, continuing past the clusters that fail,
+// and returns an error listing the failed clusters
+func (l *Launcher) deployToClusters(runCtx context.Context, foundProfiles []profiles.Profile, renderedFiles map[string]map[string]string) error {
+	failed := []string{}
+	for _, target := range l.deployTargets {
+		l.ui.Info("Deploying to cluster: %s", target.Name)
+		err := target.Err
+		if err == nil {
+			err = l.deployToCluster(runCtx, target.Client, foundProfiles, renderedFiles)
+		}
+		if err != nil {
+			l.ui.Error("Deployment to cluster %s failed: %v", target.Name, err)
+			l.logger.Error(err, "Deployment to cluster failed", "cluster", target.Name)
+			failed = append(failed, target.Name)
+			continue
+		}
+		l.ui.Success("Deployed to cluster: %s", target.Name)
+		l.logger.Info("Deployment to cluster completed", "cluster", target.Name)
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("deployment failed on %d of %d cluster(s): %s", len(failed), len(l.deployTargets), strings.Join(failed, ", "))
+	}
+	l.ui.Success("Deployed to %d cluster(s)", len(l.deployTargets))
+	return nil
+}
+
+// deployToCluster checks the permissions and deploys every profile to the cluster of c, within the deploy timeout
+func (l *Launcher) deployToCluster(runCtx context.Context, c client.Client, foundProfiles []profiles.Profile, renderedFiles map[string]map[string]string) error {
+	timeout := l.deployTimeout()
+	deployCtx, cancelDeploy := timeout.context(runCtx)
+	defer cancelDeploy()
+
+	if err := l.checkClusterMatch(deployCtx, c); err != nil {
+		return timeout.wrap(runCtx, deployCtx, err)
+	}
+	if err := l.confirmProductionDeploy(deployCtx, c); err != nil {
+		return timeout.wrap(runCtx, deployCtx, err)
+	}
+	if err := l.checkDeployPermissions(deployCtx, c, renderedFiles); err != nil {
+		return timeout.wrap(runCtx, deployCtx, err)
+	}
+	if err := l.openCheckpoint(); err != nil {
+		return err
+	}
+	defer func() { l.checkpoint = nil }()
+
+	for _, profile := range foundProfiles {
+		if len(renderedFiles[profile.Name]) == 0 {
+			continue
+		}
+		if err := l.deployConfigurationProfile(deployCtx, c, &profile, renderedFiles[profile.Name]); err != nil {
+			l.ui.Error("Deployment failed: %v", err)
+			if l.checkpoint != nil {
+				l.ui.Info("Run again with --resume to skip the %d object(s) recorded in %s", l.checkpoint.Len(), l.options.Checkpoint)
+			}
+			return timeout.wrap(runCtx, deployCtx, err)
+		}
+	}
+
+	// Nothing is left to resume
+	if err := l.checkpoint.Remove(); err != nil {
+		l.ui.Warning("Failed to remove the checkpoint: %v", err)
+	}
+	return nil
+}
+
+// openCheckpoint opens the Checkpoint the objects applied are recorded to, resuming its objects with Resume
+func (l *Launcher) openCheckpoint() error {
+	if l.options.Checkpoint == "" {
+		return nil
+	}
+	checkpoint, err := deploy.OpenCheckpoint(l.options.Checkpoint, l.options.Resume)
+	if err != nil {
+		l.ui.Error("Failed to open the checkpoint: %v", err)
+		return fmt.Errorf("failed to open checkpoint: %w", err)
+	}
+	if l.options.Resume {
+		l.ui.Info("Resuming the deployment, %d object(s) were applied before", checkpoint.Len())
+		l.logger.Info("Resuming deployment", "checkpoint", l.options.Checkpoint, "applied", checkpoint.Len())
+	}
+	l.checkpoint = checkpoint
+	return nil
+}
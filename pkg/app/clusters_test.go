@@ -0,0 +1,101 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+	"github.com/nvidia/k8s-launch-kit/pkg/ui"
+)
+
+func TestDeployToClusters(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n  namespace: default\n",
+	})
+
+	newLauncher := func(t *testing.T, targets ...deployTarget) (*Launcher, *ui.RecordingOutput) {
+		launcher := newTestLauncher(t, options.Options{
+			Fabric:         "ethernet",
+			DeploymentType: "sriov",
+			ProfilesDirs:   []string{profilesDir},
+			Deploy:         true,
+		})
+		launcher.deployTargets = targets
+		output := ui.NewRecording()
+		launcher.ui = output
+		return launcher, output
+	}
+
+	// forbidding answers every access review with a denial, failing the permission check
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+	forbidding := fake.NewClientBuilder().WithRESTMapper(mapper).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			return nil
+		},
+	}).Build()
+
+	t.Run("deploy to every cluster", func(t *testing.T) {
+		first, second := []string{}, []string{}
+		launcher, output := newLauncher(t,
+			deployTarget{Name: "first", Client: newAllowingClient(&first)},
+			deployTarget{Name: "second", Client: newAllowingClient(&second)})
+
+		require.NoError(t, launcher.executeWorkflow())
+		assert.Equal(t, []string{"ConfigMap/sriov_network"}, first)
+		assert.Equal(t, []string{"ConfigMap/sriov_network"}, second)
+		assert.Empty(t, output.Errors)
+	})
+
+	t.Run("continue past a failing cluster", func(t *testing.T) {
+		applied := []string{}
+		launcher, output := newLauncher(t,
+			deployTarget{Name: "forbidden", Client: forbidding},
+			deployTarget{Name: "healthy", Client: newAllowingClient(&applied)})
+
+		err := launcher.executeWorkflow()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "deployment failed on 1 of 2 cluster(s): forbidden")
+		assert.Equal(t, []string{"ConfigMap/sriov_network"}, applied)
+		assert.Contains(t, output.Errors, "Deployment to cluster forbidden failed: kubeconfig user is missing 2 permission(s) required for deployment")
+	})
+
+	t.Run("report the clusters that could not be reached", func(t *testing.T) {
+		applied := []string{}
+		launcher, output := newLauncher(t,
+			deployTarget{Name: "healthy", Client: newAllowingClient(&applied)},
+			deployTarget{Name: "unreachable", Err: errors.New("connection refused")})
+
+		err := launcher.executeWorkflow()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "deployment failed on 1 of 2 cluster(s): unreachable")
+		assert.Equal(t, []string{"ConfigMap/sriov_network"}, applied)
+		assert.Contains(t, output.Errors, "Deployment to cluster unreachable failed: connection refused")
+	})
+}
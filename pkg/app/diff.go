@@ -0,0 +1,64 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/deploy"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	"github.com/nvidia/k8s-launch-kit/pkg/ui"
+)
+
+// ErrDrift is returned by Run with --diff-exit-code when the cluster differs from the generated files
+var ErrDrift = errors.New("the cluster differs from the generated files")
+
+// diffProfile prints the differences between the rendered files of the profile and the cluster and returns
+// the number of changed and new objects
+func (l *Launcher) diffProfile(profile *profiles.Profile, renderedFiles map[string]string) (int, error) {
+	l.logger.Info("Comparing deployment files with the cluster", "profile", profile.Name, "contextLines", l.options.ContextLines)
+
+	ctx := ui.WithOutput(context.Background(), l.ui)
+	diffs, err := deploy.Diff(ctx, l.kubeClient, renderedFiles, deploy.DiffOptions{ContextLines: l.options.ContextLines})
+	if err != nil {
+		return 0, fmt.Errorf("failed to diff profile %s: %w", profile.Name, err)
+	}
+
+	changed, created, unchanged := 0, 0, 0
+	for _, d := range diffs {
+		switch {
+		case d.Diff == "":
+			unchanged++
+			continue
+		case d.New:
+			created++
+			l.ui.Info("%s (new)", d.Object)
+		default:
+			changed++
+			l.ui.Info("%s", d.Object)
+		}
+		for _, line := range strings.Split(strings.TrimSuffix(d.Diff, "\n"), "\n") {
+			l.ui.Info("%s", line)
+		}
+	}
+
+	l.ui.Success("Profile %s: %d changed, %d new, %d unchanged", profile.Name, changed, created, unchanged)
+	return changed + created, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/networkoperatorplugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+)
+
+func TestDiffExitCode(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n  namespace: default\ndata:\n  key: value\n",
+	})
+
+	run := func(t *testing.T, data string, exitCode bool) error {
+		live := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "sriov_network", Namespace: "default"},
+			Data:       map[string]string{"key": data},
+		}
+		launcher := newTestLauncher(t, options.Options{
+			EnabledPlugins: []string{networkoperatorplugin.PluginName},
+			ProfilesDirs:   []string{profilesDir},
+			Fabric:         "ethernet",
+			DeploymentType: "sriov",
+			Diff:           true,
+			DiffExitCode:   exitCode,
+		})
+		launcher.kubeClient = fake.NewClientBuilder().WithObjects(live).Build()
+		return launcher.Run()
+	}
+
+	t.Run("succeed without drift", func(t *testing.T) {
+		require.NoError(t, run(t, "value", true))
+	})
+
+	t.Run("fail with ErrDrift on drift", func(t *testing.T) {
+		err := run(t, "drifted", true)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrDrift))
+		assert.Contains(t, err.Error(), "1 object(s) changed or missing")
+	})
+
+	t.Run("only report drift without the flag", func(t *testing.T) {
+		require.NoError(t, run(t, "drifted", false))
+	})
+
+	t.Run("succeed when the cluster defaults fields in lists", func(t *testing.T) {
+		podProfilesDir := t.TempDir()
+		writeTestProfile(t, podProfilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+			"30-network.yaml": "apiVersion: v1\nkind: Pod\nmetadata:\n  name: test-pod\n  namespace: default\nspec:\n" +
+				"  containers:\n  - name: main\n    image: app:1.0\n    ports:\n    - containerPort: 8080\n",
+		})
+		live := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default"},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name:                     "main",
+				Image:                    "app:1.0",
+				ImagePullPolicy:          corev1.PullIfNotPresent,
+				TerminationMessagePath:   "/dev/termination-log",
+				TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+				Ports:                    []corev1.ContainerPort{{ContainerPort: 8080, Protocol: corev1.ProtocolTCP}},
+			}}},
+		}
+		launcher := newTestLauncher(t, options.Options{
+			EnabledPlugins: []string{networkoperatorplugin.PluginName},
+			ProfilesDirs:   []string{podProfilesDir},
+			Fabric:         "ethernet",
+			DeploymentType: "sriov",
+			Diff:           true,
+			DiffExitCode:   true,
+		})
+		launcher.kubeClient = fake.NewClientBuilder().WithObjects(live).Build()
+		require.NoError(t, launcher.Run())
+	})
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v2"
+	k8syaml "sigs.k8s.io/yaml"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+)
+
+// Formats of the discovered capabilities printed with --print-discovery
+const (
+	DiscoveryFormatYAML = "yaml"
+	DiscoveryFormatJSON = "json"
+)
+
+// DiscoveryFormats lists the supported formats of the printed discovery
+var DiscoveryFormats = []string{DiscoveryFormatYAML, DiscoveryFormatJSON}
+
+// writeDiscovery writes the discovered capabilities to w in format, with the keys of the config file in both formats
+func writeDiscovery(w io.Writer, format string, capabilities *config.ClusterCapabilities) error {
+	data, err := yaml.Marshal(capabilities)
+	if err != nil {
+		return fmt.Errorf("failed to marshal discovered capabilities: %w", err)
+	}
+	if format == DiscoveryFormatJSON {
+		if data, err = k8syaml.YAMLToJSON(data); err != nil {
+			return fmt.Errorf("failed to convert discovered capabilities to JSON: %w", err)
+		}
+		data = append(data, '\n')
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to print discovered capabilities: %w", err)
+	}
+	return nil
+}
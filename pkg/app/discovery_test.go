@@ -0,0 +1,155 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/networkoperatorplugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+	"github.com/nvidia/k8s-launch-kit/pkg/plugin"
+)
+
+// discoveringPlugin is a network operator plugin whose discovery reports fixed capabilities
+type discoveringPlugin struct {
+	*networkoperatorplugin.NetworkOperatorPlugin
+	capabilities config.NodesCapabilities
+}
+
+func (p *discoveringPlugin) DiscoverClusterConfig(ctx context.Context, kubeClient client.Client, defaultConfig *config.LaunchKubernetesConfig) (*plugin.DiscoveryResult, error) {
+	*defaultConfig.ClusterConfig.Capabilities.Nodes = p.capabilities
+	return &plugin.DiscoveryResult{}, nil
+}
+
+func TestPrintDiscovery(t *testing.T) {
+	discovered := config.NodesCapabilities{Sriov: true, Rdma: true, Count: 3}
+
+	run := func(t *testing.T, opts options.Options) string {
+		t.Helper()
+		t.Chdir(t.TempDir())
+		require.NoError(t, os.WriteFile("l8k-config.yaml", []byte(testClusterConfig), 0644))
+
+		opts.DiscoverClusterConfig = true
+		launcher := newTestLauncher(t, opts)
+		launcher.plugins = map[string]plugin.Plugin{
+			networkoperatorplugin.PluginName: &discoveringPlugin{&networkoperatorplugin.NetworkOperatorPlugin{}, discovered},
+		}
+		// discover instead of loading the config file of the test launcher
+		launcher.options.UserConfig = ""
+		var stdout bytes.Buffer
+		launcher.stdout = &stdout
+		require.NoError(t, launcher.executeWorkflow())
+		return stdout.String()
+	}
+
+	t.Run("print the discovered capabilities as YAML without a save path", func(t *testing.T) {
+		printed := run(t, options.Options{PrintDiscovery: DiscoveryFormatYAML})
+
+		var capabilities config.ClusterCapabilities
+		require.NoError(t, yaml.Unmarshal([]byte(printed), &capabilities))
+		require.NotNil(t, capabilities.Nodes)
+		assert.Equal(t, discovered, *capabilities.Nodes)
+		assert.NoFileExists(t, "cluster-config.yaml")
+	})
+
+	t.Run("print the discovered capabilities as JSON with the config file keys", func(t *testing.T) {
+		printed := run(t, options.Options{PrintDiscovery: DiscoveryFormatJSON})
+
+		var capabilities map[string]map[string]any
+		require.NoError(t, json.Unmarshal([]byte(printed), &capabilities))
+		assert.Equal(t, map[string]any{"sriov": true, "rdma": true, "ib": false, "count": float64(3)}, capabilities["nodes"])
+	})
+
+	t.Run("also save the config when a save path is given", func(t *testing.T) {
+		printed := run(t, options.Options{PrintDiscovery: DiscoveryFormatYAML, SaveClusterConfig: "discovered.yaml"})
+
+		assert.Contains(t, printed, "sriov: true")
+		saved, err := config.LoadFullConfig("discovered.yaml", logr.Discard())
+		require.NoError(t, err)
+		assert.Equal(t, discovered, *saved.ClusterConfig.Capabilities.Nodes)
+	})
+}
+
+func TestDiscoverFromSnapshot(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile("l8k-config.yaml", []byte(testClusterConfig), 0644))
+	require.NoError(t, os.WriteFile("snapshot.yaml", []byte(`apiVersion: v1
+kind: Namespace
+metadata:
+  name: kube-system
+  uid: 6f1c2c1e-cluster-a
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: nvidia-network-operator
+---
+apiVersion: configuration.net.nvidia.com/v1alpha1
+kind: NicDevice
+metadata:
+  name: worker-1-device
+  namespace: nvidia-network-operator
+status:
+  node: worker-1
+  ports:
+  - pci: "0000:08:00.1"
+    networkInterface: ens1f1
+    rdmaInterface: mlx5_1
+---
+apiVersion: configuration.net.nvidia.com/v1alpha1
+kind: NicDevice
+metadata:
+  name: worker-0-device
+  namespace: nvidia-network-operator
+status:
+  node: worker-0
+  ports:
+  - pci: "0000:08:00.0"
+    networkInterface: ens1f0
+    rdmaInterface: mlx5_0
+`), 0644))
+
+	launcher := newTestLauncher(t, options.Options{
+		DiscoverClusterConfig: true,
+		DiscoverySnapshot:     "snapshot.yaml",
+		SaveClusterConfig:     "discovered.yaml",
+	})
+	// discover instead of loading the config file of the test launcher
+	launcher.options.UserConfig = ""
+	require.NoError(t, launcher.executeWorkflow())
+
+	discovered, err := config.LoadFullConfig("discovered.yaml", logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, config.NodesCapabilities{Sriov: true, Rdma: true, Ib: true, Count: 2}, *discovered.ClusterConfig.Capabilities.Nodes)
+	assert.Equal(t, []string{"worker-0", "worker-1"}, discovered.ClusterConfig.WorkerNodes)
+	assert.Equal(t, []config.PFConfig{
+		{RdmaDevice: "mlx5_0", PciAddress: "0000:08:00.0", NetworkInterface: "ens1f0", Traffic: "east-west"},
+		{RdmaDevice: "mlx5_1", PciAddress: "0000:08:00.1", NetworkInterface: "ens1f1", Traffic: "east-west"},
+	}, discovered.ClusterConfig.PFs)
+	assert.Equal(t, "6f1c2c1e-cluster-a", discovered.ClusterConfig.ClusterID)
+}
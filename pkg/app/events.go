@@ -0,0 +1,95 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import "sync"
+
+// EventType identifies the kind of an Event
+type EventType string
+
+// Events published to the Events subscribers
+const (
+	// EventPhaseStart is published when a workflow phase starts, with Phase set
+	EventPhaseStart EventType = "PhaseStart"
+	// EventProfileSelected is published for every selected profile, with Profile set
+	EventProfileSelected EventType = "ProfileSelected"
+	// EventFileGenerated is published for every generated file of a profile, with Profile and File set
+	EventFileGenerated EventType = "FileGenerated"
+	// EventResourceApplied is published for every object applied and ready, with Profile and Resource set
+	EventResourceApplied EventType = "ResourceApplied"
+)
+
+// eventBuffer is the number of events a subscriber can fall behind before the run waits for it
+const eventBuffer = 64
+
+// Event is a step of a workflow run, the fields not used by its Type are empty
+type Event struct {
+	Type    EventType
+	Phase   string
+	Profile string
+	File    string
+	// Resource is the applied object, written as Kind/name
+	Resource string
+}
+
+// Events returns a channel receiving the events of the runs, closed when Run returns. Subscribe before calling
+// Run, and drain the channel: a subscriber more than a few events behind holds the run up.
+func (l *Launcher) Events() <-chan Event {
+	if l.events == nil {
+		l.events = &eventStream{}
+	}
+	return l.events.subscribe()
+}
+
+// eventStream publishes events to the subscribers of Events
+type eventStream struct {
+	mu          sync.Mutex
+	subscribers []chan Event
+}
+
+func (s *eventStream) subscribe() <-chan Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	events := make(chan Event, eventBuffer)
+	s.subscribers = append(s.subscribers, events)
+	return events
+}
+
+// publish sends the event to every subscriber, it does nothing on a nil stream
+func (s *eventStream) publish(event Event) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, events := range s.subscribers {
+		events <- event
+	}
+}
+
+// close closes the channels of the subscribers, it does nothing on a nil stream
+func (s *eventStream) close() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, events := range s.subscribers {
+		close(events)
+	}
+	s.subscribers = nil
+}
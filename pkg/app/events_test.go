@@ -0,0 +1,79 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/networkoperatorplugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+)
+
+func TestEvents(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: first\n  namespace: default\n---\n" +
+			"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: second\n  namespace: default\n",
+	})
+
+	run := func(t *testing.T, failing ...string) ([]Event, error) {
+		launcher := newTestLauncher(t, options.Options{
+			EnabledPlugins: []string{networkoperatorplugin.PluginName},
+			Fabric:         "ethernet",
+			DeploymentType: "sriov",
+			ProfilesDirs:   []string{profilesDir},
+			Deploy:         true,
+		})
+		launcher.kubeClient = newAllowingClient(&[]string{}, failing...)
+
+		received := make(chan []Event)
+		events := launcher.Events()
+		go func() {
+			all := []Event{}
+			for event := range events {
+				all = append(all, event)
+			}
+			received <- all
+		}()
+		err := launcher.Run()
+		return <-received, err
+	}
+
+	t.Run("publish the steps of a run", func(t *testing.T) {
+		events, err := run(t)
+		require.NoError(t, err)
+		assert.Equal(t, []Event{
+			{Type: EventPhaseStart, Phase: PhaseSelection},
+			{Type: EventProfileSelected, Profile: "SR-IOV"},
+			{Type: EventPhaseStart, Phase: PhaseGeneration},
+			{Type: EventFileGenerated, Profile: "SR-IOV", File: "30-network.yaml"},
+			{Type: EventPhaseStart, Phase: PhaseDeployment},
+			{Type: EventResourceApplied, Profile: "SR-IOV", Resource: "ConfigMap/first"},
+			{Type: EventResourceApplied, Profile: "SR-IOV", Resource: "ConfigMap/second"},
+		}, events)
+	})
+
+	t.Run("stop at the failed object", func(t *testing.T) {
+		events, err := run(t, "second")
+		require.Error(t, err)
+		require.NotEmpty(t, events)
+		assert.Equal(t, Event{Type: EventResourceApplied, Profile: "SR-IOV", Resource: "ConfigMap/first"}, events[len(events)-1])
+	})
+}
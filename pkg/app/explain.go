@@ -0,0 +1,153 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/plugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+)
+
+// configOrigin names where the config loaded from configPath came from
+func (l *Launcher) configOrigin(configPath string) string {
+	switch {
+	case l.options.ConfigFromSecret != "":
+		return l.options.ConfigFromSecret
+	case l.options.RunSpec != "":
+		return l.options.RunSpec
+	case l.options.TemplateConfig:
+		return l.options.UserConfig
+	}
+	return configPath
+}
+
+// trackConfigSources starts tracking the field sources of cfg loaded from configPath with ExplainConfig
+func (l *Launcher) trackConfigSources(cfg *config.LaunchKubernetesConfig, configPath string) error {
+	if !l.options.ExplainConfig {
+		return nil
+	}
+
+	sources, err := config.NewSourceTracker(cfg, config.SourceFile, l.configOrigin(configPath))
+	if err != nil {
+		return fmt.Errorf("failed to track config sources: %w", err)
+	}
+	l.sources = sources
+	return nil
+}
+
+// applySetValues overrides the fields of cfg with the --set values, attributing them to the flag
+func (l *Launcher) applySetValues(cfg *config.LaunchKubernetesConfig) error {
+	if err := config.ApplySetValues(cfg, l.options.Set); err != nil {
+		l.ui.Error("Failed to apply --set: %v", err)
+		return fmt.Errorf("failed to apply --set: %w", err)
+	}
+	for _, value := range l.options.Set {
+		field, _, err := config.ParseSetValue(value)
+		if err != nil {
+			return err
+		}
+		if err := l.sources.Record(cfg, field, config.SourceFlag, "--set "+value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyAnnotations adds the annotations of --annotation to the config, attributing them to the flag
+func (l *Launcher) applyAnnotations(cfg *config.LaunchKubernetesConfig) error {
+	if len(l.options.Annotations) == 0 {
+		return nil
+	}
+	if err := l.sources.Track(cfg, config.SourceFlag, "--annotation", func() error {
+		return config.ApplyAnnotations(cfg, l.options.Annotations)
+	}); err != nil {
+		l.ui.Error("Failed to apply --annotation: %v", err)
+		return fmt.Errorf("failed to apply --annotation: %w", err)
+	}
+	return nil
+}
+
+// resolveTrackedProfile sets the profile requirements of cfg with resolveProfile, attributing the requirements
+// of the flags and of the LLM to their source
+func (l *Launcher) resolveTrackedProfile(cfg *config.LaunchKubernetesConfig, cli, fromLLM *config.Profile) error {
+	fromConfig := cfg.Profile
+	if err := l.sources.Track(cfg, config.SourceFlag, "command line", func() error {
+		cfg.Profile = resolveProfile(cli, fromConfig, nil)
+		return nil
+	}); err != nil {
+		return err
+	}
+	origin := "prompt"
+	if l.options.ProfileJSON != "" {
+		origin = "--profile-json"
+	}
+	return l.sources.Track(cfg, config.SourceLLM, origin, func() error {
+		cfg.Profile = resolveProfile(cli, fromConfig, fromLLM)
+		return nil
+	})
+}
+
+// explainConfig prints the source of every field of cfg with ExplainConfig
+func (l *Launcher) explainConfig(cfg *config.LaunchKubernetesConfig) error {
+	explained, err := l.sources.Explain(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to explain config: %w", err)
+	}
+	if len(explained) == 0 {
+		return nil
+	}
+
+	rows := make([][]string, 0, len(explained))
+	for _, field := range explained {
+		rows = append(rows, []string{field.Field, field.Value, field.Source, field.Origin})
+	}
+	l.ui.Info("Config field sources:")
+	l.ui.Table([]string{"FIELD", "VALUE", "SOURCE", "ORIGIN"}, rows)
+	return nil
+}
+
+// explainRenderFailures prints the render result of every template of the profile with ExplainConfig, when err lists
+// the templates that failed to render
+func (l *Launcher) explainRenderFailures(profile *profiles.Profile, err error) {
+	var renderErr *plugin.RenderError
+	if !l.options.ExplainConfig || !errors.As(err, &renderErr) {
+		return
+	}
+
+	failed := make(map[string]error, len(renderErr.Failures))
+	for _, failure := range renderErr.Failures {
+		failed[failure.Template] = failure.Err
+	}
+	rows := make([][]string, 0, len(profile.Templates))
+	for _, template := range profile.Templates {
+		name := filepath.Base(template)
+		switch {
+		case failed[template] != nil:
+			rows = append(rows, []string{name, "failed", failed[template].Error()})
+		case renderErr.Rendered[name] != "":
+			rows = append(rows, []string{name, "rendered", ""})
+		default:
+			rows = append(rows, []string{name, "empty", ""})
+		}
+	}
+	l.ui.Info("Template render results of profile %s:", profile.Name)
+	l.ui.Table([]string{"TEMPLATE", "RESULT", "ERROR"}, rows)
+}
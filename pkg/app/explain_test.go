@@ -0,0 +1,154 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/networkoperatorplugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+	"github.com/nvidia/k8s-launch-kit/pkg/ui"
+)
+
+func TestExplainConfig(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n",
+	})
+
+	run := func(t *testing.T, opts options.Options) (*ui.RecordingOutput, string) {
+		t.Helper()
+		opts.Fabric = "ethernet"
+		opts.DeploymentType = "sriov"
+		opts.ProfilesDirs = []string{profilesDir}
+		opts.SaveDeploymentFiles = t.TempDir()
+		launcher := newTestLauncher(t, opts)
+		recording := ui.NewRecording()
+		launcher.ui = recording
+		require.NoError(t, launcher.executeWorkflow())
+
+		rendered, err := os.ReadFile(filepath.Join(opts.SaveDeploymentFiles, networkoperatorplugin.PluginName, "30-network.yaml"))
+		require.NoError(t, err)
+		return recording, string(rendered)
+	}
+
+	// sources returns the source and origin of every explained field
+	sources := func(t *testing.T, recording *ui.RecordingOutput) map[string][]string {
+		t.Helper()
+		require.Len(t, recording.Tables, 1)
+		assert.Equal(t, []string{"FIELD", "VALUE", "SOURCE", "ORIGIN"}, recording.Tables[0].Headers)
+		fields := map[string][]string{}
+		for _, row := range recording.Tables[0].Rows {
+			fields[row[0]] = row[1:]
+		}
+		return fields
+	}
+
+	t.Run("attribute a value overridden by --set to the flag", func(t *testing.T) {
+		recording, rendered := run(t, options.Options{
+			Set:           []string{"sriov.networkName=custom_network"},
+			ExplainConfig: true,
+		})
+
+		assert.Contains(t, rendered, "name: custom_network")
+		fields := sources(t, recording)
+		assert.Equal(t, []string{"custom_network", config.SourceFlag, "--set sriov.networkName=custom_network"}, fields["sriov.networkName"])
+		assert.Equal(t, config.SourceFile, fields["sriov.resourceName"][1], "fields that are not overridden keep the file source")
+		assert.Equal(t, []string{"ethernet", config.SourceFlag, "command line"}, fields["profile.fabric"])
+		assert.Equal(t, config.SourceDefault, fields["sriov.ethernetMtu"][1])
+	})
+
+	t.Run("apply --set without explaining", func(t *testing.T) {
+		recording, rendered := run(t, options.Options{Set: []string{"sriov.networkName=custom_network"}})
+
+		assert.Contains(t, rendered, "name: custom_network")
+		assert.Empty(t, recording.Tables)
+	})
+
+	t.Run("reject unknown fields", func(t *testing.T) {
+		launcher := newTestLauncher(t, options.Options{
+			Fabric:              "ethernet",
+			DeploymentType:      "sriov",
+			ProfilesDirs:        []string{profilesDir},
+			SaveDeploymentFiles: t.TempDir(),
+			Set:                 []string{"sriov.unknown=1"},
+		})
+		err := launcher.executeWorkflow()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to apply --set")
+	})
+}
+
+func TestExplainRenderFailures(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", `name: SR-IOV
+plugin: network-operator
+profileRequirements:
+  deployment: sriov
+templates:
+  - 10-namespace.yaml
+  - 20-broken.yaml
+  - 30-network.yaml
+`, map[string]string{
+		"10-namespace.yaml": "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: {{.NetworkOperator.Namespace}}\n",
+		"20-broken.yaml":    "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.Missing}}\n",
+		"30-network.yaml":   "{{- if false }}\nkind: ConfigMap\n{{- end }}\n",
+	})
+
+	run := func(t *testing.T, explain bool) (*ui.RecordingOutput, error) {
+		launcher := newTestLauncher(t, options.Options{
+			Fabric:              "ethernet",
+			DeploymentType:      "sriov",
+			ProfilesDirs:        []string{profilesDir},
+			SaveDeploymentFiles: t.TempDir(),
+			ExplainConfig:       explain,
+		})
+		recording := ui.NewRecording()
+		launcher.ui = recording
+		return recording, launcher.executeWorkflow()
+	}
+
+	t.Run("show the result of every template", func(t *testing.T) {
+		recording, err := run(t, true)
+		require.Error(t, err)
+
+		var results *ui.RecordedTable
+		for i := range recording.Tables {
+			if recording.Tables[i].Headers[0] == "TEMPLATE" {
+				results = &recording.Tables[i]
+			}
+		}
+		require.NotNil(t, results)
+		require.Len(t, results.Rows, 3)
+		assert.Equal(t, []string{"10-namespace.yaml", "rendered", ""}, results.Rows[0])
+		assert.Equal(t, []string{"20-broken.yaml", "failed"}, results.Rows[1][:2])
+		assert.Contains(t, results.Rows[1][2], "can't evaluate field Missing")
+		assert.Equal(t, []string{"30-network.yaml", "empty", ""}, results.Rows[2])
+	})
+
+	t.Run("show no results without --explain-config", func(t *testing.T) {
+		recording, err := run(t, false)
+		require.Error(t, err)
+		assert.Empty(t, recording.Tables)
+	})
+}
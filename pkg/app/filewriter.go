@@ -0,0 +1,198 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// FileWriter is the file system the launcher saves its output files to
+type FileWriter interface {
+	MkdirAll(path string, perm os.FileMode) error
+	WriteFile(name string, data []byte, perm os.FileMode) error
+	ReadFile(name string) ([]byte, error)
+	Remove(name string) error
+	RemoveAll(path string) error
+}
+
+// OSFileWriter implements FileWriter with the os package
+type OSFileWriter struct{}
+
+// MkdirAll creates the directory and its parents
+func (OSFileWriter) MkdirAll(path string, perm os.FileMode) error {
+	return os.MkdirAll(path, perm)
+}
+
+// WriteFile creates or truncates the file and writes data to it
+func (OSFileWriter) WriteFile(name string, data []byte, perm os.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+
+// ReadFile returns the content of the file
+func (OSFileWriter) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+// Remove removes the file
+func (OSFileWriter) Remove(name string) error {
+	return os.Remove(name)
+}
+
+// RemoveAll removes the path and everything it contains
+func (OSFileWriter) RemoveAll(path string) error {
+	return os.RemoveAll(path)
+}
+
+// MemoryFileWriter implements FileWriter in memory and records every operation, for use in tests
+type MemoryFileWriter struct {
+	mu sync.Mutex
+
+	// Files holds the content of the written files by path
+	Files map[string][]byte
+	// Dirs holds the created directories
+	Dirs map[string]bool
+	// Operations lists the calls in order, as "<op> <path>" with op one of mkdir, write, read, remove and removeall
+	Operations []string
+	// Errors are returned by the operation with the same "<op> <path>" key instead of running it
+	Errors map[string]error
+}
+
+// NewMemoryFileWriter returns an empty in-memory file system
+func NewMemoryFileWriter() *MemoryFileWriter {
+	return &MemoryFileWriter{
+		Files:  map[string][]byte{},
+		Dirs:   map[string]bool{},
+		Errors: map[string]error{},
+	}
+}
+
+// MkdirAll records the directory and its parents
+func (w *MemoryFileWriter) MkdirAll(path string, perm os.FileMode) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	path = filepath.Clean(path)
+	if err := w.record("mkdir", path); err != nil {
+		return err
+	}
+
+	for dir := path; !w.Dirs[dir]; dir = filepath.Dir(dir) {
+		w.Dirs[dir] = true
+	}
+	return nil
+}
+
+// WriteFile stores data, the parent directory must exist
+func (w *MemoryFileWriter) WriteFile(name string, data []byte, perm os.FileMode) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	name = filepath.Clean(name)
+	if err := w.record("write", name); err != nil {
+		return err
+	}
+
+	if dir := filepath.Dir(name); dir != "." && !w.Dirs[dir] {
+		return &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	w.Files[name] = append([]byte(nil), data...)
+	return nil
+}
+
+// ReadFile returns the stored content of the file
+func (w *MemoryFileWriter) ReadFile(name string) ([]byte, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	name = filepath.Clean(name)
+	if err := w.record("read", name); err != nil {
+		return nil, err
+	}
+
+	data, ok := w.Files[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return append([]byte(nil), data...), nil
+}
+
+// Remove removes the stored file
+func (w *MemoryFileWriter) Remove(name string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	name = filepath.Clean(name)
+	if err := w.record("remove", name); err != nil {
+		return err
+	}
+
+	if _, ok := w.Files[name]; !ok {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+	delete(w.Files, name)
+	return nil
+}
+
+// RemoveAll removes the path and the files and directories below it
+func (w *MemoryFileWriter) RemoveAll(path string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	path = filepath.Clean(path)
+	if err := w.record("removeall", path); err != nil {
+		return err
+	}
+
+	prefix := path + string(filepath.Separator)
+	for name := range w.Files {
+		if name == path || strings.HasPrefix(name, prefix) {
+			delete(w.Files, name)
+		}
+	}
+	for dir := range w.Dirs {
+		if dir == path || strings.HasPrefix(dir, prefix) {
+			delete(w.Dirs, dir)
+		}
+	}
+	return nil
+}
+
+// FileNames returns the paths of the stored files in sorted order
+func (w *MemoryFileWriter) FileNames() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	names := make([]string, 0, len(w.Files))
+	for name := range w.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// record appends the operation and returns its configured error, if any. The caller holds the lock.
+func (w *MemoryFileWriter) record(op, path string) error {
+	key := fmt.Sprintf("%s %s", op, path)
+	w.Operations = append(w.Operations, key)
+	return w.Errors[key]
+}
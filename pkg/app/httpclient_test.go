@@ -0,0 +1,82 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+)
+
+func TestNewHTTPClient(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	t.Run("trusts the certificates of the CA bundle", func(t *testing.T) {
+		bundle := filepath.Join(t.TempDir(), "ca.pem")
+		require.NoError(t, os.WriteFile(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0644))
+
+		client, err := newHTTPClient(bundle)
+		require.NoError(t, err)
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		_, err = http.Get(server.URL)
+		assert.ErrorContains(t, err, "certificate", "the default client does not trust the test server")
+	})
+
+	t.Run("malformed bundle is rejected", func(t *testing.T) {
+		bundle := filepath.Join(t.TempDir(), "ca.pem")
+		require.NoError(t, os.WriteFile(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("not a certificate")}), 0644))
+
+		_, err := newHTTPClient(bundle)
+		assert.ErrorContains(t, err, "invalid certificate in CA bundle")
+	})
+
+	t.Run("bundle without certificates is rejected", func(t *testing.T) {
+		bundle := filepath.Join(t.TempDir(), "ca.pem")
+		require.NoError(t, os.WriteFile(bundle, []byte("not PEM\n"), 0644))
+
+		_, err := newHTTPClient(bundle)
+		assert.ErrorContains(t, err, "no PEM certificate found")
+	})
+
+	t.Run("missing bundle is rejected", func(t *testing.T) {
+		_, err := newHTTPClient(filepath.Join(t.TempDir(), "missing.pem"))
+		assert.ErrorContains(t, err, "failed to read CA bundle")
+	})
+
+	t.Run("run fails with an invalid bundle", func(t *testing.T) {
+		bundle := filepath.Join(t.TempDir(), "ca.pem")
+		require.NoError(t, os.WriteFile(bundle, []byte("not PEM\n"), 0644))
+
+		err := newTestLauncher(t, options.Options{CABundle: bundle}).Run()
+		assert.ErrorContains(t, err, "no PEM certificate found")
+	})
+}
@@ -19,16 +19,24 @@ package app
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"maps"
+	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/trace"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/deploy"
 	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
 	"github.com/nvidia/k8s-launch-kit/pkg/llm"
 	applog "github.com/nvidia/k8s-launch-kit/pkg/log"
@@ -36,57 +44,176 @@ import (
 	"github.com/nvidia/k8s-launch-kit/pkg/options"
 	"github.com/nvidia/k8s-launch-kit/pkg/plugin"
 	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	"github.com/nvidia/k8s-launch-kit/pkg/secrets"
 	"github.com/nvidia/k8s-launch-kit/pkg/ui"
 	"gopkg.in/yaml.v2"
 )
 
 // Launcher represents the main application launcher
 type Launcher struct {
-	options    options.Options
-	logger     logr.Logger
-	plugins    map[string]plugin.Plugin
+	options options.Options
+	logger  logr.Logger
+	plugins map[string]plugin.Plugin
+	// available holds the plugins that can be enabled, by name
+	available  map[string]plugin.Plugin
 	kubeClient client.Client
 	ui         ui.Output
+	observers  []WorkflowObserver
+	// events publishes the events of the runs to the subscribers of Events, nil without subscribers
+	events *eventStream
+	files  FileWriter
+	// runStarted is the start time of the current run, used for the {timestamp} placeholder
+	runStarted time.Time
+	// httpClient sends the LLM and registry requests, the libraries' default when nil
+	httpClient *http.Client
+	// stdout receives the manifest stream of OutputFormatStream, the capabilities of PrintDiscovery and the
+	// system prompt of PrintSystemPrompt
+	stdout io.Writer
+	// runSpecPrompt is the prompt of the run spec intent, used instead of the Prompt file
+	runSpecPrompt string
+	// bundle collects the artifacts of the run with SupportBundle, nil otherwise
+	bundle *supportBundle
+	// deployTargets are the clusters of Kubeconfigs, deployed to instead of kubeClient when set
+	deployTargets []deployTarget
+	// keepOutputFiles disables every removal in the output directory, set by cleanOutputDir: false in the config file
+	keepOutputFiles bool
+	// sources tracks where every config field came from with ExplainConfig, nil otherwise
+	sources *config.SourceTracker
+	// configClusterID identifies the cluster the loaded config was discovered from, empty when unknown
+	configClusterID string
+	// checkpoint records the applied objects with Checkpoint during the deploy phase, nil otherwise
+	checkpoint *deploy.Checkpoint
+	// tracerProvider records the spans of the runs, the global OpenTelemetry provider when nil
+	tracerProvider trace.TracerProvider
 }
 
-// New creates a new Launcher instance with the given options
+// BuiltinPlugins returns a new instance of every plugin shipped with l8k, by name
+func BuiltinPlugins() map[string]plugin.Plugin {
+	return map[string]plugin.Plugin{
+		networkoperatorplugin.PluginName: &networkoperatorplugin.NetworkOperatorPlugin{},
+	}
+}
+
+// New creates a new Launcher instance with the given options and the built-in plugins
 func New(options options.Options) *Launcher {
+	return NewWithPlugins(options, BuiltinPlugins())
+}
+
+// NewWithPlugins creates a new Launcher instance with the given options, enabling the plugins of available named by
+// EnabledPlugins. The plugins may have registered their flags with plugin.RegisterFlags.
+func NewWithPlugins(options options.Options, available map[string]plugin.Plugin) *Launcher {
 	l := &Launcher{
-		options: options,
-		logger:  log.Log,
-		plugins: make(map[string]plugin.Plugin),
-		ui:      ui.New(),
+		options:   options,
+		logger:    log.Log,
+		plugins:   make(map[string]plugin.Plugin),
+		available: available,
+		ui:        ui.New(),
+		files:     OSFileWriter{},
+		stdout:    os.Stdout,
+	}
+	// Keep stdout for the manifests or the discovery, e.g. to pipe them to kubectl apply -f -
+	if options.OutputFormat == OutputFormatStream || options.PrintDiscovery != "" || options.PrintSystemPrompt {
+		l.ui = ui.NewWithWriter(os.Stderr)
 	}
 
 	return l
 }
 
+// ConfiguredPlugins returns the enabled plugins, sorted by name, whose profile is configured by the command line options
+func (l *Launcher) ConfiguredPlugins() []plugin.Plugin {
+	configured := []plugin.Plugin{}
+	for _, name := range slices.Sorted(maps.Keys(l.plugins)) {
+		if l.plugins[name].ProfileConfiguredInCmd(l.options) {
+			configured = append(configured, l.plugins[name])
+		}
+	}
+	return configured
+}
+
+// reportConfiguredPlugins prints the plugins acting on the command line options
+func (l *Launcher) reportConfiguredPlugins() {
+	configured := l.ConfiguredPlugins()
+	if len(configured) == 0 {
+		l.ui.Info("No enabled plugin is configured by the command line options")
+		return
+	}
+
+	rows := make([][]string, 0, len(configured))
+	for _, p := range configured {
+		rows = append(rows, []string{p.GetName(), p.GetVersion()})
+	}
+	l.ui.Table([]string{"PLUGIN", "VERSION"}, rows)
+}
+
 // Run executes the main application logic with the 3-phase workflow
-func (l *Launcher) Run() error {
+func (l *Launcher) Run() (err error) {
+	defer l.events.close()
+
 	if l.options.LogLevel != "" {
 		if err := applog.SetLogLevel(l.options.LogLevel); err != nil {
 			return fmt.Errorf("failed to set log level: %w", err)
 		}
 	}
 
-	for _, plugin := range l.options.EnabledPlugins {
-		switch plugin {
-		case networkoperatorplugin.PluginName:
-			l.plugins[plugin] = &networkoperatorplugin.NetworkOperatorPlugin{}
-		default:
-			err := fmt.Errorf("unknown plugin: %s", plugin)
+	// The bundle is written whether the run succeeds or not
+	if l.options.SupportBundle != "" {
+		started := time.Now()
+		l.bundle = newSupportBundle()
+		// The plugins and the deploy and llm packages log through the global logger
+		defer applog.Tee(l.bundle.logSink())()
+		defer func() {
+			if bundleErr := l.writeSupportBundle(started, err); bundleErr != nil {
+				l.ui.Error("Failed to save the support bundle: %v", bundleErr)
+				if err == nil {
+					err = bundleErr
+				}
+			}
+		}()
+	}
+
+	for _, name := range l.options.EnabledPlugins {
+		enabled, ok := l.available[name]
+		if !ok {
+			err := fmt.Errorf("unknown plugin: %s", name)
 			l.logger.Error(err, "Skipping plugin")
 			return err
 		}
+		l.plugins[name] = enabled
+	}
+
+	if l.options.WhichPlugins {
+		l.reportConfiguredPlugins()
+		return nil
+	}
+
+	if l.options.CABundle != "" {
+		httpClient, err := newHTTPClient(l.options.CABundle)
+		if err != nil {
+			return err
+		}
+		l.httpClient = httpClient
 	}
 
 	if l.options.Kubeconfig != "" {
+		if l.options.ValidateConnectivity {
+			if err := kubeclient.CheckConnectivity(l.options.Kubeconfig, kubeclient.ConnectivityTimeout); err != nil {
+				l.ui.Error("Cannot connect to the cluster: %v", err)
+				return err
+			}
+		}
 		k8sClient, err := kubeclient.New(l.options.Kubeconfig)
 		if err != nil {
 			return fmt.Errorf("failed to create k8s client: %w", err)
 		}
 		l.kubeClient = k8sClient
 	}
+	if len(l.options.Kubeconfigs) > 0 {
+		l.deployTargets = newDeployTargets(l.options.Kubeconfigs, l.options.ValidateConnectivity)
+	}
+
+	if l.options.Watch {
+		return l.watch()
+	}
 
 	if err := l.executeWorkflow(); err != nil {
 		return err
@@ -96,151 +223,353 @@ func (l *Launcher) Run() error {
 }
 
 // executeWorkflow executes the main 3-phase workflow
-func (l *Launcher) executeWorkflow() error {
+func (l *Launcher) executeWorkflow() (err error) {
 	l.ui.Header("NVIDIA Kubernetes Launch Kit")
 	l.logger.Info("Starting l8k workflow")
+	l.runStarted = time.Now()
+
+	runCtx, cancel := l.runContext()
+	defer cancel()
+	runCtx, runSpan := l.tracer().Start(runCtx, spanRun)
+	defer func() { endSpan(runSpan, err) }()
+
+	phases := newPhaseTracker(runCtx, l.observers, l.events)
+	defer func() { phases.finish(err) }()
 
 	configPath := ""
 	if l.options.DiscoverClusterConfig {
+		phaseCtx := phases.start(PhaseDiscovery)
 		l.ui.Section("Phase 1: Cluster Discovery")
-		if err := l.discoverClusterConfig(); err != nil {
+		timeout := l.discoveryTimeout()
+		discoveryCtx, cancelDiscovery := timeout.context(phaseCtx)
+		err := timeout.wrap(phaseCtx, discoveryCtx, l.discoverClusterConfig(discoveryCtx))
+		cancelDiscovery()
+		if err != nil {
 			l.ui.Error("Cluster discovery failed: %v", err)
 			return fmt.Errorf("cluster discovery failed: %w", err)
 		}
 
+		// A printed discovery that is not saved ends the run
+		if l.options.SaveClusterConfig == "" {
+			return nil
+		}
 		configPath = l.options.SaveClusterConfig
 	} else {
 		configPath = l.options.UserConfig
 	}
 
-	profilesConfiguredInCmd := true
-	for _, plugin := range l.plugins {
-		if !plugin.ProfileConfiguredInCmd(l.options) {
-			profilesConfiguredInCmd = false
-			break
-		}
+	selectionCtx := phases.start(PhaseSelection)
+	profilesConfiguredInCmd := len(l.ConfiguredPlugins()) == len(l.plugins)
+
+	fullConfig, err := l.loadConfig(selectionCtx, configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load full config: %w", err)
+	}
+	if err := l.trackConfigSources(fullConfig, configPath); err != nil {
+		return err
+	}
+	if err := l.applySetValues(fullConfig); err != nil {
+		return err
+	}
+	if err := l.applyAnnotations(fullConfig); err != nil {
+		return err
+	}
+	if resolved, err := config.ResolveAutoNumVfs(fullConfig); err != nil {
+		l.ui.Error("Invalid configuration: %v", err)
+		return fmt.Errorf("failed to compute the number of VFs: %w", err)
+	} else if resolved {
+		l.ui.Info("Using %d VFs per PF for the %d discovered PF(s)", fullConfig.Sriov.NumVfs, len(fullConfig.ClusterConfig.PFs))
+	}
+	l.keepOutputFiles = !fullConfig.CleansOutputDir()
+	l.configClusterID = ""
+	if fullConfig.ClusterConfig != nil {
+		l.configClusterID = fullConfig.ClusterConfig.ClusterID
 	}
 
-	if !profilesConfiguredInCmd && l.options.Prompt == "" && !l.options.LLMInteractive {
+	forced := l.options.ForceProfile != ""
+	// A JSON profile goes through the selection of an LLM answer
+	useLLM := !forced && (l.options.Prompt != "" || l.runSpecPrompt != "" || l.options.LLMInteractive || l.options.ProfileJSON != "")
+	if !profilesConfiguredInCmd && fullConfig.Profile == nil && !useLLM && !forced && !l.options.PrintSystemPrompt {
 		l.ui.Info("Profiles not configured, skipping deployment file generation")
-		l.logger.Info("Profiles are not configured for every plugin, skipping deployment files generation")
-		return nil
+		l.logger.Info("Profiles are not configured for every plugin or in the config file, skipping deployment files generation")
+		return l.explainConfig(fullConfig)
 	}
 
-	fullConfig, err := config.LoadFullConfig(configPath, l.logger)
+	profilesDir, cleanup, err := l.resolveProfilesDir(selectionCtx)
 	if err != nil {
-		return fmt.Errorf("failed to load full config: %w", err)
+		return err
 	}
+	defer cleanup()
 
-	if fullConfig.Profile == nil {
-		fullConfig.Profile = &config.Profile{}
+	if l.options.PrintSystemPrompt {
+		return l.printSystemPrompt(fullConfig, profilesDir)
+	}
 
-		if profilesConfiguredInCmd {
-			for _, plugin := range l.plugins {
-				if err := plugin.BuildProfileFromOptions(l.options, fullConfig.Profile); err != nil {
-					return fmt.Errorf("failed to build profile for plugin %s: %w", plugin.GetName(), err)
-				}
+	var cliProfile *config.Profile
+	// A profile flag alone, e.g. --spectrum-x=false, still overrides the config file and the LLM
+	if profilesConfiguredInCmd || len(l.options.ProfileFlagsSet) > 0 {
+		cliProfile = &config.Profile{}
+		for _, plugin := range l.plugins {
+			if err := plugin.BuildProfileFromOptions(l.options, cliProfile); err != nil {
+				return fmt.Errorf("failed to build profile for plugin %s: %w", plugin.GetName(), err)
 			}
-		} else if l.options.LLMInteractive {
-			l.ui.Section("Profile Selection (AI-Assisted)")
-			l.logger.Info("Starting interactive LLM session")
+		}
+	}
 
-			prompt, err := l.runInteractiveSession(fullConfig.ClusterConfig)
-			if err != nil {
-				l.ui.Error("Interactive session failed: %v", err)
-				return fmt.Errorf("interactive session failed: %w", err)
-			}
+	var llmProfile *config.Profile
+	if useLLM && !profileComplete(resolveProfile(cliProfile, fullConfig.Profile, nil)) {
+		if l.options.ProfileJSON != "" {
+			llmProfile, err = l.selectProfileFromJSON(profilesDir)
+		} else {
+			llmCtx, llmSpan := startSpan(selectionCtx, spanLLMSelection)
+			llmProfile, err = l.selectProfileWithLLM(llmCtx, fullConfig, profilesDir)
+			endSpan(llmSpan, err)
+		}
+		if err != nil {
+			return err
+		}
+	}
 
-			for _, plugin := range l.plugins {
-				if err := plugin.BuildProfileFromLLMResponse(prompt, fullConfig.Profile); err != nil {
-					return fmt.Errorf("failed to build profile for plugin %s: %w", plugin.GetName(), err)
+	if err := l.resolveTrackedProfile(fullConfig, cliProfile, llmProfile); err != nil {
+		return err
+	}
+	if !profileComplete(fullConfig.Profile) && !useLLM && !forced {
+		if err := l.sources.Track(fullConfig, config.SourcePicker, "interactive picker", func() error {
+			return l.pickProfile(fullConfig, profilesDir)
+		}); err != nil {
+			return err
+		}
+	}
+	l.bundle.recordConfig(fullConfig)
+	if err := l.explainConfig(fullConfig); err != nil {
+		return err
+	}
+	l.logger.Info("Resolved profile requirements",
+		"fabric", fullConfig.Profile.Fabric,
+		"deployment", fullConfig.Profile.Deployment,
+		"multirail", fullConfig.Profile.Multirail,
+		"spectrumX", fullConfig.Profile.SpectrumX,
+		"ai", fullConfig.Profile.Ai)
+
+	profileCache, err := l.openProfileCache(profilesDir)
+	if err != nil {
+		return err
+	}
+
+	foundProfiles := []profiles.Profile{}
+	profileConfigs := map[string]*config.LaunchKubernetesConfig{}
+	for pluginName, plugin := range l.plugins {
+		allRequirements := splitDeploymentTypes(fullConfig.Profile)
+		if forced {
+			allRequirements = []*config.Profile{fullConfig.Profile}
+		}
+		for _, requirements := range allRequirements {
+			var profile *profiles.Profile
+			var err error
+			if forced {
+				profile, requirements, err = l.forceProfile(profilesDir, pluginName, requirements, fullConfig.ClusterConfig.Capabilities)
+			} else {
+				profile, err = l.findApplicableProfile(profileCache, profilesDir, requirements, fullConfig.ClusterConfig.Capabilities, pluginName)
+			}
+			if errors.Is(err, profiles.ErrNoApplicableProfile) && l.options.FallbackProfile != "" {
+				profile, err = profiles.FindFallbackProfile(profilesDir, l.options.FallbackProfile, pluginName)
+				if err == nil {
+					l.ui.Warning("No profile matches fabric %s, deployment %s: using fallback profile %s", requirements.Fabric, requirements.Deployment, profile.Name)
+					l.logger.Info("Using fallback profile", "profile", profile.Name, "requirements", requirements)
 				}
 			}
-
-			l.ui.Success("Profile selected")
-			l.ui.Info("  Fabric: %s", fullConfig.Profile.Fabric)
-			l.ui.Info("  Deployment: %s", fullConfig.Profile.Deployment)
-			l.ui.Info("  Multirail: %v", fullConfig.Profile.Multirail)
-			l.logger.Info("Selected options",
-				"fabric", fullConfig.Profile.Fabric,
-				"deployment", fullConfig.Profile.Deployment,
-				"multirail", fullConfig.Profile.Multirail,
-				"spectrumX", fullConfig.Profile.SpectrumX,
-				"ai", fullConfig.Profile.Ai,
-				"reasoning", prompt["reasoning"])
-		} else if l.options.Prompt != "" {
-			l.ui.Section("Profile Selection (AI-Assisted)")
-			l.ui.Info("Analyzing requirements with AI")
-			progress := l.ui.StartProgress("Waiting for AI recommendation")
-
-			l.logger.Info("Selecting a profile using LLM-assisted prompt")
-
-			prompt, err := llm.SelectPromptWithModel(l.options.Prompt, *fullConfig.ClusterConfig, l.options.LLMApiKey, l.options.LLMApiUrl, l.options.LLMVendor, l.options.LLMModel)
 			if err != nil {
-				progress.Fail("AI selection failed")
-				l.ui.Error("Failed to get AI recommendation: %v", err)
-				return fmt.Errorf("failed to select prompt: %w", err)
-			}
-			confidence := prompt["confidence"]
-			if confidence == "low" {
-				progress.Fail("Low confidence recommendation")
-				l.ui.Warning("AI has low confidence: %s", prompt["reasoning"])
-				return fmt.Errorf("couldn't select a deployment profile based on the user prompt. Try again with a different prompt or use the cli flags (--fabric, --deployment-type, --multirail) to select the profile manually. Reason: %s", prompt["reasoning"])
+				l.ui.Error("Failed to find profile: %v", err)
+				l.logger.Error(err, "Failed to find applicable profile for the plugin", "plugin", plugin.GetName(), "cluster capabilities", fullConfig.ClusterConfig.Capabilities, "profile requirements", requirements)
+				return err
 			}
-
-			for _, plugin := range l.plugins {
-				if err := plugin.BuildProfileFromLLMResponse(prompt, fullConfig.Profile); err != nil {
-					progress.Fail("Profile building failed")
-					return fmt.Errorf("failed to build profile for plugin %s: %w", plugin.GetName(), err)
+			if l.options.Environment != "" {
+				if err := profile.ApplyEnvironment(l.options.Environment); err != nil {
+					l.ui.Error("Failed to select environment: %v", err)
+					return err
 				}
+				l.logger.Info("Using environment overlay", "profile", profile.Name, "environment", l.options.Environment)
+			}
+			if _, ok := profileConfigs[profile.Name]; ok {
+				l.logger.Info("Profile already selected for another deployment type, skipping", "profile", profile.Name, "deployment", requirements.Deployment)
+				continue
 			}
 
-			progress.Success("Profile selected")
-			l.ui.Info("  Fabric: %s", fullConfig.Profile.Fabric)
-			l.ui.Info("  Deployment: %s", fullConfig.Profile.Deployment)
-			l.ui.Info("  Multirail: %v", fullConfig.Profile.Multirail)
-			l.logger.Info("Selected options",
-				"fabric", fullConfig.Profile.Fabric,
-				"deployment", fullConfig.Profile.Deployment,
-				"multirail", fullConfig.Profile.Multirail,
-				"spectrumX", fullConfig.Profile.SpectrumX,
-				"ai", fullConfig.Profile.Ai,
-				"reasoning", prompt["reasoning"])
-		} else {
-			return fmt.Errorf("no profile configured in the command line and no prompt provided")
+			// Every profile is rendered with its own deployment type
+			profileConfig := *fullConfig
+			profileConfig.Profile = requirements
+			profileConfigs[profile.Name] = &profileConfig
+			foundProfiles = append(foundProfiles, *profile)
+			l.events.publish(Event{Type: EventProfileSelected, Profile: profile.Name})
 		}
 	}
 
-	foundProfiles := []profiles.Profile{}
-	for pluginName, plugin := range l.plugins {
-		profile, err := profiles.FindApplicableProfile(fullConfig.Profile, fullConfig.ClusterConfig.Capabilities, pluginName)
-		if err != nil {
-			l.ui.Error("Failed to find profile: %v", err)
-			l.logger.Error(err, "Failed to find applicable profile for the plugin", "plugin", plugin.GetName(), "cluster capabilities", fullConfig.ClusterConfig.Capabilities, "profile requirements", fullConfig.Profile)
-			return err
+	if err := profileCache.Save(); err != nil {
+		l.ui.Warning("Failed to save the profile cache: %v", err)
+		l.logger.Error(err, "Failed to save the profile cache", "path", l.options.ProfileCache)
+	}
+
+	enabledPlugins := slices.Sorted(maps.Keys(l.plugins))
+	for _, profile := range foundProfiles {
+		if missing := profile.MissingPlugins(enabledPlugins); len(missing) > 0 {
+			l.ui.Error("Profile %s requires plugin(s) that are not enabled: %s", profile.Name, strings.Join(missing, ", "))
+			return fmt.Errorf("profile %s requires plugin(s) that are not enabled: %s", profile.Name, strings.Join(missing, ", "))
+		}
+	}
+
+	for _, profile := range foundProfiles {
+		if err := config.ValidateClusterConfig(profileConfigs[profile.Name], filepath.Base(profile.Dir)); err != nil {
+			l.reportValidationErrors(err)
+			return fmt.Errorf("invalid cluster config for profile %s: %w", profile.Name, err)
+		}
+		for _, warning := range config.MtuWarnings(profileConfigs[profile.Name], profile.ProfileRequirements.Fabric, profile.ProfileRequirements.Deployment) {
+			l.ui.Warning("Profile %s: %s", profile.Name, warning)
+			l.logger.Info("Unusual MTU", "profile", profile.Name, "warning", warning)
 		}
-		foundProfiles = append(foundProfiles, *profile)
 	}
 
+	l.bundle.recordProfiles(foundProfiles)
+
+	phases.start(PhaseGeneration)
 	l.ui.Section("Deployment File Generation")
+	inputHashes := map[string]string{}
+	if l.options.Changed {
+		for _, profile := range foundProfiles {
+			hash, err := l.inputsHash(&profile, profileConfigs[profile.Name])
+			if err != nil {
+				return fmt.Errorf("deployment files generation failed: %w", err)
+			}
+			inputHashes[profile.Name] = hash
+		}
+	}
+
+	renderedFiles := make(map[string]map[string]string, len(foundProfiles))
 	for _, profile := range foundProfiles {
+		if l.options.Changed {
+			previous, err := readInputsHash(l.files, l.profileOutputDir(&profile, len(foundProfiles) > 1))
+			if err != nil {
+				return fmt.Errorf("deployment files generation failed: %w", err)
+			}
+			if previous == inputHashes[profile.Name] {
+				l.ui.Info("Inputs of profile %s are unchanged, skipping generation", profile.Name)
+				l.logger.Info("Skipping unchanged profile", "profile", profile.Name, "inputsHash", previous)
+				continue
+			}
+		}
+
 		l.ui.Info("Generating files for profile: %s", profile.Name)
 		l.logger.Info("Generating deployment files for profile", "profile", profile.Name)
 
-		if err := l.generateDeploymentFiles(&profile, fullConfig); err != nil {
+		files, err := l.generateDeploymentFiles(&profile, profileConfigs[profile.Name])
+		if err != nil {
 			l.ui.Error("File generation failed: %v", err)
+			l.explainRenderFailures(&profile, err)
+			return fmt.Errorf("deployment files generation failed: %w", err)
+		}
+		if err := l.checkRenderedFiles(&profile, files); err != nil {
+			return fmt.Errorf("deployment files generation failed: %w", err)
+		}
+		if files, err = annotateFiles(files, profileConfigs[profile.Name].Annotations); err != nil {
+			l.ui.Error("File generation failed: %v", err)
+			return fmt.Errorf("deployment files generation failed: %w", err)
+		}
+		if l.options.SchemaValidate {
+			if err := l.validateSchemas(&profile, files); err != nil {
+				return fmt.Errorf("deployment files generation failed: %w", err)
+			}
+		}
+		if l.options.CanonicalOutput {
+			if files, err = canonicalizeFiles(files); err != nil {
+				l.ui.Error("File generation failed: %v", err)
+				return fmt.Errorf("deployment files generation failed: %w", err)
+			}
+		}
+		if l.options.ProvenanceHeader {
+			hash, err := configHash(profileConfigs[profile.Name])
+			if err != nil {
+				return fmt.Errorf("deployment files generation failed: %w", err)
+			}
+			files = stampProvenance(files, provenanceHeader(&profile, l.options.Version, hash))
+		}
+		renderedFiles[profile.Name] = files
+		l.bundle.recordFiles(&profile, files)
+		for _, name := range sortedFileNames(files) {
+			l.events.publish(Event{Type: EventFileGenerated, Profile: profile.Name, File: name})
+		}
+	}
+
+	if len(foundProfiles) > 1 {
+		if err := detectResourceCollisions(renderedFiles); err != nil {
+			l.ui.Error("Profiles cannot be deployed together: %v", err)
 			return fmt.Errorf("deployment files generation failed: %w", err)
 		}
 	}
 
+	if l.options.SummaryDoc != "" {
+		if err := l.saveSummaryDoc(foundProfiles, profileConfigs, renderedFiles); err != nil {
+			l.ui.Error("Summary generation failed: %v", err)
+			return fmt.Errorf("deployment files generation failed: %w", err)
+		}
+	}
+
+	if l.options.OutputFormat == OutputFormatStream {
+		if err := writeManifestStream(l.stdout, renderedFiles); err != nil {
+			return fmt.Errorf("deployment files generation failed: %w", err)
+		}
+	}
+
+	if l.options.SaveDeploymentFiles != "" {
+		for _, profile := range foundProfiles {
+			if len(renderedFiles[profile.Name]) == 0 {
+				continue
+			}
+			outputDir := l.profileOutputDir(&profile, len(foundProfiles) > 1)
+			if err := l.saveDeploymentFiles(renderedFiles[profile.Name], outputDir); err != nil {
+				l.ui.Error("File generation failed: %v", err)
+				return fmt.Errorf("deployment files generation failed: failed to save deployment files: %w", err)
+			}
+			if l.options.Changed {
+				if err := writeInputsHash(l.files, outputDir, inputHashes[profile.Name]); err != nil {
+					return fmt.Errorf("deployment files generation failed: %w", err)
+				}
+			}
+			if l.options.OutputFormat == OutputFormatArgoCD {
+				if err := l.saveArgoCDApplication(&profile, outputDir); err != nil {
+					return fmt.Errorf("deployment files generation failed: %w", err)
+				}
+			}
+		}
+	}
+
+	if l.options.Diff {
+		l.ui.Section("Cluster Diff")
+		drifted := 0
+		for _, profile := range foundProfiles {
+			if len(renderedFiles[profile.Name]) == 0 {
+				continue
+			}
+			differences, err := l.diffProfile(&profile, renderedFiles[profile.Name])
+			if err != nil {
+				l.ui.Error("Diff failed: %v", err)
+				return err
+			}
+			drifted += differences
+		}
+		if l.options.DiffExitCode && drifted > 0 {
+			return fmt.Errorf("%w: %d object(s) changed or missing", ErrDrift, drifted)
+		}
+	}
+
 	// Phase 3: Cluster Deployment
 	if l.options.Deploy {
+		deployCtx := phases.start(PhaseDeployment)
 		l.ui.Section("Cluster Deployment")
-		for _, profile := range foundProfiles {
-			if err := l.deployConfigurationProfile(&profile); err != nil {
-				l.ui.Error("Deployment failed: %v", err)
+		if len(l.deployTargets) > 0 {
+			if err := l.deployToClusters(deployCtx, foundProfiles, renderedFiles); err != nil {
 				return fmt.Errorf("deployment failed: %w", err)
 			}
+		} else if err := l.deployToCluster(deployCtx, l.kubeClient, foundProfiles, renderedFiles); err != nil {
+			return fmt.Errorf("deployment failed: %w", err)
 		}
 	}
 
@@ -249,9 +578,25 @@ func (l *Launcher) executeWorkflow() error {
 	return nil
 }
 
-// discoverClusterConfig handles cluster configuration discovery
-func (l *Launcher) discoverClusterConfig() error {
-	if l.options.UserConfig != "" {
+// reportValidationErrors prints every config validation problem, grouped by config section
+func (l *Launcher) reportValidationErrors(err error) {
+	var validationErrs config.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		l.ui.Error("Invalid configuration: %v", err)
+		return
+	}
+
+	l.ui.Error("Invalid configuration (%d problem(s) found):", len(validationErrs))
+	for _, section := range validationErrs.Sections() {
+		for _, validationErr := range validationErrs.InSection(section) {
+			l.ui.Error("  [%s] %s", section, validationErr.Message)
+		}
+	}
+}
+
+// discoverClusterConfig handles cluster configuration discovery within ctx
+func (l *Launcher) discoverClusterConfig(ctx context.Context) error {
+	if l.options.UserConfig != "" && !l.options.TemplateConfig {
 		l.ui.Info("Using provided configuration: %s", l.options.UserConfig)
 		l.logger.Info("Using provided user config", "path", l.options.UserConfig)
 		// TODO: Validate and load user config file
@@ -278,17 +623,36 @@ func (l *Launcher) discoverClusterConfig() error {
 	}
 	defaults.Profile = nil
 
-	ctx := ui.WithOutput(context.Background(), l.ui)
+	kubeClient, err := l.discoveryClient()
+	if err != nil {
+		return err
+	}
+
+	ctx = ui.WithOutput(ctx, l.ui)
 	for _, plugin := range l.plugins {
-		err := plugin.DiscoverClusterConfig(ctx, l.kubeClient, defaults)
+		result, err := plugin.DiscoverClusterConfig(ctx, kubeClient, defaults)
 		if err != nil {
 			l.ui.Error("Discovery failed: %v", err)
 			return fmt.Errorf("failed to discover cluster config: %w", err)
 		}
+		for _, warning := range result.Warnings() {
+			l.ui.Warning("Partial discovery for plugin %s: %s", plugin.GetName(), warning)
+			l.logger.Info("Partial cluster discovery", "plugin", plugin.GetName(), "warning", warning)
+		}
 	}
 
+	l.recordClusterID(ctx, kubeClient, defaults)
 	discoveredConfig := *defaults
 
+	if l.options.PrintDiscovery != "" {
+		if err := writeDiscovery(l.stdout, l.options.PrintDiscovery, discoveredConfig.ClusterConfig.Capabilities); err != nil {
+			return err
+		}
+		if l.options.SaveClusterConfig == "" {
+			return nil
+		}
+	}
+
 	// Ensure output path provided
 	if l.options.SaveClusterConfig == "" {
 		return fmt.Errorf("no output path provided for discovered cluster config (use --discover-cluster-config)")
@@ -299,59 +663,265 @@ func (l *Launcher) discoverClusterConfig() error {
 	if err != nil {
 		return fmt.Errorf("failed to marshal discovered config: %w", err)
 	}
-	if err := os.MkdirAll(filepath.Dir(l.options.SaveClusterConfig), 0755); err != nil {
+	if err := l.files.MkdirAll(filepath.Dir(l.options.SaveClusterConfig), 0755); err != nil {
 		return fmt.Errorf("failed to create output directory %s: %w", filepath.Dir(l.options.SaveClusterConfig), err)
 	}
-	if err := os.WriteFile(l.options.SaveClusterConfig, data, 0644); err != nil {
+	if err := l.files.WriteFile(l.options.SaveClusterConfig, data, 0644); err != nil {
 		l.ui.Error("Failed to save configuration: %v", err)
 		return fmt.Errorf("failed to write discovered config to %s: %w", l.options.SaveClusterConfig, err)
 	}
 
+	l.bundle.recordDiscovery(&discoveredConfig)
+
 	l.ui.Success("Configuration saved: %s", l.options.SaveClusterConfig)
 	l.logger.Info("Discovered cluster config saved", "path", l.options.SaveClusterConfig)
+	l.logger.V(1).Info("Discovered cluster config", "config", config.Redact(&discoveredConfig))
 	return nil
 }
 
-// generateDeploymentFiles handles deployment file generation
-func (l *Launcher) generateDeploymentFiles(profile *profiles.Profile, clusterConfig *config.LaunchKubernetesConfig) error {
+// discoveryClient returns the client the discovery runs against: the recorded cluster of DiscoverySnapshot when set
+func (l *Launcher) discoveryClient() (client.Client, error) {
+	if l.options.DiscoverySnapshot == "" {
+		return l.kubeClient, nil
+	}
+
+	snapshotClient, err := kubeclient.NewFromSnapshot(l.options.DiscoverySnapshot)
+	if err != nil {
+		l.ui.Error("Failed to load the cluster snapshot: %v", err)
+		return nil, err
+	}
+	l.ui.Info("Using cluster snapshot: %s", l.options.DiscoverySnapshot)
+	l.logger.Info("Discovering from a recorded cluster snapshot", "path", l.options.DiscoverySnapshot)
+	return snapshotClient, nil
+}
+
+// loadConfig loads the config at configPath. With TemplateConfig, configPath holds the discovered config
+// and the user config is rendered as a template against it.
+func (l *Launcher) loadConfig(ctx context.Context, configPath string) (*config.LaunchKubernetesConfig, error) {
+	if l.options.ConfigFromSecret != "" {
+		return l.loadConfigFromCluster(ctx)
+	}
+	if l.options.RunSpec != "" {
+		return l.loadRunSpec()
+	}
+	if !l.options.TemplateConfig {
+		return config.LoadFullConfig(configPath, l.logger)
+	}
+
+	discovered, err := config.LoadFullConfig(configPath, l.logger)
+	if err != nil {
+		return nil, err
+	}
+	return config.LoadFullConfigTemplate(l.options.UserConfig, discovered, l.logger)
+}
+
+// loadRunSpec loads the config of the run spec, with the profile requirements of its intent
+func (l *Launcher) loadRunSpec() (*config.LaunchKubernetesConfig, error) {
+	spec, err := config.LoadRunSpec(l.options.RunSpec, l.logger)
+	if err != nil {
+		l.reportValidationErrors(err)
+		return nil, err
+	}
+	l.ui.Info("Using run spec: %s", l.options.RunSpec)
+
+	if profile := spec.Intent.Profile(); profile != nil {
+		spec.Config.Profile = profile
+	}
+	l.runSpecPrompt = spec.Intent.Prompt
+	return spec.Config, nil
+}
+
+// loadConfigFromCluster loads the config from the Secret or ConfigMap key referenced by ConfigFromSecret within ctx
+func (l *Launcher) loadConfigFromCluster(ctx context.Context) (*config.LaunchKubernetesConfig, error) {
+	source, err := config.ParseClusterSource(l.options.ConfigFromSecret)
+	if err != nil {
+		return nil, err
+	}
+	if l.kubeClient == nil {
+		return nil, fmt.Errorf("loading the config from %s requires a Kubernetes client", source)
+	}
+
+	l.ui.Info("Using configuration from %s", source)
+	return config.LoadFullConfigFromCluster(ctx, l.kubeClient, source, l.logger)
+}
+
+// generateDeploymentFiles renders the deployment files of the profile
+func (l *Launcher) generateDeploymentFiles(profile *profiles.Profile, clusterConfig *config.LaunchKubernetesConfig) (map[string]string, error) {
 	l.logger.Info("Generating deployment files", "profile", profile.Name)
-	l.logger.Info("Generating deployment files", "config", clusterConfig)
+	l.logger.Info("Generating deployment files", "config", config.Redact(clusterConfig))
 
-	plugin, ok := l.plugins[profile.Plugin]
+	profilePlugin, ok := l.plugins[profile.Plugin]
 	if !ok {
-		return fmt.Errorf("plugin %s not found", profile.Plugin)
+		return nil, fmt.Errorf("plugin %s not found", profile.Plugin)
 	}
 
-	renderedFiles, err := plugin.GenerateProfileDeploymentFiles(profile, clusterConfig)
+	renderedFiles, err := profilePlugin.GenerateProfileDeploymentFiles(profile, clusterConfig)
 	if err != nil {
-		return fmt.Errorf("failed to process profile templates: %w", err)
+		return nil, fmt.Errorf("failed to process profile templates: %w", err)
 	}
 
-	if l.options.SaveDeploymentFiles != "" {
-		if err := l.saveDeploymentFiles(renderedFiles, filepath.Join(l.options.SaveDeploymentFiles, profile.Plugin)); err != nil {
-			return fmt.Errorf("failed to save deployment files: %w", err)
+	if postRenderer, ok := profilePlugin.(plugin.PostRenderer); ok {
+		renderedFiles, err = postRenderer.PostRender(renderedFiles)
+		if err != nil {
+			return nil, fmt.Errorf("plugin %s failed to post-process the rendered files: %w", profile.Plugin, err)
 		}
 	}
 
-	return nil
+	return renderedFiles, nil
+}
+
+// checkRenderedFiles fails when the profile rendered no files, which almost always indicates a misconfigured profile.
+// With AllowEmptyProfile only a warning is shown and the profile is neither saved nor deployed.
+func (l *Launcher) checkRenderedFiles(profile *profiles.Profile, renderedFiles map[string]string) error {
+	if len(renderedFiles) > 0 {
+		return nil
+	}
+
+	if l.options.AllowEmptyProfile {
+		l.ui.Warning("Profile %s rendered no deployment files, skipping it", profile.Name)
+		l.logger.Info("Profile rendered no deployment files", "profile", profile.Name)
+		return nil
+	}
+
+	l.ui.Error("Profile %s rendered no deployment files", profile.Name)
+	return fmt.Errorf("profile %s rendered no deployment files, check its templates or use --allow-empty-profile", profile.Name)
+}
+
+// validateSchemas validates the rendered files of the profile against the schemas of their kinds
+func (l *Launcher) validateSchemas(profile *profiles.Profile, renderedFiles map[string]string) error {
+	validator, err := deploy.NewSchemaValidator()
+	if err != nil {
+		return err
+	}
+	violations, skipped, err := validator.ValidateManifests(renderedFiles)
+	if err != nil {
+		return fmt.Errorf("schema validation of profile %s failed: %w", profile.Name, err)
+	}
+	for _, kind := range skipped {
+		l.ui.Warning("No schema known for %s, skipping its validation", kind)
+	}
+	if len(violations) == 0 {
+		l.logger.Info("Rendered files match the schemas", "profile", profile.Name)
+		return nil
+	}
+
+	l.ui.Error("Profile %s rendered %d schema violation(s):", profile.Name, len(violations))
+	for _, violation := range violations {
+		l.ui.Error("  %s", violation)
+	}
+	return fmt.Errorf("profile %s rendered %d schema violation(s)", profile.Name, len(violations))
+}
+
+// profileOutputDir returns the directory the files of the profile are saved to.
+// When several profiles are generated together, each one gets its own subdirectory unless the
+// directory contains the {profile} placeholder.
+func (l *Launcher) profileOutputDir(profile *profiles.Profile, multipleProfiles bool) string {
+	baseDir := expandOutputDir(l.options.SaveDeploymentFiles, filepath.Base(profile.Dir), l.runStarted)
+	outputDir := filepath.Join(baseDir, profile.Plugin)
+	if multipleProfiles && !strings.Contains(l.options.SaveDeploymentFiles, ProfilePlaceholder) {
+		outputDir = filepath.Join(outputDir, filepath.Base(profile.Dir))
+	}
+	return outputDir
+}
+
+// profilesDirs returns the directories to search for deployment profiles, in precedence order
+func (l *Launcher) profilesDirs() []string {
+	if len(l.options.ProfilesDirs) > 0 {
+		return l.options.ProfilesDirs
+	}
+	return []string{profiles.ProfilesDir}
+}
+
+// resolveProfilesDir returns a local directory with the deployment profiles, pulling them first within ctx
+// from the OCI references. Several profiles directories are merged into a temporary directory, earlier ones
+// shadowing the profiles of later ones. The returned cleanup removes pulled and merged profiles.
+func (l *Launcher) resolveProfilesDir(ctx context.Context) (string, func(), error) {
+	locations := l.profilesDirs()
+	if len(locations) == 1 {
+		return l.resolveProfilesLocation(ctx, locations[0])
+	}
+
+	dirs := make([]string, 0, len(locations))
+	cleanups := make([]func(), 0, len(locations))
+	defer func() {
+		for _, cleanup := range cleanups {
+			cleanup()
+		}
+	}()
+	for _, location := range locations {
+		dir, cleanup, err := l.resolveProfilesLocation(ctx, location)
+		if err != nil {
+			return "", nil, err
+		}
+		dirs = append(dirs, dir)
+		cleanups = append(cleanups, cleanup)
+	}
+
+	merged, err := profiles.MergeProfilesDirs(dirs)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to merge profiles directories: %w", err)
+	}
+	l.logger.Info("Merged profiles directories", "profilesDirs", locations, "directory", merged)
+
+	return merged, func() {
+		if err := os.RemoveAll(merged); err != nil {
+			l.logger.Error(err, "Failed to remove merged profiles", "directory", merged)
+		}
+	}, nil
+}
+
+// resolveProfilesLocation returns a local directory with the profiles of location, pulling them first
+// within ctx when it is an OCI reference. The returned cleanup removes pulled profiles.
+func (l *Launcher) resolveProfilesLocation(ctx context.Context, location string) (string, func(), error) {
+	if !profiles.IsOCIReference(location) {
+		return location, func() {}, nil
+	}
+
+	progress := l.ui.StartProgressContext(ctx, fmt.Sprintf("Pulling profiles from %s", location))
+	dir, err := profiles.PullOCIProfiles(ctx, location, profiles.OCIOptions{
+		Secrets:    secrets.EnvProvider{},
+		HTTPClient: l.httpClient,
+	})
+	if err != nil {
+		progress.Fail("Failed to pull profiles")
+		return "", nil, fmt.Errorf("failed to pull profiles from %s: %w", location, err)
+	}
+	progress.Success("Profiles pulled")
+
+	return dir, func() {
+		if err := os.RemoveAll(dir); err != nil {
+			l.logger.Error(err, "Failed to remove pulled profiles", "directory", dir)
+		}
+	}, nil
 }
 
 // saveDeploymentFiles saves the rendered deployment files to disk
 func (l *Launcher) saveDeploymentFiles(renderedFiles map[string]string, outputDir string) error {
 	l.logger.Info("Saving deployment files", "directory", outputDir)
 
-	// Clean the output directory before saving files
-	if err := os.RemoveAll(outputDir); err != nil {
+	if l.keepOutputFiles {
+		// Cleaning is disabled in the config file, generated files are only overwritten
+		l.logger.Info("Output directory cleaning disabled by the config file", "directory", outputDir)
+	} else if l.options.NoClean {
+		// Keep files added by the user, only remove the generated files that are no longer rendered
+		removed, err := removeStaleFiles(l.files, outputDir, renderedFiles)
+		if err != nil {
+			return fmt.Errorf("failed to clean output directory %s: %w", outputDir, err)
+		}
+		for _, name := range removed {
+			l.logger.Info("Removed stale deployment file", "file", filepath.Join(outputDir, name))
+		}
+	} else if err := l.files.RemoveAll(outputDir); err != nil {
 		return fmt.Errorf("failed to clean output directory %s: %w", outputDir, err)
 	}
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
+	if err := l.files.MkdirAll(outputDir, 0755); err != nil {
 		return fmt.Errorf("failed to create output directory %s: %w", outputDir, err)
 	}
 
-	for filename, content := range renderedFiles {
+	for _, filename := range sortedFileNames(renderedFiles) {
 		outputPath := fmt.Sprintf("%s/%s", outputDir, filename)
 
-		if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+		if err := l.files.WriteFile(outputPath, []byte(renderedFiles[filename]), 0644); err != nil {
 			l.ui.Error("Failed to write file %s: %v", outputPath, err)
 			return fmt.Errorf("failed to write file %s: %w", outputPath, err)
 		}
@@ -359,6 +929,10 @@ func (l *Launcher) saveDeploymentFiles(renderedFiles map[string]string, outputDi
 		l.logger.Info("Saved deployment file", "file", outputPath)
 	}
 
+	if err := writeGeneratedFiles(l.files, outputDir, renderedFiles); err != nil {
+		return err
+	}
+
 	l.ui.Success("Saved %d file(s) to: %s", len(renderedFiles), outputDir)
 	l.logger.Info("All deployment files saved successfully",
 		"directory", outputDir,
@@ -367,8 +941,8 @@ func (l *Launcher) saveDeploymentFiles(renderedFiles map[string]string, outputDi
 	return nil
 }
 
-// deployConfigurationProfile handles cluster deployment
-func (l *Launcher) deployConfigurationProfile(profile *profiles.Profile) error {
+// deployConfigurationProfile handles deployment of the rendered files of the profile to the cluster of c within ctx
+func (l *Launcher) deployConfigurationProfile(ctx context.Context, c client.Client, profile *profiles.Profile, renderedFiles map[string]string) error {
 	if !l.options.Deploy {
 		l.logger.Info("Skipped (deploy not requested)")
 		return nil
@@ -377,19 +951,19 @@ func (l *Launcher) deployConfigurationProfile(profile *profiles.Profile) error {
 	l.ui.Info("Deploying profile: %s", profile.Name)
 	l.logger.Info("Deploying profile to cluster", "profile", profile.Name, "kubeconfig", l.options.Kubeconfig)
 
-	if l.options.SaveDeploymentFiles == "" {
-		l.ui.Error("Deployment requires generated files (use --save-deployment-files)")
-		return fmt.Errorf("--deploy requires generated files directory; provide --save-deployment-files")
-	}
-
 	plugin, ok := l.plugins[profile.Plugin]
 	if !ok {
 		l.ui.Error("Plugin not found: %s", profile.Plugin)
 		return fmt.Errorf("plugin %s not found", profile.Plugin)
 	}
 
-	ctx := ui.WithOutput(context.Background(), l.ui)
-	if err := plugin.DeployProfile(ctx, profile, l.kubeClient, filepath.Join(l.options.SaveDeploymentFiles, profile.Plugin)); err != nil {
+	ctx = ui.WithOutput(ctx, l.ui)
+	opts := l.deployOptions()
+	opts.FileOrder = profile.ApplyOrderFiles()
+	opts.Applied = func(object string) {
+		l.events.publish(Event{Type: EventResourceApplied, Profile: profile.Name, Resource: object})
+	}
+	if err := plugin.DeployProfile(ctx, profile, c, renderedFiles, opts); err != nil {
 		l.ui.Error("Deployment failed: %v", err)
 		return fmt.Errorf("failed to deploy profile: %w", err)
 	}
@@ -399,9 +973,262 @@ func (l *Launcher) deployConfigurationProfile(profile *profiles.Profile) error {
 	return nil
 }
 
-// runInteractiveSession runs an interactive chat session with the LLM
-func (l *Launcher) runInteractiveSession(clusterConfig *config.ClusterConfig) (map[string]string, error) {
-	session, err := llm.NewChatSession(*clusterConfig, l.options.LLMApiKey, l.options.LLMApiUrl, l.options.LLMVendor, l.options.LLMModel)
+// deployOptions returns the apply settings selected on the command line
+func (l *Launcher) deployOptions() deploy.Options {
+	return deploy.Options{
+		ConfirmConflicts: l.options.ConfirmConflicts,
+		ContinueOnError:  l.options.ContinueOnError,
+		FileGlobs:        l.options.ApplyFiles,
+		Kinds:            l.options.ApplyKinds,
+		Exclude:          l.options.Exclude,
+		FieldManager:     l.options.FieldManager,
+		ConflictPolicy:   deploy.ConflictPolicy(l.options.ConflictPolicy),
+		Checkpoint:       l.checkpoint,
+	}
+}
+
+// checkDeployPermissions verifies that the kubeconfig user may apply every rendered object
+// before anything is applied, reporting all missing permissions at once
+func (l *Launcher) checkDeployPermissions(ctx context.Context, c client.Client, renderedFiles map[string]map[string]string) error {
+	progress := l.ui.StartProgressContext(ctx, "Checking cluster permissions")
+
+	allFiles := map[string]string{}
+	for profileName, files := range renderedFiles {
+		for fileName, content := range files {
+			allFiles[filepath.Join(profileName, fileName)] = content
+		}
+	}
+
+	missing, err := deploy.CheckPermissionsWithOptions(ctx, c, allFiles, l.deployOptions())
+	if err != nil {
+		progress.Fail("Permission check failed")
+		return fmt.Errorf("failed to check cluster permissions: %w", err)
+	}
+
+	if len(missing) > 0 {
+		progress.Fail(fmt.Sprintf("Missing %d permission(s)", len(missing)))
+		for _, permission := range missing {
+			l.ui.Error("  cannot %s", permission)
+		}
+		return fmt.Errorf("kubeconfig user is missing %d permission(s) required for deployment", len(missing))
+	}
+
+	progress.Success("Cluster permissions verified")
+	return nil
+}
+
+// printSystemPrompt writes the system prompt the LLM is sent for the profiles in profilesDir to stdout, with the
+// secrets redacted, without calling the LLM
+func (l *Launcher) printSystemPrompt(fullConfig *config.LaunchKubernetesConfig, profilesDir string) error {
+	available, err := l.enabledProfiles(profilesDir)
+	if err != nil {
+		return err
+	}
+
+	clusterConfig := config.ClusterConfig{}
+	if fullConfig.ClusterConfig != nil {
+		clusterConfig = *fullConfig.ClusterConfig
+	}
+	prompt, err := llm.RenderSystemPrompt(llm.ProfilesContext(available), clusterConfig)
+	if err != nil {
+		l.ui.Error("Failed to render the system prompt: %v", err)
+		return err
+	}
+
+	if _, err := l.stdout.Write(redact([]byte(prompt+"\n"), redactedSecrets(l.options))); err != nil {
+		return fmt.Errorf("failed to print the system prompt: %w", err)
+	}
+	return nil
+}
+
+// selectProfileWithLLM asks the LLM for the profile requirements, interactively or from the prompt file.
+// The system prompt lists the options supported by the profiles of the enabled plugins in profilesDir.
+// The LLM requests are bounded by the LLM timeout within runCtx.
+func (l *Launcher) selectProfileWithLLM(runCtx context.Context, fullConfig *config.LaunchKubernetesConfig, profilesDir string) (*config.Profile, error) {
+	l.ui.Section("Profile Selection (AI-Assisted)")
+
+	available, err := l.enabledProfiles(profilesDir)
+	if err != nil {
+		return nil, err
+	}
+	profilesContext := llm.ProfilesContext(available)
+
+	var prompt map[string]string
+	var progress ui.Progress
+	if l.options.LLMInteractive {
+		l.logger.Info("Starting interactive LLM session")
+
+		prompt, err = l.runInteractiveSession(runCtx, fullConfig.ClusterConfig, profilesContext)
+		if err != nil {
+			l.ui.Error("Interactive session failed: %v", err)
+			return nil, fmt.Errorf("interactive session failed: %w", err)
+		}
+	} else {
+		l.ui.Info("Analyzing requirements with AI")
+		progress = l.ui.StartProgressContext(runCtx, "Waiting for AI recommendation")
+
+		l.logger.Info("Selecting a profile using LLM-assisted prompt")
+
+		prompt, err = l.selectPrompt(runCtx, fullConfig.ClusterConfig, profilesContext, llm.NewVocabulary(available))
+		var lowConfidence *llm.ErrLowConfidence
+		if errors.As(err, &lowConfidence) {
+			progress.Fail("Low confidence recommendation")
+			// The interactive session reports its own progress
+			progress = nil
+			prompt, err = l.clarifyLowConfidence(runCtx, lowConfidence, fullConfig.ClusterConfig, profilesContext)
+			if err != nil {
+				return nil, err
+			}
+		} else if err != nil {
+			progress.Fail("AI selection failed")
+			l.ui.Error("Failed to get AI recommendation: %v", err)
+			return nil, fmt.Errorf("failed to select prompt: %w", err)
+		}
+	}
+
+	profile, err := l.buildProfileFromLLMResponse(prompt)
+	if err != nil {
+		if progress != nil {
+			progress.Fail("Profile building failed")
+		}
+		return nil, err
+	}
+
+	if progress != nil {
+		progress.Success("Profile selected")
+	} else {
+		l.ui.Success("Profile selected")
+	}
+	l.ui.Info("  Fabric: %s", profile.Fabric)
+	l.ui.Info("  Deployment: %s", profile.Deployment)
+	l.ui.Info("  Multirail: %v", profile.Multirail)
+	l.logger.Info("Selected options",
+		"fabric", profile.Fabric,
+		"deployment", profile.Deployment,
+		"multirail", profile.Multirail,
+		"spectrumX", profile.SpectrumX,
+		"ai", profile.Ai,
+		"reasoning", prompt["reasoning"])
+
+	return profile, nil
+}
+
+// selectProfileFromJSON builds the profile requirements from the ProfileJSON answer, checked like an LLM answer
+// against the options of the profiles of the enabled plugins in profilesDir
+func (l *Launcher) selectProfileFromJSON(profilesDir string) (*config.Profile, error) {
+	available, err := l.enabledProfiles(profilesDir)
+	if err != nil {
+		return nil, err
+	}
+	prompt, err := llm.ParseProfileJSON(l.options.ProfileJSON, llm.NewVocabulary(available))
+	if err != nil {
+		l.ui.Error("Invalid --profile-json: %v", err)
+		return nil, err
+	}
+
+	profile, err := l.buildProfileFromLLMResponse(prompt)
+	if err != nil {
+		return nil, err
+	}
+	l.ui.Info("Using the profile of --profile-json: fabric %s, deployment %s", profile.Fabric, profile.Deployment)
+	l.logger.Info("Selected options from the JSON profile",
+		"fabric", profile.Fabric,
+		"deployment", profile.Deployment,
+		"multirail", profile.Multirail,
+		"spectrumX", profile.SpectrumX,
+		"ai", profile.Ai)
+	return profile, nil
+}
+
+// buildProfileFromLLMResponse builds the profile requirements from the LLM response with every enabled plugin
+func (l *Launcher) buildProfileFromLLMResponse(prompt map[string]string) (*config.Profile, error) {
+	profile := &config.Profile{}
+	for _, plugin := range l.plugins {
+		if err := plugin.BuildProfileFromLLMResponse(prompt, profile); err != nil {
+			return nil, fmt.Errorf("failed to build profile for plugin %s: %w", plugin.GetName(), err)
+		}
+	}
+	return profile, nil
+}
+
+// selectPrompt asks the LLM for the profile matching the prompt of the run spec or of the Prompt file,
+// among the options of the vocabulary
+func (l *Launcher) selectPrompt(runCtx context.Context, clusterConfig *config.ClusterConfig, profilesContext string, vocabulary llm.Vocabulary) (map[string]string, error) {
+	userPrompt := l.runSpecPrompt
+	if userPrompt == "" {
+		data, err := os.ReadFile(l.options.Prompt)
+		if err != nil {
+			return nil, err
+		}
+		userPrompt = string(data)
+	}
+
+	timeout := l.llmTimeout()
+	ctx, cancel := timeout.context(runCtx)
+	defer cancel()
+	prompt, err := llm.SelectPromptTextWithVocabulary(ctx, userPrompt, *clusterConfig, profilesContext, vocabulary, l.llmConfig())
+	return prompt, timeout.wrap(runCtx, ctx, err)
+}
+
+// lowConfidenceFields are the profile fields of a low confidence LLM answer shown to the user
+var lowConfidenceFields = []string{"fabric", "deploymentType", "multirail", "spectrumX", "ai"}
+
+// clarifyLowConfidence shows the reasoning and partial selection of a low confidence LLM answer, and offers to refine
+// the requirements in an interactive session
+func (l *Launcher) clarifyLowConfidence(runCtx context.Context, lowConfidence *llm.ErrLowConfidence, clusterConfig *config.ClusterConfig, profilesContext string) (map[string]string, error) {
+	l.ui.Warning("AI has %s confidence: %s", lowConfidence.Confidence, lowConfidence.Reasoning)
+	for _, field := range lowConfidenceFields {
+		if value := lowConfidence.Profile[field]; value != "" {
+			l.ui.Info("  %s: %s", field, value)
+		}
+	}
+
+	if !l.ui.Confirm("Refine the requirements in an interactive session?") {
+		return nil, fmt.Errorf("couldn't select a deployment profile based on the user prompt. Try again with a different prompt or use the cli flags (--fabric, --deployment-type, --multirail) to select the profile manually: %w", lowConfidence)
+	}
+
+	l.logger.Info("Starting interactive LLM session after a low confidence answer", "reasoning", lowConfidence.Reasoning)
+	prompt, err := l.runInteractiveSession(runCtx, clusterConfig, profilesContext)
+	if err != nil {
+		l.ui.Error("Interactive session failed: %v", err)
+		return nil, fmt.Errorf("interactive session failed: %w", err)
+	}
+	return prompt, nil
+}
+
+// llmConfig returns the LLM provider settings selected on the command line
+func (l *Launcher) llmConfig() llm.LLMConfig {
+	return llm.LLMConfig{
+		Vendor:        l.options.LLMVendor,
+		APIKey:        l.options.LLMApiKey,
+		BaseURL:       l.options.LLMApiUrl,
+		Model:         l.options.LLMModel,
+		Fixture:       l.options.LLMFixture,
+		HTTPClient:    l.httpClient,
+		MinConfidence: l.options.MinConfidence,
+	}
+}
+
+// enabledProfiles returns the profiles of the enabled plugins, whose options are offered to the LLM
+func (l *Launcher) enabledProfiles(profilesDir string) ([]profiles.Profile, error) {
+	available, err := profiles.LoadProfilesDir(profilesDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load profiles: %w", err)
+	}
+
+	enabled := available[:0]
+	for _, profile := range available {
+		if _, ok := l.plugins[profile.Plugin]; ok {
+			enabled = append(enabled, profile)
+		}
+	}
+	return enabled, nil
+}
+
+// runInteractiveSession runs an interactive chat session with the LLM. Every response is bounded by the LLM timeout
+// within runCtx, not the whole session, so that the time spent typing doesn't count.
+func (l *Launcher) runInteractiveSession(runCtx context.Context, clusterConfig *config.ClusterConfig, profilesContext string) (map[string]string, error) {
+	session, err := llm.NewChatSessionWithConfig(*clusterConfig, profilesContext, l.llmConfig())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create chat session: %w", err)
 	}
@@ -412,7 +1239,7 @@ func (l *Launcher) runInteractiveSession(clusterConfig *config.ClusterConfig) (m
 	fmt.Println("Ask questions about network configuration or describe your requirements.")
 	fmt.Println("Type 'generate' to generate manifests based on the recommended profile.")
 	fmt.Println("Type 'exit' or 'quit' to cancel.")
-	fmt.Println("================================\n")
+	fmt.Print("================================\n\n")
 
 	for {
 		fmt.Print("You: ")
@@ -439,8 +1266,8 @@ func (l *Launcher) runInteractiveSession(clusterConfig *config.ClusterConfig) (m
 			}
 
 			confidence := profile["confidence"]
-			if confidence == "low" {
-				fmt.Printf("\nWarning: The LLM has low confidence in this recommendation.\n")
+			if !llm.MeetsConfidence(confidence, l.options.MinConfidence) {
+				fmt.Printf("\nWarning: The LLM has %s confidence in this recommendation.\n", confidence)
 				fmt.Printf("Reason: %s\n", profile["reasoning"])
 				fmt.Print("Do you want to proceed anyway? (yes/no): ")
 
@@ -456,17 +1283,37 @@ func (l *Launcher) runInteractiveSession(clusterConfig *config.ClusterConfig) (m
 			return profile, nil
 		}
 
-		// Send message to LLM
-		progress := l.ui.StartProgress("Waiting for AI response")
-		response, err := session.SendMessage(context.Background(), input)
+		// Send message to LLM, streaming the response once the first chunk arrives
+		progress := l.ui.StartProgressContext(runCtx, "Waiting for AI response")
+		streamed := false
+		timeout := l.llmTimeout()
+		ctx, cancel := timeout.context(runCtx)
+		response, err := session.SendMessageStreaming(ctx, input, func(chunk string) {
+			if !streamed {
+				streamed = true
+				progress.Success("Response started")
+				l.ui.Stream("\nAssistant: ")
+			}
+			l.ui.Stream(chunk)
+		})
+		err = timeout.wrap(runCtx, ctx, err)
+		cancel()
 		if err != nil {
-			progress.Fail("AI request failed")
-			fmt.Printf("Error: %v\n", err)
+			if !streamed {
+				progress.Fail("AI request failed")
+			}
+			// The session cannot continue once the run timed out
+			if runCtx.Err() != nil {
+				return nil, err
+			}
+			fmt.Printf("\nError: %v\n", err)
 			continue
 		}
-		progress.Success("Response received")
+		if !streamed {
+			progress.Success("Response received")
+			l.ui.Stream("\nAssistant: " + response)
+		}
 
-		fmt.Printf("\nAssistant: %s", response)
 		fmt.Println(llm.InteractivePromptSuffix)
 		fmt.Println()
 	}
@@ -0,0 +1,1088 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	sigsyaml "sigs.k8s.io/yaml"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/deploy"
+	"github.com/nvidia/k8s-launch-kit/pkg/networkoperatorplugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+	"github.com/nvidia/k8s-launch-kit/pkg/plugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	"github.com/nvidia/k8s-launch-kit/pkg/ui"
+)
+
+const testClusterConfig = `networkOperator:
+  version: v25.10.0
+  componentVersion: network-operator-v25.10.0
+  repository: nvcr.io/nvidia/mellanox
+  namespace: nvidia-network-operator
+sriov:
+  numVfs: 8
+  resourceName: sriov_resource
+  networkName: sriov_network
+hostdev:
+  resourceName: hostdev-resource
+  networkName: hostdev-network
+clusterConfig:
+  capabilities:
+    nodes:
+      sriov: true
+      rdma: true
+      ib: false
+  pfs:
+  - pciAddress: "0000:08:00.0"
+    networkInterface: ens1f0
+    traffic: east-west
+`
+
+// writeTestProfile creates a profile directory with the given templates
+func writeTestProfile(t *testing.T, profilesDir, dirName, manifest string, templates map[string]string) {
+	t.Helper()
+	dir := filepath.Join(profilesDir, dirName)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "profile.yaml"), []byte(manifest), 0644))
+	for name, content := range templates {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+}
+
+// newTestLauncher returns a launcher with the network operator plugin, silent output and a config file
+func newTestLauncher(t *testing.T, opts options.Options) *Launcher {
+	t.Helper()
+	if opts.UserConfig == "" {
+		opts.UserConfig = filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(opts.UserConfig, []byte(testClusterConfig), 0644))
+	}
+
+	return &Launcher{
+		options: opts,
+		logger:  logr.Discard(),
+		plugins: map[string]plugin.Plugin{
+			networkoperatorplugin.PluginName: &networkoperatorplugin.NetworkOperatorPlugin{},
+		},
+		available: BuiltinPlugins(),
+		ui:        ui.NewSilent(),
+		files:     OSFileWriter{},
+	}
+}
+
+// newAllowingClient returns a fake client that knows ConfigMaps, allows every access review and records the applied
+// objects, rejecting the applies of the objects named in failing
+func newAllowingClient(applied *[]string, failing ...string) client.Client {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+	return fake.NewClientBuilder().WithRESTMapper(mapper).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if review, ok := obj.(*authorizationv1.SelfSubjectAccessReview); ok {
+				review.Status.Allowed = true
+				return nil
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			if slices.Contains(failing, obj.GetName()) {
+				return fmt.Errorf("apply of %s rejected", obj.GetName())
+			}
+			*applied = append(*applied, obj.GetObjectKind().GroupVersionKind().Kind+"/"+obj.GetName())
+			return nil
+		},
+	}).Build()
+}
+
+const sriovProfileManifest = `name: SR-IOV
+plugin: network-operator
+profileRequirements:
+  deployment: sriov
+templates:
+  - 30-network.yaml
+`
+
+const hostdevProfileManifest = `name: Host device
+plugin: network-operator
+profileRequirements:
+  deployment: host_device
+templates:
+  - 30-network.yaml
+`
+
+func TestSeveralProfilesDirs(t *testing.T) {
+	teamDir, orgDir := t.TempDir(), t.TempDir()
+	writeTestProfile(t, orgDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: org-network\n",
+	})
+	writeTestProfile(t, orgDir, "host-device-rdma", hostdevProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Hostdev.NetworkName}}\n",
+	})
+	writeTestProfile(t, teamDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: team-network\n",
+	})
+
+	outputDir := t.TempDir()
+	launcher := newTestLauncher(t, options.Options{
+		Fabric:              "ethernet",
+		DeploymentType:      "sriov,host_device",
+		SaveDeploymentFiles: outputDir,
+		ProfilesDirs:        []string{teamDir, orgDir},
+	})
+
+	require.NoError(t, launcher.executeWorkflow())
+
+	sriovFile, err := os.ReadFile(filepath.Join(outputDir, networkoperatorplugin.PluginName, "sriov-ethernet-rdma", "30-network.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(sriovFile), "name: team-network", "the team profile shadows the org profile")
+
+	hostdevFile, err := os.ReadFile(filepath.Join(outputDir, networkoperatorplugin.PluginName, "host-device-rdma", "30-network.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(hostdevFile), "name: hostdev-network", "org profiles without a team override are kept")
+}
+
+func TestGenerateMultipleProfiles(t *testing.T) {
+	t.Run("render every deployment type into its own subdirectory", func(t *testing.T) {
+		profilesDir := t.TempDir()
+		writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+			"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n",
+		})
+		writeTestProfile(t, profilesDir, "host-device-rdma", hostdevProfileManifest, map[string]string{
+			"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Hostdev.NetworkName}}\n",
+		})
+
+		outputDir := t.TempDir()
+		launcher := newTestLauncher(t, options.Options{
+			Fabric:              "ethernet",
+			DeploymentType:      "sriov,host_device",
+			SaveDeploymentFiles: outputDir,
+			ProfilesDirs:        []string{profilesDir},
+		})
+
+		require.NoError(t, launcher.executeWorkflow())
+
+		sriovFile, err := os.ReadFile(filepath.Join(outputDir, networkoperatorplugin.PluginName, "sriov-ethernet-rdma", "30-network.yaml"))
+		require.NoError(t, err)
+		assert.Contains(t, string(sriovFile), "name: sriov_network")
+
+		hostdevFile, err := os.ReadFile(filepath.Join(outputDir, networkoperatorplugin.PluginName, "host-device-rdma", "30-network.yaml"))
+		require.NoError(t, err)
+		assert.Contains(t, string(hostdevFile), "name: hostdev-network")
+	})
+
+	t.Run("fail when profiles render different objects with the same name", func(t *testing.T) {
+		profilesDir := t.TempDir()
+		writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+			"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: shared\ndata:\n  type: {{.Profile.Deployment}}\n",
+		})
+		writeTestProfile(t, profilesDir, "host-device-rdma", hostdevProfileManifest, map[string]string{
+			"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: shared\ndata:\n  type: {{.Profile.Deployment}}\n",
+		})
+
+		outputDir := t.TempDir()
+		launcher := newTestLauncher(t, options.Options{
+			Fabric:              "ethernet",
+			DeploymentType:      "sriov,host_device",
+			SaveDeploymentFiles: outputDir,
+			ProfilesDirs:        []string{profilesDir},
+		})
+
+		err := launcher.executeWorkflow()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "resource name collision")
+		assert.Contains(t, err.Error(), "ConfigMap/shared")
+
+		entries, err := os.ReadDir(outputDir)
+		require.NoError(t, err)
+		assert.Empty(t, entries, "nothing should be saved when profiles collide")
+	})
+}
+
+func TestDeployWithoutSavedFiles(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n  namespace: default\n",
+	})
+
+	applied := []string{}
+	launcher := newTestLauncher(t, options.Options{
+		Fabric:         "ethernet",
+		DeploymentType: "sriov",
+		ProfilesDirs:   []string{profilesDir},
+		Deploy:         true,
+		NoDeployFiles:  true,
+	})
+	launcher.kubeClient = newAllowingClient(&applied)
+	files := NewMemoryFileWriter()
+	launcher.files = files
+
+	require.NoError(t, launcher.executeWorkflow())
+	assert.Equal(t, []string{"ConfigMap/sriov_network"}, applied)
+	assert.Empty(t, files.Files, "no deployment file should be written")
+	assert.Empty(t, files.Dirs, "no output directory should be created")
+}
+
+func TestDeployResume(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: first\n  namespace: default\n---\n" +
+			"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: second\n  namespace: default\n",
+	})
+	checkpoint := filepath.Join(t.TempDir(), "checkpoint.yaml")
+
+	deployOnce := func(t *testing.T, resume bool, failing ...string) ([]string, *ui.RecordingOutput, error) {
+		applied := []string{}
+		output := ui.NewRecording()
+		launcher := newTestLauncher(t, options.Options{
+			Fabric:              "ethernet",
+			DeploymentType:      "sriov",
+			ProfilesDirs:        []string{profilesDir},
+			SaveDeploymentFiles: t.TempDir(),
+			Deploy:              true,
+			Checkpoint:          checkpoint,
+			Resume:              resume,
+		})
+		launcher.ui = output
+		launcher.kubeClient = newAllowingClient(&applied, failing...)
+		return applied, output, launcher.executeWorkflow()
+	}
+
+	applied, output, err := deployOnce(t, false, "second")
+	require.Error(t, err)
+	assert.Equal(t, []string{"ConfigMap/first"}, applied)
+	assert.Contains(t, output.Infos, "Run again with --resume to skip the 1 object(s) recorded in "+checkpoint)
+	require.FileExists(t, checkpoint)
+
+	applied, output, err = deployOnce(t, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ConfigMap/second"}, applied)
+	assert.Contains(t, output.Infos, "Resuming the deployment, 1 object(s) were applied before")
+	assert.NoFileExists(t, checkpoint, "the checkpoint is removed once the deployment completes")
+}
+
+func TestGenerateWithEnvironment(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml":                   "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\ndata:\n  mtu: \"1500\"\n",
+		"environments/prod/30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\ndata:\n  mtu: \"9000\"\n",
+		"environments/prod/90-monitor.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: monitor\n",
+	})
+
+	generate := func(t *testing.T, environment string) (string, error) {
+		outputDir := t.TempDir()
+		launcher := newTestLauncher(t, options.Options{
+			Fabric:              "ethernet",
+			DeploymentType:      "sriov",
+			SaveDeploymentFiles: outputDir,
+			ProfilesDirs:        []string{profilesDir},
+			Environment:         environment,
+		})
+		return filepath.Join(outputDir, networkoperatorplugin.PluginName), launcher.executeWorkflow()
+	}
+
+	t.Run("base only", func(t *testing.T) {
+		outputDir, err := generate(t, "")
+		require.NoError(t, err)
+
+		network, err := os.ReadFile(filepath.Join(outputDir, "30-network.yaml"))
+		require.NoError(t, err)
+		assert.Contains(t, string(network), `mtu: "1500"`)
+		assert.NoFileExists(t, filepath.Join(outputDir, "90-monitor.yaml"))
+	})
+
+	t.Run("environment overlay", func(t *testing.T) {
+		outputDir, err := generate(t, "prod")
+		require.NoError(t, err)
+
+		network, err := os.ReadFile(filepath.Join(outputDir, "30-network.yaml"))
+		require.NoError(t, err)
+		assert.Contains(t, string(network), "name: sriov_network")
+		assert.Contains(t, string(network), `mtu: "9000"`)
+		assert.FileExists(t, filepath.Join(outputDir, "90-monitor.yaml"))
+	})
+
+	t.Run("unknown environment", func(t *testing.T) {
+		_, err := generate(t, "staging")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "available environments: prod")
+	})
+}
+
+func TestGenerateEmptyProfile(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", "name: Empty\nplugin: network-operator\n", nil)
+
+	newLauncher := func(t *testing.T, outputDir string, allowEmpty bool) (*Launcher, *ui.RecordingOutput) {
+		launcher := newTestLauncher(t, options.Options{
+			Fabric:              "ethernet",
+			DeploymentType:      "sriov",
+			SaveDeploymentFiles: outputDir,
+			ProfilesDirs:        []string{profilesDir},
+			AllowEmptyProfile:   allowEmpty,
+		})
+		output := ui.NewRecording()
+		launcher.ui = output
+		return launcher, output
+	}
+
+	t.Run("fail by default", func(t *testing.T) {
+		launcher, output := newLauncher(t, t.TempDir(), false)
+
+		err := launcher.executeWorkflow()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "profile Empty rendered no deployment files")
+		assert.Contains(t, output.Errors, "Profile Empty rendered no deployment files")
+	})
+
+	t.Run("warn and keep existing files when allowed", func(t *testing.T) {
+		outputDir := t.TempDir()
+		existing := filepath.Join(outputDir, networkoperatorplugin.PluginName, "10-existing.yaml")
+		require.NoError(t, os.MkdirAll(filepath.Dir(existing), 0755))
+		require.NoError(t, os.WriteFile(existing, []byte("kind: ConfigMap"), 0644))
+
+		launcher, output := newLauncher(t, outputDir, true)
+
+		require.NoError(t, launcher.executeWorkflow())
+		assert.Equal(t, []string{"Profile Empty rendered no deployment files, skipping it"}, output.Warnings)
+		assert.FileExists(t, existing, "the output directory must not be wiped for an empty profile")
+	})
+}
+
+func TestGenerateWithFallbackProfile(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n",
+	})
+	writeTestProfile(t, profilesDir, "broken", "name: Broken\nplugin: network-operator\nprofileRequirements:\n  deployment: rdma_shared\nunknownField: true\n", nil)
+
+	newLauncher := func(t *testing.T, outputDir, fallback string) (*Launcher, *ui.RecordingOutput) {
+		launcher := newTestLauncher(t, options.Options{
+			Fabric:              "ethernet",
+			DeploymentType:      "host_device",
+			SaveDeploymentFiles: outputDir,
+			ProfilesDirs:        []string{profilesDir},
+			FallbackProfile:     fallback,
+		})
+		output := ui.NewRecording()
+		launcher.ui = output
+		return launcher, output
+	}
+
+	t.Run("fail without a fallback", func(t *testing.T) {
+		launcher, _ := newLauncher(t, t.TempDir(), "")
+
+		err := launcher.executeWorkflow()
+		require.ErrorIs(t, err, profiles.ErrNoApplicableProfile)
+	})
+
+	t.Run("use the fallback when no profile matches", func(t *testing.T) {
+		outputDir := t.TempDir()
+		launcher, output := newLauncher(t, outputDir, "sriov-ethernet-rdma")
+
+		require.NoError(t, launcher.executeWorkflow())
+		assert.Equal(t, []string{"No profile matches fabric ethernet, deployment host_device: using fallback profile SR-IOV"}, output.Warnings)
+		assert.FileExists(t, filepath.Join(outputDir, networkoperatorplugin.PluginName, "30-network.yaml"))
+	})
+
+	t.Run("find the fallback by profile name", func(t *testing.T) {
+		launcher, _ := newLauncher(t, t.TempDir(), "SR-IOV")
+
+		require.NoError(t, launcher.executeWorkflow())
+	})
+
+	t.Run("fail when the fallback does not exist", func(t *testing.T) {
+		launcher, output := newLauncher(t, t.TempDir(), "missing")
+
+		err := launcher.executeWorkflow()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fallback profile missing not found")
+		assert.Empty(t, output.Warnings)
+	})
+
+	t.Run("fail when the fallback does not pass schema validation", func(t *testing.T) {
+		launcher, _ := newLauncher(t, t.TempDir(), "broken")
+
+		err := launcher.executeWorkflow()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fallback profile broken is invalid")
+		assert.Contains(t, err.Error(), "unknownField")
+	})
+}
+
+func TestGenerateWithForcedProfile(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n",
+	})
+	writeTestProfile(t, profilesDir, "host-device-rdma", hostdevProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Hostdev.NetworkName}}\n",
+	})
+
+	newLauncher := func(t *testing.T, opts options.Options) (*Launcher, *ui.RecordingOutput) {
+		opts.ProfilesDirs = []string{profilesDir}
+		opts.ForceProfile = "sriov-ethernet-rdma"
+		launcher := newTestLauncher(t, opts)
+		output := ui.NewRecording()
+		launcher.ui = output
+		return launcher, output
+	}
+
+	t.Run("render the forced profile without requirements", func(t *testing.T) {
+		outputDir := t.TempDir()
+		launcher, output := newLauncher(t, options.Options{SaveDeploymentFiles: outputDir})
+
+		require.NoError(t, launcher.executeWorkflow())
+		assert.Empty(t, output.Warnings)
+		content, err := os.ReadFile(filepath.Join(outputDir, networkoperatorplugin.PluginName, "30-network.yaml"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "sriov_network")
+	})
+
+	t.Run("warn when the forced profile does not match", func(t *testing.T) {
+		outputDir := t.TempDir()
+		launcher, output := newLauncher(t, options.Options{
+			Fabric:              "ethernet",
+			DeploymentType:      "host_device",
+			SaveDeploymentFiles: outputDir,
+		})
+
+		require.NoError(t, launcher.executeWorkflow())
+		assert.Equal(t, []string{"Forced profile SR-IOV does not match the cluster: selected deployment type does not match profile requirements: sriov"}, output.Warnings)
+		assert.FileExists(t, filepath.Join(outputDir, networkoperatorplugin.PluginName, "30-network.yaml"))
+	})
+
+	t.Run("validate the config requirements of the forced profile", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), "config.yaml")
+		withoutSriov := strings.Replace(testClusterConfig, "sriov:\n  numVfs: 8\n  resourceName: sriov_resource\n  networkName: sriov_network\n", "", 1)
+		require.NoError(t, os.WriteFile(configPath, []byte(withoutSriov), 0644))
+		launcher, _ := newLauncher(t, options.Options{UserConfig: configPath, SaveDeploymentFiles: t.TempDir()})
+
+		err := launcher.executeWorkflow()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid cluster config for profile SR-IOV")
+	})
+
+	t.Run("fail when the forced profile does not exist", func(t *testing.T) {
+		launcher, _ := newLauncher(t, options.Options{Fabric: "ethernet", DeploymentType: "sriov"})
+		launcher.options.ForceProfile = "missing"
+
+		err := launcher.executeWorkflow()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "forced profile missing not found")
+	})
+}
+
+func TestGenerateChecksRequiredPlugins(t *testing.T) {
+	generate := func(t *testing.T, requiredPlugin string) (string, *ui.RecordingOutput, error) {
+		profilesDir := t.TempDir()
+		writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest+"requiredPlugins:\n  - "+requiredPlugin+"\n", map[string]string{
+			"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n",
+		})
+		outputDir := t.TempDir()
+		launcher := newTestLauncher(t, options.Options{
+			Fabric:              "ethernet",
+			DeploymentType:      "sriov",
+			ProfilesDirs:        []string{profilesDir},
+			SaveDeploymentFiles: outputDir,
+		})
+		output := ui.NewRecording()
+		launcher.ui = output
+		return outputDir, output, launcher.executeWorkflow()
+	}
+
+	t.Run("generate when the required plugin is enabled", func(t *testing.T) {
+		outputDir, _, err := generate(t, networkoperatorplugin.PluginName)
+		require.NoError(t, err)
+		assert.FileExists(t, filepath.Join(outputDir, networkoperatorplugin.PluginName, "30-network.yaml"))
+	})
+
+	t.Run("fail before generation when a required plugin is missing", func(t *testing.T) {
+		outputDir, output, err := generate(t, "gpu-operator")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "profile SR-IOV requires plugin(s) that are not enabled: gpu-operator")
+		assert.Equal(t, []string{"Profile SR-IOV requires plugin(s) that are not enabled: gpu-operator"}, output.Errors)
+		entries, err := os.ReadDir(outputDir)
+		require.NoError(t, err)
+		assert.Empty(t, entries, "no file may be generated")
+	})
+}
+
+func TestGenerateWarnsAboutUnusualMtu(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", "name: SR-IOV\nplugin: network-operator\nprofileRequirements:\n  fabric: ethernet\n  deployment: sriov\ntemplates:\n  - 30-network.yaml\n", map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\ndata:\n  mtu: \"{{.Sriov.EthernetMtu}}\"\n",
+	})
+
+	generate := func(t *testing.T, mtu int) *ui.RecordingOutput {
+		userConfig := filepath.Join(t.TempDir(), "config.yaml")
+		content := strings.Replace(testClusterConfig, "sriov:\n", fmt.Sprintf("sriov:\n  ethernetMtu: %d\n", mtu), 1)
+		require.NoError(t, os.WriteFile(userConfig, []byte(content), 0644))
+
+		launcher := newTestLauncher(t, options.Options{
+			Fabric:              "ethernet",
+			DeploymentType:      "sriov",
+			UserConfig:          userConfig,
+			SaveDeploymentFiles: t.TempDir(),
+			ProfilesDirs:        []string{profilesDir},
+		})
+		output := ui.NewRecording()
+		launcher.ui = output
+		require.NoError(t, launcher.executeWorkflow())
+		return output
+	}
+
+	t.Run("warn about an MTU above the typical maximum", func(t *testing.T) {
+		output := generate(t, 90000)
+		assert.Equal(t, []string{"Profile SR-IOV: sriov.ethernetMtu 90000 of the SR-IOV Ethernet networks exceeds the typical maximum of 9000"}, output.Warnings)
+	})
+
+	t.Run("accept jumbo frames", func(t *testing.T) {
+		output := generate(t, 9000)
+		assert.Empty(t, output.Warnings)
+	})
+}
+
+func TestGenerateWithProvenanceHeader(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n",
+	})
+
+	generate := func(t *testing.T, provenance bool, configContent string) string {
+		outputDir := t.TempDir()
+		opts := options.Options{
+			DeploymentType:      "sriov",
+			Fabric:              "ethernet",
+			SaveDeploymentFiles: outputDir,
+			ProfilesDirs:        []string{profilesDir},
+			ProvenanceHeader:    provenance,
+			Version:             "v1.2.3",
+		}
+		if configContent != "" {
+			opts.UserConfig = filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(opts.UserConfig, []byte(configContent), 0644))
+		}
+		require.NoError(t, newTestLauncher(t, opts).executeWorkflow())
+
+		network, err := os.ReadFile(filepath.Join(outputDir, networkoperatorplugin.PluginName, "30-network.yaml"))
+		require.NoError(t, err)
+		return string(network)
+	}
+
+	t.Run("header names the profile, version and config hash", func(t *testing.T) {
+		network := generate(t, true, "")
+		assert.Regexp(t, `^# Generated by l8k v1\.2\.3\n# Profile: SR-IOV \(sriov-ethernet-rdma\)\n# Config hash: sha256:[0-9a-f]{64}\n`, network)
+
+		obj := map[string]interface{}{}
+		require.NoError(t, sigsyaml.Unmarshal([]byte(network), &obj))
+		assert.Equal(t, "ConfigMap", obj["kind"])
+		assert.Equal(t, map[string]interface{}{"name": "sriov_network"}, obj["metadata"])
+	})
+
+	t.Run("hash follows the config", func(t *testing.T) {
+		hashLine := func(content string) string {
+			for _, line := range strings.Split(content, "\n") {
+				if strings.HasPrefix(line, "# Config hash:") {
+					return line
+				}
+			}
+			return ""
+		}
+
+		first := hashLine(generate(t, true, ""))
+		assert.Equal(t, first, hashLine(generate(t, true, "")))
+		assert.NotEqual(t, first, hashLine(generate(t, true, strings.Replace(testClusterConfig, "numVfs: 8", "numVfs: 4", 1))))
+	})
+
+	t.Run("no header by default", func(t *testing.T) {
+		assert.Equal(t, "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: sriov_network\n", generate(t, false, ""))
+	})
+}
+
+func TestGenerateChanged(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n",
+	})
+	writeTestProfile(t, profilesDir, "host-device-rdma", hostdevProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Hostdev.NetworkName}}\n",
+	})
+
+	outputDir := t.TempDir()
+	userConfig := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(userConfig, []byte(testClusterConfig), 0644))
+	sriovFile := filepath.Join(outputDir, networkoperatorplugin.PluginName, "sriov-ethernet-rdma", "30-network.yaml")
+	hostdevFile := filepath.Join(outputDir, networkoperatorplugin.PluginName, "host-device-rdma", "30-network.yaml")
+
+	generate := func(t *testing.T) *ui.RecordingOutput {
+		launcher := newTestLauncher(t, options.Options{
+			Fabric:              "ethernet",
+			DeploymentType:      "sriov,host_device",
+			UserConfig:          userConfig,
+			SaveDeploymentFiles: outputDir,
+			ProfilesDirs:        []string{profilesDir},
+			Changed:             true,
+		})
+		output := ui.NewRecording()
+		launcher.ui = output
+		require.NoError(t, launcher.executeWorkflow())
+		return output
+	}
+
+	t.Run("generate every profile without recorded inputs", func(t *testing.T) {
+		output := generate(t)
+		assert.Contains(t, output.Infos, "Generating files for profile: SR-IOV")
+		assert.Contains(t, output.Infos, "Generating files for profile: Host device")
+		assert.FileExists(t, filepath.Join(outputDir, networkoperatorplugin.PluginName, "sriov-ethernet-rdma", inputsIndex))
+	})
+
+	t.Run("skip every profile when nothing changed", func(t *testing.T) {
+		// A regenerated file would be overwritten
+		require.NoError(t, os.WriteFile(sriovFile, []byte("edited\n"), 0644))
+
+		output := generate(t)
+		assert.Contains(t, output.Infos, "Inputs of profile SR-IOV are unchanged, skipping generation")
+		assert.Contains(t, output.Infos, "Inputs of profile Host device are unchanged, skipping generation")
+
+		content, err := os.ReadFile(sriovFile)
+		require.NoError(t, err)
+		assert.Equal(t, "edited\n", string(content))
+	})
+
+	t.Run("regenerate only the profile whose template changed", func(t *testing.T) {
+		writeTestProfile(t, profilesDir, "host-device-rdma", hostdevProfileManifest, map[string]string{
+			"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Hostdev.NetworkName}}-v2\n",
+		})
+
+		output := generate(t)
+		assert.Contains(t, output.Infos, "Inputs of profile SR-IOV are unchanged, skipping generation")
+		assert.Contains(t, output.Infos, "Generating files for profile: Host device")
+
+		content, err := os.ReadFile(hostdevFile)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "name: hostdev-network-v2")
+		content, err = os.ReadFile(sriovFile)
+		require.NoError(t, err)
+		assert.Equal(t, "edited\n", string(content))
+	})
+
+	t.Run("regenerate every profile when the config changed", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(userConfig, []byte(strings.Replace(testClusterConfig, "networkName: sriov_network", "networkName: sriov_network_v2", 1)), 0644))
+
+		output := generate(t)
+		assert.Contains(t, output.Infos, "Generating files for profile: SR-IOV")
+		assert.Contains(t, output.Infos, "Generating files for profile: Host device")
+
+		content, err := os.ReadFile(sriovFile)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "name: sriov_network_v2")
+	})
+}
+
+func TestGenerateOutputDirPlaceholders(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n",
+	})
+	writeTestProfile(t, profilesDir, "host-device-rdma", hostdevProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Hostdev.NetworkName}}\n",
+	})
+
+	outputDir := t.TempDir()
+	launcher := newTestLauncher(t, options.Options{
+		Fabric:              "ethernet",
+		DeploymentType:      "sriov,host_device",
+		SaveDeploymentFiles: filepath.Join(outputDir, "runs", "{timestamp}", "{profile}"),
+		ProfilesDirs:        []string{profilesDir},
+	})
+	require.NoError(t, launcher.executeWorkflow())
+
+	runs, err := os.ReadDir(filepath.Join(outputDir, "runs"))
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, launcher.runStarted.Format(timestampLayout), runs[0].Name())
+	assert.Regexp(t, `^\d{8}-\d{6}$`, runs[0].Name())
+
+	// The profile placeholder replaces the per-profile subdirectory
+	for _, profile := range []string{"sriov-ethernet-rdma", "host-device-rdma"} {
+		assert.FileExists(t, filepath.Join(outputDir, "runs", runs[0].Name(), profile, networkoperatorplugin.PluginName, "30-network.yaml"))
+		assert.NoDirExists(t, filepath.Join(outputDir, "runs", runs[0].Name(), profile, networkoperatorplugin.PluginName, profile))
+	}
+}
+
+func TestGenerateSchemaValidate(t *testing.T) {
+	generate := func(t *testing.T, network string) (*ui.RecordingOutput, error) {
+		profilesDir := t.TempDir()
+		writeTestProfile(t, profilesDir, "host-device-rdma", hostdevProfileManifest, map[string]string{
+			"30-network.yaml": network + "---\napiVersion: nv-ipam.nvidia.com/v1alpha1\nkind: IPPool\nmetadata:\n  name: pool\n",
+		})
+		launcher := newTestLauncher(t, options.Options{
+			Fabric:              "ethernet",
+			DeploymentType:      "host_device",
+			SaveDeploymentFiles: t.TempDir(),
+			ProfilesDirs:        []string{profilesDir},
+			SchemaValidate:      true,
+		})
+		output := ui.NewRecording()
+		launcher.ui = output
+		return output, launcher.executeWorkflow()
+	}
+
+	t.Run("accept objects matching their schema", func(t *testing.T) {
+		output, err := generate(t, "apiVersion: mellanox.com/v1alpha1\nkind: HostDeviceNetwork\nmetadata:\n  name: {{.Hostdev.NetworkName}}\nspec:\n  resourceName: hostdev\n")
+		require.NoError(t, err)
+		assert.Contains(t, output.Warnings, "No schema known for nv-ipam.nvidia.com/v1alpha1, Kind=IPPool, skipping its validation")
+	})
+
+	t.Run("fail on fields of the wrong type", func(t *testing.T) {
+		output, err := generate(t, "apiVersion: mellanox.com/v1alpha1\nkind: HostDeviceNetwork\nmetadata:\n  name: {{.Hostdev.NetworkName}}\nspec:\n  resourceName: [hostdev]\n")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "profile Host device rendered 1 schema violation(s)")
+		assert.Contains(t, output.Errors, "  30-network.yaml: HostDeviceNetwork/hostdev-network: spec.resourceName: must be of type string: \"array\"")
+	})
+}
+
+func TestValidateOutputDir(t *testing.T) {
+	assert.NoError(t, ValidateOutputDir("/tmp/l8k"))
+	assert.NoError(t, ValidateOutputDir("/tmp/l8k/{timestamp}/{profile}"))
+
+	err := ValidateOutputDir("/tmp/l8k/{date}")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown placeholder {date}")
+}
+
+// labelingPlugin is a network operator plugin adding a label to every rendered manifest
+type labelingPlugin struct {
+	networkoperatorplugin.NetworkOperatorPlugin
+	err error
+}
+
+func (p *labelingPlugin) PostRender(files map[string]string) (map[string]string, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	labeled := make(map[string]string, len(files))
+	for name, content := range files {
+		docs := []string{}
+		for _, doc := range deploy.SplitYAMLDocuments(content) {
+			obj := &unstructured.Unstructured{}
+			if err := sigsyaml.Unmarshal([]byte(doc), &obj.Object); err != nil {
+				return nil, err
+			}
+			labels := obj.GetLabels()
+			if labels == nil {
+				labels = map[string]string{}
+			}
+			labels["team"] = "networking"
+			obj.SetLabels(labels)
+
+			data, err := sigsyaml.Marshal(obj.Object)
+			if err != nil {
+				return nil, err
+			}
+			docs = append(docs, string(data))
+		}
+		labeled[name] = strings.Join(docs, "---\n")
+	}
+	return labeled, nil
+}
+
+func TestGenerateWithPostRender(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: second\n  labels:\n    app: test\n",
+	})
+
+	newLauncher := func(t *testing.T, outputDir string, postRenderer *labelingPlugin) *Launcher {
+		launcher := newTestLauncher(t, options.Options{
+			Fabric:              "ethernet",
+			DeploymentType:      "sriov",
+			SaveDeploymentFiles: outputDir,
+			ProfilesDirs:        []string{profilesDir},
+		})
+		launcher.plugins = map[string]plugin.Plugin{networkoperatorplugin.PluginName: postRenderer}
+		return launcher
+	}
+
+	t.Run("save the post-processed files", func(t *testing.T) {
+		outputDir := t.TempDir()
+		require.NoError(t, newLauncher(t, outputDir, &labelingPlugin{}).executeWorkflow())
+
+		content, err := os.ReadFile(filepath.Join(outputDir, networkoperatorplugin.PluginName, "30-network.yaml"))
+		require.NoError(t, err)
+		docs := deploy.SplitYAMLDocuments(string(content))
+		require.Len(t, docs, 2)
+		for _, doc := range docs {
+			obj := &unstructured.Unstructured{}
+			require.NoError(t, sigsyaml.Unmarshal([]byte(doc), &obj.Object))
+			assert.Equal(t, "networking", obj.GetLabels()["team"], obj.GetName())
+		}
+		assert.Contains(t, string(content), "app: test")
+	})
+
+	t.Run("abort when post-processing fails", func(t *testing.T) {
+		outputDir := t.TempDir()
+		err := newLauncher(t, outputDir, &labelingPlugin{err: errors.New("sidecar not found")}).executeWorkflow()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "plugin network-operator failed to post-process the rendered files: sidecar not found")
+
+		entries, err := os.ReadDir(outputDir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
+
+func TestSaveDeploymentFilesNoClean(t *testing.T) {
+	outputDir := t.TempDir()
+	launcher := newTestLauncher(t, options.Options{NoClean: true})
+
+	require.NoError(t, launcher.saveDeploymentFiles(map[string]string{
+		"10-policy.yaml":  "kind: NicClusterPolicy",
+		"20-network.yaml": "kind: ConfigMap",
+	}, outputDir))
+	userFile := filepath.Join(outputDir, "README.md")
+	require.NoError(t, os.WriteFile(userFile, []byte("patches applied by hand"), 0644))
+
+	require.NoError(t, launcher.saveDeploymentFiles(map[string]string{
+		"10-policy.yaml": "kind: NicClusterPolicy\nspec: {}",
+	}, outputDir))
+
+	assert.FileExists(t, userFile, "user files must survive with --no-clean")
+	assert.NoFileExists(t, filepath.Join(outputDir, "20-network.yaml"), "stale generated files must be removed")
+	policy, err := os.ReadFile(filepath.Join(outputDir, "10-policy.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "kind: NicClusterPolicy\nspec: {}", string(policy))
+
+	t.Run("clean by default", func(t *testing.T) {
+		launcher := newTestLauncher(t, options.Options{})
+		require.NoError(t, launcher.saveDeploymentFiles(map[string]string{"10-policy.yaml": "kind: NicClusterPolicy"}, outputDir))
+		assert.NoFileExists(t, userFile)
+	})
+}
+
+func TestGenerateWithCleaningDisabled(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n",
+	})
+
+	generate := func(t *testing.T, config string) string {
+		outputDir := t.TempDir()
+		userFile := filepath.Join(outputDir, networkoperatorplugin.PluginName, "README.md")
+		require.NoError(t, os.MkdirAll(filepath.Dir(userFile), 0755))
+		require.NoError(t, os.WriteFile(userFile, []byte("notes"), 0644))
+
+		configPath := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(configPath, []byte(config), 0644))
+		launcher := newTestLauncher(t, options.Options{
+			Fabric:              "ethernet",
+			DeploymentType:      "sriov",
+			ProfilesDirs:        []string{profilesDir},
+			SaveDeploymentFiles: outputDir,
+			UserConfig:          configPath,
+		})
+		require.NoError(t, launcher.executeWorkflow())
+		assert.FileExists(t, filepath.Join(outputDir, networkoperatorplugin.PluginName, "30-network.yaml"))
+		return userFile
+	}
+
+	t.Run("keep the output directory with cleanOutputDir false", func(t *testing.T) {
+		assert.FileExists(t, generate(t, testClusterConfig+"cleanOutputDir: false\n"))
+	})
+
+	t.Run("clean the output directory by default", func(t *testing.T) {
+		assert.NoFileExists(t, generate(t, testClusterConfig))
+	})
+}
+
+func TestSaveDeploymentFilesOperations(t *testing.T) {
+	renderedFiles := map[string]string{
+		"20-network.yaml": "kind: ConfigMap",
+		"10-policy.yaml":  "kind: NicClusterPolicy",
+	}
+
+	newLauncher := func(t *testing.T, opts options.Options) (*Launcher, *MemoryFileWriter) {
+		launcher := newTestLauncher(t, opts)
+		fw := NewMemoryFileWriter()
+		launcher.files = fw
+		return launcher, fw
+	}
+
+	t.Run("clean and write in file name order", func(t *testing.T) {
+		launcher, fw := newLauncher(t, options.Options{})
+
+		require.NoError(t, launcher.saveDeploymentFiles(renderedFiles, "/out"))
+		assert.Equal(t, []string{
+			"removeall /out",
+			"mkdir /out",
+			"write /out/10-policy.yaml",
+			"write /out/20-network.yaml",
+			"write /out/" + generatedFilesIndex,
+		}, fw.Operations)
+		assert.Equal(t, "10-policy.yaml\n20-network.yaml\n", string(fw.Files["/out/"+generatedFilesIndex]))
+	})
+
+	t.Run("no-clean removes only stale generated files", func(t *testing.T) {
+		launcher, fw := newLauncher(t, options.Options{NoClean: true})
+		require.NoError(t, fw.MkdirAll("/out", 0755))
+		require.NoError(t, fw.WriteFile("/out/"+generatedFilesIndex, []byte("10-policy.yaml\n30-stale.yaml\n"), 0644))
+		require.NoError(t, fw.WriteFile("/out/30-stale.yaml", []byte("kind: Pod"), 0644))
+		require.NoError(t, fw.WriteFile("/out/README.md", []byte("notes"), 0644))
+		fw.Operations = nil
+
+		require.NoError(t, launcher.saveDeploymentFiles(renderedFiles, "/out"))
+		assert.Equal(t, []string{
+			"read /out/" + generatedFilesIndex,
+			"remove /out/30-stale.yaml",
+			"mkdir /out",
+			"write /out/10-policy.yaml",
+			"write /out/20-network.yaml",
+			"write /out/" + generatedFilesIndex,
+		}, fw.Operations)
+		assert.Equal(t, []string{"/out/" + generatedFilesIndex, "/out/10-policy.yaml", "/out/20-network.yaml", "/out/README.md"}, fw.FileNames())
+	})
+
+	t.Run("remove nothing when cleaning is disabled in the config file", func(t *testing.T) {
+		for _, opts := range []options.Options{{}, {NoClean: true}} {
+			launcher, fw := newLauncher(t, opts)
+			launcher.keepOutputFiles = true
+			require.NoError(t, fw.MkdirAll("/out", 0755))
+			require.NoError(t, fw.WriteFile("/out/"+generatedFilesIndex, []byte("10-policy.yaml\n30-stale.yaml\n"), 0644))
+			require.NoError(t, fw.WriteFile("/out/30-stale.yaml", []byte("kind: Pod"), 0644))
+			fw.Operations = nil
+
+			require.NoError(t, launcher.saveDeploymentFiles(renderedFiles, "/out"))
+			assert.Equal(t, []string{
+				"mkdir /out",
+				"write /out/10-policy.yaml",
+				"write /out/20-network.yaml",
+				"write /out/" + generatedFilesIndex,
+			}, fw.Operations)
+			assert.Contains(t, fw.FileNames(), "/out/30-stale.yaml")
+		}
+	})
+
+	t.Run("stop at the first failed write", func(t *testing.T) {
+		launcher, fw := newLauncher(t, options.Options{})
+		fw.Errors["write /out/10-policy.yaml"] = os.ErrPermission
+
+		err := launcher.saveDeploymentFiles(renderedFiles, "/out")
+		require.ErrorIs(t, err, os.ErrPermission)
+		assert.Contains(t, err.Error(), "failed to write file /out/10-policy.yaml")
+		assert.Empty(t, fw.FileNames(), "no file may be written after a failure")
+		assert.NotContains(t, fw.Operations, "write /out/"+generatedFilesIndex)
+	})
+
+	t.Run("fail when the output directory cannot be cleaned", func(t *testing.T) {
+		launcher, fw := newLauncher(t, options.Options{})
+		fw.Errors["removeall /out"] = os.ErrPermission
+
+		err := launcher.saveDeploymentFiles(renderedFiles, "/out")
+		require.ErrorIs(t, err, os.ErrPermission)
+		assert.Contains(t, err.Error(), "failed to clean output directory /out")
+		assert.Equal(t, []string{"removeall /out"}, fw.Operations)
+	})
+}
+
+func TestDetectResourceCollisions(t *testing.T) {
+	shared := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: shared\n  namespace: default\n"
+
+	assert.NoError(t, detectResourceCollisions(map[string]map[string]string{
+		"a": {"10.yaml": shared},
+		"b": {"10.yaml": shared},
+	}), "identical objects can be shared between profiles")
+
+	err := detectResourceCollisions(map[string]map[string]string{
+		"a": {"10.yaml": shared},
+		"b": {"10.yaml": shared + "data:\n  key: value\n"},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ConfigMap/default/shared is rendered by profiles a and b")
+}
+
+// claimingPlugin is a named plugin configured by the options for which claims returns true
+type claimingPlugin struct {
+	networkoperatorplugin.NetworkOperatorPlugin
+	name   string
+	claims func(options.Options) bool
+}
+
+func (p *claimingPlugin) GetName() string { return p.name }
+
+func (p *claimingPlugin) ProfileConfiguredInCmd(opts options.Options) bool { return p.claims(opts) }
+
+func TestConfiguredPlugins(t *testing.T) {
+	newLauncher := func(opts options.Options) (*Launcher, *ui.RecordingOutput) {
+		output := ui.NewRecording()
+		launcher := newTestLauncher(t, opts)
+		launcher.ui = output
+		launcher.plugins = map[string]plugin.Plugin{
+			"storage":  &claimingPlugin{name: "storage", claims: func(o options.Options) bool { return o.Prompt != "" }},
+			"gpu":      &claimingPlugin{name: "gpu", claims: func(o options.Options) bool { return o.Ai }},
+			"ethernet": &claimingPlugin{name: "ethernet", claims: func(o options.Options) bool { return o.Fabric == "ethernet" }},
+		}
+		return launcher, output
+	}
+	names := func(plugins []plugin.Plugin) []string {
+		result := []string{}
+		for _, p := range plugins {
+			result = append(result, p.GetName())
+		}
+		return result
+	}
+
+	t.Run("return only the plugins claiming the options", func(t *testing.T) {
+		launcher, _ := newLauncher(options.Options{Fabric: "ethernet", Ai: true})
+		assert.Equal(t, []string{"ethernet", "gpu"}, names(launcher.ConfiguredPlugins()))
+
+		launcher, _ = newLauncher(options.Options{Fabric: "infiniband"})
+		assert.Empty(t, launcher.ConfiguredPlugins())
+	})
+
+	t.Run("print the configured plugins", func(t *testing.T) {
+		launcher, output := newLauncher(options.Options{Fabric: "ethernet", Prompt: "prompt.txt"})
+		launcher.reportConfiguredPlugins()
+		require.Len(t, output.Tables, 1)
+		assert.Equal(t, []string{"PLUGIN", "VERSION"}, output.Tables[0].Headers)
+		assert.Equal(t, [][]string{{"ethernet", networkoperatorplugin.PluginVersion}, {"storage", networkoperatorplugin.PluginVersion}}, output.Tables[0].Rows)
+	})
+
+	t.Run("report when no plugin is configured", func(t *testing.T) {
+		launcher, output := newLauncher(options.Options{})
+		launcher.reportConfiguredPlugins()
+		assert.Empty(t, output.Tables)
+		assert.Contains(t, output.Infos, "No enabled plugin is configured by the command line options")
+	})
+}
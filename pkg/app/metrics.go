@@ -0,0 +1,92 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// metrics exposes the workflow runs of watch mode in the Prometheus format
+type metrics struct {
+	registry      *prometheus.Registry
+	runs          prometheus.Counter
+	failures      prometheus.Counter
+	lastSuccess   prometheus.Gauge
+	phaseDuration *prometheus.GaugeVec
+	phaseFailures *prometheus.CounterVec
+}
+
+func newMetrics() *metrics {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+		runs: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "l8k_runs_total",
+			Help: "Number of workflow runs.",
+		}),
+		failures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "l8k_run_failures_total",
+			Help: "Number of failed workflow runs.",
+		}),
+		lastSuccess: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "l8k_last_success_timestamp_seconds",
+			Help: "Unix time of the last successful workflow run.",
+		}),
+		phaseDuration: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "l8k_phase_duration_seconds",
+			Help: "Duration of the last run of each workflow phase.",
+		}, []string{"phase"}),
+		phaseFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "l8k_phase_failures_total",
+			Help: "Number of workflow runs that failed in each phase.",
+		}, []string{"phase"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.runs, m.failures, m.lastSuccess, m.phaseDuration, m.phaseFailures,
+	)
+	return m
+}
+
+// PhaseCompleted records the duration of the phase and whether the run failed in it
+func (m *metrics) PhaseCompleted(phase string, duration time.Duration, err error) {
+	m.phaseDuration.WithLabelValues(phase).Set(duration.Seconds())
+	if err != nil {
+		m.phaseFailures.WithLabelValues(phase).Inc()
+	}
+}
+
+// RunCompleted counts the run and records the time of successful runs
+func (m *metrics) RunCompleted(_ time.Duration, err error) {
+	m.runs.Inc()
+	if err != nil {
+		m.failures.Inc()
+		return
+	}
+	m.lastSuccess.SetToCurrentTime()
+}
+
+// handler serves the metrics in the Prometheus exposition format
+func (m *metrics) handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
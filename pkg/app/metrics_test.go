@@ -0,0 +1,62 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+)
+
+func TestMetricsAfterRun(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n",
+	})
+
+	launcher := newTestLauncher(t, options.Options{
+		Fabric:              "ethernet",
+		DeploymentType:      "sriov",
+		SaveDeploymentFiles: t.TempDir(),
+		ProfilesDirs:        []string{profilesDir},
+	})
+	m := newMetrics()
+	launcher.AddObserver(m)
+
+	require.NoError(t, launcher.executeWorkflow())
+
+	launcher.options.ProfilesDirs = []string{"does-not-exist"}
+	require.Error(t, launcher.executeWorkflow())
+
+	rec := httptest.NewRecorder()
+	m.handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	body := rec.Body.String()
+	assert.Contains(t, body, "l8k_runs_total 2")
+	assert.Contains(t, body, "l8k_run_failures_total 1")
+	assert.Contains(t, body, "l8k_last_success_timestamp_seconds")
+	assert.Contains(t, body, `l8k_phase_duration_seconds{phase="selection"}`)
+	assert.Contains(t, body, `l8k_phase_duration_seconds{phase="generation"}`)
+	assert.Contains(t, body, `l8k_phase_failures_total{phase="selection"} 1`)
+	assert.NotContains(t, body, `l8k_phase_duration_seconds{phase="deployment"}`)
+}
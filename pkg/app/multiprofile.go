@@ -0,0 +1,105 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/deploy"
+)
+
+// splitDeploymentTypes returns one profile requirement per deployment type.
+// The deployment field may hold a comma-separated list to generate several profiles together.
+func splitDeploymentTypes(profile *config.Profile) []*config.Profile {
+	requirements := []*config.Profile{}
+	for _, deployment := range strings.Split(profile.Deployment, ",") {
+		deployment = strings.TrimSpace(deployment)
+		if deployment == "" && len(requirements) > 0 {
+			continue
+		}
+		requirement := *profile
+		requirement.Deployment = deployment
+		requirements = append(requirements, &requirement)
+	}
+	return requirements
+}
+
+// resourceKey identifies a Kubernetes object in the rendered manifests
+type resourceKey struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+func (k resourceKey) String() string {
+	if k.Namespace == "" {
+		return fmt.Sprintf("%s/%s", k.Kind, k.Name)
+	}
+	return fmt.Sprintf("%s/%s/%s", k.Kind, k.Namespace, k.Name)
+}
+
+// detectResourceCollisions returns an error if several profiles render an object with the same
+// kind, namespace and name but different content. Identical objects are shared and allowed.
+func detectResourceCollisions(renderedFiles map[string]map[string]string) error {
+	type renderedObject struct {
+		profile string
+		content map[string]interface{}
+	}
+
+	profileNames := make([]string, 0, len(renderedFiles))
+	for name := range renderedFiles {
+		profileNames = append(profileNames, name)
+	}
+	sort.Strings(profileNames)
+
+	seen := map[resourceKey]renderedObject{}
+	collisions := []string{}
+	for _, profileName := range profileNames {
+		fileNames := make([]string, 0, len(renderedFiles[profileName]))
+		for name := range renderedFiles[profileName] {
+			fileNames = append(fileNames, name)
+		}
+		sort.Strings(fileNames)
+
+		for _, fileName := range fileNames {
+			objects, err := deploy.ParseManifests([]byte(renderedFiles[profileName][fileName]))
+			if err != nil {
+				return fmt.Errorf("failed to parse %s of profile %s: %w", fileName, profileName, err)
+			}
+			for _, obj := range objects {
+				key := resourceKey{Kind: obj.GetKind(), Namespace: obj.GetNamespace(), Name: obj.GetName()}
+				if previous, ok := seen[key]; ok {
+					if previous.profile != profileName && !reflect.DeepEqual(previous.content, obj.Object) {
+						collisions = append(collisions, fmt.Sprintf("%s is rendered by profiles %s and %s", key, previous.profile, profileName))
+					}
+					continue
+				}
+				seen[key] = renderedObject{profile: profileName, content: obj.Object}
+			}
+		}
+	}
+
+	if len(collisions) > 0 {
+		return fmt.Errorf("resource name collision: %s", strings.Join(collisions, "; "))
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// generatedFilesIndex lists the files l8k saved to an output directory, so that stale generated files can be
+// removed without touching the files added by the user
+const generatedFilesIndex = ".l8k-generated"
+
+// readGeneratedFiles returns the file names listed in the index of the output directory, if any
+func readGeneratedFiles(fw FileWriter, outputDir string) ([]string, error) {
+	data, err := fw.ReadFile(filepath.Join(outputDir, generatedFilesIndex))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read generated files index: %w", err)
+	}
+
+	var files []string
+	for _, line := range strings.Split(string(data), "\n") {
+		name := strings.TrimSpace(line)
+		// Entries pointing outside the output directory are never l8k-owned
+		if name == "" || !filepath.IsLocal(name) {
+			continue
+		}
+		files = append(files, name)
+	}
+	return files, nil
+}
+
+// writeGeneratedFiles writes the index of the files generated into the output directory
+func writeGeneratedFiles(fw FileWriter, outputDir string, renderedFiles map[string]string) error {
+	content := strings.Join(sortedFileNames(renderedFiles), "\n") + "\n"
+	if err := fw.WriteFile(filepath.Join(outputDir, generatedFilesIndex), []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write generated files index: %w", err)
+	}
+	return nil
+}
+
+// removeStaleFiles removes the previously generated files of the output directory that are no longer rendered
+func removeStaleFiles(fw FileWriter, outputDir string, renderedFiles map[string]string) ([]string, error) {
+	previous, err := readGeneratedFiles(fw, outputDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	for _, name := range previous {
+		if _, ok := renderedFiles[name]; ok {
+			continue
+		}
+		err := fw.Remove(filepath.Join(outputDir, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return removed, fmt.Errorf("failed to remove stale file %s: %w", name, err)
+		}
+		removed = append(removed, name)
+	}
+	return removed, nil
+}
+
+// sortedFileNames returns the names of the rendered files in sorted order
+func sortedFileNames(renderedFiles map[string]string) []string {
+	names := make([]string, 0, len(renderedFiles))
+	for name := range renderedFiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
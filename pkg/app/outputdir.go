@@ -0,0 +1,53 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Placeholders of the --save-deployment-files directory, expanded for every profile of a run
+const (
+	ProfilePlaceholder   = "{profile}"
+	TimestampPlaceholder = "{timestamp}"
+
+	// timestampLayout formats the run start time for {timestamp}, sorting runs chronologically
+	timestampLayout = "20060102-150405"
+)
+
+var outputDirPlaceholder = regexp.MustCompile(`\{[^{}]*\}`)
+
+// ValidateOutputDir checks that the output directory only contains known placeholders
+func ValidateOutputDir(dir string) error {
+	for _, placeholder := range outputDirPlaceholder.FindAllString(dir, -1) {
+		if placeholder != ProfilePlaceholder && placeholder != TimestampPlaceholder {
+			return fmt.Errorf("unknown placeholder %s in %s, supported placeholders are %s and %s", placeholder, dir, ProfilePlaceholder, TimestampPlaceholder)
+		}
+	}
+	return nil
+}
+
+// expandOutputDir replaces the placeholders of the output directory with the profile and the run start time
+func expandOutputDir(dir, profile string, started time.Time) string {
+	return strings.NewReplacer(
+		ProfilePlaceholder, profile,
+		TimestampPlaceholder, started.Format(timestampLayout),
+	).Replace(dir)
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Workflow phases reported to a WorkflowObserver
+const (
+	PhaseDiscovery  = "discovery"
+	PhaseSelection  = "selection"
+	PhaseGeneration = "generation"
+	PhaseDeployment = "deployment"
+)
+
+// WorkflowObserver is notified about the progress of every workflow run
+type WorkflowObserver interface {
+	// PhaseCompleted is called when a phase ends, err is the failure that ended the run in that phase
+	PhaseCompleted(phase string, duration time.Duration, err error)
+	// RunCompleted is called when a workflow run ends
+	RunCompleted(duration time.Duration, err error)
+}
+
+// AddObserver registers an observer notified about every workflow run
+func (l *Launcher) AddObserver(observer WorkflowObserver) {
+	l.observers = append(l.observers, observer)
+}
+
+// phaseTracker times the phases of a single workflow run, reports them to the observers and traces them
+type phaseTracker struct {
+	observers []WorkflowObserver
+	events    *eventStream
+	// runCtx carries the span of the run, the parent of the phase spans
+	runCtx     context.Context
+	runStart   time.Time
+	phase      string
+	phaseStart time.Time
+	phaseSpan  trace.Span
+}
+
+func newPhaseTracker(runCtx context.Context, observers []WorkflowObserver, events *eventStream) *phaseTracker {
+	return &phaseTracker{observers: observers, events: events, runCtx: runCtx, runStart: time.Now()}
+}
+
+// start ends the current phase successfully and starts the next one, returning the run context with its span
+func (t *phaseTracker) start(phase string) context.Context {
+	t.endPhase(nil)
+	t.phase = phase
+	t.phaseStart = time.Now()
+	t.events.publish(Event{Type: EventPhaseStart, Phase: phase})
+
+	ctx, span := startSpan(t.runCtx, spanPhasePrefix+phase)
+	t.phaseSpan = span
+	return ctx
+}
+
+// finish ends the current phase and the run with the result of the run
+func (t *phaseTracker) finish(err error) {
+	t.endPhase(err)
+	for _, observer := range t.observers {
+		observer.RunCompleted(time.Since(t.runStart), err)
+	}
+}
+
+func (t *phaseTracker) endPhase(err error) {
+	if t.phase == "" {
+		return
+	}
+	for _, observer := range t.observers {
+		observer.PhaseCompleted(t.phase, time.Since(t.phaseStart), err)
+	}
+	endSpan(t.phaseSpan, err)
+	t.phase = ""
+	t.phaseSpan = nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	"gopkg.in/yaml.v2"
+)
+
+// configHash returns the sha256 of the config a profile is rendered with
+func configHash(cfg *config.LaunchKubernetesConfig) (string, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal config: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// provenanceHeader returns the YAML comment identifying how the files of the profile were generated
+func provenanceHeader(profile *profiles.Profile, version, hash string) string {
+	return fmt.Sprintf("# Generated by l8k %s\n# Profile: %s (%s)\n# Config hash: %s\n", version, profile.Name, filepath.Base(profile.Dir), hash)
+}
+
+// stampProvenance prepends the provenance header to every YAML file of the rendered files
+func stampProvenance(renderedFiles map[string]string, header string) map[string]string {
+	stamped := make(map[string]string, len(renderedFiles))
+	for name, content := range renderedFiles {
+		ext := strings.ToLower(filepath.Ext(name))
+		if ext == ".yaml" || ext == ".yml" {
+			content = header + content
+		}
+		stamped[name] = content
+	}
+	return stamped
+}
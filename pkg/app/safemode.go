@@ -0,0 +1,71 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/kubeclient"
+)
+
+// DefaultEnvironmentKey is the default label or annotation of the kube-system namespace naming the cluster environment
+const DefaultEnvironmentKey = "environment"
+
+// productionEnvironments are the environment values of production clusters, compared case-insensitively
+var productionEnvironments = []string{"production", "prod"}
+
+// confirmProductionDeploy asks for a confirmation before deploying to a production cluster, unless Yes is set.
+// A cluster whose environment cannot be read is deployed to like a production one.
+func (l *Launcher) confirmProductionDeploy(ctx context.Context, c client.Client) error {
+	if l.options.EnvironmentKey == "" {
+		return nil
+	}
+
+	environment, err := kubeclient.ClusterEnvironment(ctx, c, l.options.EnvironmentKey)
+	if err != nil {
+		l.logger.Info("Cannot read the cluster environment", "key", l.options.EnvironmentKey, "error", err.Error())
+		if l.options.Yes {
+			l.ui.Warning("Deploying to a cluster whose environment cannot be read (%s): %v", l.options.EnvironmentKey, err)
+			return nil
+		}
+		if !l.ui.Confirm("The environment of the cluster cannot be read (%s): %v, deploy to it?", l.options.EnvironmentKey, err) {
+			l.ui.Error("Deployment to the cluster of unknown environment not confirmed, pass --yes to deploy without a confirmation")
+			return fmt.Errorf("deployment to cluster of unknown environment not confirmed: %w", err)
+		}
+		l.logger.Info("Deployment to cluster of unknown environment confirmed")
+		return nil
+	}
+	if !slices.Contains(productionEnvironments, strings.ToLower(environment)) {
+		return nil
+	}
+
+	if l.options.Yes {
+		l.ui.Warning("Deploying to %s cluster (%s=%s)", environment, l.options.EnvironmentKey, environment)
+		return nil
+	}
+	if !l.ui.Confirm("The cluster is a %s cluster (%s=%s), deploy to it?", environment, l.options.EnvironmentKey, environment) {
+		l.ui.Error("Deployment to the %s cluster not confirmed, pass --yes to deploy without a confirmation", environment)
+		return fmt.Errorf("deployment to %s cluster not confirmed", environment)
+	}
+	l.logger.Info("Deployment to production cluster confirmed", "environment", environment)
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+	"github.com/nvidia/k8s-launch-kit/pkg/ui"
+)
+
+func TestConfirmProductionDeploy(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n  namespace: default\n",
+	})
+
+	// deployTo deploys to a cluster whose kube-system namespace has the labels and annotations, a cluster
+	// without a readable kube-system namespace when both are nil
+	deployTo := func(t *testing.T, labels, annotations map[string]string, yes bool, confirm ...bool) ([]string, *ui.RecordingOutput, error) {
+		applied := []string{}
+		c := newAllowingClient(&applied)
+		if labels != nil || annotations != nil {
+			require.NoError(t, c.Create(context.Background(), &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "kube-system", Labels: labels, Annotations: annotations},
+			}))
+		}
+
+		output := ui.NewRecording()
+		output.ConfirmResponses = confirm
+		launcher := newTestLauncher(t, options.Options{
+			Fabric:              "ethernet",
+			DeploymentType:      "sriov",
+			ProfilesDirs:        []string{profilesDir},
+			SaveDeploymentFiles: t.TempDir(),
+			Deploy:              true,
+			EnvironmentKey:      DefaultEnvironmentKey,
+			Yes:                 yes,
+		})
+		launcher.ui = output
+		launcher.kubeClient = c
+		return applied, output, launcher.executeWorkflow()
+	}
+	production := map[string]string{"environment": "production"}
+
+	t.Run("deploy to a dev cluster without confirmation", func(t *testing.T) {
+		applied, output, err := deployTo(t, map[string]string{"environment": "dev"}, nil, false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap/sriov_network"}, applied)
+		assert.Empty(t, output.Confirms)
+	})
+
+	t.Run("refuse a production cluster without confirmation", func(t *testing.T) {
+		applied, output, err := deployTo(t, production, nil, false, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "deployment to production cluster not confirmed")
+		assert.Empty(t, applied, "nothing is applied")
+		assert.Equal(t, []string{"The cluster is a production cluster (environment=production), deploy to it?"}, output.Confirms)
+	})
+
+	t.Run("deploy to a confirmed production cluster", func(t *testing.T) {
+		applied, _, err := deployTo(t, production, nil, false, true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap/sriov_network"}, applied)
+	})
+
+	t.Run("deploy to a production cluster with --yes", func(t *testing.T) {
+		applied, output, err := deployTo(t, production, nil, true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap/sriov_network"}, applied)
+		assert.Empty(t, output.Confirms)
+		assert.Contains(t, output.Warnings, "Deploying to production cluster (environment=production)")
+	})
+
+	t.Run("treat a cluster of unknown environment like a production one", func(t *testing.T) {
+		applied, output, err := deployTo(t, nil, nil, false, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "deployment to cluster of unknown environment not confirmed")
+		assert.Empty(t, applied, "nothing is applied")
+		require.Len(t, output.Confirms, 1)
+		assert.Contains(t, output.Confirms[0], "The environment of the cluster cannot be read (environment)")
+
+		applied, _, err = deployTo(t, nil, nil, false, true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap/sriov_network"}, applied)
+
+		applied, output, err = deployTo(t, nil, nil, true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap/sriov_network"}, applied)
+		assert.Empty(t, output.Confirms)
+		require.Len(t, output.Warnings, 1)
+		assert.Contains(t, output.Warnings[0], "Deploying to a cluster whose environment cannot be read (environment)")
+	})
+
+	t.Run("read the environment from an annotation", func(t *testing.T) {
+		applied, _, err := deployTo(t, nil, map[string]string{"environment": "Prod"}, false)
+		require.Error(t, err)
+		assert.Empty(t, applied)
+	})
+}
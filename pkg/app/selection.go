@@ -0,0 +1,161 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+)
+
+// resolveProfile merges the profile requirements of every source into the profile to generate. Any source may be nil.
+//
+// Precedence is CLI flags > config file profile section > LLM, field by field: fabric and deployment are taken from the
+// first source that sets them, and so are multirail, spectrumX and ai, an explicit false included.
+func resolveProfile(cli, fromConfig, fromLLM *config.Profile) *config.Profile {
+	sources := []*config.Profile{cli, fromConfig, fromLLM}
+	resolved := &config.Profile{}
+	for _, source := range sources {
+		if source == nil {
+			continue
+		}
+		if resolved.Fabric == "" {
+			resolved.Fabric = source.Fabric
+		}
+		if resolved.Deployment == "" {
+			resolved.Deployment = source.Deployment
+		}
+	}
+
+	resolved.Multirail = resolveFlag(sources, "multirail")
+	resolved.SpectrumX = resolveFlag(sources, "spectrumX")
+	resolved.Ai = resolveFlag(sources, "ai")
+
+	return resolved
+}
+
+// resolveFlag returns the boolean requirement of the first source that sets it, false when none does
+func resolveFlag(sources []*config.Profile, key string) bool {
+	for _, source := range sources {
+		if source == nil {
+			continue
+		}
+		if value, set := source.Flag(key); set {
+			return value
+		}
+	}
+	return false
+}
+
+// profileComplete reports whether the profile selects both a fabric and a deployment type
+func profileComplete(profile *config.Profile) bool {
+	return profile.Fabric != "" && profile.Deployment != ""
+}
+
+// pickProfile lets the user choose among the profiles matching incomplete requirements, filling in the fabric and
+// deployment type of the chosen profile. Without an interactive input the requirements are left unchanged.
+func (l *Launcher) pickProfile(fullConfig *config.LaunchKubernetesConfig, profilesDir string) error {
+	available, err := profiles.LoadProfilesDir(profilesDir)
+	if err != nil {
+		return fmt.Errorf("failed to load profiles: %w", err)
+	}
+
+	candidates := []profiles.Profile{}
+	choices := []string{}
+	for _, profile := range available {
+		if _, ok := l.plugins[profile.Plugin]; !ok {
+			continue
+		}
+		if valid, _ := profile.Validate(completeWith(fullConfig.Profile, &profile), fullConfig.ClusterConfig.Capabilities); !valid {
+			continue
+		}
+		candidates = append(candidates, profile)
+		choices = append(choices, fmt.Sprintf("%s (%s): %s", profile.Name, filepath.Base(profile.Dir), profile.Description))
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	choice := l.ui.Select("The profile requirements are incomplete, select the profile to generate", choices)
+	if choice < 0 {
+		l.logger.Info("No profile selected interactively", "candidates", len(candidates))
+		return nil
+	}
+
+	chosen := &candidates[choice]
+	fullConfig.Profile = completeWith(fullConfig.Profile, chosen)
+	l.ui.Info("Selected profile: %s", chosen.Name)
+	l.logger.Info("Profile selected interactively", "profile", chosen.Name, "fabric", fullConfig.Profile.Fabric, "deployment", fullConfig.Profile.Deployment)
+	return nil
+}
+
+// completeWith returns the requirements with the missing fabric and deployment type taken from the profile
+func completeWith(requirements *config.Profile, profile *profiles.Profile) *config.Profile {
+	completed := *requirements
+	if completed.Fabric == "" {
+		completed.Fabric = profile.ProfileRequirements.Fabric
+	}
+	if completed.Deployment == "" {
+		completed.Deployment = profile.ProfileRequirements.Deployment
+	}
+	return &completed
+}
+
+// forceProfile loads the --force-profile profile of the plugin without matching it against the requirements.
+// The missing fabric and deployment type are taken from the profile, a mismatch is only reported as a warning.
+func (l *Launcher) forceProfile(profilesDir, pluginName string, requirements *config.Profile, capabilities *config.ClusterCapabilities) (*profiles.Profile, *config.Profile, error) {
+	profile, err := profiles.FindForcedProfile(profilesDir, l.options.ForceProfile, pluginName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	requirements = completeWith(requirements, profile)
+	if valid, reason := profile.Validate(requirements, capabilities); !valid {
+		l.ui.Warning("Forced profile %s does not match the cluster: %s", profile.Name, reason)
+		l.logger.Info("Forced profile does not match the requirements", "profile", profile.Name, "reason", reason)
+	} else {
+		l.logger.Info("Using forced profile", "profile", profile.Name)
+	}
+	return profile, requirements, nil
+}
+
+// openProfileCache opens the ProfileCache selection cache of profilesDir, nil when no cache is set
+func (l *Launcher) openProfileCache(profilesDir string) (*profiles.SelectionCache, error) {
+	if l.options.ProfileCache == "" {
+		return nil, nil
+	}
+	cache, err := profiles.OpenSelectionCache(l.options.ProfileCache, profilesDir)
+	if err != nil {
+		l.ui.Error("Failed to open the profile cache: %v", err)
+		return nil, err
+	}
+	return cache, nil
+}
+
+// findApplicableProfile matches the profile of the plugin in profilesDir, reusing the selection of cache when set
+func (l *Launcher) findApplicableProfile(cache *profiles.SelectionCache, profilesDir string, requirements *config.Profile, capabilities *config.ClusterCapabilities, pluginName string) (*profiles.Profile, error) {
+	if cache == nil {
+		return profiles.FindApplicableProfileInDir(profilesDir, requirements, capabilities, pluginName)
+	}
+	profile, cached, err := cache.FindApplicableProfile(requirements, capabilities, pluginName)
+	if cached {
+		l.ui.Info("Using cached profile selection: %s", profile.Name)
+	}
+	return profile, err
+}
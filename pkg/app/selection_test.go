@@ -0,0 +1,446 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/llm"
+	"github.com/nvidia/k8s-launch-kit/pkg/networkoperatorplugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	"github.com/nvidia/k8s-launch-kit/pkg/ui"
+)
+
+func TestResolveProfile(t *testing.T) {
+	t.Run("CLI overrides config", func(t *testing.T) {
+		resolved := resolveProfile(
+			&config.Profile{Fabric: "infiniband", Deployment: "sriov", Multirail: true},
+			&config.Profile{Fabric: "ethernet", Deployment: "host_device"},
+			nil)
+		assert.Equal(t, &config.Profile{Fabric: "infiniband", Deployment: "sriov", Multirail: true}, resolved)
+	})
+
+	t.Run("config fills gaps", func(t *testing.T) {
+		resolved := resolveProfile(
+			&config.Profile{Deployment: "sriov"},
+			&config.Profile{Fabric: "ethernet", Deployment: "host_device", Multirail: true},
+			nil)
+		assert.Equal(t, &config.Profile{Fabric: "ethernet", Deployment: "sriov", Multirail: true}, resolved)
+	})
+
+	t.Run("config overrides LLM field by field", func(t *testing.T) {
+		resolved := resolveProfile(
+			nil,
+			&config.Profile{Deployment: "rdma_shared", Set: []string{"multirail"}},
+			&config.Profile{Fabric: "infiniband", Deployment: "sriov", Multirail: true, SpectrumX: true})
+		assert.Equal(t, &config.Profile{Fabric: "infiniband", Deployment: "rdma_shared", SpectrumX: true}, resolved)
+	})
+
+	t.Run("an explicit false flag overrides config and LLM", func(t *testing.T) {
+		resolved := resolveProfile(
+			&config.Profile{Fabric: "ethernet", Set: []string{"spectrumX"}},
+			&config.Profile{Deployment: "sriov", SpectrumX: true, Ai: true},
+			&config.Profile{SpectrumX: true, Multirail: true})
+		assert.Equal(t, &config.Profile{Fabric: "ethernet", Deployment: "sriov", Multirail: true, Ai: true}, resolved)
+	})
+
+	t.Run("record the flags set by the config file", func(t *testing.T) {
+		cfg, err := config.ParseFullConfig([]byte(testClusterConfig+"profile:\n  fabric: ethernet\n  spectrumX: false\n"), "config.yaml", logr.Discard())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"spectrumX"}, cfg.Profile.Set)
+
+		resolved := resolveProfile(nil, cfg.Profile, &config.Profile{Deployment: "sriov", SpectrumX: true, Ai: true})
+		assert.Equal(t, &config.Profile{Fabric: "ethernet", Deployment: "sriov", Ai: true}, resolved)
+	})
+
+	t.Run("LLM alone", func(t *testing.T) {
+		llmProfile := &config.Profile{Fabric: "infiniband", Deployment: "sriov", Multirail: true, Ai: true}
+		assert.Equal(t, llmProfile, resolveProfile(nil, nil, llmProfile))
+	})
+}
+
+func TestGenerateWithConfigProfile(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n",
+	})
+	writeTestProfile(t, profilesDir, "host-device-rdma", hostdevProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Hostdev.NetworkName}}\n",
+	})
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(testClusterConfig+"profile:\n  fabric: ethernet\n  deployment: host_device\n"), 0644))
+
+	generate := func(t *testing.T, deploymentType string) string {
+		outputDir := t.TempDir()
+		launcher := newTestLauncher(t, options.Options{
+			UserConfig:          configPath,
+			DeploymentType:      deploymentType,
+			SaveDeploymentFiles: outputDir,
+			ProfilesDirs:        []string{profilesDir},
+		})
+		require.NoError(t, launcher.executeWorkflow())
+
+		network, err := os.ReadFile(filepath.Join(outputDir, networkoperatorplugin.PluginName, "30-network.yaml"))
+		require.NoError(t, err)
+		return string(network)
+	}
+
+	t.Run("config selects the profile", func(t *testing.T) {
+		assert.Contains(t, generate(t, ""), "name: hostdev-network")
+	})
+
+	t.Run("CLI overrides the config deployment type", func(t *testing.T) {
+		assert.Contains(t, generate(t, "sriov"), "name: sriov_network")
+	})
+
+	t.Run("an explicit false flag alone overrides the config", func(t *testing.T) {
+		flagProfilesDir := t.TempDir()
+		writeTestProfile(t, flagProfilesDir, "host-device-rdma", hostdevProfileManifest, map[string]string{
+			"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: spectrum-x-{{.Profile.SpectrumX}}\n",
+		})
+		spectrumXConfigPath := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(spectrumXConfigPath, []byte(testClusterConfig+"profile:\n  fabric: ethernet\n  deployment: host_device\n  spectrumX: true\n"), 0644))
+
+		outputDir := t.TempDir()
+		launcher := newTestLauncher(t, options.Options{
+			UserConfig:          spectrumXConfigPath,
+			ProfileFlagsSet:     []string{"spectrumX"},
+			SaveDeploymentFiles: outputDir,
+			ProfilesDirs:        []string{flagProfilesDir},
+		})
+		require.NoError(t, launcher.executeWorkflow())
+
+		network, err := os.ReadFile(filepath.Join(outputDir, networkoperatorplugin.PluginName, "30-network.yaml"))
+		require.NoError(t, err)
+		assert.Contains(t, string(network), "name: spectrum-x-false")
+	})
+}
+
+func TestGeneratePickProfile(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n",
+	})
+	writeTestProfile(t, profilesDir, "host-device-rdma", hostdevProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Hostdev.NetworkName}}\n",
+	})
+
+	generate := func(t *testing.T, responses ...int) (*ui.RecordingOutput, string, error) {
+		outputDir := t.TempDir()
+		launcher := newTestLauncher(t, options.Options{
+			Fabric:              "ethernet",
+			SaveDeploymentFiles: outputDir,
+			ProfilesDirs:        []string{profilesDir},
+		})
+		output := ui.NewRecording()
+		output.SelectResponses = responses
+		launcher.ui = output
+		if err := launcher.executeWorkflow(); err != nil {
+			return output, "", err
+		}
+
+		network, err := os.ReadFile(filepath.Join(outputDir, networkoperatorplugin.PluginName, "30-network.yaml"))
+		require.NoError(t, err)
+		return output, string(network), nil
+	}
+
+	t.Run("generate the chosen profile", func(t *testing.T) {
+		output, network, err := generate(t, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"The profile requirements are incomplete, select the profile to generate"}, output.Selects)
+		assert.Contains(t, output.Infos, "Selected profile: Host device")
+		assert.Contains(t, network, "name: hostdev-network")
+	})
+
+	t.Run("fail as before without a choice", func(t *testing.T) {
+		output, _, err := generate(t)
+		assert.ErrorIs(t, err, profiles.ErrNoApplicableProfile)
+		assert.Len(t, output.Selects, 1)
+	})
+}
+
+func TestCompleteWith(t *testing.T) {
+	profile := &profiles.Profile{ProfileRequirements: profiles.ProfileRequirements{Fabric: "infiniband", Deployment: "sriov"}}
+
+	assert.Equal(t, &config.Profile{Fabric: "infiniband", Deployment: "sriov", Multirail: true}, completeWith(&config.Profile{Multirail: true}, profile))
+	assert.Equal(t, &config.Profile{Fabric: "ethernet", Deployment: "sriov"}, completeWith(&config.Profile{Fabric: "ethernet"}, profile))
+}
+
+func TestGenerateWithLLMFixture(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n",
+	})
+	writeTestProfile(t, profilesDir, "host-device-rdma", hostdevProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Hostdev.NetworkName}}\n",
+	})
+
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile(llm.SystemPromptPath, []byte("SYSTEM: select a profile\n\nCluster configuration:\n"), 0644))
+	require.NoError(t, os.WriteFile("prompt.txt", []byte("Use host device networking"), 0644))
+	require.NoError(t, os.WriteFile("response.json", []byte(`{"fabric": "ethernet", "deploymentType": "host_device", "multirail": "false", "confidence": "high", "reasoning": "recorded"}`), 0644))
+
+	outputDir := t.TempDir()
+	launcher := newTestLauncher(t, options.Options{
+		Prompt:              "prompt.txt",
+		LLMFixture:          "response.json",
+		SaveDeploymentFiles: outputDir,
+		ProfilesDirs:        []string{profilesDir},
+	})
+	require.NoError(t, launcher.executeWorkflow())
+
+	network, err := os.ReadFile(filepath.Join(outputDir, networkoperatorplugin.PluginName, "30-network.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(network), "name: hostdev-network")
+}
+
+func TestGenerateFromRunSpec(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n",
+	})
+	writeTestProfile(t, profilesDir, "host-device-rdma", hostdevProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Hostdev.NetworkName}}\n",
+	})
+
+	// runSpec embeds the test cluster config under the intent
+	runSpec := func(intent string) string {
+		return intent + "config:\n  " + strings.ReplaceAll(strings.TrimSuffix(testClusterConfig, "\n"), "\n", "\n  ") + "\n"
+	}
+	generate := func(t *testing.T, spec string, opts options.Options) string {
+		opts.RunSpec = filepath.Join(t.TempDir(), "run-spec.yaml")
+		require.NoError(t, os.WriteFile(opts.RunSpec, []byte(spec), 0644))
+		opts.SaveDeploymentFiles = t.TempDir()
+		opts.ProfilesDirs = []string{profilesDir}
+
+		launcher := newTestLauncher(t, opts)
+		require.NoError(t, launcher.executeWorkflow())
+
+		network, err := os.ReadFile(filepath.Join(opts.SaveDeploymentFiles, networkoperatorplugin.PluginName, "30-network.yaml"))
+		require.NoError(t, err)
+		return string(network)
+	}
+
+	t.Run("select the profile of the intent", func(t *testing.T) {
+		network := generate(t, runSpec("intent:\n  fabric: ethernet\n  deployment: host_device\n"), options.Options{})
+		assert.Contains(t, network, "name: hostdev-network")
+	})
+
+	t.Run("select the profile with the prompt of the intent", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		require.NoError(t, os.WriteFile(llm.SystemPromptPath, []byte("SYSTEM: select a profile\n\nCluster configuration:\n"), 0644))
+		require.NoError(t, os.WriteFile("response.json", []byte(`{"fabric": "ethernet", "deploymentType": "sriov", "multirail": "false", "confidence": "high", "reasoning": "recorded"}`), 0644))
+
+		network := generate(t, runSpec("intent:\n  prompt: Use SR-IOV networking\n"), options.Options{LLMFixture: "response.json"})
+		assert.Contains(t, network, "name: sriov_network")
+	})
+}
+
+func TestGenerateWithLLMLowConfidence(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n",
+	})
+
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile(llm.SystemPromptPath, []byte("SYSTEM: select a profile\n\nCluster configuration:\n"), 0644))
+	require.NoError(t, os.WriteFile("prompt.txt", []byte("Use fast networking"), 0644))
+	require.NoError(t, os.WriteFile("response.json", []byte(`{"fabric": "ethernet", "deploymentType": "", "confidence": "low", "reasoning": "no deployment type in the prompt"}`), 0644))
+
+	output := ui.NewRecording()
+	launcher := newTestLauncher(t, options.Options{
+		Prompt:              "prompt.txt",
+		LLMFixture:          "response.json",
+		SaveDeploymentFiles: t.TempDir(),
+		ProfilesDirs:        []string{profilesDir},
+	})
+	launcher.ui = output
+
+	err := launcher.executeWorkflow()
+	var lowConfidence *llm.ErrLowConfidence
+	require.ErrorAs(t, err, &lowConfidence)
+	assert.Equal(t, "no deployment type in the prompt", lowConfidence.Reasoning)
+	assert.Equal(t, "ethernet", lowConfidence.Profile["fabric"])
+
+	assert.Contains(t, output.Warnings, "AI has low confidence: no deployment type in the prompt")
+	assert.Contains(t, output.Infos, "  fabric: ethernet")
+	assert.Equal(t, []string{"Refine the requirements in an interactive session?"}, output.Confirms)
+}
+
+func TestPrintSystemPrompt(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n",
+	})
+	writeTestProfile(t, profilesDir, "host-device-rdma", hostdevProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Hostdev.NetworkName}}\n",
+	})
+
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile(llm.SystemPromptPath, []byte("SYSTEM: select a profile, key test-api-key\n\nCluster configuration:\n"), 0644))
+
+	outputDir := t.TempDir()
+	launcher := newTestLauncher(t, options.Options{
+		PrintSystemPrompt:   true,
+		LLMVendor:           llm.VendorOpenAI,
+		LLMApiKey:           "test-api-key",
+		LLMApiUrl:           "http://127.0.0.1:0",
+		SaveDeploymentFiles: outputDir,
+		ProfilesDirs:        []string{profilesDir},
+	})
+	var stdout bytes.Buffer
+	launcher.stdout = &stdout
+	require.NoError(t, launcher.executeWorkflow())
+
+	printed := stdout.String()
+	assert.Contains(t, printed, "VALID OPTIONS:\n")
+	assert.Contains(t, printed, "Valid deployment types: host_device, sriov\n")
+	assert.Contains(t, printed, "- SR-IOV: fabric any, deploymentType sriov")
+	assert.Less(t, strings.Index(printed, "VALID OPTIONS:"), strings.Index(printed, "Cluster configuration:"), "the profiles are listed before the cluster config")
+	assert.Contains(t, printed, `"PciAddress":"0000:08:00.0"`)
+	assert.Contains(t, printed, "key <redacted>")
+	assert.NotContains(t, printed, "test-api-key")
+	assert.NoDirExists(t, filepath.Join(outputDir, networkoperatorplugin.PluginName), "no profile is generated")
+}
+
+func TestGenerateWithMinConfidence(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n",
+	})
+
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile(llm.SystemPromptPath, []byte("SYSTEM: select a profile\n\nCluster configuration:\n"), 0644))
+	require.NoError(t, os.WriteFile("prompt.txt", []byte("Use SR-IOV networking"), 0644))
+	require.NoError(t, os.WriteFile("response.json", []byte(`{"fabric": "ethernet", "deploymentType": "sriov", "confidence": "medium", "reasoning": "SR-IOV is likely"}`), 0644))
+
+	generate := func(t *testing.T, minConfidence string) (*ui.RecordingOutput, string, error) {
+		output := ui.NewRecording()
+		outputDir := t.TempDir()
+		launcher := newTestLauncher(t, options.Options{
+			Prompt:              "prompt.txt",
+			LLMFixture:          "response.json",
+			MinConfidence:       minConfidence,
+			SaveDeploymentFiles: outputDir,
+			ProfilesDirs:        []string{profilesDir},
+		})
+		launcher.ui = output
+		return output, outputDir, launcher.executeWorkflow()
+	}
+
+	t.Run("accept a medium confidence answer by default", func(t *testing.T) {
+		_, outputDir, err := generate(t, llm.DefaultMinConfidence)
+		require.NoError(t, err)
+		assert.FileExists(t, filepath.Join(outputDir, networkoperatorplugin.PluginName, "30-network.yaml"))
+	})
+
+	t.Run("clarify a medium confidence answer when high confidence is required", func(t *testing.T) {
+		output, _, err := generate(t, llm.ConfidenceHigh)
+		var lowConfidence *llm.ErrLowConfidence
+		require.ErrorAs(t, err, &lowConfidence)
+		assert.Contains(t, output.Warnings, "AI has medium confidence: SR-IOV is likely")
+		assert.Equal(t, []string{"Refine the requirements in an interactive session?"}, output.Confirms)
+	})
+}
+
+func TestProfileCache(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n",
+	})
+	writeTestProfile(t, profilesDir, "host-device-rdma", hostdevProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Hostdev.NetworkName}}\n",
+	})
+	cachePath := filepath.Join(t.TempDir(), "profile-cache.yaml")
+
+	run := func(t *testing.T) *ui.RecordingOutput {
+		t.Helper()
+		outputDir := t.TempDir()
+		launcher := newTestLauncher(t, options.Options{
+			Fabric:              "ethernet",
+			DeploymentType:      "sriov",
+			ProfilesDirs:        []string{profilesDir},
+			ProfileCache:        cachePath,
+			SaveDeploymentFiles: outputDir,
+		})
+		output := ui.NewRecording()
+		launcher.ui = output
+		require.NoError(t, launcher.executeWorkflow())
+		assert.DirExists(t, filepath.Join(outputDir, networkoperatorplugin.PluginName))
+		return output
+	}
+
+	assert.NotContains(t, run(t).Infos, "Using cached profile selection: SR-IOV")
+	assert.Contains(t, run(t).Infos, "Using cached profile selection: SR-IOV")
+
+	// A changed profile drops the cached selections
+	manifest := filepath.Join(profilesDir, "host-device-rdma", "profile.yaml")
+	require.NoError(t, os.WriteFile(manifest, []byte(hostdevProfileManifest+"description: changed\n"), 0644))
+	assert.NotContains(t, run(t).Infos, "Using cached profile selection: SR-IOV")
+}
+
+func TestGenerateFromProfileJSON(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n",
+	})
+	writeTestProfile(t, profilesDir, "host-device-rdma", hostdevProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Hostdev.NetworkName}}\n",
+	})
+
+	generate := func(t *testing.T, profileJSON string) (string, error) {
+		outputDir := t.TempDir()
+		launcher := newTestLauncher(t, options.Options{
+			ProfileJSON:         profileJSON,
+			SaveDeploymentFiles: outputDir,
+			ProfilesDirs:        []string{profilesDir},
+		})
+		if err := launcher.executeWorkflow(); err != nil {
+			return "", err
+		}
+		network, err := os.ReadFile(filepath.Join(outputDir, networkoperatorplugin.PluginName, "30-network.yaml"))
+		require.NoError(t, err)
+		return string(network), nil
+	}
+
+	t.Run("generate the profile of a valid JSON profile", func(t *testing.T) {
+		network, err := generate(t, `{"fabric": "ethernet", "deploymentType": "host_device", "multirail": false}`)
+		require.NoError(t, err)
+		assert.Contains(t, network, "name: hostdev-network")
+	})
+
+	t.Run("reject an invalid JSON profile", func(t *testing.T) {
+		_, err := generate(t, `{"fabric": "ethernet", "deploymentType": "macvlan"}`)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `deploymentType "macvlan" is not supported`)
+
+		_, err = generate(t, `{"fabric": "ethernet"`)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid profile JSON")
+	})
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Output formats of the generated deployment files
+const (
+	// OutputFormatFiles saves the files with --save-deployment-files
+	OutputFormatFiles = "files"
+	// OutputFormatStream writes all files to stdout as a single multi-document YAML stream
+	OutputFormatStream = "stream"
+	// OutputFormatArgoCD saves the files with an Argo CD Application syncing them from a git repository
+	OutputFormatArgoCD = "argocd"
+)
+
+// OutputFormats lists the supported output formats
+var OutputFormats = []string{OutputFormatFiles, OutputFormatStream, OutputFormatArgoCD}
+
+// writeManifestStream writes the rendered files of every profile to w as one multi-document YAML stream.
+// Profiles are written in name order and their files in file name order, each document is preceded by
+// a comment naming its source file.
+func writeManifestStream(w io.Writer, renderedFiles map[string]map[string]string) error {
+	profileNames := make([]string, 0, len(renderedFiles))
+	for name := range renderedFiles {
+		profileNames = append(profileNames, name)
+	}
+	sort.Strings(profileNames)
+
+	var stream strings.Builder
+	for _, profileName := range profileNames {
+		files := renderedFiles[profileName]
+		for _, fileName := range sortedFileNames(files) {
+			content := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(files[fileName]), "---"))
+			if content == "" {
+				continue
+			}
+			fmt.Fprintf(&stream, "---\n# Source: %s/%s\n%s\n", profileName, fileName, content)
+		}
+	}
+
+	if _, err := io.WriteString(w, stream.String()); err != nil {
+		return fmt.Errorf("failed to write manifest stream: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+)
+
+// parseStream returns the Kind/name of every document of a multi-document YAML stream
+func parseStream(t *testing.T, stream string) []string {
+	t.Helper()
+	decoder := utilyaml.NewYAMLOrJSONDecoder(strings.NewReader(stream), 4096)
+	objects := []string{}
+	for {
+		obj := &unstructured.Unstructured{}
+		err := decoder.Decode(&obj.Object)
+		if errors.Is(err, io.EOF) {
+			return objects
+		}
+		require.NoError(t, err)
+		if len(obj.Object) > 0 {
+			objects = append(objects, obj.GetKind()+"/"+obj.GetName())
+		}
+	}
+}
+
+func TestGenerateStream(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest+"  - 10-policy.yaml\n", map[string]string{
+		"10-policy.yaml":  "---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: first\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: second\n",
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n",
+	})
+	writeTestProfile(t, profilesDir, "host-device-rdma", hostdevProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Hostdev.NetworkName}}\n",
+	})
+
+	generate := func() string {
+		var stdout bytes.Buffer
+		launcher := newTestLauncher(t, options.Options{
+			Fabric:         "ethernet",
+			DeploymentType: "sriov,host_device",
+			OutputFormat:   OutputFormatStream,
+			ProfilesDirs:   []string{profilesDir},
+		})
+		launcher.stdout = &stdout
+		require.NoError(t, launcher.executeWorkflow())
+		return stdout.String()
+	}
+
+	t.Run("stream parses into the documents of every profile", func(t *testing.T) {
+		stream := generate()
+		assert.Equal(t, []string{
+			"ConfigMap/hostdev-network",
+			"ConfigMap/first",
+			"ConfigMap/second",
+			"ConfigMap/sriov_network",
+		}, parseStream(t, stream))
+		assert.Contains(t, stream, "# Source: Host device/30-network.yaml\n")
+		assert.Contains(t, stream, "# Source: SR-IOV/10-policy.yaml\n")
+	})
+
+	t.Run("stream is deterministic", func(t *testing.T) {
+		first := generate()
+		for range 5 {
+			assert.Equal(t, first, generate())
+		}
+	})
+
+	t.Run("only the stream is written without output directory", func(t *testing.T) {
+		dir := t.TempDir()
+		t.Chdir(dir)
+		generate()
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
+
+func TestWriteManifestStream(t *testing.T) {
+	var stream bytes.Buffer
+	require.NoError(t, writeManifestStream(&stream, map[string]map[string]string{
+		"b": {"20.yaml": "kind: B\n", "10.yaml": "\n---\nkind: A\n"},
+		"a": {"empty.yaml": "---\n", "10.yaml": "kind: C"},
+	}))
+	assert.Equal(t, "---\n# Source: a/10.yaml\nkind: C\n---\n# Source: b/10.yaml\nkind: A\n---\n# Source: b/20.yaml\nkind: B\n", stream.String())
+}
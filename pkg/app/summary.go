@@ -0,0 +1,212 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/deploy"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+)
+
+// summarySetting is a row of the settings table of the summary
+type summarySetting struct {
+	name  string
+	value string
+}
+
+// renderSummaryDoc returns the markdown summary of the profiles to deploy: their requirements, the settings of
+// their config and the objects of their rendered files. The profiles are listed by name so that the same inputs
+// always render the same summary.
+func renderSummaryDoc(selected []profiles.Profile, configs map[string]*config.LaunchKubernetesConfig, renderedFiles map[string]map[string]string) (string, error) {
+	sorted := slices.Clone(selected)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	var doc strings.Builder
+	doc.WriteString("# Deployment Summary\n")
+	for _, profile := range sorted {
+		cfg := configs[profile.Name]
+
+		fmt.Fprintf(&doc, "\n## %s\n\n", profile.Name)
+		fmt.Fprintf(&doc, "Profile `%s` of the `%s` plugin.", filepath.Base(profile.Dir), profile.Plugin)
+		if description := strings.TrimSpace(profile.Description); description != "" {
+			fmt.Fprintf(&doc, " %s", description)
+		}
+		doc.WriteString("\n")
+
+		if cfg != nil && cfg.Profile != nil {
+			doc.WriteString("\n### Requirements\n\n")
+			writeMarkdownTable(&doc, []string{"Fabric", "Deployment", "Multirail", "Spectrum-X", "AI"}, [][]string{{
+				cfg.Profile.Fabric,
+				cfg.Profile.Deployment,
+				strconv.FormatBool(cfg.Profile.Multirail),
+				strconv.FormatBool(cfg.Profile.SpectrumX),
+				strconv.FormatBool(cfg.Profile.Ai),
+			}})
+		}
+
+		if settings := summarySettings(cfg); len(settings) > 0 {
+			doc.WriteString("\n### Settings\n\n")
+			rows := make([][]string, 0, len(settings))
+			for _, setting := range settings {
+				rows = append(rows, []string{setting.name, setting.value})
+			}
+			writeMarkdownTable(&doc, []string{"Setting", "Value"}, rows)
+		}
+
+		doc.WriteString("\n### Objects\n\n")
+		files := renderedFiles[profile.Name]
+		if len(files) == 0 {
+			doc.WriteString("No files were generated for this profile.\n")
+			continue
+		}
+		var rows [][]string
+		for _, name := range sortedFileNames(files) {
+			objects, err := deploy.ParseManifests([]byte(files[name]))
+			if err != nil {
+				return "", fmt.Errorf("failed to parse %s of profile %s: %w", name, profile.Name, err)
+			}
+			for _, obj := range objects {
+				namespace := obj.GetNamespace()
+				if namespace == "" {
+					namespace = "-"
+				}
+				rows = append(rows, []string{obj.GetKind(), obj.GetName(), namespace, name})
+			}
+		}
+		writeMarkdownTable(&doc, []string{"Kind", "Name", "Namespace", "File"}, rows)
+	}
+	return doc.String(), nil
+}
+
+// summarySettings returns the settings of cfg that shape the deployment of its profile, skipping the unset ones
+func summarySettings(cfg *config.LaunchKubernetesConfig) []summarySetting {
+	if cfg == nil {
+		return nil
+	}
+
+	var settings []summarySetting
+	add := func(name string, value interface{}) {
+		text := fmt.Sprint(value)
+		if text != "" && text != "0" {
+			settings = append(settings, summarySetting{name: name, value: text})
+		}
+	}
+
+	deployment := ""
+	fabric := ""
+	if cfg.Profile != nil {
+		deployment, fabric = cfg.Profile.Deployment, cfg.Profile.Fabric
+	}
+
+	if operator := cfg.NetworkOperator; operator != nil {
+		add("Network Operator version", operator.Version)
+		add("Namespace", operator.Namespace)
+		for _, component := range slices.Sorted(maps.Keys(operator.Namespaces)) {
+			add(fmt.Sprintf("Namespace of %s", component), operator.ComponentNamespace(component))
+		}
+		add("Image repository", operator.Repository)
+	}
+	if cfg.DOCADriver != nil {
+		add("DOCA driver version", cfg.DOCADriver.Version)
+	}
+	if ipam := cfg.NvIpam; ipam != nil {
+		add("IP pool", ipam.PoolName)
+		for _, subnet := range ipam.Subnets {
+			add("Subnet", fmt.Sprintf("%s (gateway %s)", subnet.Subnet, subnet.Gateway))
+		}
+	}
+	if sriov := cfg.Sriov; sriov != nil && deployment == "sriov" {
+		if fabric == "infiniband" {
+			add("MTU", sriov.InfinibandMtu)
+		} else {
+			add("MTU", sriov.EthernetMtu)
+		}
+		add("VFs per PF", sriov.NumVfs)
+		for _, device := range sriov.Devices {
+			add(fmt.Sprintf("VFs of %s", device.PciAddress), device.NumVfs)
+		}
+		add("Resource name", sriov.ResourceName)
+		add("Network name", sriov.NetworkName)
+	}
+	if hostdev := cfg.Hostdev; hostdev != nil && deployment == "host_device" {
+		add("Resource name", hostdev.ResourceName)
+		add("Network name", hostdev.NetworkName)
+	}
+	if rdma := cfg.RdmaShared; rdma != nil && deployment == "rdma_shared" {
+		add("Resource name", rdma.ResourceName)
+		add("HCAs per device", rdma.HcaMax)
+		if fabric == "infiniband" && cfg.Ipoib != nil {
+			add("Network name", cfg.Ipoib.NetworkName)
+		}
+		if fabric == "ethernet" && cfg.Macvlan != nil {
+			if cfg.Sriov != nil {
+				add("MTU", cfg.Sriov.EthernetMtu)
+			}
+			add("Network name", cfg.Macvlan.NetworkName)
+		}
+	}
+	if cluster := cfg.ClusterConfig; cluster != nil {
+		if cluster.Capabilities != nil && cluster.Capabilities.Nodes != nil {
+			add("NIC-equipped nodes", cluster.Capabilities.Nodes.Count)
+		}
+		add("PFs", len(cluster.PFs))
+	}
+	return settings
+}
+
+// writeMarkdownTable writes a markdown table with the headers and rows
+func writeMarkdownTable(doc *strings.Builder, headers []string, rows [][]string) {
+	fmt.Fprintf(doc, "| %s |\n", strings.Join(headers, " | "))
+	separators := make([]string, len(headers))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	fmt.Fprintf(doc, "| %s |\n", strings.Join(separators, " | "))
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = strings.ReplaceAll(cell, "|", `\|`)
+		}
+		fmt.Fprintf(doc, "| %s |\n", strings.Join(cells, " | "))
+	}
+}
+
+// saveSummaryDoc writes the markdown summary of the profiles to SummaryDoc
+func (l *Launcher) saveSummaryDoc(selected []profiles.Profile, configs map[string]*config.LaunchKubernetesConfig, renderedFiles map[string]map[string]string) error {
+	doc, err := renderSummaryDoc(selected, configs, renderedFiles)
+	if err != nil {
+		return fmt.Errorf("failed to render summary: %w", err)
+	}
+	if err := l.files.MkdirAll(filepath.Dir(l.options.SummaryDoc), 0755); err != nil {
+		return fmt.Errorf("failed to create summary directory: %w", err)
+	}
+	if err := l.files.WriteFile(l.options.SummaryDoc, []byte(doc), 0644); err != nil {
+		return fmt.Errorf("failed to write summary %s: %w", l.options.SummaryDoc, err)
+	}
+
+	l.ui.Success("Saved deployment summary to: %s", l.options.SummaryDoc)
+	l.logger.Info("Saved deployment summary", "file", l.options.SummaryDoc, "profiles", len(selected))
+	return nil
+}
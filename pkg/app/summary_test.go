@@ -0,0 +1,64 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+)
+
+func TestSummaryDoc(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n  namespace: {{.NetworkOperator.Namespace}}\n",
+	})
+
+	generate := func(t *testing.T) string {
+		t.Helper()
+		summaryPath := filepath.Join(t.TempDir(), "docs", "summary.md")
+		launcher := newTestLauncher(t, options.Options{
+			Fabric:              "ethernet",
+			DeploymentType:      "sriov",
+			ProfilesDirs:        []string{profilesDir},
+			SaveDeploymentFiles: t.TempDir(),
+			SummaryDoc:          summaryPath,
+			Set:                 []string{"sriov.ethernetMtu=9000"},
+		})
+		require.NoError(t, launcher.executeWorkflow())
+
+		data, err := os.ReadFile(summaryPath)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	summary := generate(t)
+	assert.Contains(t, summary, "## SR-IOV\n")
+	assert.Contains(t, summary, "Profile `sriov-ethernet-rdma` of the `network-operator` plugin.")
+	assert.Contains(t, summary, "| ethernet | sriov | false | false | false |\n")
+	assert.Contains(t, summary, "| Namespace | nvidia-network-operator |\n")
+	assert.Contains(t, summary, "| MTU | 9000 |\n")
+	assert.Contains(t, summary, "| VFs per PF | 8 |\n")
+	assert.Contains(t, summary, "| ConfigMap | sriov_network | nvidia-network-operator | 30-network.yaml |\n")
+
+	assert.Equal(t, summary, generate(t), "the summary is deterministic")
+}
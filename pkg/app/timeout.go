@@ -0,0 +1,76 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// phaseTimeout bounds one phase of the run with the timeout of its flag
+type phaseTimeout struct {
+	// phase names the phase in the timeout error
+	phase   string
+	flag    string
+	timeout time.Duration
+	// global is the timeout of the whole run the phase is nested in
+	global time.Duration
+}
+
+// discoveryTimeout, llmTimeout and deployTimeout return the timeouts of the phases selected on the command line
+func (l *Launcher) discoveryTimeout() phaseTimeout {
+	return phaseTimeout{phase: "cluster discovery", flag: "--discovery-timeout", timeout: l.options.DiscoveryTimeout, global: l.options.Timeout}
+}
+
+func (l *Launcher) llmTimeout() phaseTimeout {
+	return phaseTimeout{phase: "AI-assisted profile selection", flag: "--llm-timeout", timeout: l.options.LLMTimeout, global: l.options.Timeout}
+}
+
+func (l *Launcher) deployTimeout() phaseTimeout {
+	return phaseTimeout{phase: "deployment", flag: "--deploy-timeout", timeout: l.options.DeployTimeout, global: l.options.Timeout}
+}
+
+// runContext returns the context of a workflow run, bounded by the global Timeout when set
+func (l *Launcher) runContext() (context.Context, context.CancelFunc) {
+	if l.options.Timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), l.options.Timeout)
+}
+
+// context returns the context of the phase, nested in the run context so that the earlier deadline applies
+func (t phaseTimeout) context(runCtx context.Context) (context.Context, context.CancelFunc) {
+	if t.timeout <= 0 {
+		return context.WithCancel(runCtx)
+	}
+	return context.WithTimeout(runCtx, t.timeout)
+}
+
+// wrap names the timeout that aborted the phase when one of the contexts expired, and returns err otherwise
+func (t phaseTimeout) wrap(runCtx, phaseCtx context.Context, err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(runCtx.Err(), context.DeadlineExceeded):
+		return fmt.Errorf("run did not complete within --timeout %s: %w", t.global, err)
+	case errors.Is(phaseCtx.Err(), context.DeadlineExceeded):
+		return fmt.Errorf("%s did not complete within %s %s: %w", t.phase, t.flag, t.timeout, err)
+	}
+	return err
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/deploy"
+	"github.com/nvidia/k8s-launch-kit/pkg/llm"
+	"github.com/nvidia/k8s-launch-kit/pkg/networkoperatorplugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+	"github.com/nvidia/k8s-launch-kit/pkg/plugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+)
+
+// blockingPlugin is a network operator plugin whose discovery and deployment wait for their context to end
+type blockingPlugin struct {
+	*networkoperatorplugin.NetworkOperatorPlugin
+}
+
+func (p *blockingPlugin) DiscoverClusterConfig(ctx context.Context, kubeClient client.Client, defaultConfig *config.LaunchKubernetesConfig) (*plugin.DiscoveryResult, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (p *blockingPlugin) DeployProfile(ctx context.Context, profile *profiles.Profile, kubeClient client.Client, renderedFiles map[string]string, opts deploy.Options) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// newBlockingLauncher returns a test launcher with the blocking plugin and a client allowed to apply every ConfigMap
+func newBlockingLauncher(t *testing.T, opts options.Options) *Launcher {
+	t.Helper()
+	launcher := newTestLauncher(t, opts)
+	launcher.plugins = map[string]plugin.Plugin{
+		networkoperatorplugin.PluginName: &blockingPlugin{&networkoperatorplugin.NetworkOperatorPlugin{}},
+	}
+	launcher.kubeClient = newAllowingClient(&[]string{})
+	return launcher
+}
+
+func TestPhaseTimeouts(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n  namespace: default\n",
+	})
+
+	t.Run("abort the discovery after --discovery-timeout", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		require.NoError(t, os.WriteFile("l8k-config.yaml", []byte(testClusterConfig), 0644))
+
+		launcher := newBlockingLauncher(t, options.Options{
+			DiscoverClusterConfig: true,
+			SaveClusterConfig:     "discovered.yaml",
+			DiscoveryTimeout:      10 * time.Millisecond,
+		})
+		// discover instead of loading the config file of the test launcher
+		launcher.options.UserConfig = ""
+		err := launcher.executeWorkflow()
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, err.Error(), "cluster discovery did not complete within --discovery-timeout 10ms")
+	})
+
+	t.Run("abort the profile selection after --llm-timeout", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.Copy(io.Discard, r.Body)
+			<-r.Context().Done()
+		}))
+		defer server.Close()
+
+		t.Chdir(t.TempDir())
+		require.NoError(t, os.WriteFile(llm.SystemPromptPath, []byte("SYSTEM: select a profile\n\nCluster configuration:\n"), 0644))
+		require.NoError(t, os.WriteFile("prompt.txt", []byte("Use SR-IOV networking"), 0644))
+
+		launcher := newBlockingLauncher(t, options.Options{
+			Prompt:              "prompt.txt",
+			LLMVendor:           llm.VendorOpenAI,
+			LLMApiKey:           "test-api-key",
+			LLMApiUrl:           server.URL,
+			SaveDeploymentFiles: t.TempDir(),
+			ProfilesDirs:        []string{profilesDir},
+			LLMTimeout:          10 * time.Millisecond,
+		})
+		err := launcher.executeWorkflow()
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, err.Error(), "AI-assisted profile selection did not complete within --llm-timeout 10ms")
+	})
+
+	t.Run("abort the deployment after --deploy-timeout", func(t *testing.T) {
+		launcher := newBlockingLauncher(t, options.Options{
+			Fabric:              "ethernet",
+			DeploymentType:      "sriov",
+			SaveDeploymentFiles: t.TempDir(),
+			ProfilesDirs:        []string{profilesDir},
+			Deploy:              true,
+			DeployTimeout:       10 * time.Millisecond,
+		})
+		err := launcher.executeWorkflow()
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, err.Error(), "deployment did not complete within --deploy-timeout 10ms")
+	})
+
+	t.Run("abort a phase at the global --timeout before its own timeout", func(t *testing.T) {
+		launcher := newBlockingLauncher(t, options.Options{
+			Fabric:              "ethernet",
+			DeploymentType:      "sriov",
+			SaveDeploymentFiles: t.TempDir(),
+			ProfilesDirs:        []string{profilesDir},
+			Deploy:              true,
+			Timeout:             10 * time.Millisecond,
+			DeployTimeout:       time.Hour,
+		})
+		err := launcher.executeWorkflow()
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, err.Error(), "run did not complete within --timeout 10ms")
+	})
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName names the tracer of the workflow spans
+const tracerName = "github.com/nvidia/k8s-launch-kit/pkg/app"
+
+// Spans of a workflow run, the phases are children of the run span
+const (
+	spanRun          = "l8k.run"
+	spanPhasePrefix  = "l8k."
+	spanLLMSelection = "l8k.llm-selection"
+)
+
+// SetTracerProvider sets the OpenTelemetry provider recording a span per workflow run and phase, the global
+// provider of otel.SetTracerProvider by default. The spans are no-ops until a provider with an exporter is set.
+func (l *Launcher) SetTracerProvider(provider trace.TracerProvider) {
+	l.tracerProvider = provider
+}
+
+// tracer returns the tracer starting the run span, the phase spans use the one carried by the run context
+func (l *Launcher) tracer() trace.Tracer {
+	if l.tracerProvider == nil {
+		return otel.Tracer(tracerName)
+	}
+	return l.tracerProvider.Tracer(tracerName)
+}
+
+// startSpan starts a child of the span of ctx with the tracer provider of that span, a no-op without one
+func startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return trace.SpanFromContext(ctx).TracerProvider().Tracer(tracerName).Start(ctx, name)
+}
+
+// endSpan ends span, recording err as its failure
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
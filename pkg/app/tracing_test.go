@@ -0,0 +1,164 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/embedded"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/llm"
+	"github.com/nvidia/k8s-launch-kit/pkg/networkoperatorplugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/options"
+	"github.com/nvidia/k8s-launch-kit/pkg/plugin"
+)
+
+// recordedSpan is an ended span of a spanRecorder
+type recordedSpan struct {
+	Name   string
+	Parent string
+	Status codes.Code
+}
+
+// spanRecorder is an in-memory tracer provider recording the ended spans
+type spanRecorder struct {
+	embedded.TracerProvider
+
+	mu    sync.Mutex
+	ended []recordedSpan
+}
+
+func (r *spanRecorder) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return recorderTracer{recorder: r}
+}
+
+func (r *spanRecorder) spans() []recordedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]recordedSpan{}, r.ended...)
+}
+
+// recorderTracer starts the spans of a spanRecorder
+type recorderTracer struct {
+	embedded.Tracer
+	recorder *spanRecorder
+}
+
+func (t recorderTracer) Start(ctx context.Context, name string, _ ...trace.SpanStartOption) (context.Context, trace.Span) {
+	span := &recorderSpan{Span: noop.Span{}, recorder: t.recorder, name: name}
+	if parent, ok := trace.SpanFromContext(ctx).(*recorderSpan); ok {
+		span.parent = parent.name
+	}
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+// recorderSpan is a span of a spanRecorder, recorded when it ends
+type recorderSpan struct {
+	trace.Span
+	recorder *spanRecorder
+	name     string
+	parent   string
+	status   codes.Code
+}
+
+func (s *recorderSpan) SetStatus(code codes.Code, _ string) {
+	s.status = code
+}
+
+func (s *recorderSpan) TracerProvider() trace.TracerProvider {
+	return s.recorder
+}
+
+func (s *recorderSpan) End(...trace.SpanEndOption) {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	s.recorder.ended = append(s.recorder.ended, recordedSpan{Name: s.name, Parent: s.parent, Status: s.status})
+}
+
+func TestTracing(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeTestProfile(t, profilesDir, "sriov-ethernet-rdma", sriovProfileManifest, map[string]string{
+		"30-network.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n  namespace: default\n",
+	})
+
+	run := func(t *testing.T, failing ...string) ([]recordedSpan, error) {
+		t.Chdir(t.TempDir())
+		require.NoError(t, os.WriteFile("l8k-config.yaml", []byte(testClusterConfig), 0644))
+		require.NoError(t, os.WriteFile(llm.SystemPromptPath, []byte("SYSTEM: select a profile\n\nCluster configuration:\n"), 0644))
+		require.NoError(t, os.WriteFile("prompt.txt", []byte("Use SR-IOV networking"), 0644))
+		require.NoError(t, os.WriteFile("response.json", []byte(`{"fabric": "ethernet", "deploymentType": "sriov", "multirail": "false", "confidence": "high", "reasoning": "recorded"}`), 0644))
+
+		launcher := newTestLauncher(t, options.Options{
+			DiscoverClusterConfig: true,
+			SaveClusterConfig:     "discovered.yaml",
+			Prompt:                "prompt.txt",
+			LLMFixture:            "response.json",
+			ProfilesDirs:          []string{profilesDir},
+			Deploy:                true,
+		})
+		launcher.plugins = map[string]plugin.Plugin{
+			networkoperatorplugin.PluginName: &discoveringPlugin{&networkoperatorplugin.NetworkOperatorPlugin{}, config.NodesCapabilities{Sriov: true, Rdma: true}},
+		}
+		launcher.options.UserConfig = ""
+		launcher.kubeClient = newAllowingClient(&[]string{}, failing...)
+
+		recorder := &spanRecorder{}
+		launcher.SetTracerProvider(recorder)
+		err := launcher.executeWorkflow()
+		return recorder.spans(), err
+	}
+
+	t.Run("record a span per phase", func(t *testing.T) {
+		spans, err := run(t)
+		require.NoError(t, err)
+		assert.Equal(t, []recordedSpan{
+			{Name: "l8k.discovery", Parent: "l8k.run"},
+			{Name: "l8k.llm-selection", Parent: "l8k.selection"},
+			{Name: "l8k.selection", Parent: "l8k.run"},
+			{Name: "l8k.generation", Parent: "l8k.run"},
+			{Name: "l8k.deployment", Parent: "l8k.run"},
+			{Name: "l8k.run"},
+		}, spans)
+	})
+
+	t.Run("record the failed phase", func(t *testing.T) {
+		spans, err := run(t, "sriov_network")
+		require.Error(t, err)
+		require.Len(t, spans, 6)
+		assert.Equal(t, recordedSpan{Name: "l8k.generation", Parent: "l8k.run"}, spans[3])
+		assert.Equal(t, recordedSpan{Name: "l8k.deployment", Parent: "l8k.run", Status: codes.Error}, spans[4])
+		assert.Equal(t, recordedSpan{Name: "l8k.run", Status: codes.Error}, spans[5])
+	})
+
+	t.Run("trace nothing without a tracer provider", func(t *testing.T) {
+		launcher := newTestLauncher(t, options.Options{})
+		ctx, span := launcher.tracer().Start(context.Background(), spanRun)
+		defer span.End()
+		assert.False(t, span.IsRecording())
+		_, phaseSpan := startSpan(ctx, spanPhasePrefix+PhaseSelection)
+		assert.False(t, phaseSpan.IsRecording())
+	})
+}
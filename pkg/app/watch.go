@@ -0,0 +1,177 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os/signal"
+	"path/filepath"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-logr/logr"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/ui"
+)
+
+// DefaultHealthAddr is the address the health endpoints listen on in watch mode
+const DefaultHealthAddr = ":8081"
+
+// watchDebounce groups the bursts of events editors and ConfigMap updates produce into a single re-run
+const watchDebounce = 500 * time.Millisecond
+
+// configWatcher re-runs the workflow whenever the watched config file changes
+type configWatcher struct {
+	path     string
+	run      func() error
+	debounce time.Duration
+	logger   logr.Logger
+	ui       ui.Output
+	// ready is true while the last run succeeded
+	ready atomic.Bool
+}
+
+// watch runs the workflow, then re-runs it on every change of the config file until interrupted,
+// serving /healthz, /readyz and /metrics on the health address
+func (l *Launcher) watch() error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	metrics := newMetrics()
+	l.AddObserver(metrics)
+
+	w := &configWatcher{
+		path:     l.options.UserConfig,
+		run:      l.executeWorkflow,
+		debounce: watchDebounce,
+		logger:   l.logger,
+		ui:       l.ui,
+	}
+
+	addr := l.options.HealthAddr
+	if addr == "" {
+		addr = DefaultHealthAddr
+	}
+	mux := w.healthHandler()
+	mux.Handle("/metrics", metrics.handler())
+	server := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			l.logger.Error(err, "Health endpoint failed", "address", addr)
+			stop()
+		}
+	}()
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	l.ui.Info("Watching %s for changes, health and metrics endpoints on %s", l.options.UserConfig, addr)
+	return w.Start(ctx)
+}
+
+// Start runs the workflow once and then on every change of the watched file, until ctx is done.
+// Failed runs are reported and mark the watcher not ready, but don't stop watching.
+func (w *configWatcher) Start(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	// Watch the directory rather than the file, so that atomic renames and
+	// ConfigMap symlink swaps are noticed as well
+	path, err := filepath.Abs(w.path)
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		return fmt.Errorf("failed to watch %s: %w", w.path, err)
+	}
+
+	w.runOnce()
+
+	var rerun <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if !w.affects(event, path) {
+				continue
+			}
+			w.logger.V(1).Info("Config file event", "event", event.String())
+			rerun = time.After(w.debounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			w.logger.Error(err, "File watcher error")
+		case <-rerun:
+			rerun = nil
+			w.ui.Info("Config file %s changed, re-running", w.path)
+			w.runOnce()
+		}
+	}
+}
+
+// affects reports whether the event changes the watched file
+func (w *configWatcher) affects(event fsnotify.Event, path string) bool {
+	if event.Op == fsnotify.Chmod {
+		return false
+	}
+	// ConfigMap volumes update the file by swapping the ..data symlink
+	return event.Name == path || filepath.Base(event.Name) == "..data"
+}
+
+func (w *configWatcher) runOnce() {
+	if err := w.run(); err != nil {
+		w.ready.Store(false)
+		w.ui.Error("Run failed: %v", err)
+		w.logger.Error(err, "Workflow run failed in watch mode")
+		return
+	}
+	w.ready.Store(true)
+}
+
+// healthHandler serves /healthz, always ok while the process runs, and /readyz, ok while the last run succeeded
+func (w *configWatcher) healthHandler() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+		_, _ = rw.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/readyz", func(rw http.ResponseWriter, _ *http.Request) {
+		if !w.ready.Load() {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = rw.Write([]byte("last run failed"))
+			return
+		}
+		rw.WriteHeader(http.StatusOK)
+		_, _ = rw.Write([]byte("ok"))
+	})
+	return mux
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/ui"
+)
+
+func TestConfigWatcher(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(testClusterConfig), 0644))
+
+	var runs atomic.Int32
+	var fail atomic.Bool
+	w := &configWatcher{
+		path: configPath,
+		run: func() error {
+			runs.Add(1)
+			if fail.Load() {
+				return errors.New("invalid config")
+			}
+			return nil
+		},
+		debounce: 10 * time.Millisecond,
+		logger:   logr.Discard(),
+		ui:       ui.NewSilent(),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- w.Start(ctx) }()
+
+	require.Eventually(t, func() bool { return runs.Load() == 1 }, 5*time.Second, 10*time.Millisecond, "initial run")
+	assert.True(t, w.ready.Load())
+
+	// Files other than the config don't trigger a re-run
+	require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(configPath), "other.yaml"), []byte("x"), 0644))
+
+	fail.Store(true)
+	require.NoError(t, os.WriteFile(configPath, []byte(testClusterConfig+"\n"), 0644))
+	require.Eventually(t, func() bool { return runs.Load() == 2 }, 5*time.Second, 10*time.Millisecond, "re-run after change")
+	require.Eventually(t, func() bool { return !w.ready.Load() }, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+	assert.Equal(t, int32(2), runs.Load())
+}
+
+func TestConfigWatcherHealthHandler(t *testing.T) {
+	w := &configWatcher{}
+	handler := w.healthHandler()
+
+	status := func(path string) int {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, status("/healthz"))
+	assert.Equal(t, http.StatusServiceUnavailable, status("/readyz"))
+
+	w.ready.Store(true)
+	assert.Equal(t, http.StatusOK, status("/readyz"))
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/doctor"
+	"github.com/nvidia/k8s-launch-kit/pkg/llm"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	"github.com/nvidia/k8s-launch-kit/pkg/ui"
+)
+
+var doctorOptions doctor.Options
+
+// doctorCmd checks the prerequisites of l8k without changing anything
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the environment for the prerequisites of l8k",
+	Long: `Check that the kubeconfig can be loaded, the API server is reachable, the profiles directory contains profiles
+and the LLM settings are usable, printing a hint for every problem found. No changes are made to the cluster or the files.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		output := ui.New()
+		output.Section("Checking prerequisites")
+
+		if !doctor.Report(output, doctor.Run(doctorOptions)) {
+			os.Exit(1)
+		}
+		output.Success("All prerequisites are met")
+	},
+}
+
+func init() {
+	doctorCmd.Flags().StringVar(&doctorOptions.Kubeconfig, "kubeconfig", "", "Path to the kubeconfig file, $KUBECONFIG or ~/.kube/config by default")
+	doctorCmd.Flags().StringVar(&doctorOptions.ProfilesDir, "profiles-dir", profiles.ProfilesDir, "Directory with the deployment profiles")
+	doctorCmd.Flags().StringVar(&doctorOptions.LLMApiKey, "llm-api-key", "", "API key for the LLM API")
+	doctorCmd.Flags().StringVar(&doctorOptions.LLMVendor, "llm-vendor", "", "Vendor of the LLM API: "+strings.Join(llm.Vendors, ", ")+"; when unset, inferred from --llm-model and --llm-api-url")
+	doctorCmd.Flags().StringVar(&doctorOptions.LLMModel, "llm-model", "", "Model name for the LLM API")
+	doctorCmd.Flags().StringVar(&doctorOptions.LLMApiUrl, "llm-api-url", "", "API URL for the LLM API")
+	rootCmd.AddCommand(doctorCmd)
+}
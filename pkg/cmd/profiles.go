@@ -0,0 +1,151 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/llm"
+	"github.com/nvidia/k8s-launch-kit/pkg/networkoperatorplugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	"github.com/nvidia/k8s-launch-kit/pkg/ui"
+)
+
+// profilesCmd groups the commands for profile authors
+var profilesCmd = &cobra.Command{
+	Use:   "profiles",
+	Short: "Work with deployment profiles",
+}
+
+// profilesValidateCmd lints profiles before they are shipped
+var profilesValidateCmd = &cobra.Command{
+	Use:   "validate <dir>",
+	Short: "Validate deployment profiles",
+	Long: `Validate the profile.yaml schema and the referenced templates of every profile in the directory.
+The directory can be a single profile or a directory of profiles. A missing deployment guide and the files not referenced
+by a profile are reported as warnings.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		output := ui.New()
+
+		results, err := profiles.ValidateProfilesDir(args[0], networkoperatorplugin.TemplateFuncs)
+		if err != nil {
+			output.Error("Failed to validate profiles: %v", err)
+			os.Exit(1)
+		}
+
+		if !reportProfileValidation(output, results) {
+			os.Exit(1)
+		}
+	},
+}
+
+// profilesPromptContextCmd prints the LLM system prompt section generated from the profiles
+var profilesPromptContextCmd = &cobra.Command{
+	Use:   "prompt-context [dir]",
+	Short: "Print the LLM system prompt context generated from the profiles",
+	Long: `Print the list of valid fabrics, deployment types and profiles that is added to the LLM system prompt.
+The context is generated from the profiles in the directory, the built-in profiles directory by default.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dir := profiles.ProfilesDir
+		if len(args) == 1 {
+			dir = args[0]
+		}
+
+		available, err := profiles.LoadProfilesDir(dir)
+		if err != nil {
+			ui.New().Error("Failed to load profiles: %v", err)
+			os.Exit(1)
+		}
+		fmt.Print(llm.ProfilesContext(available))
+	},
+}
+
+// profilesMatrixCmd prints which requirements and cluster capabilities every profile supports
+var profilesMatrixCmd = &cobra.Command{
+	Use:   "matrix [dir]",
+	Short: "Print the compatibility matrix of the profiles",
+	Long: `Print the profile requirements and cluster capabilities of every profile in the directory, the built-in profiles
+directory by default. Fields a profile places no requirement on are shown as "any".`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		output := ui.New()
+
+		dir := profiles.ProfilesDir
+		if len(args) == 1 {
+			dir = args[0]
+		}
+
+		available, err := profiles.LoadProfilesDir(dir)
+		if err != nil {
+			output.Error("Failed to load profiles: %v", err)
+			os.Exit(1)
+		}
+
+		matrix := profiles.BuildCompatibilityMatrix(available)
+		output.Table(matrix.Headers, matrix.Rows)
+	},
+}
+
+// reportProfileValidation prints a pass/fail table followed by the problems found, and reports whether all profiles passed
+func reportProfileValidation(output ui.Output, results []profiles.ValidationResult) bool {
+	rows := make([][]string, 0, len(results))
+	passed := true
+	for _, result := range results {
+		status := "PASS"
+		if !result.Passed() {
+			status = "FAIL"
+			passed = false
+		}
+		issues := pluralize(len(result.Errors), "error") + ", " + pluralize(len(result.Warnings), "warning")
+		rows = append(rows, []string{result.Profile, result.Dir, status, issues})
+	}
+	output.Table([]string{"PROFILE", "DIRECTORY", "RESULT", "ISSUES"}, rows)
+
+	for _, result := range results {
+		if len(result.Errors) == 0 && len(result.Warnings) == 0 {
+			continue
+		}
+		output.Section(result.Profile)
+		for _, e := range result.Errors {
+			output.Error("%s", e)
+		}
+		for _, w := range result.Warnings {
+			output.Warning("%s", w)
+		}
+	}
+
+	return passed
+}
+
+func pluralize(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
+
+func init() {
+	profilesCmd.AddCommand(profilesValidateCmd)
+	profilesCmd.AddCommand(profilesPromptContextCmd)
+	profilesCmd.AddCommand(profilesMatrixCmd)
+	rootCmd.AddCommand(profilesCmd)
+}
@@ -17,23 +17,32 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/nvidia/k8s-launch-kit/pkg/app"
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	appdeploy "github.com/nvidia/k8s-launch-kit/pkg/deploy"
+	"github.com/nvidia/k8s-launch-kit/pkg/llm"
 	applog "github.com/nvidia/k8s-launch-kit/pkg/log"
 	"github.com/nvidia/k8s-launch-kit/pkg/networkoperatorplugin"
 	"github.com/nvidia/k8s-launch-kit/pkg/options"
+	"github.com/nvidia/k8s-launch-kit/pkg/plugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
 )
 
 var (
 	logLevel              string
 	logFile               string
+	supportBundle         string
 	fabric                string
 	deploymentType        string
 	multirail             bool
@@ -45,14 +54,71 @@ var (
 	llmVendor             string
 	llmModel              string
 	llmInteractive        bool
+	llmFixture            string
+	profileJSON           string
+	printSystemPrompt     bool
+	minConfidence         string
+	caBundle              string
 	saveDeploymentFiles   string
+	outputFormat          string
+	argoCDRepoURL         string
+	argoCDPath            string
+	argoCDRevision        string
 	deploy                bool
+	noDeployFiles         bool
+	checkpoint            string
+	resume                bool
+	clusterMatch          string
+	environmentKey        string
+	yes                   bool
 	kubeconfig            string
+	kubeconfigs           []string
+	validateConnectivity  bool
 	userConfig            string
+	configFromSecret      string
+	runSpec               string
 	discoverClusterConfig bool
+	discoverySnapshot     string
 	saveClusterConfig     string
+	printDiscovery        string
+	setValues             []string
+	annotations           []string
+	explainConfig         bool
 	logger                = log.Log.WithName("l8k")
 	enabledPlugins        string
+	profilesDirs          []string
+	environment           string
+	fallbackProfile       string
+	forceProfile          string
+	profileCache          string
+	summaryDoc            string
+	allowEmptyProfile     bool
+	noClean               bool
+	provenanceHeader      bool
+	canonicalOutput       bool
+	changed               bool
+	schemaValidate        bool
+	whichPlugins          bool
+	confirmConflicts      bool
+	fieldManager          string
+	conflictPolicy        string
+	continueOnError       bool
+	applyFiles            []string
+	applyKinds            []string
+	exclude               []string
+	diff                  bool
+	diffExitCode          bool
+	contextLines          int
+	watch                 bool
+	healthAddr            string
+	templateConfig        bool
+	timeout               time.Duration
+	discoveryTimeout      time.Duration
+	llmTimeout            time.Duration
+	deployTimeout         time.Duration
+
+	// availablePlugins are the plugins that can be enabled, their flags are registered with the root flags
+	availablePlugins = app.BuiltinPlugins()
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -73,34 +139,115 @@ Based on the discovered or provided configuration,
 generate a complete set of YAML deployment files for the selected network profile. 
 Files can be saved to disk using --save-deployment-files.
 The profile can be defined manually with --fabric, --deployment-type and --multirail flags,
-OR generated by an LLM-assisted profile generator with --prompt (requires --llm-api-key and --llm-vendor).
+OR generated by an LLM-assisted profile generator with --prompt (requires --llm-api-key).
 
 ### Deploy to Cluster
 Apply the generated deployment files to your Kubernetes cluster by using --deploy. This phase requires --kubeconfig and can be skipped if --deploy is not specified.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		enabledPlugins := parseEnabledPlugins(enabledPlugins)
+		// The stream replaces the default output directory, the files are also saved only when asked for
+		if outputFormat == app.OutputFormatStream && !cmd.Flags().Changed("save-deployment-files") {
+			saveDeploymentFiles = ""
+		}
+		// Deploying without files replaces the default output directory
+		if noDeployFiles && !cmd.Flags().Changed("save-deployment-files") {
+			saveDeploymentFiles = ""
+		}
+		// The vendor is inferred from the model and the API URL unless set, the default serves the models of no known
+		// family and the OpenAI models of other endpoints than the OpenAI API
+		if !cmd.Flags().Changed("llm-vendor") {
+			llmVendor = ""
+		}
+		// The profile flags given explicitly override the config file and the LLM, false ones included
+		var profileFlagsSet []string
+		for _, flag := range []struct{ name, key string }{{"multirail", "multirail"}, {"spectrum-x", "spectrumX"}, {"ai", "ai"}} {
+			if cmd.Flags().Changed(flag.name) {
+				profileFlagsSet = append(profileFlagsSet, flag.key)
+			}
+		}
+		// The printed discovery replaces the default save path, it is also saved only when asked for
+		if printDiscovery != "" && !cmd.Flags().Changed("save-cluster-config") {
+			saveClusterConfig = ""
+		}
 		// Create application options from CLI flags
 		options := options.Options{
 			LogLevel:              logLevel,
 			LogFile:               logFile,
+			SupportBundle:         supportBundle,
 			UserConfig:            userConfig,
+			ConfigFromSecret:      configFromSecret,
+			RunSpec:               runSpec,
+			TemplateConfig:        templateConfig,
 			DiscoverClusterConfig: discoverClusterConfig,
+			DiscoverySnapshot:     discoverySnapshot,
 			Fabric:                fabric,
 			DeploymentType:        deploymentType,
 			Multirail:             multirail,
 			SpectrumX:             spectrumX,
 			Ai:                    ai,
+			ProfileFlagsSet:       profileFlagsSet,
 			Prompt:                prompt,
+			ProfileJSON:           profileJSON,
 			SaveDeploymentFiles:   saveDeploymentFiles,
+			OutputFormat:          outputFormat,
+			ArgoCDRepoURL:         argoCDRepoURL,
+			ArgoCDPath:            argoCDPath,
+			ArgoCDRevision:        argoCDRevision,
 			Deploy:                deploy,
+			NoDeployFiles:         noDeployFiles,
+			Checkpoint:            checkpoint,
+			Resume:                resume,
+			ClusterMatch:          clusterMatch,
+			EnvironmentKey:        environmentKey,
+			Yes:                   yes,
 			Kubeconfig:            kubeconfig,
+			Kubeconfigs:           kubeconfigs,
+			ValidateConnectivity:  validateConnectivity,
 			SaveClusterConfig:     saveClusterConfig,
+			PrintDiscovery:        printDiscovery,
+			Set:                   setValues,
+			Annotations:           annotations,
+			ExplainConfig:         explainConfig,
 			EnabledPlugins:        enabledPlugins,
+			ProfilesDirs:          profilesDirs,
+			Environment:           environment,
+			FallbackProfile:       fallbackProfile,
+			ForceProfile:          forceProfile,
+			ProfileCache:          profileCache,
+			SummaryDoc:            summaryDoc,
+			AllowEmptyProfile:     allowEmptyProfile,
+			NoClean:               noClean,
+			ProvenanceHeader:      provenanceHeader,
+			CanonicalOutput:       canonicalOutput,
+			Changed:               changed,
+			SchemaValidate:        schemaValidate,
+			WhichPlugins:          whichPlugins,
+			Version:               Version,
+			ConfirmConflicts:      confirmConflicts,
+			FieldManager:          fieldManager,
+			ConflictPolicy:        conflictPolicy,
+			ContinueOnError:       continueOnError,
+			ApplyFiles:            applyFiles,
+			ApplyKinds:            applyKinds,
+			Exclude:               exclude,
+			Diff:                  diff,
+			DiffExitCode:          diffExitCode,
+			ContextLines:          contextLines,
+			Watch:                 watch,
+			HealthAddr:            healthAddr,
 			LLMApiKey:             llmApiKey,
 			LLMApiUrl:             llmApiUrl,
 			LLMVendor:             llmVendor,
 			LLMModel:              llmModel,
 			LLMInteractive:        llmInteractive,
+			LLMFixture:            llmFixture,
+			PrintSystemPrompt:     printSystemPrompt,
+			MinConfidence:         minConfidence,
+			CABundle:              caBundle,
+			Timeout:               timeout,
+			DiscoveryTimeout:      discoveryTimeout,
+			LLMTimeout:            llmTimeout,
+			DeployTimeout:         deployTimeout,
 		}
 
 		// Validate CLI configuration
@@ -112,8 +259,12 @@ Apply the generated deployment files to your Kubernetes cluster by using --deplo
 		logger.Info("SaveConfig", "val", options)
 
 		// Create and run the application
-		launcher := app.New(options)
+		launcher := app.NewWithPlugins(options, availablePlugins)
 		if err := launcher.Run(); err != nil {
+			if errors.Is(err, app.ErrDrift) {
+				fmt.Printf("\n%s\n\n", err)
+				os.Exit(2)
+			}
 			fmt.Printf("\nFatal error: %s\n", err)
 			fmt.Println()
 			os.Exit(1)
@@ -135,33 +286,95 @@ func init() {
 
 	// Phase 0: Plugin flags
 	rootCmd.Flags().StringVar(&enabledPlugins, "enabled-plugins", "network-operator", "Comma-separated list of plugins to enable")
+	rootCmd.Flags().BoolVar(&whichPlugins, "which-plugins", false, "Print the enabled plugins configured by the profile flags, e.g. --fabric and --deployment-type, and exit")
+
+	// Timeout flags
+	rootCmd.Flags().DurationVar(&timeout, "timeout", 0, "Abort the run when it takes longer than this duration, e.g. 30m (0 for no limit)")
+	rootCmd.Flags().DurationVar(&discoveryTimeout, "discovery-timeout", 0, "Abort the cluster discovery when it takes longer than this duration, within --timeout (0 for no limit)")
+	rootCmd.Flags().DurationVar(&llmTimeout, "llm-timeout", 0, "Abort the AI-assisted profile selection, or every response with --llm-interactive, when it takes longer than this duration, within --timeout (0 for no limit)")
+	rootCmd.Flags().DurationVar(&deployTimeout, "deploy-timeout", 0, "Abort the deployment when it takes longer than this duration, within --timeout (0 for no limit)")
 
 	// Phase 1: Cluster discovery flags
 	rootCmd.Flags().BoolVar(&discoverClusterConfig, "discover-cluster-config", false, "Deploy a thin Network Operator profile to discover cluster capabilities")
+	rootCmd.Flags().StringVar(&discoverySnapshot, "discovery-snapshot", "", "Discover the cluster capabilities from the cluster objects recorded in this file instead of the cluster (requires --discover-cluster-config)")
 	rootCmd.Flags().StringVar(&saveClusterConfig, "save-cluster-config", "/opt/nvidia/k8s-launch-kit/cluster-config.yaml", "Save discovered cluster configuration to the specified path")
 	rootCmd.Flags().StringVar(&userConfig, "user-config", "", "Use provided cluster configuration file instead of auto-discovery (skips cluster discovery)")
+	rootCmd.Flags().StringVar(&runSpec, "run-spec", "", "Path to a manifest holding both the cluster configuration and the profile selection intent (skips cluster discovery)")
+	rootCmd.Flags().StringVar(&configFromSecret, "config-from-secret", "", "Load the cluster configuration from a <secret|configmap>/<namespace>/<name>[:<key>] key (default key: config.yaml, skips cluster discovery)")
+	rootCmd.Flags().StringVar(&printDiscovery, "print-discovery", "", "Print the discovered cluster capabilities to stdout as yaml or json (requires --discover-cluster-config)")
+	rootCmd.Flags().StringArrayVar(&annotations, "annotation", nil, "Add an annotation as key=value to every generated object, e.g. example.com/team=networking (can be repeated)")
+	rootCmd.Flags().StringArrayVar(&setValues, "set", nil, "Override a config field as path=value, e.g. sriov.numVfs=16 (can be repeated, later values win)")
+	rootCmd.Flags().BoolVar(&explainConfig, "explain-config", false, "Print the source (file, flag, llm, picker or default) of every resolved config field")
+	rootCmd.Flags().BoolVar(&templateConfig, "template-config", false, "Render --user-config as a Go template against the discovered cluster config (requires --discover-cluster-config)")
 
 	// Phase 2: Deployment generation flags
 	rootCmd.Flags().StringVar(&fabric, "fabric", "", "Select the fabric type to deploy (infiniband, ethernet)")
-	rootCmd.Flags().StringVar(&deploymentType, "deployment-type", "", "Select the deployment type (sriov, rdma_shared, host_device)")
+	rootCmd.Flags().StringVar(&deploymentType, "deployment-type", "", "Select the deployment type (sriov, rdma_shared, host_device), comma-separated to generate several profiles together")
 	rootCmd.Flags().BoolVar(&multirail, "multirail", false, "Enable multirail deployment")
 	rootCmd.Flags().BoolVar(&spectrumX, "spectrum-x", false, "Enable Spectrum X deployment")
 	rootCmd.Flags().BoolVar(&ai, "ai", false, "Enable AI deployment")
 	rootCmd.Flags().StringVar(&prompt, "prompt", "", "Path to file with a prompt to use for LLM-assisted profile generation")
 	rootCmd.Flags().StringVar(&llmApiKey, "llm-api-key", "", "API key for the LLM API (required when using --prompt)")
 	rootCmd.Flags().StringVar(&llmApiUrl, "llm-api-url", "", "API URL for the LLM API")
-	rootCmd.Flags().StringVar(&llmVendor, "llm-vendor", "openai-azure", "Vendor of the LLM API: openai, openai-azure, anthropic, gemini")
+	rootCmd.Flags().StringVar(&llmVendor, "llm-vendor", llm.DefaultVendor, "Vendor of the LLM API: openai, openai-azure, anthropic, gemini; when unset, inferred from --llm-model and --llm-api-url, e.g. anthropic for claude-* models")
 	rootCmd.Flags().StringVar(&llmModel, "llm-model", "", "Model name for the LLM API (e.g., claude-3-5-sonnet-20241022, gpt-4)")
 	rootCmd.Flags().BoolVar(&llmInteractive, "llm-interactive", false, "Enable interactive chat mode for LLM-assisted profile selection")
-	rootCmd.Flags().StringVar(&saveDeploymentFiles, "save-deployment-files", "/opt/nvidia/k8s-launch-kit/deployment", "Save generated deployment files to the specified directory")
+	rootCmd.Flags().StringVar(&caBundle, "ca-bundle", "", "PEM file with the CA certificates to trust, besides the system ones, when connecting to the LLM API and OCI registries")
+	rootCmd.Flags().StringVar(&minConfidence, "min-confidence", llm.DefaultMinConfidence, "Confidence the LLM needs in its answer to accept it: low, medium or high")
+	rootCmd.Flags().BoolVar(&printSystemPrompt, "print-system-prompt", false, "Print the system prompt sent to the LLM, with the secrets redacted, without calling the LLM API")
+	rootCmd.Flags().StringVar(&profileJSON, "profile-json", "", `Profile as the JSON object an LLM would answer, e.g. '{"fabric": "ethernet", "deploymentType": "sriov"}', selected without calling the LLM API`)
+	rootCmd.Flags().StringVar(&llmFixture, "llm-fixture", "", "Reply to --prompt or --llm-interactive with the recorded LLM response in this file instead of calling the LLM API")
+	rootCmd.Flags().StringArrayVar(&profilesDirs, "profiles-dir", []string{profiles.ProfilesDir}, "Directory with the deployment profiles, or an oci://registry/repository:tag reference to pull them from. Can be repeated, earlier directories win over later ones on profile name conflicts")
+	rootCmd.Flags().StringVar(&environment, "environment", "", "Render the profile with the templates of the given environment overlay (e.g. dev, prod)")
+	rootCmd.Flags().StringVar(&fallbackProfile, "fallback-profile", "", "Profile (directory or name) to use when no profile matches the requirements")
+	rootCmd.Flags().StringVar(&forceProfile, "force-profile", "", "Profile (directory or name) to render without matching it against the requirements")
+	rootCmd.Flags().StringVar(&profileCache, "profile-cache", "", "File caching the profile matched for the cluster capabilities and profile requirements, reused until the profiles change")
+	rootCmd.Flags().BoolVar(&allowEmptyProfile, "allow-empty-profile", false, "Warn instead of failing when a profile renders no deployment files")
+	rootCmd.Flags().BoolVar(&provenanceHeader, "provenance-header", false, "Start every generated file with a comment naming the profile, the l8k version and a hash of the config")
+	rootCmd.Flags().BoolVar(&canonicalOutput, "canonical-output", false, "Write the generated YAML documents with sorted keys and a consistent indentation, dropping their comments, for stable GitOps diffs")
+	rootCmd.Flags().BoolVar(&changed, "changed", false, "Only regenerate, and deploy, the profiles whose config, prompt or templates changed since their files were last saved")
+	rootCmd.Flags().BoolVar(&schemaValidate, "schema-validate", false, "Validate the generated objects against the OpenAPI schemas of their kinds, reporting the violating fields")
+	rootCmd.Flags().StringVar(&summaryDoc, "summary-doc", "", "Write a markdown summary of the profiles, settings and objects to deploy to the specified path, e.g. for change tickets")
+	rootCmd.Flags().BoolVar(&noClean, "no-clean", false, "Keep other files in the --save-deployment-files directory, only replacing and removing files generated by l8k")
+	rootCmd.Flags().StringVar(&outputFormat, "output-format", app.OutputFormatFiles, "How to output the generated deployment files: files, stream to write them to stdout as one multi-document YAML, or argocd to save them with an Argo CD Application")
+	rootCmd.Flags().StringVar(&argoCDRepoURL, "argocd-repo-url", "", "Git repository the Argo CD Application syncs the generated files from (with --output-format argocd)")
+	rootCmd.Flags().StringVar(&argoCDPath, "argocd-path", "", "Path in the git repository of the --save-deployment-files directory, with the same placeholders (with --output-format argocd)")
+	rootCmd.Flags().StringVar(&argoCDRevision, "argocd-revision", app.DefaultArgoCDRevision, "Git revision the Argo CD Application tracks (with --output-format argocd)")
+	rootCmd.Flags().StringVar(&saveDeploymentFiles, "save-deployment-files", "/opt/nvidia/k8s-launch-kit/deployment", "Save generated deployment files to the specified directory, {profile} and {timestamp} are replaced with the profile directory name and the run start time")
 
 	// Phase 3: Cluster deployment flags
 	rootCmd.Flags().BoolVar(&deploy, "deploy", false, "Deploy the generated files to the Kubernetes cluster")
+	rootCmd.Flags().BoolVar(&noDeployFiles, "no-deploy-files", false, "Deploy the generated files from memory without saving them to --save-deployment-files (with --deploy)")
+	rootCmd.Flags().StringVar(&checkpoint, "checkpoint", "", "File recording the objects applied, removed once the deployment completes (with --deploy)")
+	rootCmd.Flags().BoolVar(&resume, "resume", false, "Resume a failed deployment, skipping the objects of --checkpoint applied and unchanged since")
+	rootCmd.Flags().StringVar(&environmentKey, "cluster-environment-key", app.DefaultEnvironmentKey, "Label or annotation of the kube-system namespace naming the environment of the cluster. Deploying to a production cluster asks for a confirmation, an empty key disables the check")
+	rootCmd.Flags().BoolVar(&yes, "yes", false, "Deploy to production clusters without asking for a confirmation")
+	rootCmd.Flags().StringVar(&clusterMatch, "cluster-match", app.ClusterMatchWarn, "What to do when the cluster deployed to is not the one the config was discovered from: warn, fail or ignore")
+	rootCmd.Flags().BoolVar(&confirmConflicts, "confirm-conflicts", false, "Ask before taking ownership of fields managed by another field manager instead of always forcing it (refused when not interactive)")
+	rootCmd.Flags().StringVar(&fieldManager, "field-manager", appdeploy.FieldOwner, "Field manager used for server-side apply")
+	rootCmd.Flags().StringVar(&conflictPolicy, "conflict-policy", string(appdeploy.ConflictPolicyForce), "What to do with fields owned by another field manager: fail or force")
+	rootCmd.Flags().BoolVar(&continueOnError, "continue-on-error", false, "Keep applying the remaining objects when one fails and report every failed object")
+	rootCmd.Flags().StringSliceVar(&applyFiles, "apply-files", nil, "Only deploy the generated files whose name matches one of these globs, e.g. 30-*.yaml")
+	rootCmd.Flags().StringSliceVar(&applyKinds, "apply-kinds", nil, "Only deploy the objects of these kinds, as Kind, Kind.group or group/version/Kind")
+	rootCmd.Flags().StringSliceVar(&exclude, "exclude", nil, "Skip the generated files matching these globs and the objects of these kinds, as Kind, Kind.group or group/version/Kind")
+	rootCmd.Flags().BoolVar(&diff, "diff", false, "Show the differences between the generated files and the objects in the cluster (requires --kubeconfig)")
+	rootCmd.Flags().BoolVar(&diffExitCode, "diff-exit-code", false, "Exit with code 2 when --diff finds differences with the cluster, 0 when it finds none and 1 on errors")
+	rootCmd.Flags().IntVar(&contextLines, "context-lines", appdeploy.DefaultContextLines, "Number of unchanged lines shown around each change with --diff")
 	rootCmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file for cluster deployment (required when using --deploy)")
+	rootCmd.Flags().StringSliceVar(&kubeconfigs, "kubeconfigs", nil, "Kubeconfig files of several clusters to deploy to, instead of --kubeconfig")
+	rootCmd.Flags().BoolVar(&validateConnectivity, "validate-connectivity", false, "Check that the API server of the kubeconfig is reachable before running any phase")
+
+	// Watch mode flags
+	rootCmd.Flags().BoolVar(&watch, "watch", false, "Keep running and re-run generation and deployment whenever the --user-config file changes")
+	rootCmd.Flags().StringVar(&healthAddr, "health-addr", app.DefaultHealthAddr, "Address of the /healthz and /readyz endpoints in watch mode")
 
 	// Logging flags
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "", "Enable logging at specified level (debug, info, warn, error)")
 	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Write logs to file instead of stderr")
+	rootCmd.Flags().StringVar(&supportBundle, "support-bundle", "", "Write a tarball with the redacted config, discovery result, selected profiles, generated files and logs of the run to this path")
+
+	// Flags contributed by the plugins
+	cobra.CheckErr(plugin.RegisterFlags(rootCmd.Flags(), slices.Collect(maps.Values(availablePlugins))...))
 }
 
 // validateConfig validates the CLI flag combinations
@@ -171,35 +384,208 @@ func validateConfig(options options.Options) error {
 		return fmt.Errorf("no plugins enabled, use --enabled-plugins to enable plugins")
 	}
 
-	// Either user-config or discover-cluster-config should be provided
-	if options.UserConfig == "" && !options.DiscoverClusterConfig {
-		return fmt.Errorf("either --user-config or --discover-cluster-config must be provided")
+	// Either user-config, config-from-secret, run-spec or discover-cluster-config should be provided
+	if options.UserConfig == "" && options.ConfigFromSecret == "" && options.RunSpec == "" && !options.DiscoverClusterConfig {
+		return fmt.Errorf("either --user-config, --config-from-secret, --run-spec or --discover-cluster-config must be provided")
 	}
 
-	// Both user-config and discover-cluster-config cannot be provided together
-	if options.UserConfig != "" && options.DiscoverClusterConfig {
+	// The run spec holds both the config and the profile intent
+	if options.RunSpec != "" {
+		if options.UserConfig != "" || options.ConfigFromSecret != "" || options.DiscoverClusterConfig {
+			return fmt.Errorf("--run-spec cannot be used with --user-config, --config-from-secret or --discover-cluster-config")
+		}
+		if options.Fabric != "" || options.DeploymentType != "" || options.Prompt != "" || options.LLMInteractive || options.ProfileJSON != "" {
+			return fmt.Errorf("--run-spec cannot be used with --fabric, --deployment-type, --prompt, --llm-interactive or --profile-json")
+		}
+		if options.SaveDeploymentFiles == "" && options.OutputFormat != app.OutputFormatStream && !options.Deploy && !options.Diff {
+			return fmt.Errorf("--run-spec requires either --save-deployment-files, --output-format stream, --diff or --deploy")
+		}
+	}
+
+	if options.ConfigFromSecret != "" {
+		if options.UserConfig != "" || options.DiscoverClusterConfig {
+			return fmt.Errorf("--config-from-secret cannot be used with --user-config or --discover-cluster-config")
+		}
+		if options.Kubeconfig == "" {
+			return fmt.Errorf("--config-from-secret requires --kubeconfig to be specified")
+		}
+		if _, err := config.ParseClusterSource(options.ConfigFromSecret); err != nil {
+			return fmt.Errorf("invalid --config-from-secret: %w", err)
+		}
+	}
+
+	// Both user-config and discover-cluster-config cannot be provided together,
+	// unless the user config is a template rendered against the discovery results
+	if options.TemplateConfig && (options.UserConfig == "" || !options.DiscoverClusterConfig) {
+		return fmt.Errorf("--template-config requires both --user-config and --discover-cluster-config")
+	}
+	if options.UserConfig != "" && options.DiscoverClusterConfig && !options.TemplateConfig {
 		return fmt.Errorf("--user-config and --discover-cluster-config cannot be used together")
 	}
 
-	// If discover-cluster-config is provided, kubeconfig should be too
-	if options.DiscoverClusterConfig && options.Kubeconfig == "" {
-		return fmt.Errorf("--discover-cluster-config requires --kubeconfig to be specified")
+	// If discover-cluster-config is provided, kubeconfig or a recorded cluster should be too
+	if options.DiscoverClusterConfig && options.Kubeconfig == "" && options.DiscoverySnapshot == "" {
+		return fmt.Errorf("--discover-cluster-config requires --kubeconfig or --discovery-snapshot to be specified")
+	}
+	if options.DiscoverySnapshot != "" && !options.DiscoverClusterConfig {
+		return fmt.Errorf("--discovery-snapshot requires --discover-cluster-config to be specified")
+	}
+
+	for _, value := range options.Set {
+		if _, _, err := config.ParseSetValue(value); err != nil {
+			return fmt.Errorf("invalid --set: %w", err)
+		}
+	}
+
+	for _, value := range options.Annotations {
+		if _, _, err := config.ParseAnnotation(value); err != nil {
+			return fmt.Errorf("invalid --annotation: %w", err)
+		}
+	}
+
+	if options.PrintDiscovery != "" {
+		if !options.DiscoverClusterConfig {
+			return fmt.Errorf("--print-discovery requires --discover-cluster-config to be specified")
+		}
+		if !slices.Contains(app.DiscoveryFormats, options.PrintDiscovery) {
+			return fmt.Errorf("--print-discovery must be one of: %s", strings.Join(app.DiscoveryFormats, ", "))
+		}
+		// Both own stdout
+		if options.OutputFormat == app.OutputFormatStream {
+			return fmt.Errorf("--print-discovery cannot be used with --output-format stream")
+		}
 	}
 
 	// If deploy is provided, kubeconfig should be too
-	if options.Deploy && options.Kubeconfig == "" {
-		return fmt.Errorf("--deploy requires --kubeconfig to be specified")
+	if options.Deploy && options.Kubeconfig == "" && len(options.Kubeconfigs) == 0 {
+		return fmt.Errorf("--deploy requires --kubeconfig or --kubeconfigs to be specified")
+	}
+
+	if len(options.Kubeconfigs) > 0 && !options.Deploy {
+		return fmt.Errorf("--kubeconfigs requires --deploy to be specified")
+	}
+
+	if options.Diff && options.Kubeconfig == "" {
+		return fmt.Errorf("--diff requires --kubeconfig to be specified")
+	}
+
+	if options.Yes && !options.Deploy {
+		return fmt.Errorf("--yes requires --deploy to be specified")
+	}
+
+	if options.DiffExitCode && !options.Diff {
+		return fmt.Errorf("--diff-exit-code requires --diff to be specified")
+	}
+
+	if options.DiffExitCode && options.Deploy {
+		return fmt.Errorf("--diff-exit-code cannot be used with --deploy")
+	}
+
+	if options.ValidateConnectivity && options.Kubeconfig == "" && len(options.Kubeconfigs) == 0 {
+		return fmt.Errorf("--validate-connectivity requires --kubeconfig or --kubeconfigs to be specified")
+	}
+
+	if options.FieldManager == "" {
+		return fmt.Errorf("--field-manager must not be empty")
+	}
+
+	if !slices.Contains(appdeploy.ConflictPolicies, appdeploy.ConflictPolicy(options.ConflictPolicy)) {
+		return fmt.Errorf("--conflict-policy must be one of: fail, force")
+	}
+
+	if options.ForceProfile != "" && options.FallbackProfile != "" {
+		return fmt.Errorf("--force-profile and --fallback-profile cannot be used together")
+	}
+
+	if options.ConfirmConflicts && options.ConflictPolicy == string(appdeploy.ConflictPolicyFail) {
+		return fmt.Errorf("--confirm-conflicts and --conflict-policy fail cannot be used together")
+	}
+
+	if options.NoDeployFiles {
+		if !options.Deploy {
+			return fmt.Errorf("--no-deploy-files requires --deploy to be specified")
+		}
+		if options.SaveDeploymentFiles != "" {
+			return fmt.Errorf("--no-deploy-files cannot be used with --save-deployment-files")
+		}
+	}
+
+	if options.Checkpoint != "" {
+		if !options.Deploy {
+			return fmt.Errorf("--checkpoint requires --deploy to be specified")
+		}
+		if len(options.Kubeconfigs) > 0 {
+			return fmt.Errorf("--checkpoint cannot be used with --kubeconfigs")
+		}
+	}
+
+	if !slices.Contains(app.ClusterMatchPolicies, options.ClusterMatch) {
+		return fmt.Errorf("--cluster-match must be one of: %s", strings.Join(app.ClusterMatchPolicies, ", "))
+	}
+
+	if options.Resume && options.Checkpoint == "" {
+		return fmt.Errorf("--resume requires --checkpoint to be specified")
+	}
+
+	if err := app.ValidateOutputDir(options.SaveDeploymentFiles); err != nil {
+		return fmt.Errorf("invalid --save-deployment-files: %w", err)
+	}
+
+	if !slices.Contains(app.OutputFormats, options.OutputFormat) {
+		return fmt.Errorf("--output-format must be one of: %s", strings.Join(app.OutputFormats, ", "))
+	}
+
+	if options.OutputFormat == app.OutputFormatArgoCD {
+		if options.ArgoCDRepoURL == "" || options.ArgoCDPath == "" {
+			return fmt.Errorf("--output-format argocd requires --argocd-repo-url and --argocd-path to be specified")
+		}
+		if options.SaveDeploymentFiles == "" {
+			return fmt.Errorf("--output-format argocd requires --save-deployment-files to be specified")
+		}
+	} else if options.ArgoCDRepoURL != "" || options.ArgoCDPath != "" || (options.ArgoCDRevision != "" && options.ArgoCDRevision != app.DefaultArgoCDRevision) {
+		return fmt.Errorf("--argocd-repo-url, --argocd-path and --argocd-revision require --output-format argocd")
+	}
+
+	// The manifest stream owns stdout
+	if options.OutputFormat == app.OutputFormatStream && (options.LLMInteractive || options.Diff || options.Watch) {
+		return fmt.Errorf("--output-format stream cannot be used with --llm-interactive, --diff or --watch")
+	}
+
+	if options.Changed && options.SaveDeploymentFiles == "" {
+		return fmt.Errorf("--changed requires --save-deployment-files to be specified")
+	}
+
+	if options.Changed && strings.Contains(options.SaveDeploymentFiles, app.TimestampPlaceholder) {
+		return fmt.Errorf("--changed cannot be used with the %s placeholder in --save-deployment-files", app.TimestampPlaceholder)
+	}
+
+	if options.ContextLines < 0 {
+		return fmt.Errorf("--context-lines must not be negative")
+	}
+
+	if options.Timeout < 0 || options.DiscoveryTimeout < 0 || options.LLMTimeout < 0 || options.DeployTimeout < 0 {
+		return fmt.Errorf("--timeout, --discovery-timeout, --llm-timeout and --deploy-timeout must not be negative")
+	}
+
+	// Watch mode re-runs on changes of the user config file
+	if options.Watch && options.UserConfig == "" {
+		return fmt.Errorf("--watch requires --user-config to be specified")
+	}
+
+	// The support bundle captures a single run
+	if options.Watch && options.SupportBundle != "" {
+		return fmt.Errorf("--support-bundle cannot be used with --watch")
 	}
 
 	// Network Operator plugin rules
 	if slices.Contains(options.EnabledPlugins, networkoperatorplugin.PluginName) {
 		// If profile is selected, either save-deployment-files or deploy options should be provided
-		if (options.Fabric != "" || options.DeploymentType != "" || options.Prompt != "" || options.LLMInteractive) && options.SaveDeploymentFiles == "" && !options.Deploy {
-			return fmt.Errorf("when --deployment-type, --prompt, or --llm-interactive is specified, either --save-deployment-files or --deploy must be provided")
+		if (options.Fabric != "" || options.DeploymentType != "" || options.Prompt != "" || options.LLMInteractive || options.ProfileJSON != "") && options.SaveDeploymentFiles == "" && options.OutputFormat != app.OutputFormatStream && !options.Deploy && !options.Diff {
+			return fmt.Errorf("when --deployment-type, --prompt, or --llm-interactive is specified, either --save-deployment-files, --output-format stream, --diff or --deploy must be provided")
 		}
 
-		// Save-deployment-files or deploy can't work without profile
-		if options.Fabric == "" && options.DeploymentType == "" && options.Prompt == "" && !options.LLMInteractive && options.Deploy {
+		// Save-deployment-files or deploy can't work without profile, the user config may define it in its profile section
+		if options.Fabric == "" && options.DeploymentType == "" && options.Prompt == "" && !options.LLMInteractive && options.ProfileJSON == "" && options.Deploy && options.UserConfig == "" && options.ConfigFromSecret == "" && options.RunSpec == "" {
 			return fmt.Errorf("--deploy requires --deployment-type, --prompt, or --llm-interactive to be specified")
 		}
 
@@ -211,7 +597,13 @@ func validateConfig(options options.Options) error {
 			return fmt.Errorf("--prompt and --llm-interactive cannot be used together")
 		}
 
-		if (options.DeploymentType != "" && options.Fabric == "") || (options.Fabric != "" && options.DeploymentType == "") {
+		// The JSON profile replaces the LLM answer
+		if options.ProfileJSON != "" && (options.Fabric != "" || options.DeploymentType != "" || options.Prompt != "" || options.LLMInteractive || options.ForceProfile != "") {
+			return fmt.Errorf("--profile-json cannot be used with --fabric, --deployment-type, --prompt, --llm-interactive or --force-profile")
+		}
+
+		// The user config profile section can fill in the other one
+		if options.UserConfig == "" && options.ConfigFromSecret == "" && ((options.DeploymentType != "" && options.Fabric == "") || (options.Fabric != "" && options.DeploymentType == "")) {
 			return fmt.Errorf("--deployment-type requires --fabric to be specified")
 		}
 
@@ -219,19 +611,38 @@ func validateConfig(options options.Options) error {
 			return fmt.Errorf("--fabric must be one of: infiniband, ethernet")
 		}
 
-		if options.DeploymentType != "" && !slices.Contains([]string{"sriov", "rdma_shared", "host_device"}, options.DeploymentType) {
-			return fmt.Errorf("--deployment-type must be one of: sriov, rdma_shared, host_device")
+		if options.DeploymentType != "" {
+			for _, deploymentType := range strings.Split(options.DeploymentType, ",") {
+				if !slices.Contains([]string{"sriov", "rdma_shared", "host_device"}, strings.TrimSpace(deploymentType)) {
+					return fmt.Errorf("--deployment-type must be one or a comma-separated list of: sriov, rdma_shared, host_device")
+				}
+			}
 		}
 	}
 
 	// LLM options validation
-	if options.Prompt != "" || options.LLMInteractive {
-		if options.LLMApiKey == "" || options.LLMVendor == "" {
-			return fmt.Errorf("--prompt or --llm-interactive requires --llm-api-key and --llm-vendor to be specified")
+	if !slices.Contains(llm.ConfidenceLevels, options.MinConfidence) {
+		return fmt.Errorf("--min-confidence must be one of: %s", strings.Join(llm.ConfidenceLevels, ", "))
+	}
+	// The system prompt is printed to stdout instead of selecting a profile
+	if options.PrintSystemPrompt && (options.OutputFormat == app.OutputFormatStream || options.PrintDiscovery != "" || options.Deploy || options.LLMInteractive) {
+		return fmt.Errorf("--print-system-prompt cannot be used with --output-format stream, --print-discovery, --deploy or --llm-interactive")
+	}
+	if options.LLMFixture != "" && options.Prompt == "" && !options.LLMInteractive && options.RunSpec == "" {
+		return fmt.Errorf("--llm-fixture requires --prompt, --llm-interactive or --run-spec to be specified")
+	}
+
+	if (options.Prompt != "" || options.LLMInteractive) && options.LLMFixture == "" {
+		if options.LLMApiKey == "" {
+			return fmt.Errorf("--prompt or --llm-interactive requires --llm-api-key to be specified")
 		}
 
-		if !slices.Contains([]string{"openai", "openai-azure", "anthropic", "gemini"}, options.LLMVendor) {
-			return fmt.Errorf("--llm-vendor must be one of: openai, openai-azure, anthropic, gemini")
+		if options.LLMVendor == "" {
+			if _, err := llm.InferVendor(options.LLMModel, options.LLMApiUrl); err != nil {
+				return fmt.Errorf("--llm-vendor must be specified: %w", err)
+			}
+		} else if !slices.Contains(llm.Vendors, options.LLMVendor) {
+			return fmt.Errorf("--llm-vendor must be one of: %s", strings.Join(llm.Vendors, ", "))
 		}
 	}
 
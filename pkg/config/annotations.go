@@ -0,0 +1,67 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ParseAnnotation splits an --annotation value into the annotation key and value, validating the key
+func ParseAnnotation(value string) (string, string, error) {
+	key, annotationValue, ok := strings.Cut(value, "=")
+	if !ok || key == "" {
+		return "", "", fmt.Errorf("%q must be key=value, e.g. example.com/team=networking", value)
+	}
+	if msgs := validateAnnotationKey(key); len(msgs) > 0 {
+		return "", "", fmt.Errorf("annotation key %q is invalid: %s", key, strings.Join(msgs, "; "))
+	}
+	return key, annotationValue, nil
+}
+
+// ApplyAnnotations adds the key=value annotations to the annotations of the config, overriding the ones with the same key
+func ApplyAnnotations(cfg *LaunchKubernetesConfig, values []string) error {
+	for _, value := range values {
+		key, annotationValue, err := ParseAnnotation(value)
+		if err != nil {
+			return err
+		}
+		if cfg.Annotations == nil {
+			cfg.Annotations = map[string]string{}
+		}
+		cfg.Annotations[key] = annotationValue
+	}
+	return nil
+}
+
+// validateAnnotationKey validates the key like the API server does, as a qualified name in lower case
+func validateAnnotationKey(key string) []string {
+	return validation.IsQualifiedName(strings.ToLower(key))
+}
+
+// validateAnnotations validates the keys of the annotations added to the generated objects
+func validateAnnotations(annotations map[string]string, errs *ValidationErrors) {
+	for _, key := range slices.Sorted(maps.Keys(annotations)) {
+		for _, msg := range validateAnnotationKey(key) {
+			errs.add("annotations", "annotations key %q is invalid: %s", key, msg)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnotationsConfig(t *testing.T) {
+	newConfig := func(annotations map[string]string) *LaunchKubernetesConfig {
+		return &LaunchKubernetesConfig{
+			NetworkOperator: &NetworkOperatorConfig{
+				ComponentVersion: "network-operator-v25.10.0",
+				Repository:       "nvcr.io/nvidia/mellanox",
+				Namespace:        "nvidia-network-operator",
+			},
+			Annotations: annotations,
+		}
+	}
+
+	t.Run("accept qualified keys", func(t *testing.T) {
+		config := newConfig(map[string]string{"example.com/cost-center": "cc-42", "team": "networking"})
+		assert.NoError(t, ValidateClusterConfig(config, "macvlan-rdma-shared"))
+	})
+
+	t.Run("reject invalid keys", func(t *testing.T) {
+		err := ValidateClusterConfig(newConfig(map[string]string{"-team": "networking", "example.com/": "x"}), "macvlan-rdma-shared")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `annotations key "-team" is invalid`)
+		assert.Contains(t, err.Error(), `annotations key "example.com/" is invalid`)
+	})
+
+	t.Run("flag annotations override the config ones", func(t *testing.T) {
+		config := newConfig(map[string]string{"example.com/team": "compute", "example.com/owner": "ops"})
+		require.NoError(t, ApplyAnnotations(config, []string{"example.com/team=networking", "example.com/cost-center=cc=42"}))
+		assert.Equal(t, map[string]string{
+			"example.com/team":        "networking",
+			"example.com/owner":       "ops",
+			"example.com/cost-center": "cc=42",
+		}, config.Annotations)
+	})
+
+	t.Run("reject malformed flag annotations", func(t *testing.T) {
+		for _, value := range []string{"team", "=networking", "bad key=value"} {
+			_, _, err := ParseAnnotation(value)
+			assert.Error(t, err, value)
+		}
+	})
+}
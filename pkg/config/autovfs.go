@@ -0,0 +1,97 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+)
+
+// AutoNumVfs is the sriov.numVfs value deriving the number of VFs from the discovered PF count
+const AutoNumVfs = "auto"
+
+const (
+	// DefaultAutoNumVfsMultiplier is the default number of VFs per discovered PF of numVfs: auto
+	DefaultAutoNumVfsMultiplier = 8
+	// DefaultAutoNumVfsMax is the default cap of numVfs: auto
+	DefaultAutoNumVfsMax = 64
+)
+
+// AutoNumVfsConfig computes numVfs: auto as the discovered PF count times Multiplier, capped at Max
+type AutoNumVfsConfig struct {
+	Multiplier int `yaml:"multiplier,omitempty"`
+	Max        int `yaml:"max,omitempty"`
+}
+
+// UnmarshalYAML decodes the SR-IOV config, accepting numVfs: auto
+func (s *SriovConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain SriovConfig
+	err := unmarshal((*plain)(s))
+	var typeErr *yaml.TypeError
+	if err == nil || !errors.As(err, &typeErr) || len(typeErr.Errors) != 1 {
+		return err
+	}
+
+	// The other fields are decoded, the single type error is expected from numVfs: auto
+	var fields yaml.MapSlice
+	if unmarshal(&fields) != nil {
+		return err
+	}
+	for _, field := range fields {
+		if field.Key == "numVfs" && field.Value == AutoNumVfs {
+			s.NumVfs = 0
+			s.NumVfsAuto = true
+			return nil
+		}
+	}
+	return err
+}
+
+// ResolveAutoNumVfs computes the sriov.numVfs of cfg from the PFs of its cluster config when it is auto.
+// It returns whether numVfs was computed.
+func ResolveAutoNumVfs(cfg *LaunchKubernetesConfig) (bool, error) {
+	if cfg.Sriov == nil || !cfg.Sriov.NumVfsAuto {
+		return false, nil
+	}
+
+	pfs := 0
+	if cfg.ClusterConfig != nil {
+		pfs = len(cfg.ClusterConfig.PFs)
+	}
+	if pfs == 0 {
+		return false, fmt.Errorf("sriov.numVfs: %s requires the PFs discovered in clusterConfig.pfs", AutoNumVfs)
+	}
+
+	multiplier, max := DefaultAutoNumVfsMultiplier, DefaultAutoNumVfsMax
+	if auto := cfg.Sriov.AutoNumVfs; auto != nil {
+		if auto.Multiplier < 0 || auto.Max < 0 {
+			return false, fmt.Errorf("sriov.autoNumVfs.multiplier and sriov.autoNumVfs.max must not be negative")
+		}
+		if auto.Multiplier > 0 {
+			multiplier = auto.Multiplier
+		}
+		if auto.Max > 0 {
+			max = auto.Max
+		}
+	}
+
+	cfg.Sriov.NumVfs = min(pfs*multiplier, max)
+	cfg.Sriov.NumVfsAuto = false
+	return true, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoNumVfs(t *testing.T) {
+	parse := func(sriov string) (*LaunchKubernetesConfig, error) {
+		data := "networkOperator:\n  namespace: nvidia-network-operator\nsriov:\n" + sriov + "clusterConfig:\n  pfs:\n  - pciAddress: \"0000:03:00.0\"\n  - pciAddress: \"0000:03:00.1\"\n"
+		return ParseFullConfig([]byte(data), "config.yaml", logr.Discard())
+	}
+
+	t.Run("derive the VFs from the discovered PFs", func(t *testing.T) {
+		cfg, err := parse("  numVfs: auto\n  resourceName: sriov_resource\n")
+		require.NoError(t, err)
+		assert.True(t, cfg.Sriov.NumVfsAuto)
+		assert.Equal(t, "sriov_resource", cfg.Sriov.ResourceName, "the other fields are decoded")
+
+		resolved, err := ResolveAutoNumVfs(cfg)
+		require.NoError(t, err)
+		assert.True(t, resolved)
+		assert.Equal(t, 2*DefaultAutoNumVfsMultiplier, cfg.Sriov.NumVfs)
+		assert.False(t, cfg.Sriov.NumVfsAuto)
+	})
+
+	t.Run("respect the multiplier and the cap", func(t *testing.T) {
+		cfg, err := parse("  numVfs: auto\n  autoNumVfs:\n    multiplier: 4\n    max: 32\n")
+		require.NoError(t, err)
+		_, err = ResolveAutoNumVfs(cfg)
+		require.NoError(t, err)
+		assert.Equal(t, 8, cfg.Sriov.NumVfs)
+
+		cfg, err = parse("  numVfs: auto\n  autoNumVfs:\n    multiplier: 32\n    max: 48\n")
+		require.NoError(t, err)
+		_, err = ResolveAutoNumVfs(cfg)
+		require.NoError(t, err)
+		assert.Equal(t, 48, cfg.Sriov.NumVfs)
+	})
+
+	t.Run("keep a fixed number of VFs", func(t *testing.T) {
+		cfg, err := parse("  numVfs: 4\n")
+		require.NoError(t, err)
+		resolved, err := ResolveAutoNumVfs(cfg)
+		require.NoError(t, err)
+		assert.False(t, resolved)
+		assert.Equal(t, 4, cfg.Sriov.NumVfs)
+	})
+
+	t.Run("require discovered PFs", func(t *testing.T) {
+		cfg := &LaunchKubernetesConfig{Sriov: &SriovConfig{NumVfsAuto: true}}
+		_, err := ResolveAutoNumVfs(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "requires the PFs discovered in clusterConfig.pfs")
+	})
+
+	t.Run("reject other strings", func(t *testing.T) {
+		_, err := parse("  numVfs: many\n")
+		assert.Error(t, err)
+	})
+
+	t.Run("set numVfs to auto", func(t *testing.T) {
+		cfg := &LaunchKubernetesConfig{Sriov: &SriovConfig{NumVfs: 8}}
+		require.NoError(t, ApplySetValues(cfg, []string{"sriov.numVfs=auto"}))
+		assert.True(t, cfg.Sriov.NumVfsAuto)
+
+		assert.Error(t, ApplySetValues(cfg, []string{"sriov.numVfs=auto", "sriov.unknown=1"}), "unknown fields are still rejected")
+	})
+}
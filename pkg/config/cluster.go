@@ -0,0 +1,139 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultConfigKey is the key read from the Secret or ConfigMap when the reference doesn't name one
+const DefaultConfigKey = "config.yaml"
+
+// ClusterSource references a key of a Secret or ConfigMap holding the cluster configuration
+type ClusterSource struct {
+	// Kind is either "secret" or "configmap"
+	Kind      string
+	Namespace string
+	Name      string
+	Key       string
+}
+
+func (s ClusterSource) String() string {
+	return fmt.Sprintf("%s/%s/%s:%s", s.Kind, s.Namespace, s.Name, s.Key)
+}
+
+// ParseClusterSource parses a <secret|configmap>/<namespace>/<name>[:<key>] reference
+func ParseClusterSource(ref string) (ClusterSource, error) {
+	source := ClusterSource{Key: DefaultConfigKey}
+
+	path, key, hasKey := strings.Cut(ref, ":")
+	if hasKey {
+		if key == "" {
+			return source, fmt.Errorf("invalid config reference %q: empty key", ref)
+		}
+		source.Key = key
+	}
+
+	parts := strings.Split(path, "/")
+	if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
+		return source, fmt.Errorf("invalid config reference %q: expected <secret|configmap>/<namespace>/<name>[:<key>]", ref)
+	}
+	source.Kind, source.Namespace, source.Name = strings.ToLower(parts[0]), parts[1], parts[2]
+	if source.Kind != "secret" && source.Kind != "configmap" {
+		return source, fmt.Errorf("invalid config reference %q: kind must be secret or configmap", ref)
+	}
+
+	return source, nil
+}
+
+// LoadFullConfigFromCluster loads and parses the cluster configuration stored in a Secret or ConfigMap
+func LoadFullConfigFromCluster(ctx context.Context, c client.Reader, source ClusterSource, logger logr.Logger) (*LaunchKubernetesConfig, error) {
+	logger.Info("Loading cluster configuration", "source", source.String())
+
+	configData, err := readClusterSource(ctx, c, source)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseFullConfig(configData, source.String(), logger)
+}
+
+// readClusterSource returns the value of the key referenced by source
+func readClusterSource(ctx context.Context, c client.Reader, source ClusterSource) ([]byte, error) {
+	key := client.ObjectKey{Namespace: source.Namespace, Name: source.Name}
+
+	var (
+		kind      string
+		data      []byte
+		found     bool
+		available []string
+	)
+	switch source.Kind {
+	case "secret":
+		kind = "Secret"
+		secret := &corev1.Secret{}
+		if err := c.Get(ctx, key, secret); err != nil {
+			return nil, getError(err, kind, key)
+		}
+		data, found = secret.Data[source.Key]
+		for name := range secret.Data {
+			available = append(available, name)
+		}
+	case "configmap":
+		kind = "ConfigMap"
+		configMap := &corev1.ConfigMap{}
+		if err := c.Get(ctx, key, configMap); err != nil {
+			return nil, getError(err, kind, key)
+		}
+		if value, ok := configMap.Data[source.Key]; ok {
+			data, found = []byte(value), true
+		} else {
+			data, found = configMap.BinaryData[source.Key]
+		}
+		for name := range configMap.Data {
+			available = append(available, name)
+		}
+		for name := range configMap.BinaryData {
+			available = append(available, name)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported config source kind %q", source.Kind)
+	}
+
+	if !found {
+		slices.Sort(available)
+		return nil, fmt.Errorf("key %s not found in %s %s (available keys: %s)", source.Key, kind, key, strings.Join(available, ", "))
+	}
+
+	return data, nil
+}
+
+// getError describes a failed Get of the Secret or ConfigMap
+func getError(err error, kind string, key client.ObjectKey) error {
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("%s %s not found", kind, key)
+	}
+	return fmt.Errorf("failed to get %s %s: %w", kind, key, err)
+}
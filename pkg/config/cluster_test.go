@@ -0,0 +1,113 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const clusterConfigYAML = `networkOperator:
+  version: v25.10.0
+  namespace: nvidia-network-operator
+sriov:
+  numVfs: 8
+`
+
+func TestParseClusterSource(t *testing.T) {
+	t.Run("default key", func(t *testing.T) {
+		source, err := ParseClusterSource("configmap/l8k/cluster-config")
+		require.NoError(t, err)
+		assert.Equal(t, ClusterSource{Kind: "configmap", Namespace: "l8k", Name: "cluster-config", Key: DefaultConfigKey}, source)
+	})
+
+	t.Run("explicit key", func(t *testing.T) {
+		source, err := ParseClusterSource("Secret/l8k/cluster-config:l8k.yaml")
+		require.NoError(t, err)
+		assert.Equal(t, ClusterSource{Kind: "secret", Namespace: "l8k", Name: "cluster-config", Key: "l8k.yaml"}, source)
+	})
+
+	t.Run("invalid references", func(t *testing.T) {
+		for _, ref := range []string{"", "cluster-config", "configmap/cluster-config", "configmap/l8k/cluster-config:", "pod/l8k/cluster-config"} {
+			_, err := ParseClusterSource(ref)
+			assert.Error(t, err, ref)
+		}
+	})
+}
+
+func TestLoadFullConfigFromCluster(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-config", Namespace: "l8k"},
+			Data:       map[string]string{DefaultConfigKey: clusterConfigYAML, "notes": "unused"},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-config", Namespace: "l8k"},
+			Data:       map[string][]byte{"l8k.yaml": []byte(clusterConfigYAML)},
+		},
+	).Build()
+
+	load := func(ref string) (*LaunchKubernetesConfig, error) {
+		source, err := ParseClusterSource(ref)
+		require.NoError(t, err)
+		return LoadFullConfigFromCluster(ctx, c, source, logr.Discard())
+	}
+
+	t.Run("load from a ConfigMap", func(t *testing.T) {
+		cfg, err := load("configmap/l8k/cluster-config")
+		require.NoError(t, err)
+		assert.Equal(t, "v25.10.0", cfg.NetworkOperator.Version)
+		assert.Equal(t, 8, cfg.Sriov.NumVfs)
+	})
+
+	t.Run("load from a Secret", func(t *testing.T) {
+		cfg, err := load("secret/l8k/cluster-config:l8k.yaml")
+		require.NoError(t, err)
+		assert.Equal(t, "nvidia-network-operator", cfg.NetworkOperator.Namespace)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		_, err := load("configmap/l8k/cluster-config:l8k.yaml")
+		require.Error(t, err)
+		assert.Equal(t, "key l8k.yaml not found in ConfigMap l8k/cluster-config (available keys: config.yaml, notes)", err.Error())
+	})
+
+	t.Run("missing resource", func(t *testing.T) {
+		_, err := load("secret/default/cluster-config")
+		require.Error(t, err)
+		assert.Equal(t, "Secret default/cluster-config not found", err.Error())
+	})
+
+	t.Run("invalid YAML", func(t *testing.T) {
+		invalid := fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-config", Namespace: "l8k"},
+			Data:       map[string]string{DefaultConfigKey: "networkOperator: ["},
+		}).Build()
+
+		_, err := LoadFullConfigFromCluster(ctx, invalid, ClusterSource{Kind: "configmap", Namespace: "l8k", Name: "cluster-config", Key: DefaultConfigKey}, logr.Discard())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse cluster config YAML configmap/l8k/cluster-config:config.yaml")
+	})
+}
@@ -18,10 +18,18 @@ package config
 
 import (
 	"fmt"
+	"maps"
 	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 // LaunchKubernetesConfig represents the l8k-config.yaml structure
@@ -34,8 +42,18 @@ type LaunchKubernetesConfig struct {
 	RdmaShared      *RdmaSharedConfig      `yaml:"rdmaShared,omitempty"`
 	Ipoib           *IpoibConfig           `yaml:"ipoib,omitempty"`
 	Macvlan         *MacvlanConfig         `yaml:"macvlan,omitempty"`
+	Qos             *QosConfig             `yaml:"qos,omitempty"`
 	Profile         *Profile               `yaml:"profile,omitempty"`
 	ClusterConfig   *ClusterConfig         `yaml:"clusterConfig,omitempty"`
+	// CleanOutputDir set to false keeps l8k from deleting files in the output directory, whatever the run's options
+	CleanOutputDir *bool `yaml:"cleanOutputDir,omitempty"`
+	// Annotations are added to the metadata of every generated object, the annotations set by the templates win
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+// CleansOutputDir reports whether l8k may delete files in the output directory, true unless disabled
+func (c *LaunchKubernetesConfig) CleansOutputDir() bool {
+	return c.CleanOutputDir == nil || *c.CleanOutputDir
 }
 
 type NetworkOperatorConfig struct {
@@ -43,6 +61,121 @@ type NetworkOperatorConfig struct {
 	ComponentVersion string `yaml:"componentVersion"`
 	Repository       string `yaml:"repository"`
 	Namespace        string `yaml:"namespace"`
+	// ContainerResources sets the resource requests and limits of the operator containers
+	ContainerResources []ContainerResourcesConfig `yaml:"containerResources,omitempty"`
+	// DaemonSets restricts the nodes the operator DaemonSets run on
+	DaemonSets *DaemonSetsConfig `yaml:"daemonSets,omitempty"`
+	// Namespaces overrides Namespace for individual components, keyed by one of NamespaceComponents
+	Namespaces map[string]string `yaml:"namespaces,omitempty"`
+	// ImageDigests pins images to a digest instead of the version tag, keyed by image name, e.g. doca-driver
+	ImageDigests map[string]string `yaml:"imageDigests,omitempty"`
+	// NamePrefix and NameSuffix are added to the names of the generated objects, except the NicClusterPolicy
+	NamePrefix string `yaml:"namePrefix,omitempty"`
+	NameSuffix string `yaml:"nameSuffix,omitempty"`
+}
+
+// ObjectName returns the name of a generated object with the configured prefix and suffix
+func (c NetworkOperatorConfig) ObjectName(name string) string {
+	return c.NamePrefix + name + c.NameSuffix
+}
+
+// NamespaceComponents are the components whose objects can be rendered into their own namespace:
+// the nv-ipam IP pools and the SR-IOV node policies and networks
+var NamespaceComponents = []string{"nvIpam", "sriov"}
+
+// ComponentNamespace returns the namespace of the objects of the component, Namespace unless overridden
+func (c NetworkOperatorConfig) ComponentNamespace(component string) string {
+	if namespace, ok := c.Namespaces[component]; ok && namespace != "" {
+		return namespace
+	}
+	return c.Namespace
+}
+
+// SriovProfiles are the profiles that require the sriov section. sriov-ethernet-rdma is the profile directory of
+// SR-IOV over Ethernet, sriov-rdma the name of the profile before it was split by fabric.
+var SriovProfiles = []string{"sriov-rdma", "sriov-ethernet-rdma", "sriov-ib-rdma"}
+
+// ImageNames are the images rendered by the profiles that can be pinned with ImageDigests
+var ImageNames = []string{"doca-driver", "nvidia-k8s-ipam", "plugins", "multus-cni", "sriov-network-device-plugin", "k8s-rdma-shared-dev-plugin", "ipoib-cni"}
+
+// imageDigestPattern matches a sha256 image digest
+var imageDigestPattern = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// ImageVersion returns the pinned digest of the image, or version when the image is not pinned.
+// The network operator pulls an image whose version is a digest as repository/image@sha256:...
+func (c NetworkOperatorConfig) ImageVersion(image, version string) string {
+	if digest, ok := c.ImageDigests[image]; ok {
+		return digest
+	}
+	return version
+}
+
+// PriorityClassName returns the PriorityClass of the DaemonSets, empty when unset or on a nil config
+func (c *NetworkOperatorConfig) PriorityClassName() string {
+	if c == nil || c.DaemonSets == nil {
+		return ""
+	}
+	return c.DaemonSets.PriorityClassName
+}
+
+// DaemonSetsConfig sets the scheduling of the DaemonSets managed by the NicClusterPolicy
+type DaemonSetsConfig struct {
+	// NodeSelector labels the nodes must have, an empty value only requires the label to exist
+	NodeSelector map[string]string  `yaml:"nodeSelector,omitempty"`
+	Tolerations  []TolerationConfig `yaml:"tolerations,omitempty"`
+	// PriorityClassName is set on the DaemonSets, Deployments and StatefulSets rendered by the profiles that don't
+	// set one. The NicClusterPolicy can't take it.
+	PriorityClassName string `yaml:"priorityClassName,omitempty"`
+	// PriorityClass, when set, renders the PriorityClassName PriorityClass
+	PriorityClass *PriorityClassConfig `yaml:"priorityClass,omitempty"`
+}
+
+// PriorityClassConfig defines the PriorityClass generated for the DaemonSets
+type PriorityClassConfig struct {
+	// Value is the priority of the pods, at most 1000000000 for a user-defined PriorityClass
+	Value       int32  `yaml:"value"`
+	Description string `yaml:"description,omitempty"`
+}
+
+// TolerationConfig is a Kubernetes toleration of the DaemonSet pods
+type TolerationConfig struct {
+	Key string `yaml:"key,omitempty"`
+	// Operator is Equal or Exists, Equal by default
+	Operator string `yaml:"operator,omitempty"`
+	Value    string `yaml:"value,omitempty"`
+	// Effect is NoSchedule, PreferNoSchedule or NoExecute, all effects when empty
+	Effect            string `yaml:"effect,omitempty"`
+	TolerationSeconds *int64 `yaml:"tolerationSeconds,omitempty"`
+}
+
+// OperatorOrDefault returns the operator of the toleration, Equal when unset
+func (t TolerationConfig) OperatorOrDefault() string {
+	if t.Operator == "" {
+		return string(corev1.TolerationOpEqual)
+	}
+	return t.Operator
+}
+
+// ContainerResourcesConfig sets the resource requests and limits of a NicClusterPolicy container
+type ContainerResourcesConfig struct {
+	// Name of the container, e.g. mofed-container or kube-multus
+	Name     string         `yaml:"name"`
+	Requests ResourceValues `yaml:"requests,omitempty"`
+	Limits   ResourceValues `yaml:"limits,omitempty"`
+}
+
+// ContainerNames are the NicClusterPolicy containers whose resources the profiles render
+var ContainerNames = []string{"mofed-container", "nv-ipam-node", "nv-ipam-controller", "cni-plugins", "kube-multus", "kube-sriovdp", "rdma-shared-dp", "ipoib-cni"}
+
+// ResourceValues are CPU and memory quantities such as "500m" or "256Mi"
+type ResourceValues struct {
+	CPU    string `yaml:"cpu,omitempty"`
+	Memory string `yaml:"memory,omitempty"`
+}
+
+// IsEmpty reports whether no quantity is set
+func (v ResourceValues) IsEmpty() bool {
+	return v.CPU == "" && v.Memory == ""
 }
 
 type DOCADriverConfig struct {
@@ -57,8 +190,8 @@ type NvIpamConfig struct {
 }
 
 type NvIpamSubnetConfig struct {
-	Subnet  string `yaml:"subnet"`
-	Gateway string `yaml:"gateway"`
+	Subnet  string `yaml:"subnet" redact:"true"`
+	Gateway string `yaml:"gateway" redact:"true"`
 }
 
 type SriovConfig struct {
@@ -66,8 +199,157 @@ type SriovConfig struct {
 	InfinibandMtu int    `yaml:"infinibandMtu"`
 	NumVfs        int    `yaml:"numVfs"`
 	Priority      int    `yaml:"priority"`
-	ResourceName  string `yaml:"resourceName"`
-	NetworkName   string `yaml:"networkName"`
+	ResourceName  string `yaml:"resourceName,omitempty"`
+	NetworkName   string `yaml:"networkName,omitempty"`
+	// Pools defines several named SR-IOV resources and networks instead of the single resourceName and networkName
+	Pools []SriovPoolConfig `yaml:"pools,omitempty"`
+	// Devices overrides the VF configuration of individual PFs, NumVfs is used for the PFs not listed here
+	Devices []SriovDeviceConfig `yaml:"devices,omitempty"`
+	// AutoNumVfs derives NumVfs from the discovered PF count when numVfs is auto
+	AutoNumVfs *AutoNumVfsConfig `yaml:"autoNumVfs,omitempty"`
+	// NumVfsAuto is set by numVfs: auto until ResolveAutoNumVfs computes NumVfs
+	NumVfsAuto bool `yaml:"-"`
+}
+
+// SriovDeviceConfig configures the VFs of a single PF
+type SriovDeviceConfig struct {
+	PciAddress string `yaml:"pciAddress"`
+	NumVfs     int    `yaml:"numVfs,omitempty"`
+	// VfRange selects the VFs to use on the PF in the "first-last" form, e.g. "0-7"
+	VfRange string `yaml:"vfRange,omitempty"`
+}
+
+// SriovPoolConfig defines a named SR-IOV resource and the network that uses it
+type SriovPoolConfig struct {
+	Name         string `yaml:"name"`
+	ResourceName string `yaml:"resourceName"`
+	NetworkName  string `yaml:"networkName"`
+	// NumVfs defaults to sriov.numVfs
+	NumVfs int `yaml:"numVfs,omitempty"`
+	// PciAddresses selects the PFs of the pool, all PFs are used when empty
+	PciAddresses []string `yaml:"pciAddresses,omitempty"`
+}
+
+// NameSuffix returns the suffix of the objects rendered for the pool, empty for the single legacy definition
+func (p SriovPoolConfig) NameSuffix() string {
+	if p.Name == "" {
+		return ""
+	}
+	return "-" + p.Name
+}
+
+// Definitions returns the SR-IOV resource and network definitions to render.
+// Configs without pools yield a single unnamed definition built from resourceName and networkName.
+func (s *SriovConfig) Definitions() []SriovPoolConfig {
+	if len(s.Pools) == 0 {
+		return []SriovPoolConfig{{ResourceName: s.ResourceName, NetworkName: s.NetworkName, NumVfs: s.NumVfs}}
+	}
+
+	pools := make([]SriovPoolConfig, len(s.Pools))
+	for i, pool := range s.Pools {
+		if pool.NumVfs == 0 {
+			pool.NumVfs = s.NumVfs
+		}
+		pools[i] = pool
+	}
+	return pools
+}
+
+// PoolFor returns the definition that the PF with the given PCI address belongs to, nil if it belongs to none
+func (s *SriovConfig) PoolFor(pciAddress string) *SriovPoolConfig {
+	for _, pool := range s.Definitions() {
+		if len(pool.PciAddresses) == 0 || slices.Contains(pool.PciAddresses, pciAddress) {
+			return &pool
+		}
+	}
+	return nil
+}
+
+// ParseVfRange parses a VF range in the "first-last" form
+func ParseVfRange(vfRange string) (int, int, error) {
+	bounds := strings.Split(vfRange, "-")
+	if len(bounds) != 2 {
+		return 0, 0, fmt.Errorf("invalid VF range %q, expected the first-last form", vfRange)
+	}
+
+	first, err := strconv.Atoi(strings.TrimSpace(bounds[0]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid VF range %q: %w", vfRange, err)
+	}
+	last, err := strconv.Atoi(strings.TrimSpace(bounds[1]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid VF range %q: %w", vfRange, err)
+	}
+
+	if first < 0 || last < first {
+		return 0, 0, fmt.Errorf("invalid VF range %q, first VF must be non-negative and not greater than the last VF", vfRange)
+	}
+
+	return first, last, nil
+}
+
+// device returns the per-device configuration of the PF, if any
+func (s *SriovConfig) device(pciAddress string) *SriovDeviceConfig {
+	for i := range s.Devices {
+		if s.Devices[i].PciAddress == pciAddress {
+			return &s.Devices[i]
+		}
+	}
+	return nil
+}
+
+// NumVfsFor returns the number of VFs to create on the PF with the given PCI address
+func (s *SriovConfig) NumVfsFor(pciAddress string) int {
+	numVfs := s.NumVfs
+	if pool := s.PoolFor(pciAddress); pool != nil {
+		numVfs = pool.NumVfs
+	}
+
+	device := s.device(pciAddress)
+	if device == nil {
+		return numVfs
+	}
+
+	if device.NumVfs > 0 {
+		return device.NumVfs
+	}
+
+	if device.VfRange != "" {
+		if _, last, err := ParseVfRange(device.VfRange); err == nil {
+			return last + 1
+		}
+	}
+
+	return numVfs
+}
+
+// MaxNumVfsFor returns the largest number of VFs to create on the PFs with the given PCI addresses
+func (s *SriovConfig) MaxNumVfsFor(pciAddresses []string) int {
+	maxNumVfs := 0
+	for _, pciAddress := range pciAddresses {
+		maxNumVfs = max(maxNumVfs, s.NumVfsFor(pciAddress))
+	}
+	return maxNumVfs
+}
+
+// VfRangeFor returns the VF range to select on the PF with the given PCI address.
+// An empty string is returned when all VFs of the PF are used.
+func (s *SriovConfig) VfRangeFor(pciAddress string) string {
+	device := s.device(pciAddress)
+	if device == nil || device.VfRange == "" {
+		return ""
+	}
+
+	first, last, err := ParseVfRange(device.VfRange)
+	if err != nil {
+		return ""
+	}
+
+	if first == 0 && last+1 == s.NumVfsFor(pciAddress) {
+		return ""
+	}
+
+	return fmt.Sprintf("%d-%d", first, last)
 }
 
 type HostdevConfig struct {
@@ -88,19 +370,169 @@ type MacvlanConfig struct {
 	NetworkName string `yaml:"networkName"`
 }
 
+// objectNames returns the names of the objects set by the config, by field
+func (c *LaunchKubernetesConfig) objectNames() map[string]string {
+	names := map[string]string{}
+	if c.NvIpam != nil && c.NvIpam.PoolName != "" {
+		names["nvIpam.poolName"] = c.NvIpam.PoolName
+	}
+	if c.Sriov != nil {
+		if c.Sriov.NetworkName != "" {
+			names["sriov.networkName"] = c.Sriov.NetworkName
+		}
+		for i, pool := range c.Sriov.Pools {
+			if pool.NetworkName != "" {
+				names[fmt.Sprintf("sriov.pools[%d].networkName", i)] = pool.NetworkName
+			}
+		}
+	}
+	if c.Hostdev != nil && c.Hostdev.NetworkName != "" {
+		names["hostdev.networkName"] = c.Hostdev.NetworkName
+	}
+	if c.Ipoib != nil && c.Ipoib.NetworkName != "" {
+		names["ipoib.networkName"] = c.Ipoib.NetworkName
+	}
+	if c.Macvlan != nil && c.Macvlan.NetworkName != "" {
+		names["macvlan.networkName"] = c.Macvlan.NetworkName
+	}
+	return names
+}
+
+// WithObjectNames returns a copy of the config whose object names, such as the network and IP pool names,
+// carry the configured prefix and suffix. The config itself is returned when none is configured.
+func (c *LaunchKubernetesConfig) WithObjectNames() *LaunchKubernetesConfig {
+	if c.NetworkOperator == nil || (c.NetworkOperator.NamePrefix == "" && c.NetworkOperator.NameSuffix == "") {
+		return c
+	}
+
+	named := *c
+	name := func(name string) string {
+		if name == "" {
+			return ""
+		}
+		return c.NetworkOperator.ObjectName(name)
+	}
+	if c.NvIpam != nil {
+		nvIpam := *c.NvIpam
+		nvIpam.PoolName = name(nvIpam.PoolName)
+		named.NvIpam = &nvIpam
+	}
+	if c.Sriov != nil {
+		sriov := *c.Sriov
+		sriov.NetworkName = name(sriov.NetworkName)
+		sriov.Pools = slices.Clone(sriov.Pools)
+		for i := range sriov.Pools {
+			sriov.Pools[i].NetworkName = name(sriov.Pools[i].NetworkName)
+		}
+		named.Sriov = &sriov
+	}
+	if c.Hostdev != nil {
+		hostdev := *c.Hostdev
+		hostdev.NetworkName = name(hostdev.NetworkName)
+		named.Hostdev = &hostdev
+	}
+	if c.Ipoib != nil {
+		ipoib := *c.Ipoib
+		ipoib.NetworkName = name(ipoib.NetworkName)
+		named.Ipoib = &ipoib
+	}
+	if c.Macvlan != nil {
+		macvlan := *c.Macvlan
+		macvlan.NetworkName = name(macvlan.NetworkName)
+		named.Macvlan = &macvlan
+	}
+	return &named
+}
+
+// QosConfig sets the RoCE QoS of the NICs of Spectrum-X clusters, applied by the nic-configuration-operator
+type QosConfig struct {
+	// Trust is the trust mode of the NICs, dscp or pfc
+	Trust string `yaml:"trust"`
+	// PfcPriorities are the priorities with Priority Flow Control, the lossless priorities
+	PfcPriorities []int `yaml:"pfcPriorities"`
+}
+
+// MaxQosPriority is the highest priority of the PFC priorities
+const MaxQosPriority = 7
+
+// QosTrustModes are the trust modes supported by the nic-configuration-operator
+var QosTrustModes = []string{"dscp", "pfc"}
+
+// DefaultQos is the QoS of Spectrum-X clusters without a qos section, the RoCE defaults of the nic-configuration-operator
+var DefaultQos = QosConfig{Trust: "dscp", PfcPriorities: []int{3}}
+
+// QosOrDefault returns the qos section, DefaultQos when it is unset
+func (c *LaunchKubernetesConfig) QosOrDefault() QosConfig {
+	if c.Qos == nil {
+		return DefaultQos
+	}
+	return *c.Qos
+}
+
+// PfcMask returns the PFC setting of the priorities 0-7 in the comma separated form of the NIC tools, e.g. 0,0,0,1,0,0,0,0
+func (q QosConfig) PfcMask() string {
+	mask := make([]string, MaxQosPriority+1)
+	for i := range mask {
+		mask[i] = "0"
+		if slices.Contains(q.PfcPriorities, i) {
+			mask[i] = "1"
+		}
+	}
+	return strings.Join(mask, ",")
+}
+
 type Profile struct {
 	Fabric     string `yaml:"fabric"`
 	Deployment string `yaml:"deployment"`
 	Multirail  bool   `yaml:"multirail"`
 	SpectrumX  bool   `yaml:"spectrumX"`
 	Ai         bool   `yaml:"ai"`
+	// Set lists the ProfileFlags the source sets explicitly, false ones included
+	Set []string `yaml:"-"`
+}
+
+// ProfileFlags are the keys of the boolean profile requirements
+var ProfileFlags = []string{"multirail", "spectrumX", "ai"}
+
+// UnmarshalYAML decodes the profile, recording the ProfileFlags it sets in Set
+func (p *Profile) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain Profile
+	if err := unmarshal((*plain)(p)); err != nil {
+		return err
+	}
+	var fields yaml.MapSlice
+	if err := unmarshal(&fields); err != nil {
+		return err
+	}
+	for _, field := range fields {
+		if key, ok := field.Key.(string); ok && slices.Contains(ProfileFlags, key) {
+			p.Set = append(p.Set, key)
+		}
+	}
+	return nil
+}
+
+// Flag returns the boolean requirement of the ProfileFlags key, and whether the profile sets it. An enabled
+// requirement is always set.
+func (p *Profile) Flag(key string) (value, set bool) {
+	switch key {
+	case "multirail":
+		value = p.Multirail
+	case "spectrumX":
+		value = p.SpectrumX
+	case "ai":
+		value = p.Ai
+	}
+	return value, value || slices.Contains(p.Set, key)
 }
 
 type ClusterConfig struct {
 	Capabilities *ClusterCapabilities `yaml:"capabilities"`
 	PFs          []PFConfig           `yaml:"pfs"`
-	WorkerNodes  []string             `yaml:"workerNodes"`
+	WorkerNodes  []string             `yaml:"workerNodes" redact:"true"`
 	NodeSelector map[string]string    `yaml:"nodeSelector,omitempty"`
+	// ClusterID identifies the cluster the config was discovered from, checked against the cluster deployed to
+	ClusterID string `yaml:"clusterID,omitempty"`
 }
 
 type ClusterCapabilities struct {
@@ -111,6 +543,8 @@ type NodesCapabilities struct {
 	Sriov bool `yaml:"sriov"`
 	Rdma  bool `yaml:"rdma"`
 	Ib    bool `yaml:"ib"`
+	// Count is the number of discovered NIC-equipped nodes
+	Count int `yaml:"count"`
 }
 
 type PFConfig struct {
@@ -118,6 +552,31 @@ type PFConfig struct {
 	PciAddress       string `yaml:"pciAddress"`
 	NetworkInterface string `yaml:"networkInterface"`
 	Traffic          string `yaml:"traffic"`
+	// NicType is the PCI device ID of the NIC of the PF, e.g. 101d
+	NicType string `yaml:"nicType,omitempty"`
+}
+
+// NicGroup is the PFs of a NIC type
+type NicGroup struct {
+	NicType      string
+	PciAddresses []string
+}
+
+// NicGroups groups the PFs by NIC type, in the order of the PFs. It fails when a PF has no NIC type.
+func (c *ClusterConfig) NicGroups() ([]NicGroup, error) {
+	var groups []NicGroup
+	for i, pf := range c.PFs {
+		if pf.NicType == "" {
+			return nil, fmt.Errorf("clusterConfig.pfs[%d] %s has no nicType, run the discovery again or set it", i, pf.PciAddress)
+		}
+		index := slices.IndexFunc(groups, func(g NicGroup) bool { return g.NicType == pf.NicType })
+		if index == -1 {
+			groups = append(groups, NicGroup{NicType: pf.NicType})
+			index = len(groups) - 1
+		}
+		groups[index].PciAddresses = append(groups[index].PciAddresses, pf.PciAddress)
+	}
+	return groups, nil
 }
 
 // LoadFullConfig loads and parses the cluster configuration from the specified path
@@ -139,10 +598,15 @@ func LoadFullConfig(configPath string, logger logr.Logger) (*LaunchKubernetesCon
 		return nil, fmt.Errorf("failed to read cluster config file %s: %w", configPath, err)
 	}
 
+	return ParseFullConfig(configData, configPath, logger)
+}
+
+// ParseFullConfig parses the cluster configuration YAML read from source
+func ParseFullConfig(configData []byte, source string, logger logr.Logger) (*LaunchKubernetesConfig, error) {
 	// Parse the YAML configuration
 	var config LaunchKubernetesConfig
 	if err := yaml.Unmarshal(configData, &config); err != nil {
-		return nil, fmt.Errorf("failed to parse cluster config YAML %s: %w", configPath, err)
+		return nil, fmt.Errorf("failed to parse cluster config YAML %s: %w", source, err)
 	}
 
 	logger.Info("Cluster configuration loaded successfully",
@@ -152,38 +616,430 @@ func LoadFullConfig(configPath string, logger logr.Logger) (*LaunchKubernetesCon
 	return &config, nil
 }
 
-// ValidateClusterConfig validates that essential fields are present in the cluster config
-func ValidateClusterConfig(config *LaunchKubernetesConfig, profile string) error {
-	if config.NetworkOperator.Repository == "" {
-		return fmt.Errorf("networkOperator.repository is required")
+// ValidationError describes a single problem found in one section of the config
+type ValidationError struct {
+	Section string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return e.Message
+}
+
+// ValidationErrors collects every problem found while validating the config
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, section := range e.Sections() {
+		for _, validationErr := range e.InSection(section) {
+			messages = append(messages, validationErr.Message)
+		}
 	}
+	return strings.Join(messages, "; ")
+}
 
-	if config.NetworkOperator.ComponentVersion == "" {
-		return fmt.Errorf("networkOperator.componentVersion is required")
+// Sections returns the config sections that have errors, in the order they were first reported
+func (e ValidationErrors) Sections() []string {
+	sections := []string{}
+	for _, validationErr := range e {
+		if !slices.Contains(sections, validationErr.Section) {
+			sections = append(sections, validationErr.Section)
+		}
 	}
+	return sections
+}
 
-	if config.NetworkOperator.Namespace == "" {
-		return fmt.Errorf("networkOperator.namespace is required")
+// InSection returns the errors reported for the given config section
+func (e ValidationErrors) InSection(section string) []ValidationError {
+	errs := []ValidationError{}
+	for _, validationErr := range e {
+		if validationErr.Section == section {
+			errs = append(errs, validationErr)
+		}
 	}
+	return errs
+}
+
+func (e *ValidationErrors) add(section string, format string, args ...interface{}) {
+	*e = append(*e, ValidationError{Section: section, Message: fmt.Sprintf(format, args...)})
+}
+
+// ValidateClusterConfig validates that essential fields are present in the cluster config.
+// All problems are collected and returned together as ValidationErrors.
+func ValidateClusterConfig(config *LaunchKubernetesConfig, profile string) error {
+	errs := ValidationErrors{}
+
+	if config.NetworkOperator == nil {
+		errs.add("networkOperator", "networkOperator section is required")
+	} else {
+		if config.NetworkOperator.Repository == "" {
+			errs.add("networkOperator", "networkOperator.repository is required")
+		}
+
+		if config.NetworkOperator.ComponentVersion == "" {
+			errs.add("networkOperator", "networkOperator.componentVersion is required")
+		}
+
+		if config.NetworkOperator.Namespace == "" {
+			errs.add("networkOperator", "networkOperator.namespace is required")
+		}
+		validateNamespaces(config.NetworkOperator.Namespaces, &errs)
+
+		validateContainerResources(config.NetworkOperator.ContainerResources, &errs)
+		if config.NetworkOperator.DaemonSets != nil {
+			validateDaemonSets(config.NetworkOperator.DaemonSets, &errs)
+		}
+		validateImageDigests(config.NetworkOperator.ImageDigests, &errs)
+		validateObjectNames(config, &errs)
+	}
+	validateAnnotations(config.Annotations, &errs)
 
 	// Validate profile-specific requirements based on the selected profile
 	if profile == "host-device-rdma" || profile == "hostdevice" {
-		if config.Hostdev.ResourceName == "" {
-			return fmt.Errorf("hostdev.resourceName is required for hostdevice profiles")
+		if config.Hostdev == nil {
+			errs.add("hostdev", "hostdev section is required for hostdevice profiles")
+		} else {
+			if config.Hostdev.ResourceName == "" {
+				errs.add("hostdev", "hostdev.resourceName is required for hostdevice profiles")
+			}
+			if config.Hostdev.NetworkName == "" {
+				errs.add("hostdev", "hostdev.networkName is required for hostdevice profiles")
+			}
+		}
+	}
+
+	if slices.Contains(SriovProfiles, profile) {
+		if config.Sriov == nil {
+			errs.add("sriov", "sriov section is required for SR-IOV profiles")
+		} else {
+			if len(config.Sriov.Pools) == 0 {
+				if config.Sriov.ResourceName == "" {
+					errs.add("sriov", "sriov.resourceName is required for SR-IOV profiles")
+				}
+				if config.Sriov.NetworkName == "" {
+					errs.add("sriov", "sriov.networkName is required for SR-IOV profiles")
+				}
+			} else {
+				validateSriovPools(config.Sriov, &errs)
+			}
+			// The per-PF VF configuration is rendered into the policy of each PF, which only multirail profiles create
+			if len(config.Sriov.Devices) > 0 && (config.Profile == nil || !config.Profile.Multirail) {
+				errs.add("sriov", "sriov.devices requires a multirail profile, the VFs of every PF are configured by sriov.numVfs otherwise")
+			}
+			for i, device := range config.Sriov.Devices {
+				if device.PciAddress == "" {
+					errs.add("sriov", "sriov.devices[%d].pciAddress is required", i)
+				}
+				if device.NumVfs < 0 {
+					errs.add("sriov", "sriov.devices[%d].numVfs must not be negative", i)
+				}
+				if device.VfRange != "" {
+					_, last, err := ParseVfRange(device.VfRange)
+					if err != nil {
+						errs.add("sriov", "sriov.devices[%d].vfRange: %v", i, err)
+					} else if device.NumVfs > 0 && last >= device.NumVfs {
+						errs.add("sriov", "sriov.devices[%d].vfRange %s exceeds numVfs %d", i, device.VfRange, device.NumVfs)
+					}
+				}
+			}
+		}
+	}
+
+	if config.Profile != nil && config.Profile.SpectrumX {
+		validateQos(config.Qos, &errs)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// Typical MTU range of the networks, values outside of it are most likely typos
+const (
+	// MaxTypicalEthernetMtu is the usual size of Ethernet jumbo frames
+	MaxTypicalEthernetMtu = 9000
+	// MaxTypicalInfinibandMtu is the largest InfiniBand MTU
+	MaxTypicalInfinibandMtu = 4096
+	// MinTypicalMtu is the minimum MTU of IPv6 links
+	MinTypicalMtu = 1280
+)
+
+// MtuWarnings reports the MTUs of the networks rendered by a profile of the fabric and deployment type that
+// are valid but unusual. Unlike ValidateClusterConfig, the warnings don't prevent the generation.
+func MtuWarnings(config *LaunchKubernetesConfig, fabric, deployment string) []string {
+	if config.Sriov == nil {
+		return nil
+	}
+
+	var field, network string
+	var mtu, maxMtu int
+	switch {
+	case fabric == "ethernet" && deployment == "sriov":
+		field, network, mtu, maxMtu = "sriov.ethernetMtu", "SR-IOV Ethernet", config.Sriov.EthernetMtu, MaxTypicalEthernetMtu
+	case fabric == "ethernet" && deployment == "rdma_shared":
+		field, network, mtu, maxMtu = "sriov.ethernetMtu", "macvlan", config.Sriov.EthernetMtu, MaxTypicalEthernetMtu
+	case fabric == "infiniband" && deployment == "sriov":
+		field, network, mtu, maxMtu = "sriov.infinibandMtu", "SR-IOV InfiniBand", config.Sriov.InfinibandMtu, MaxTypicalInfinibandMtu
+	default:
+		// The host device and IPoIB networks use the MTU of the PF
+		return nil
+	}
+
+	switch {
+	case mtu > maxMtu:
+		return []string{fmt.Sprintf("%s %d of the %s networks exceeds the typical maximum of %d", field, mtu, network, maxMtu)}
+	case mtu > 0 && mtu < MinTypicalMtu:
+		return []string{fmt.Sprintf("%s %d of the %s networks is below the typical minimum of %d", field, mtu, network, MinTypicalMtu)}
+	}
+	return nil
+}
+
+// validateQos validates the RoCE QoS settings of Spectrum-X, DefaultQos applies without a qos section
+func validateQos(qos *QosConfig, errs *ValidationErrors) {
+	if qos == nil {
+		return
+	}
+
+	if !slices.Contains(QosTrustModes, qos.Trust) {
+		errs.add("qos", "qos.trust %q must be one of %s", qos.Trust, strings.Join(QosTrustModes, ", "))
+	}
+	if len(qos.PfcPriorities) == 0 {
+		errs.add("qos", "qos.pfcPriorities must enable PFC on at least one priority")
+	}
+	for i, priority := range qos.PfcPriorities {
+		if priority < 0 || priority > MaxQosPriority {
+			errs.add("qos", "qos.pfcPriorities[%d] %d must be between 0 and %d", i, priority, MaxQosPriority)
+		} else if slices.Index(qos.PfcPriorities, priority) != i {
+			errs.add("qos", "qos.pfcPriorities[%d] %d is not unique", i, priority)
 		}
-		if config.Hostdev.NetworkName == "" {
-			return fmt.Errorf("hostdev.networkName is required for hostdevice profiles")
+	}
+}
+
+// validateContainerResources validates the names and quantities of the container resources
+func validateContainerResources(containers []ContainerResourcesConfig, errs *ValidationErrors) {
+	names := map[string]bool{}
+	for i, container := range containers {
+		field := fmt.Sprintf("networkOperator.containerResources[%d]", i)
+		if container.Name == "" {
+			errs.add("networkOperator", "%s.name is required", field)
+		} else if !slices.Contains(ContainerNames, container.Name) {
+			errs.add("networkOperator", "%s.name %s is not a known container, expected one of %s", field, container.Name, strings.Join(ContainerNames, ", "))
+		} else if names[container.Name] {
+			errs.add("networkOperator", "%s.name %s is not unique", field, container.Name)
+		}
+		names[container.Name] = true
+
+		requests := parseResourceValues(container.Requests, field+".requests", errs)
+		limits := parseResourceValues(container.Limits, field+".limits", errs)
+		for _, resourceName := range []string{"cpu", "memory"} {
+			request, hasRequest := requests[resourceName]
+			limit, hasLimit := limits[resourceName]
+			if hasRequest && hasLimit && request.Cmp(limit) > 0 {
+				errs.add("networkOperator", "%s.requests.%s %s exceeds the limit %s", field, resourceName, request.String(), limit.String())
+			}
 		}
 	}
+}
 
-	if profile == "sriov-rdma" || profile == "sriov-ib-rdma" {
-		if config.Sriov.ResourceName == "" {
-			return fmt.Errorf("sriov.resourceName is required for SR-IOV profiles")
+// validateNamespaces validates the components and the names of the namespace overrides
+func validateNamespaces(namespaces map[string]string, errs *ValidationErrors) {
+	for _, component := range slices.Sorted(maps.Keys(namespaces)) {
+		if !slices.Contains(NamespaceComponents, component) {
+			errs.add("networkOperator", "networkOperator.namespaces has unknown component %q, expected one of %s", component, strings.Join(NamespaceComponents, ", "))
+			continue
 		}
-		if config.Sriov.NetworkName == "" {
-			return fmt.Errorf("sriov.networkName is required for SR-IOV profiles")
+		for _, msg := range validation.IsDNS1123Label(namespaces[component]) {
+			errs.add("networkOperator", "networkOperator.namespaces[%s] %q is invalid: %s", component, namespaces[component], msg)
 		}
 	}
+}
 
-	return nil
+// validateObjectNames checks that the object names remain valid DNS-1123 subdomains with the configured prefix and suffix
+func validateObjectNames(config *LaunchKubernetesConfig, errs *ValidationErrors) {
+	operator := config.NetworkOperator
+	if operator.NamePrefix == "" && operator.NameSuffix == "" {
+		return
+	}
+
+	// Also covers the names set by the templates, e.g. of the test pods
+	if msgs := validation.IsDNS1123Subdomain(operator.ObjectName("name")); len(msgs) > 0 {
+		errs.add("networkOperator", "networkOperator.namePrefix %q and nameSuffix %q do not form valid object names: %s", operator.NamePrefix, operator.NameSuffix, strings.Join(msgs, "; "))
+		return
+	}
+
+	names := config.objectNames()
+	for _, field := range slices.Sorted(maps.Keys(names)) {
+		name := operator.ObjectName(names[field])
+		for _, msg := range validation.IsDNS1123Subdomain(name) {
+			errs.add("networkOperator", "%s with the name prefix and suffix, %q, is not a valid object name: %s", field, name, msg)
+		}
+	}
+}
+
+// validateImageDigests validates that the pinned digests are in the sha256:<hex> form
+func validateImageDigests(digests map[string]string, errs *ValidationErrors) {
+	images := make([]string, 0, len(digests))
+	for image := range digests {
+		images = append(images, image)
+	}
+	slices.Sort(images)
+	for _, image := range images {
+		if image == "" {
+			errs.add("networkOperator", "networkOperator.imageDigests keys must be image names")
+		} else if !slices.Contains(ImageNames, image) {
+			errs.add("networkOperator", "networkOperator.imageDigests[%s] is not a known image, expected one of %s", image, strings.Join(ImageNames, ", "))
+		} else if !imageDigestPattern.MatchString(digests[image]) {
+			errs.add("networkOperator", "networkOperator.imageDigests[%s] %q must be a digest in the sha256:<64 hex characters> form", image, digests[image])
+		}
+	}
+}
+
+// systemPriorityClassPrefix is reserved to the built-in systemPriorityClasses
+const systemPriorityClassPrefix = "system-"
+
+// systemPriorityClasses are the PriorityClasses every cluster has
+var systemPriorityClasses = []string{"system-node-critical", "system-cluster-critical"}
+
+// maxUserPriority is the highest value of a user-defined PriorityClass
+const maxUserPriority = 1000000000
+
+// validateDaemonSets validates the node selector labels and the tolerations of the DaemonSets
+func validateDaemonSets(daemonSets *DaemonSetsConfig, errs *ValidationErrors) {
+	keys := make([]string, 0, len(daemonSets.NodeSelector))
+	for key := range daemonSets.NodeSelector {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		for _, msg := range validation.IsQualifiedName(key) {
+			errs.add("networkOperator", "networkOperator.daemonSets.nodeSelector key %q is invalid: %s", key, msg)
+		}
+		for _, msg := range validation.IsValidLabelValue(daemonSets.NodeSelector[key]) {
+			errs.add("networkOperator", "networkOperator.daemonSets.nodeSelector[%s] value %q is invalid: %s", key, daemonSets.NodeSelector[key], msg)
+		}
+	}
+
+	for i, toleration := range daemonSets.Tolerations {
+		field := fmt.Sprintf("networkOperator.daemonSets.tolerations[%d]", i)
+		if toleration.Key != "" {
+			for _, msg := range validation.IsQualifiedName(toleration.Key) {
+				errs.add("networkOperator", "%s.key %q is invalid: %s", field, toleration.Key, msg)
+			}
+		}
+
+		switch corev1.TolerationOperator(toleration.OperatorOrDefault()) {
+		case corev1.TolerationOpEqual:
+			if toleration.Key == "" {
+				errs.add("networkOperator", "%s.key is required with the Equal operator", field)
+			}
+			for _, msg := range validation.IsValidLabelValue(toleration.Value) {
+				errs.add("networkOperator", "%s.value %q is invalid: %s", field, toleration.Value, msg)
+			}
+		case corev1.TolerationOpExists:
+			if toleration.Value != "" {
+				errs.add("networkOperator", "%s.value must be empty with the Exists operator", field)
+			}
+		default:
+			errs.add("networkOperator", "%s.operator must be Equal or Exists", field)
+		}
+
+		switch corev1.TaintEffect(toleration.Effect) {
+		case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+		default:
+			errs.add("networkOperator", "%s.effect must be NoSchedule, PreferNoSchedule or NoExecute", field)
+		}
+		if toleration.TolerationSeconds != nil && corev1.TaintEffect(toleration.Effect) != corev1.TaintEffectNoExecute {
+			errs.add("networkOperator", "%s.tolerationSeconds requires the NoExecute effect", field)
+		}
+	}
+
+	validatePriorityClass(daemonSets, errs)
+}
+
+// validatePriorityClass validates the PriorityClass of the DaemonSets, and the one generated when requested
+func validatePriorityClass(daemonSets *DaemonSetsConfig, errs *ValidationErrors) {
+	name := daemonSets.PriorityClassName
+	if name == "" {
+		if daemonSets.PriorityClass != nil {
+			errs.add("networkOperator", "networkOperator.daemonSets.priorityClass requires networkOperator.daemonSets.priorityClassName")
+		}
+		return
+	}
+
+	for _, msg := range validation.IsDNS1123Subdomain(name) {
+		errs.add("networkOperator", "networkOperator.daemonSets.priorityClassName %q is invalid: %s", name, msg)
+	}
+	if strings.HasPrefix(name, systemPriorityClassPrefix) {
+		if !slices.Contains(systemPriorityClasses, name) {
+			errs.add("networkOperator", "networkOperator.daemonSets.priorityClassName %q must be one of %s, the %s prefix is reserved", name, strings.Join(systemPriorityClasses, ", "), systemPriorityClassPrefix)
+		} else if daemonSets.PriorityClass != nil {
+			errs.add("networkOperator", "networkOperator.daemonSets.priorityClass cannot be used with the built-in PriorityClass %s", name)
+		}
+	}
+	if daemonSets.PriorityClass != nil && daemonSets.PriorityClass.Value > maxUserPriority {
+		errs.add("networkOperator", "networkOperator.daemonSets.priorityClass.value must be at most %d", maxUserPriority)
+	}
+}
+
+// parseResourceValues parses the set quantities, reporting the ones that are not valid quantities
+func parseResourceValues(values ResourceValues, field string, errs *ValidationErrors) map[string]resource.Quantity {
+	quantities := map[string]resource.Quantity{}
+	for _, value := range []struct{ name, quantity string }{{"cpu", values.CPU}, {"memory", values.Memory}} {
+		if value.quantity == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(value.quantity)
+		if err != nil {
+			errs.add("networkOperator", "%s.%s %q is not a valid quantity", field, value.name, value.quantity)
+			continue
+		}
+		quantities[value.name] = quantity
+	}
+	return quantities
+}
+
+// validateSriovPools validates the named SR-IOV definitions of the config
+func validateSriovPools(sriov *SriovConfig, errs *ValidationErrors) {
+	if sriov.ResourceName != "" || sriov.NetworkName != "" {
+		errs.add("sriov", "sriov.resourceName and sriov.networkName cannot be used together with sriov.pools")
+	}
+
+	names := map[string]bool{}
+	resources := map[string]bool{}
+	owners := map[string]string{}
+	for i, pool := range sriov.Pools {
+		if pool.Name == "" {
+			errs.add("sriov", "sriov.pools[%d].name is required", i)
+		} else if names[pool.Name] {
+			errs.add("sriov", "sriov.pools[%d].name %s is not unique", i, pool.Name)
+		}
+		names[pool.Name] = true
+
+		if pool.ResourceName == "" {
+			errs.add("sriov", "sriov.pools[%d].resourceName is required", i)
+		} else if resources[pool.ResourceName] {
+			errs.add("sriov", "sriov.pools[%d].resourceName %s is not unique", i, pool.ResourceName)
+		}
+		resources[pool.ResourceName] = true
+
+		if pool.NetworkName == "" {
+			errs.add("sriov", "sriov.pools[%d].networkName is required", i)
+		}
+		if pool.NumVfs < 0 {
+			errs.add("sriov", "sriov.pools[%d].numVfs must not be negative", i)
+		}
+		if len(sriov.Pools) > 1 && len(pool.PciAddresses) == 0 {
+			errs.add("sriov", "sriov.pools[%d].pciAddresses is required when several pools are defined", i)
+		}
+		for _, pciAddress := range pool.PciAddresses {
+			if owner, ok := owners[pciAddress]; ok {
+				errs.add("sriov", "sriov.pools[%d]: PF %s is already used by pool %s", i, pciAddress, owner)
+				continue
+			}
+			owners[pciAddress] = pool.Name
+		}
+	}
 }
@@ -19,6 +19,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -210,6 +211,22 @@ func TestValidateClusterConfig(t *testing.T) {
 		assert.Contains(t, err.Error(), "sriov.networkName is required")
 	})
 
+	t.Run("require the sriov section for every SR-IOV profile", func(t *testing.T) {
+		for _, profile := range SriovProfiles {
+			config := &LaunchKubernetesConfig{
+				NetworkOperator: &NetworkOperatorConfig{
+					Version:          "v25.10.0",
+					ComponentVersion: "network-operator-v25.10.0",
+					Repository:       "nvcr.io/nvidia/mellanox",
+					Namespace:        "nvidia-network-operator",
+				},
+			}
+
+			err := ValidateClusterConfig(config, profile)
+			assert.ErrorContains(t, err, "sriov section is required for SR-IOV profiles", profile)
+		}
+	})
+
 	t.Run("validate hostdev profile with missing resource name", func(t *testing.T) {
 		config := &LaunchKubernetesConfig{
 			NetworkOperator: &NetworkOperatorConfig{
@@ -252,6 +269,125 @@ func TestValidateClusterConfig(t *testing.T) {
 	})
 }
 
+func TestValidateClusterConfigReportsAllErrors(t *testing.T) {
+	t.Run("report every problem grouped by section", func(t *testing.T) {
+		config := &LaunchKubernetesConfig{
+			NetworkOperator: &NetworkOperatorConfig{
+				Version:          "v25.10.0",
+				ComponentVersion: "", // Missing
+				Repository:       "", // Missing
+				Namespace:        "nvidia-network-operator",
+			},
+			Sriov: &SriovConfig{
+				ResourceName: "", // Missing
+				NetworkName:  "", // Missing
+			},
+		}
+
+		err := ValidateClusterConfig(config, "sriov-ethernet-rdma")
+		require.Error(t, err)
+
+		var validationErrs ValidationErrors
+		require.ErrorAs(t, err, &validationErrs)
+		assert.Len(t, validationErrs, 4)
+		assert.Equal(t, []string{"networkOperator", "sriov"}, validationErrs.Sections())
+		assert.Len(t, validationErrs.InSection("networkOperator"), 2)
+		assert.Len(t, validationErrs.InSection("sriov"), 2)
+
+		assert.Contains(t, err.Error(), "networkOperator.repository is required")
+		assert.Contains(t, err.Error(), "networkOperator.componentVersion is required")
+		assert.Contains(t, err.Error(), "sriov.resourceName is required")
+		assert.Contains(t, err.Error(), "sriov.networkName is required")
+	})
+
+	t.Run("report missing profile sections instead of panicking", func(t *testing.T) {
+		config := &LaunchKubernetesConfig{}
+
+		err := ValidateClusterConfig(config, "host-device-rdma")
+		require.Error(t, err)
+
+		var validationErrs ValidationErrors
+		require.ErrorAs(t, err, &validationErrs)
+		assert.Equal(t, []string{"networkOperator", "hostdev"}, validationErrs.Sections())
+		assert.Contains(t, err.Error(), "networkOperator section is required")
+		assert.Contains(t, err.Error(), "hostdev section is required")
+	})
+}
+
+func TestSriovDeviceConfig(t *testing.T) {
+	t.Run("numVfs shorthand applies to PFs without device configuration", func(t *testing.T) {
+		sriov := &SriovConfig{NumVfs: 8}
+		assert.Equal(t, 8, sriov.NumVfsFor("0000:08:00.0"))
+		assert.Equal(t, "", sriov.VfRangeFor("0000:08:00.0"))
+	})
+
+	t.Run("VF range defines the number of VFs", func(t *testing.T) {
+		sriov := &SriovConfig{
+			NumVfs: 4,
+			Devices: []SriovDeviceConfig{
+				{PciAddress: "0000:08:00.0", VfRange: "0-7"},
+				{PciAddress: "0000:08:00.1", NumVfs: 16, VfRange: "8-15"},
+			},
+		}
+		assert.Equal(t, 8, sriov.NumVfsFor("0000:08:00.0"))
+		assert.Equal(t, "", sriov.VfRangeFor("0000:08:00.0"))
+		assert.Equal(t, 16, sriov.NumVfsFor("0000:08:00.1"))
+		assert.Equal(t, "8-15", sriov.VfRangeFor("0000:08:00.1"))
+		assert.Equal(t, 4, sriov.NumVfsFor("0000:3b:00.0"))
+	})
+
+	t.Run("validate invalid device configuration", func(t *testing.T) {
+		config := &LaunchKubernetesConfig{
+			NetworkOperator: &NetworkOperatorConfig{
+				ComponentVersion: "network-operator-v25.10.0",
+				Repository:       "nvcr.io/nvidia/mellanox",
+				Namespace:        "nvidia-network-operator",
+			},
+			Sriov: &SriovConfig{
+				NumVfs:       8,
+				ResourceName: "sriov_resource",
+				NetworkName:  "sriov_network",
+				Devices: []SriovDeviceConfig{
+					{VfRange: "0-3"},
+					{PciAddress: "0000:08:00.1", VfRange: "7-2"},
+					{PciAddress: "0000:3b:00.0", NumVfs: 4, VfRange: "0-7"},
+				},
+			},
+			Profile: &Profile{Multirail: true},
+		}
+
+		err := ValidateClusterConfig(config, "sriov-ethernet-rdma")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "sriov.devices[0].pciAddress is required")
+		assert.Contains(t, err.Error(), "sriov.devices[1].vfRange")
+		assert.Contains(t, err.Error(), "sriov.devices[2].vfRange 0-7 exceeds numVfs 4")
+		assert.NotContains(t, err.Error(), "requires a multirail profile")
+	})
+
+	t.Run("reject device configuration without multirail", func(t *testing.T) {
+		config := &LaunchKubernetesConfig{
+			NetworkOperator: &NetworkOperatorConfig{
+				ComponentVersion: "network-operator-v25.10.0",
+				Repository:       "nvcr.io/nvidia/mellanox",
+				Namespace:        "nvidia-network-operator",
+			},
+			Sriov: &SriovConfig{
+				NumVfs:       8,
+				ResourceName: "sriov_resource",
+				NetworkName:  "sriov_network",
+				Devices:      []SriovDeviceConfig{{PciAddress: "0000:08:00.0", NumVfs: 4}},
+			},
+			Profile: &Profile{Fabric: "ethernet", Deployment: "sriov"},
+		}
+
+		err := ValidateClusterConfig(config, "sriov-ethernet-rdma")
+		assert.ErrorContains(t, err, "sriov.devices requires a multirail profile")
+
+		config.Profile.Multirail = true
+		assert.NoError(t, ValidateClusterConfig(config, "sriov-ethernet-rdma"))
+	})
+}
+
 func TestSriovConfig(t *testing.T) {
 	t.Run("verify separate MTU fields in struct", func(t *testing.T) {
 		config := &SriovConfig{
@@ -289,3 +425,414 @@ func TestSriovConfig(t *testing.T) {
 		assert.Equal(t, 4000, infinibandConfig.InfinibandMtu)
 	})
 }
+
+func TestSriovPoolConfig(t *testing.T) {
+	t.Run("single resource and network are a single definition", func(t *testing.T) {
+		sriov := &SriovConfig{NumVfs: 8, ResourceName: "sriov_resource", NetworkName: "sriov_network"}
+		assert.Equal(t, []SriovPoolConfig{{ResourceName: "sriov_resource", NetworkName: "sriov_network", NumVfs: 8}}, sriov.Definitions())
+		assert.Equal(t, "", sriov.Definitions()[0].NameSuffix())
+		assert.Equal(t, "sriov_resource", sriov.PoolFor("0000:08:00.0").ResourceName)
+	})
+
+	t.Run("pools define their PFs and VFs", func(t *testing.T) {
+		sriov := &SriovConfig{
+			NumVfs: 8,
+			Pools: []SriovPoolConfig{
+				{Name: "compute", ResourceName: "compute_resource", NetworkName: "compute_network", PciAddresses: []string{"0000:08:00.0"}},
+				{Name: "storage", ResourceName: "storage_resource", NetworkName: "storage_network", NumVfs: 4, PciAddresses: []string{"0000:08:00.1"}},
+			},
+		}
+
+		definitions := sriov.Definitions()
+		require.Len(t, definitions, 2)
+		assert.Equal(t, 8, definitions[0].NumVfs)
+		assert.Equal(t, "-storage", definitions[1].NameSuffix())
+		assert.Equal(t, "storage", sriov.PoolFor("0000:08:00.1").Name)
+		assert.Nil(t, sriov.PoolFor("0000:3b:00.0"))
+		assert.Equal(t, 4, sriov.NumVfsFor("0000:08:00.1"))
+		assert.Equal(t, 8, sriov.NumVfsFor("0000:08:00.0"))
+	})
+
+	t.Run("validate invalid pools", func(t *testing.T) {
+		config := &LaunchKubernetesConfig{
+			NetworkOperator: &NetworkOperatorConfig{
+				ComponentVersion: "network-operator-v25.10.0",
+				Repository:       "nvcr.io/nvidia/mellanox",
+				Namespace:        "nvidia-network-operator",
+			},
+			Sriov: &SriovConfig{
+				NumVfs:       8,
+				ResourceName: "sriov_resource",
+				Pools: []SriovPoolConfig{
+					{Name: "compute", ResourceName: "compute_resource", NetworkName: "compute_network", PciAddresses: []string{"0000:08:00.0"}},
+					{Name: "compute", ResourceName: "compute_resource", PciAddresses: []string{"0000:08:00.0"}},
+					{ResourceName: "storage_resource", NetworkName: "storage_network"},
+				},
+			},
+		}
+
+		err := ValidateClusterConfig(config, "sriov-ethernet-rdma")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be used together with sriov.pools")
+		assert.Contains(t, err.Error(), "sriov.pools[1].name compute is not unique")
+		assert.Contains(t, err.Error(), "sriov.pools[1].resourceName compute_resource is not unique")
+		assert.Contains(t, err.Error(), "sriov.pools[1].networkName is required")
+		assert.Contains(t, err.Error(), "sriov.pools[1]: PF 0000:08:00.0 is already used by pool compute")
+		assert.Contains(t, err.Error(), "sriov.pools[2].name is required")
+		assert.Contains(t, err.Error(), "sriov.pools[2].pciAddresses is required when several pools are defined")
+		assert.NotContains(t, err.Error(), "sriov.networkName is required")
+	})
+}
+
+func TestContainerResourcesConfig(t *testing.T) {
+	newConfig := func(containers ...ContainerResourcesConfig) *LaunchKubernetesConfig {
+		return &LaunchKubernetesConfig{
+			NetworkOperator: &NetworkOperatorConfig{
+				ComponentVersion:   "network-operator-v25.10.0",
+				Repository:         "nvcr.io/nvidia/mellanox",
+				Namespace:          "nvidia-network-operator",
+				ContainerResources: containers,
+			},
+		}
+	}
+
+	t.Run("accept valid quantities", func(t *testing.T) {
+		config := newConfig(
+			ContainerResourcesConfig{Name: "mofed-container", Requests: ResourceValues{CPU: "500m", Memory: "1Gi"}, Limits: ResourceValues{CPU: "2", Memory: "4Gi"}},
+			ContainerResourcesConfig{Name: "kube-multus", Limits: ResourceValues{Memory: "256Mi"}},
+		)
+		assert.NoError(t, ValidateClusterConfig(config, "macvlan-rdma-shared"))
+	})
+
+	t.Run("reject invalid quantities", func(t *testing.T) {
+		config := newConfig(
+			ContainerResourcesConfig{Name: "mofed-container", Requests: ResourceValues{CPU: "half", Memory: "1Gi"}, Limits: ResourceValues{Memory: "512Mi"}},
+			ContainerResourcesConfig{Name: "mofed-container", Limits: ResourceValues{Memory: "1GB of RAM"}},
+			ContainerResourcesConfig{Requests: ResourceValues{CPU: "100m"}},
+			ContainerResourcesConfig{Name: "mofed", Limits: ResourceValues{Memory: "1Gi"}},
+		)
+
+		err := ValidateClusterConfig(config, "macvlan-rdma-shared")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `networkOperator.containerResources[0].requests.cpu "half" is not a valid quantity`)
+		assert.Contains(t, err.Error(), "networkOperator.containerResources[0].requests.memory 1Gi exceeds the limit 512Mi")
+		assert.Contains(t, err.Error(), "networkOperator.containerResources[1].name mofed-container is not unique")
+		assert.Contains(t, err.Error(), `networkOperator.containerResources[1].limits.memory "1GB of RAM" is not a valid quantity`)
+		assert.Contains(t, err.Error(), "networkOperator.containerResources[2].name is required")
+		assert.Contains(t, err.Error(), "networkOperator.containerResources[3].name mofed is not a known container")
+	})
+}
+
+func TestDaemonSetsConfig(t *testing.T) {
+	newConfig := func(daemonSets *DaemonSetsConfig) *LaunchKubernetesConfig {
+		return &LaunchKubernetesConfig{
+			NetworkOperator: &NetworkOperatorConfig{
+				ComponentVersion: "network-operator-v25.10.0",
+				Repository:       "nvcr.io/nvidia/mellanox",
+				Namespace:        "nvidia-network-operator",
+				DaemonSets:       daemonSets,
+			},
+		}
+	}
+
+	t.Run("accept valid labels and tolerations", func(t *testing.T) {
+		seconds := int64(60)
+		config := newConfig(&DaemonSetsConfig{
+			NodeSelector: map[string]string{"feature.node.kubernetes.io/pci-15b3.present": "true", "nic": ""},
+			Tolerations: []TolerationConfig{
+				{Key: "nvidia.com/gpu", Operator: "Exists", Effect: "NoSchedule"},
+				{Key: "dedicated", Value: "networking", Effect: "NoExecute", TolerationSeconds: &seconds},
+				{Operator: "Exists"},
+			},
+		})
+		assert.NoError(t, ValidateClusterConfig(config, "macvlan-rdma-shared"))
+	})
+
+	t.Run("reject invalid labels and tolerations", func(t *testing.T) {
+		seconds := int64(60)
+		config := newConfig(&DaemonSetsConfig{
+			NodeSelector: map[string]string{"bad key": "true", "nic": "not valid!"},
+			Tolerations: []TolerationConfig{
+				{Value: "networking"},
+				{Key: "dedicated", Operator: "Exists", Value: "networking"},
+				{Key: "dedicated", Operator: "In", Effect: "Evict"},
+				{Key: "dedicated", Value: "networking", TolerationSeconds: &seconds},
+			},
+		})
+
+		err := ValidateClusterConfig(config, "macvlan-rdma-shared")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `networkOperator.daemonSets.nodeSelector key "bad key" is invalid`)
+		assert.Contains(t, err.Error(), `networkOperator.daemonSets.nodeSelector[nic] value "not valid!" is invalid`)
+		assert.Contains(t, err.Error(), "networkOperator.daemonSets.tolerations[0].key is required with the Equal operator")
+		assert.Contains(t, err.Error(), "networkOperator.daemonSets.tolerations[1].value must be empty with the Exists operator")
+		assert.Contains(t, err.Error(), "networkOperator.daemonSets.tolerations[2].operator must be Equal or Exists")
+		assert.Contains(t, err.Error(), "networkOperator.daemonSets.tolerations[2].effect must be NoSchedule, PreferNoSchedule or NoExecute")
+		assert.Contains(t, err.Error(), "networkOperator.daemonSets.tolerations[3].tolerationSeconds requires the NoExecute effect")
+	})
+
+	t.Run("accept valid priority classes", func(t *testing.T) {
+		for _, daemonSets := range []*DaemonSetsConfig{
+			{PriorityClassName: "system-node-critical"},
+			{PriorityClassName: "network-critical", PriorityClass: &PriorityClassConfig{Value: 1000000000}},
+		} {
+			assert.NoError(t, ValidateClusterConfig(newConfig(daemonSets), "macvlan-rdma-shared"), daemonSets.PriorityClassName)
+		}
+	})
+
+	t.Run("reject invalid priority classes", func(t *testing.T) {
+		for daemonSets, message := range map[*DaemonSetsConfig]string{
+			{PriorityClassName: "Network_Critical"}:                                                `networkOperator.daemonSets.priorityClassName "Network_Critical" is invalid`,
+			{PriorityClassName: "system-network"}:                                                  `must be one of system-node-critical, system-cluster-critical, the system- prefix is reserved`,
+			{PriorityClassName: "system-node-critical", PriorityClass: &PriorityClassConfig{}}:     "cannot be used with the built-in PriorityClass system-node-critical",
+			{PriorityClassName: "network", PriorityClass: &PriorityClassConfig{Value: 2000000000}}: "networkOperator.daemonSets.priorityClass.value must be at most 1000000000",
+			{PriorityClass: &PriorityClassConfig{Value: 1000}}:                                     "networkOperator.daemonSets.priorityClass requires networkOperator.daemonSets.priorityClassName",
+		} {
+			err := ValidateClusterConfig(newConfig(daemonSets), "macvlan-rdma-shared")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), message)
+		}
+	})
+}
+
+func TestImageDigestsConfig(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("ab", 32)
+	newConfig := func(digests map[string]string) *LaunchKubernetesConfig {
+		return &LaunchKubernetesConfig{
+			NetworkOperator: &NetworkOperatorConfig{
+				ComponentVersion: "network-operator-v25.10.0",
+				Repository:       "nvcr.io/nvidia/mellanox",
+				Namespace:        "nvidia-network-operator",
+				ImageDigests:     digests,
+			},
+		}
+	}
+
+	t.Run("accept sha256 digests", func(t *testing.T) {
+		config := newConfig(map[string]string{"doca-driver": digest, "multus-cni": digest})
+		assert.NoError(t, ValidateClusterConfig(config, "macvlan-rdma-shared"))
+		assert.Equal(t, digest, config.NetworkOperator.ImageVersion("doca-driver", "doca3.1.0"))
+		assert.Equal(t, "network-operator-v25.10.0", config.NetworkOperator.ImageVersion("plugins", "network-operator-v25.10.0"))
+	})
+
+	t.Run("reject malformed digests", func(t *testing.T) {
+		config := newConfig(map[string]string{
+			"doca-driver":     "doca3.1.0",
+			"multus-cni":      "sha256:" + strings.Repeat("AB", 32),
+			"nvidia-k8s-ipam": "sha512:" + strings.Repeat("ab", 64),
+			"plugins":         "sha256:abc",
+		})
+
+		err := ValidateClusterConfig(config, "macvlan-rdma-shared")
+		require.Error(t, err)
+		for _, image := range []string{"doca-driver", "multus-cni", "nvidia-k8s-ipam", "plugins"} {
+			assert.Contains(t, err.Error(), "networkOperator.imageDigests["+image+"]")
+		}
+		assert.Contains(t, err.Error(), "must be a digest in the sha256:<64 hex characters> form")
+	})
+
+	t.Run("reject unknown images", func(t *testing.T) {
+		config := newConfig(map[string]string{"doca-drivers": digest})
+
+		err := ValidateClusterConfig(config, "macvlan-rdma-shared")
+		assert.ErrorContains(t, err, "networkOperator.imageDigests[doca-drivers] is not a known image")
+	})
+}
+
+func TestNamespacesConfig(t *testing.T) {
+	newConfig := func(namespaces map[string]string) *LaunchKubernetesConfig {
+		return &LaunchKubernetesConfig{
+			NetworkOperator: &NetworkOperatorConfig{
+				ComponentVersion: "network-operator-v25.10.0",
+				Repository:       "nvcr.io/nvidia/mellanox",
+				Namespace:        "nvidia-network-operator",
+				Namespaces:       namespaces,
+			},
+		}
+	}
+
+	t.Run("default every component to the operator namespace", func(t *testing.T) {
+		config := newConfig(nil)
+		assert.NoError(t, ValidateClusterConfig(config, "macvlan-rdma-shared"))
+		for _, component := range NamespaceComponents {
+			assert.Equal(t, "nvidia-network-operator", config.NetworkOperator.ComponentNamespace(component))
+		}
+	})
+
+	t.Run("override the namespace of a component", func(t *testing.T) {
+		config := newConfig(map[string]string{"sriov": "sriov-network-operator"})
+		assert.NoError(t, ValidateClusterConfig(config, "macvlan-rdma-shared"))
+		assert.Equal(t, "sriov-network-operator", config.NetworkOperator.ComponentNamespace("sriov"))
+		assert.Equal(t, "nvidia-network-operator", config.NetworkOperator.ComponentNamespace("nvIpam"))
+	})
+
+	t.Run("reject unknown components and invalid namespaces", func(t *testing.T) {
+		config := newConfig(map[string]string{"multus": "kube-system", "nvIpam": "NV_IPAM"})
+
+		err := ValidateClusterConfig(config, "macvlan-rdma-shared")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `networkOperator.namespaces has unknown component "multus", expected one of nvIpam, sriov`)
+		assert.Contains(t, err.Error(), `networkOperator.namespaces[nvIpam] "NV_IPAM" is invalid`)
+	})
+}
+
+func TestObjectNamesConfig(t *testing.T) {
+	newConfig := func(prefix, suffix string) *LaunchKubernetesConfig {
+		return &LaunchKubernetesConfig{
+			NetworkOperator: &NetworkOperatorConfig{
+				ComponentVersion: "network-operator-v25.10.0",
+				Repository:       "nvcr.io/nvidia/mellanox",
+				Namespace:        "nvidia-network-operator",
+				NamePrefix:       prefix,
+				NameSuffix:       suffix,
+			},
+			NvIpam:  &NvIpamConfig{PoolName: "nv-ipam-pool"},
+			Macvlan: &MacvlanConfig{NetworkName: "macvlan-network"},
+		}
+	}
+
+	t.Run("apply the prefix and suffix to a copy of the config", func(t *testing.T) {
+		config := newConfig("team-a-", "-v2")
+		require.NoError(t, ValidateClusterConfig(config, "macvlan-rdma-shared"))
+
+		named := config.WithObjectNames()
+		assert.Equal(t, "team-a-nv-ipam-pool-v2", named.NvIpam.PoolName)
+		assert.Equal(t, "team-a-macvlan-network-v2", named.Macvlan.NetworkName)
+		assert.Equal(t, "team-a-macvlan-test-pod-v2", named.NetworkOperator.ObjectName("macvlan-test-pod"))
+		assert.Equal(t, "nv-ipam-pool", config.NvIpam.PoolName)
+		assert.Equal(t, "macvlan-network", config.Macvlan.NetworkName)
+	})
+
+	t.Run("keep the names without a prefix or suffix", func(t *testing.T) {
+		config := newConfig("", "")
+		assert.Same(t, config, config.WithObjectNames())
+	})
+
+	t.Run("reject an invalid prefix", func(t *testing.T) {
+		err := ValidateClusterConfig(newConfig("Team_A-", ""), "macvlan-rdma-shared")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `networkOperator.namePrefix "Team_A-" and nameSuffix "" do not form valid object names`)
+	})
+
+	t.Run("reject names that are invalid after the prefix is applied", func(t *testing.T) {
+		config := newConfig("team-a-", "")
+		config.Macvlan.NetworkName = "macvlan_network"
+
+		err := ValidateClusterConfig(config, "macvlan-rdma-shared")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `macvlan.networkName with the name prefix and suffix, "team-a-macvlan_network", is not a valid object name`)
+	})
+}
+
+func TestMtuWarnings(t *testing.T) {
+	newConfig := func(ethernetMtu, infinibandMtu int) *LaunchKubernetesConfig {
+		return &LaunchKubernetesConfig{Sriov: &SriovConfig{EthernetMtu: ethernetMtu, InfinibandMtu: infinibandMtu}}
+	}
+
+	t.Run("warn about MTUs above the typical maximum of the network", func(t *testing.T) {
+		config := newConfig(90000, 40000)
+		assert.Equal(t, []string{"sriov.ethernetMtu 90000 of the SR-IOV Ethernet networks exceeds the typical maximum of 9000"}, MtuWarnings(config, "ethernet", "sriov"))
+		assert.Equal(t, []string{"sriov.ethernetMtu 90000 of the macvlan networks exceeds the typical maximum of 9000"}, MtuWarnings(config, "ethernet", "rdma_shared"))
+		assert.Equal(t, []string{"sriov.infinibandMtu 40000 of the SR-IOV InfiniBand networks exceeds the typical maximum of 4096"}, MtuWarnings(config, "infiniband", "sriov"))
+	})
+
+	t.Run("warn at the boundaries of the typical range", func(t *testing.T) {
+		tests := []struct {
+			fabric, deployment string
+			ethernetMtu        int
+			infinibandMtu      int
+			warn               bool
+		}{
+			{fabric: "ethernet", deployment: "sriov", ethernetMtu: MaxTypicalEthernetMtu},
+			{fabric: "ethernet", deployment: "sriov", ethernetMtu: MaxTypicalEthernetMtu + 1, warn: true},
+			{fabric: "ethernet", deployment: "rdma_shared", ethernetMtu: MinTypicalMtu},
+			{fabric: "ethernet", deployment: "rdma_shared", ethernetMtu: MinTypicalMtu - 1, warn: true},
+			{fabric: "infiniband", deployment: "sriov", infinibandMtu: MaxTypicalInfinibandMtu},
+			{fabric: "infiniband", deployment: "sriov", infinibandMtu: MaxTypicalInfinibandMtu + 1, warn: true},
+			{fabric: "infiniband", deployment: "sriov", infinibandMtu: MinTypicalMtu - 1, warn: true},
+		}
+		for _, tt := range tests {
+			warnings := MtuWarnings(newConfig(tt.ethernetMtu, tt.infinibandMtu), tt.fabric, tt.deployment)
+			assert.Equal(t, tt.warn, len(warnings) == 1, "%+v: %v", tt, warnings)
+		}
+	})
+
+	t.Run("only check the MTU rendered by the profile", func(t *testing.T) {
+		assert.Empty(t, MtuWarnings(newConfig(1500, 40000), "ethernet", "sriov"))
+		assert.Empty(t, MtuWarnings(newConfig(90000, 40000), "infiniband", "rdma_shared"))
+		assert.Empty(t, MtuWarnings(newConfig(90000, 40000), "", "host_device"))
+		assert.Empty(t, MtuWarnings(newConfig(0, 0), "ethernet", "sriov"))
+		assert.Empty(t, MtuWarnings(&LaunchKubernetesConfig{}, "ethernet", "sriov"))
+	})
+}
+
+func TestQosConfig(t *testing.T) {
+	newConfig := func(qos *QosConfig, spectrumX bool) *LaunchKubernetesConfig {
+		return &LaunchKubernetesConfig{
+			NetworkOperator: &NetworkOperatorConfig{
+				ComponentVersion: "network-operator-v25.10.0",
+				Repository:       "nvcr.io/nvidia/mellanox",
+				Namespace:        "nvidia-network-operator",
+			},
+			Qos:     qos,
+			Profile: &Profile{Fabric: "ethernet", Deployment: "rdma_shared", SpectrumX: spectrumX},
+		}
+	}
+
+	t.Run("accept the Spectrum-X defaults", func(t *testing.T) {
+		assert.NoError(t, ValidateClusterConfig(newConfig(&QosConfig{Trust: "pfc", PfcPriorities: []int{3}}, true), "macvlan-rdma-shared"))
+		assert.Equal(t, "0,0,0,1,0,0,0,0", DefaultQos.PfcMask())
+	})
+
+	t.Run("apply the defaults without a qos section", func(t *testing.T) {
+		cfg := newConfig(nil, true)
+		assert.NoError(t, ValidateClusterConfig(cfg, "macvlan-rdma-shared"))
+		assert.Equal(t, DefaultQos, cfg.QosOrDefault())
+
+		qos := &QosConfig{Trust: "pfc", PfcPriorities: []int{3, 4}}
+		assert.Equal(t, *qos, newConfig(qos, true).QosOrDefault())
+	})
+
+	t.Run("only validate the qos section for Spectrum-X", func(t *testing.T) {
+		assert.NoError(t, ValidateClusterConfig(newConfig(&QosConfig{Trust: "none"}, false), "macvlan-rdma-shared"))
+	})
+
+	t.Run("reject invalid values", func(t *testing.T) {
+		qos := &QosConfig{Trust: "pcp", PfcPriorities: []int{3, 8, 3}}
+
+		err := ValidateClusterConfig(newConfig(qos, true), "macvlan-rdma-shared")
+		require.Error(t, err)
+		var validationErrs ValidationErrors
+		require.ErrorAs(t, err, &validationErrs)
+		assert.Equal(t, []string{"qos"}, validationErrs.Sections())
+		assert.Contains(t, err.Error(), `qos.trust "pcp" must be one of dscp, pfc`)
+		assert.Contains(t, err.Error(), "qos.pfcPriorities[1] 8 must be between 0 and 7")
+		assert.Contains(t, err.Error(), "qos.pfcPriorities[2] 3 is not unique")
+
+		assert.ErrorContains(t, ValidateClusterConfig(newConfig(&QosConfig{Trust: "dscp"}, true), "macvlan-rdma-shared"), "qos.pfcPriorities must enable PFC on at least one priority")
+	})
+}
+
+func TestNicGroups(t *testing.T) {
+	t.Run("group the PFs by NIC type", func(t *testing.T) {
+		cluster := &ClusterConfig{PFs: []PFConfig{
+			{PciAddress: "0000:08:00.0", NicType: "101d"},
+			{PciAddress: "0000:09:00.0", NicType: "a2dc"},
+			{PciAddress: "0000:08:00.1", NicType: "101d"},
+		}}
+
+		groups, err := cluster.NicGroups()
+		require.NoError(t, err)
+		assert.Equal(t, []NicGroup{
+			{NicType: "101d", PciAddresses: []string{"0000:08:00.0", "0000:08:00.1"}},
+			{NicType: "a2dc", PciAddresses: []string{"0000:09:00.0"}},
+		}, groups)
+	})
+
+	t.Run("require the NIC type of every PF", func(t *testing.T) {
+		cluster := &ClusterConfig{PFs: []PFConfig{{PciAddress: "0000:08:00.0", NicType: "101d"}, {PciAddress: "0000:08:00.1"}}}
+
+		_, err := cluster.NicGroups()
+		assert.EqualError(t, err, "clusterConfig.pfs[1] 0000:08:00.1 has no nicType, run the discovery again or set it")
+	})
+}
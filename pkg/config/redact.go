@@ -0,0 +1,75 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import "reflect"
+
+// RedactedValue replaces the sensitive values of a redacted config
+const RedactedValue = "<redacted>"
+
+// redactTag marks a sensitive field, e.g. `redact:"true"`. The strings of a tagged field, including the elements of
+// slices and the values of maps, are masked.
+const redactTag = "redact"
+
+// Redact returns a deep copy of cfg with the sensitive fields masked, to be saved, logged or bundled.
+// A nil cfg returns an empty config.
+func Redact(cfg *LaunchKubernetesConfig) LaunchKubernetesConfig {
+	if cfg == nil {
+		return LaunchKubernetesConfig{}
+	}
+	return redactValue(reflect.ValueOf(*cfg), false).Interface().(LaunchKubernetesConfig)
+}
+
+// redactValue returns a deep copy of v, with its strings masked when sensitive is set
+func redactValue(v reflect.Value, sensitive bool) reflect.Value {
+	out := reflect.New(v.Type()).Elem()
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			out.Set(redactValue(v.Elem(), sensitive).Addr())
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			out.Field(i).Set(redactValue(v.Field(i), sensitive || field.Tag.Get(redactTag) == "true"))
+		}
+	case reflect.Slice:
+		if !v.IsNil() {
+			out.Set(reflect.MakeSlice(v.Type(), v.Len(), v.Len()))
+			for i := 0; i < v.Len(); i++ {
+				out.Index(i).Set(redactValue(v.Index(i), sensitive))
+			}
+		}
+	case reflect.Map:
+		if !v.IsNil() {
+			out.Set(reflect.MakeMapWithSize(v.Type(), v.Len()))
+			iter := v.MapRange()
+			for iter.Next() {
+				out.SetMapIndex(iter.Key(), redactValue(iter.Value(), sensitive))
+			}
+		}
+	case reflect.String:
+		if sensitive && v.Len() > 0 {
+			out.SetString(RedactedValue)
+		} else {
+			out.Set(v)
+		}
+	default:
+		out.Set(v)
+	}
+	return out
+}
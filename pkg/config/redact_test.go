@@ -0,0 +1,78 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedact(t *testing.T) {
+	newConfig := func() *LaunchKubernetesConfig {
+		return &LaunchKubernetesConfig{
+			NetworkOperator: &NetworkOperatorConfig{
+				Repository: "nvcr.io/nvidia/mellanox",
+				Namespaces: map[string]string{"sriov": "sriov-system"},
+			},
+			NvIpam: &NvIpamConfig{
+				PoolName: "nv-ipam-pool",
+				Subnets:  []NvIpamSubnetConfig{{Subnet: "192.168.2.0/24", Gateway: "192.168.2.1"}, {}},
+			},
+			ClusterConfig: &ClusterConfig{
+				Capabilities: &ClusterCapabilities{Nodes: &NodesCapabilities{Sriov: true, Count: 2}},
+				PFs:          []PFConfig{{PciAddress: "0000:08:00.0", NetworkInterface: "ens1f0"}},
+				WorkerNodes:  []string{"worker-1", "worker-2"},
+			},
+		}
+	}
+
+	t.Run("mask the tagged fields", func(t *testing.T) {
+		redacted := Redact(newConfig())
+		assert.Equal(t, []NvIpamSubnetConfig{{Subnet: RedactedValue, Gateway: RedactedValue}, {}}, redacted.NvIpam.Subnets)
+		assert.Equal(t, []string{RedactedValue, RedactedValue}, redacted.ClusterConfig.WorkerNodes)
+	})
+
+	t.Run("preserve the untagged fields", func(t *testing.T) {
+		cfg := newConfig()
+		redacted := Redact(cfg)
+		assert.Equal(t, cfg.NetworkOperator, redacted.NetworkOperator)
+		assert.Equal(t, "nv-ipam-pool", redacted.NvIpam.PoolName)
+		assert.Equal(t, cfg.ClusterConfig.Capabilities, redacted.ClusterConfig.Capabilities)
+		assert.Equal(t, cfg.ClusterConfig.PFs, redacted.ClusterConfig.PFs)
+		assert.Nil(t, redacted.Sriov)
+	})
+
+	t.Run("return a deep copy", func(t *testing.T) {
+		cfg := newConfig()
+		redacted := Redact(cfg)
+		require.NotSame(t, cfg.NetworkOperator, redacted.NetworkOperator)
+
+		redacted.NetworkOperator.Namespaces["sriov"] = "changed"
+		redacted.ClusterConfig.PFs[0].PciAddress = "changed"
+		redacted.ClusterConfig.Capabilities.Nodes.Count = 5
+		assert.Equal(t, "sriov-system", cfg.NetworkOperator.Namespaces["sriov"])
+		assert.Equal(t, "0000:08:00.0", cfg.ClusterConfig.PFs[0].PciAddress)
+		assert.Equal(t, 2, cfg.ClusterConfig.Capabilities.Nodes.Count)
+		assert.Equal(t, []string{"worker-1", "worker-2"}, cfg.ClusterConfig.WorkerNodes)
+	})
+
+	t.Run("nil config", func(t *testing.T) {
+		assert.Equal(t, LaunchKubernetesConfig{}, Redact(nil))
+	})
+}
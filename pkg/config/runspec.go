@@ -0,0 +1,130 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"gopkg.in/yaml.v2"
+)
+
+// RunSpec is a single manifest holding both the cluster config and the profile selection intent
+type RunSpec struct {
+	Intent *RunIntent              `yaml:"intent"`
+	Config *LaunchKubernetesConfig `yaml:"config"`
+}
+
+// RunIntent selects the profile, either with the profile requirements or with a prompt for the LLM
+type RunIntent struct {
+	Fabric     string `yaml:"fabric,omitempty"`
+	Deployment string `yaml:"deployment,omitempty"`
+	Multirail  bool   `yaml:"multirail,omitempty"`
+	SpectrumX  bool   `yaml:"spectrumX,omitempty"`
+	Ai         bool   `yaml:"ai,omitempty"`
+	Prompt     string `yaml:"prompt,omitempty"`
+}
+
+// Profile returns the profile requirements of the intent, nil when the intent is a prompt
+func (i *RunIntent) Profile() *Profile {
+	if i.Prompt != "" {
+		return nil
+	}
+	return &Profile{
+		Fabric:     i.Fabric,
+		Deployment: i.Deployment,
+		Multirail:  i.Multirail,
+		SpectrumX:  i.SpectrumX,
+		Ai:         i.Ai,
+	}
+}
+
+// LoadRunSpec loads and validates the run spec at path
+func LoadRunSpec(path string, logger logr.Logger) (*RunSpec, error) {
+	logger.Info("Loading run spec", "path", path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read run spec %s: %w", path, err)
+	}
+
+	var spec RunSpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse run spec %s: %w", path, err)
+	}
+	if err := ValidateRunSpec(&spec); err != nil {
+		return nil, fmt.Errorf("invalid run spec %s: %w", path, err)
+	}
+
+	return &spec, nil
+}
+
+// ValidateRunSpec validates the intent and the profile independent parts of the config of the run spec.
+// The profile specific parts of the config are validated once the profile is selected.
+func ValidateRunSpec(spec *RunSpec) error {
+	errs := ValidationErrors{}
+
+	if spec.Intent == nil {
+		errs.add("intent", "intent section is required")
+	} else {
+		validateRunIntent(spec.Intent, &errs)
+	}
+
+	if spec.Config == nil {
+		errs.add("config", "config section is required")
+	} else {
+		var configErrs ValidationErrors
+		if errors.As(ValidateClusterConfig(spec.Config, ""), &configErrs) {
+			errs = append(errs, configErrs...)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// validateRunIntent validates that the intent holds either a prompt or complete profile requirements
+func validateRunIntent(intent *RunIntent, errs *ValidationErrors) {
+	if intent.Prompt != "" {
+		if intent.Fabric != "" || intent.Deployment != "" {
+			errs.add("intent", "intent.prompt cannot be used together with intent.fabric and intent.deployment")
+		}
+		return
+	}
+
+	if intent.Fabric == "" || intent.Deployment == "" {
+		errs.add("intent", "intent requires either a prompt or both fabric and deployment")
+	}
+	if intent.Fabric != "" && !slices.Contains([]string{"infiniband", "ethernet"}, intent.Fabric) {
+		errs.add("intent", "intent.fabric must be one of: infiniband, ethernet")
+	}
+	if intent.Deployment != "" {
+		for _, deployment := range strings.Split(intent.Deployment, ",") {
+			if !slices.Contains([]string{"sriov", "rdma_shared", "host_device"}, strings.TrimSpace(deployment)) {
+				errs.add("intent", "intent.deployment must be one or a comma-separated list of: sriov, rdma_shared, host_device")
+				break
+			}
+		}
+	}
+}
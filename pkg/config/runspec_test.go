@@ -0,0 +1,94 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const runSpecConfig = `config:
+  networkOperator:
+    componentVersion: network-operator-v25.10.0
+    repository: nvcr.io/nvidia/mellanox
+    namespace: nvidia-network-operator
+  sriov:
+    resourceName: sriov_resource
+    networkName: sriov_network
+  clusterConfig:
+    workerNodes:
+    - worker-1
+`
+
+func TestLoadRunSpec(t *testing.T) {
+	writeSpec := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "run-spec.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	t.Run("load the config and the profile requirements", func(t *testing.T) {
+		spec, err := LoadRunSpec(writeSpec(t, "intent:\n  fabric: ethernet\n  deployment: sriov\n  multirail: true\n"+runSpecConfig), logr.Discard())
+		require.NoError(t, err)
+
+		assert.Equal(t, &Profile{Fabric: "ethernet", Deployment: "sriov", Multirail: true}, spec.Intent.Profile())
+		assert.Equal(t, "nvidia-network-operator", spec.Config.NetworkOperator.Namespace)
+		assert.Equal(t, "sriov_network", spec.Config.Sriov.NetworkName)
+		assert.Equal(t, []string{"worker-1"}, spec.Config.ClusterConfig.WorkerNodes)
+	})
+
+	t.Run("load the config and the prompt", func(t *testing.T) {
+		spec, err := LoadRunSpec(writeSpec(t, "intent:\n  prompt: |\n    Use SR-IOV on the Ethernet NICs\n"+runSpecConfig), logr.Discard())
+		require.NoError(t, err)
+
+		assert.Equal(t, "Use SR-IOV on the Ethernet NICs\n", spec.Intent.Prompt)
+		assert.Nil(t, spec.Intent.Profile())
+		assert.Equal(t, "sriov_resource", spec.Config.Sriov.ResourceName)
+	})
+
+	t.Run("validate the intent and the config", func(t *testing.T) {
+		_, err := LoadRunSpec(writeSpec(t, "intent:\n  fabric: roce\nconfig:\n  networkOperator:\n    namespace: nvidia-network-operator\n"), logr.Discard())
+		require.Error(t, err)
+
+		var validationErrs ValidationErrors
+		require.ErrorAs(t, err, &validationErrs)
+		assert.Equal(t, []ValidationError{
+			{Section: "intent", Message: "intent requires either a prompt or both fabric and deployment"},
+			{Section: "intent", Message: "intent.fabric must be one of: infiniband, ethernet"},
+			{Section: "networkOperator", Message: "networkOperator.repository is required"},
+			{Section: "networkOperator", Message: "networkOperator.componentVersion is required"},
+		}, []ValidationError(validationErrs))
+	})
+
+	t.Run("reject a prompt together with the profile requirements", func(t *testing.T) {
+		_, err := LoadRunSpec(writeSpec(t, "intent:\n  prompt: Use SR-IOV\n  fabric: ethernet\n"+runSpecConfig), logr.Discard())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "intent.prompt cannot be used together with intent.fabric and intent.deployment")
+	})
+
+	t.Run("require both sections", func(t *testing.T) {
+		_, err := LoadRunSpec(writeSpec(t, "{}\n"), logr.Discard())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "intent section is required")
+		assert.Contains(t, err.Error(), "config section is required")
+	})
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ParseSetValue splits a --set value into the dotted path of the config field and its YAML value
+func ParseSetValue(value string) (string, string, error) {
+	path, fieldValue, ok := strings.Cut(value, "=")
+	if !ok || path == "" {
+		return "", "", fmt.Errorf("%q must be path=value, e.g. networkOperator.namespace=nvidia-network-operator", value)
+	}
+	for _, key := range strings.Split(path, ".") {
+		if key == "" {
+			return "", "", fmt.Errorf("%q has an empty key in path %s", value, path)
+		}
+	}
+	return path, fieldValue, nil
+}
+
+// ApplySetValues overrides the config fields of the path=value settings in order. A value is parsed as YAML,
+// so numbers and booleans keep their type, and a path naming no config field is an error.
+func ApplySetValues(cfg *LaunchKubernetesConfig, values []string) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	doc := map[interface{}]interface{}{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	for _, value := range values {
+		path, fieldValue, err := ParseSetValue(value)
+		if err != nil {
+			return err
+		}
+		var parsed interface{}
+		if err := yaml.Unmarshal([]byte(fieldValue), &parsed); err != nil {
+			return fmt.Errorf("invalid value of %s: %w", path, err)
+		}
+		if err := setPath(doc, strings.Split(path, "."), parsed); err != nil {
+			return fmt.Errorf("cannot set %s: %w", path, err)
+		}
+	}
+
+	data, err = yaml.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	var updated LaunchKubernetesConfig
+	if err := yaml.UnmarshalStrict(data, &updated); err != nil {
+		return fmt.Errorf("invalid config override: %w", err)
+	}
+	*cfg = updated
+	return nil
+}
+
+// Section returns the config section, or field, at the dotted path of its YAML keys, e.g. sriov or
+// networkOperator.daemonSets, as the generic YAML value the config file holds
+func Section(cfg *LaunchKubernetesConfig, path string) (interface{}, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	var value interface{} = map[interface{}]interface{}{}
+	if err := yaml.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	for _, key := range strings.Split(path, ".") {
+		section, ok := value.(map[interface{}]interface{})
+		if !ok {
+			return nil, fmt.Errorf("config has no section %s", path)
+		}
+		if value, ok = section[key]; !ok {
+			return nil, fmt.Errorf("config has no section %s", path)
+		}
+	}
+	return value, nil
+}
+
+// setPath sets the value at the keys of doc, creating the missing maps on the way
+func setPath(doc map[interface{}]interface{}, keys []string, value interface{}) error {
+	if len(keys) == 1 {
+		doc[keys[0]] = value
+		return nil
+	}
+
+	switch child := doc[keys[0]].(type) {
+	case nil:
+		nested := map[interface{}]interface{}{}
+		doc[keys[0]] = nested
+		return setPath(nested, keys[1:], value)
+	case map[interface{}]interface{}:
+		return setPath(child, keys[1:], value)
+	default:
+		return fmt.Errorf("%s is not a section", keys[0])
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplySetValues(t *testing.T) {
+	t.Run("override fields keeping the value types", func(t *testing.T) {
+		cfg := &LaunchKubernetesConfig{Sriov: &SriovConfig{NumVfs: 8, ResourceName: "sriov_resource"}}
+
+		require.NoError(t, ApplySetValues(cfg, []string{"sriov.numVfs=16", "networkOperator.namespace=custom", "cleanOutputDir=false"}))
+		assert.Equal(t, 16, cfg.Sriov.NumVfs)
+		assert.Equal(t, "sriov_resource", cfg.Sriov.ResourceName, "fields that are not set are kept")
+		require.NotNil(t, cfg.NetworkOperator, "missing sections are created")
+		assert.Equal(t, "custom", cfg.NetworkOperator.Namespace)
+		assert.False(t, cfg.CleansOutputDir())
+	})
+
+	t.Run("later values win", func(t *testing.T) {
+		cfg := &LaunchKubernetesConfig{}
+
+		require.NoError(t, ApplySetValues(cfg, []string{"sriov.numVfs=4", "sriov.numVfs=2"}))
+		assert.Equal(t, 2, cfg.Sriov.NumVfs)
+	})
+
+	t.Run("reject unknown fields", func(t *testing.T) {
+		cfg := &LaunchKubernetesConfig{}
+
+		err := ApplySetValues(cfg, []string{"sriov.unknown=1"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown")
+	})
+
+	t.Run("reject paths below a value", func(t *testing.T) {
+		cfg := &LaunchKubernetesConfig{Sriov: &SriovConfig{NumVfs: 8}}
+
+		err := ApplySetValues(cfg, []string{"sriov.numVfs.count=1"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot set sriov.numVfs.count: numVfs is not a section")
+	})
+
+	t.Run("reject malformed settings", func(t *testing.T) {
+		for _, value := range []string{"sriov.numVfs", "=8", "sriov..numVfs=8"} {
+			_, _, err := ParseSetValue(value)
+			assert.Error(t, err, value)
+		}
+	})
+}
+
+func TestSection(t *testing.T) {
+	cfg := &LaunchKubernetesConfig{Sriov: &SriovConfig{NumVfs: 8, ResourceName: "sriov_resource"}}
+
+	t.Run("return a section by its YAML keys", func(t *testing.T) {
+		section, err := Section(cfg, "sriov")
+		require.NoError(t, err)
+		require.IsType(t, map[interface{}]interface{}{}, section)
+		assert.Equal(t, 8, section.(map[interface{}]interface{})["numVfs"])
+		assert.Equal(t, "sriov_resource", section.(map[interface{}]interface{})["resourceName"])
+
+		numVfs, err := Section(cfg, "sriov.numVfs")
+		require.NoError(t, err)
+		assert.Equal(t, 8, numVfs)
+	})
+
+	t.Run("reject missing sections", func(t *testing.T) {
+		for _, path := range []string{"nvIpam", "sriov.unknown", "sriov.numVfs.value"} {
+			_, err := Section(cfg, path)
+			assert.Error(t, err, path)
+		}
+	})
+}
@@ -0,0 +1,199 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Sources of a resolved config field
+const (
+	// SourceDefault is a field left at its zero value, defaulted when the files are rendered
+	SourceDefault = "default"
+	// SourceFile is a field set by the config file, the discovered config or the run spec
+	SourceFile = "file"
+	// SourceFlag is a field set by a command line flag
+	SourceFlag = "flag"
+	// SourceLLM is a profile requirement selected by the LLM
+	SourceLLM = "llm"
+	// SourcePicker is a profile requirement chosen in the interactive picker
+	SourcePicker = "picker"
+)
+
+// FieldSource tells where the resolved value of a config field came from
+type FieldSource struct {
+	// Field is the dotted path of the field, e.g. sriov.mtu or clusterConfig.pfs[0].pciAddress
+	Field  string
+	Value  string
+	Source string
+	// Origin names the file, flag or prompt the value came from, empty for defaults
+	Origin string
+}
+
+// SourceTracker records the source of every config field as the config is loaded and overridden.
+// Its methods do nothing on a nil tracker, so that tracking is only paid for when explaining the config.
+type SourceTracker struct {
+	sources map[string]FieldSource
+}
+
+// NewSourceTracker returns a tracker of the fields of cfg, the fields already set are attributed to source
+func NewSourceTracker(cfg *LaunchKubernetesConfig, source, origin string) (*SourceTracker, error) {
+	fields, err := configFields(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &SourceTracker{sources: map[string]FieldSource{}}
+	for field, value := range fields {
+		if !isZero(value) {
+			t.sources[field] = FieldSource{Field: field, Source: source, Origin: origin}
+		}
+	}
+	return t, nil
+}
+
+// Record attributes field of cfg and every field below it to source
+func (t *SourceTracker) Record(cfg *LaunchKubernetesConfig, field, source, origin string) error {
+	if t == nil {
+		return nil
+	}
+
+	fields, err := configFields(cfg)
+	if err != nil {
+		return err
+	}
+	for name := range fields {
+		if name == field || strings.HasPrefix(name, field+".") || strings.HasPrefix(name, field+"[") {
+			t.sources[name] = FieldSource{Field: name, Source: source, Origin: origin}
+		}
+	}
+	return nil
+}
+
+// Track runs update on cfg and attributes the fields it changes to source, new fields left at their zero value
+// stay defaults
+func (t *SourceTracker) Track(cfg *LaunchKubernetesConfig, source, origin string, update func() error) error {
+	if t == nil {
+		return update()
+	}
+
+	before, err := configFields(cfg)
+	if err != nil {
+		return err
+	}
+	if err := update(); err != nil {
+		return err
+	}
+	after, err := configFields(cfg)
+	if err != nil {
+		return err
+	}
+
+	for field, value := range after {
+		previous, ok := before[field]
+		if ok && !reflect.DeepEqual(previous, value) || !ok && !isZero(value) {
+			t.sources[field] = FieldSource{Field: field, Source: source, Origin: origin}
+		}
+	}
+	return nil
+}
+
+// Explain returns every field of cfg with its value and source, sorted by field
+func (t *SourceTracker) Explain(cfg *LaunchKubernetesConfig) ([]FieldSource, error) {
+	if t == nil {
+		return nil, nil
+	}
+
+	fields, err := configFields(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	explained := make([]FieldSource, 0, len(fields))
+	for field, value := range fields {
+		source, ok := t.sources[field]
+		if !ok {
+			source = FieldSource{Field: field, Source: SourceDefault}
+		}
+		source.Value = fmt.Sprint(value)
+		if value == nil {
+			source.Value = ""
+		}
+		explained = append(explained, source)
+	}
+	sort.Slice(explained, func(i, j int) bool { return explained[i].Field < explained[j].Field })
+	return explained, nil
+}
+
+// configFields flattens cfg to its leaf fields, keyed by their dotted path
+func configFields(cfg *LaunchKubernetesConfig) (map[string]interface{}, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	fields := map[string]interface{}{}
+	flattenFields(fields, "", doc)
+	return fields, nil
+}
+
+// flattenFields adds the leaves of node below prefix to fields, empty maps and lists are leaves too
+func flattenFields(fields map[string]interface{}, prefix string, node interface{}) {
+	switch node := node.(type) {
+	case map[interface{}]interface{}:
+		if len(node) == 0 && prefix != "" {
+			fields[prefix] = node
+		}
+		for key, value := range node {
+			field := fmt.Sprint(key)
+			if prefix != "" {
+				field = prefix + "." + field
+			}
+			flattenFields(fields, field, value)
+		}
+	case []interface{}:
+		if len(node) == 0 {
+			fields[prefix] = node
+		}
+		for i, value := range node {
+			flattenFields(fields, fmt.Sprintf("%s[%d]", prefix, i), value)
+		}
+	default:
+		fields[prefix] = node
+	}
+}
+
+// isZero reports whether a leaf value is unset
+func isZero(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Map || v.Kind() == reflect.Slice {
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sourceOf returns the explained source of field
+func sourceOf(t *testing.T, explained []FieldSource, field string) FieldSource {
+	t.Helper()
+	for _, source := range explained {
+		if source.Field == field {
+			return source
+		}
+	}
+	require.Failf(t, "field not explained", "field %s", field)
+	return FieldSource{}
+}
+
+func TestSourceTracker(t *testing.T) {
+	cfg := &LaunchKubernetesConfig{
+		Sriov:         &SriovConfig{NumVfs: 8, ResourceName: "sriov_resource"},
+		ClusterConfig: &ClusterConfig{WorkerNodes: []string{"worker-1"}},
+	}
+
+	tracker, err := NewSourceTracker(cfg, SourceFile, "config.yaml")
+	require.NoError(t, err)
+	require.NoError(t, ApplySetValues(cfg, []string{"sriov.numVfs=16", "cleanOutputDir=false"}))
+	require.NoError(t, tracker.Record(cfg, "sriov.numVfs", SourceFlag, "--set"))
+	require.NoError(t, tracker.Record(cfg, "cleanOutputDir", SourceFlag, "--set"))
+	require.NoError(t, tracker.Track(cfg, SourceFlag, "--fabric", func() error {
+		cfg.Profile = &Profile{Fabric: "ethernet"}
+		return nil
+	}))
+
+	explained, err := tracker.Explain(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, FieldSource{Field: "sriov.numVfs", Value: "16", Source: SourceFlag, Origin: "--set"}, sourceOf(t, explained, "sriov.numVfs"))
+	assert.Equal(t, FieldSource{Field: "cleanOutputDir", Value: "false", Source: SourceFlag, Origin: "--set"}, sourceOf(t, explained, "cleanOutputDir"),
+		"a field set to its zero value is attributed to the flag")
+	assert.Equal(t, FieldSource{Field: "profile.fabric", Value: "ethernet", Source: SourceFlag, Origin: "--fabric"}, sourceOf(t, explained, "profile.fabric"))
+	assert.Equal(t, SourceDefault, sourceOf(t, explained, "profile.deployment").Source, "new fields left at their zero value are defaults")
+	assert.Equal(t, FieldSource{Field: "sriov.resourceName", Value: "sriov_resource", Source: SourceFile, Origin: "config.yaml"}, sourceOf(t, explained, "sriov.resourceName"))
+	assert.Equal(t, FieldSource{Field: "clusterConfig.workerNodes[0]", Value: "worker-1", Source: SourceFile, Origin: "config.yaml"}, sourceOf(t, explained, "clusterConfig.workerNodes[0]"))
+	assert.Equal(t, FieldSource{Field: "sriov.ethernetMtu", Value: "0", Source: SourceDefault}, sourceOf(t, explained, "sriov.ethernetMtu"))
+
+	t.Run("a failed update changes no source", func(t *testing.T) {
+		err := tracker.Track(cfg, SourceLLM, "prompt.txt", func() error {
+			return ApplySetValues(cfg, []string{"sriov.unknown=1"})
+		})
+		require.Error(t, err)
+		explained, err := tracker.Explain(cfg)
+		require.NoError(t, err)
+		assert.Equal(t, SourceFile, sourceOf(t, explained, "sriov.resourceName").Source)
+	})
+
+	t.Run("a nil tracker only runs the update", func(t *testing.T) {
+		var tracker *SourceTracker
+		updated := false
+		require.NoError(t, tracker.Record(cfg, "sriov", SourceFlag, "--set"))
+		require.NoError(t, tracker.Track(cfg, SourceFlag, "--fabric", func() error {
+			updated = true
+			return nil
+		}))
+		assert.True(t, updated)
+		explained, err := tracker.Explain(cfg)
+		require.NoError(t, err)
+		assert.Empty(t, explained)
+	})
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/go-logr/logr"
+	"gopkg.in/yaml.v2"
+)
+
+// configTemplateFuncs provides helper functions for config templates
+var configTemplateFuncs = template.FuncMap{
+	"add": func(a, b int) int { return a + b },
+	"sub": func(a, b int) int { return a - b },
+	"mul": func(a, b int) int { return a * b },
+	"div": func(a, b int) (int, error) {
+		if b == 0 {
+			return 0, fmt.Errorf("division of %d by zero", a)
+		}
+		return a / b, nil
+	},
+}
+
+// RenderConfigTemplate renders a config file written as a Go template against the discovered config.
+// The template can reference every discovered value, e.g. {{ len .ClusterConfig.WorkerNodes }}.
+func RenderConfigTemplate(name string, data []byte, discovered *LaunchKubernetesConfig) ([]byte, error) {
+	tmpl, err := template.New(name).Funcs(configTemplateFuncs).Option("missingkey=error").Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config template %s: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, discovered); err != nil {
+		return nil, fmt.Errorf("failed to render config template %s: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// LoadFullConfigTemplate renders the config template at configPath against the discovered config and parses the result.
+// When the rendered config has no clusterConfig section, the discovered one is used.
+func LoadFullConfigTemplate(configPath string, discovered *LaunchKubernetesConfig, logger logr.Logger) (*LaunchKubernetesConfig, error) {
+	logger.Info("Rendering cluster configuration template", "path", configPath)
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cluster config template %s: %w", configPath, err)
+	}
+
+	rendered, err := RenderConfigTemplate(configPath, data, discovered)
+	if err != nil {
+		return nil, err
+	}
+	logger.V(1).Info("Rendered cluster configuration", "config", string(rendered))
+
+	var config LaunchKubernetesConfig
+	if err := yaml.Unmarshal(rendered, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse rendered cluster config %s: %w", configPath, err)
+	}
+
+	if config.ClusterConfig == nil {
+		config.ClusterConfig = discovered.ClusterConfig
+	}
+
+	return &config, nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadFullConfigTemplate(t *testing.T) {
+	discovered := &LaunchKubernetesConfig{
+		NetworkOperator: &NetworkOperatorConfig{Namespace: "nvidia-network-operator"},
+		ClusterConfig: &ClusterConfig{
+			WorkerNodes: []string{"worker-1", "worker-2", "worker-3"},
+		},
+	}
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml.tmpl")
+	require.NoError(t, os.WriteFile(configPath, []byte(`networkOperator:
+  namespace: {{ .NetworkOperator.Namespace }}
+sriov:
+  numVfs: {{ mul (len .ClusterConfig.WorkerNodes) 4 }}
+  resourceName: sriov_resource
+`), 0644))
+
+	config, err := LoadFullConfigTemplate(configPath, discovered, logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, 12, config.Sriov.NumVfs)
+	assert.Equal(t, "nvidia-network-operator", config.NetworkOperator.Namespace)
+	assert.Equal(t, discovered.ClusterConfig, config.ClusterConfig, "discovered cluster config is used when the template has none")
+
+	t.Run("invalid template", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(configPath, []byte("sriov:\n  numVfs: {{ .Unknown }}\n"), 0644))
+
+		_, err := LoadFullConfigTemplate(configPath, discovered, logr.Discard())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to render config template")
+	})
+
+	t.Run("fail on a division by zero", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(configPath, []byte("sriov:\n  numVfs: {{ div 64 (len .ClusterConfig.PFs) }}\n"), 0644))
+
+		_, err := LoadFullConfigTemplate(configPath, discovered, logr.Discard())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "division of 64 by zero")
+	})
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// Checkpoint records the objects applied successfully, with the hash of their manifest, so that an apply failing
+// partway can be resumed: the objects applied before and unchanged since are skipped. It is written to a file after
+// every applied object.
+type Checkpoint struct {
+	path    string
+	mu      sync.Mutex
+	applied map[string]string
+}
+
+// checkpointFile is the persisted form of a Checkpoint
+type checkpointFile struct {
+	// Applied maps apiVersion/kind/namespace/name of the applied objects to the hash of their manifest
+	Applied map[string]string `json:"applied"`
+}
+
+// OpenCheckpoint returns a checkpoint written to path. With resume, the objects recorded in path by an earlier
+// apply are skipped, a missing file resumes nothing; otherwise the checkpoint starts empty.
+func OpenCheckpoint(path string, resume bool) (*Checkpoint, error) {
+	checkpoint := &Checkpoint{path: path, applied: map[string]string{}}
+	if !resume {
+		return checkpoint, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return checkpoint, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint %s: %w", path, err)
+	}
+	var state checkpointFile
+	if err := yaml.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint %s: %w", path, err)
+	}
+	if state.Applied != nil {
+		checkpoint.applied = state.Applied
+	}
+	return checkpoint, nil
+}
+
+// Len returns the number of objects recorded as applied
+func (c *Checkpoint) Len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.applied)
+}
+
+// Remove deletes the checkpoint file, once nothing is left to resume
+func (c *Checkpoint) Remove() error {
+	if c == nil {
+		return nil
+	}
+	if err := os.Remove(c.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove checkpoint %s: %w", c.path, err)
+	}
+	return nil
+}
+
+// isApplied reports whether the object was recorded as applied with the same manifest
+func (c *Checkpoint) isApplied(obj *unstructured.Unstructured) bool {
+	if c == nil {
+		return false
+	}
+	hash, err := manifestHash(obj)
+	if err != nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.applied[checkpointKey(obj)] == hash
+}
+
+// record adds the object key, with the hash of its manifest, to the checkpoint and writes it
+func (c *Checkpoint) record(key, hash string) error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.applied[key] = hash
+
+	data, err := yaml.Marshal(checkpointFile{Applied: c.applied})
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("failed to create checkpoint directory: %w", err)
+	}
+	if err := os.WriteFile(c.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write checkpoint %s: %w", c.path, err)
+	}
+	return nil
+}
+
+// checkpointKey identifies the object in the checkpoint
+func checkpointKey(obj *unstructured.Unstructured) string {
+	return strings.Join([]string{obj.GetAPIVersion(), obj.GetKind(), obj.GetNamespace(), obj.GetName()}, "/")
+}
+
+// manifestHash returns the sha256 of the object to apply
+func manifestHash(obj *unstructured.Unstructured) (string, error) {
+	data, err := json.Marshal(obj.Object)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal %s/%s: %w", obj.GetKind(), obj.GetName(), err)
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
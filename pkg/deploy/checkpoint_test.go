@@ -0,0 +1,146 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/ui"
+)
+
+func TestApplyManifestsCheckpoint(t *testing.T) {
+	files := map[string]string{
+		"10-policy.yaml":     policyManifest,
+		"20-configmaps.yaml": configMapManifest,
+	}
+
+	// newClient records the applied objects and rejects the applies of the objects named in failing
+	newClient := func(applied *[]string, failing ...string) client.Client {
+		return fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				u := obj.(*unstructured.Unstructured)
+				for _, name := range failing {
+					if u.GetName() == name {
+						return apierrors.NewForbidden(schema.GroupResource{Resource: "configmaps"}, name, errors.New("denied"))
+					}
+				}
+				*applied = append(*applied, u.GetKind()+"/"+u.GetName())
+				return nil
+			},
+		}).Build()
+	}
+
+	// applyWithCheckpoint applies the files with the checkpoint of path
+	applyWithCheckpoint := func(t *testing.T, c client.Client, files map[string]string, path string, resume bool) (*ui.RecordingOutput, error) {
+		checkpoint, err := OpenCheckpoint(path, resume)
+		require.NoError(t, err)
+		output := ui.NewRecording()
+		return output, ApplyManifestsWithOptions(ui.WithOutput(context.Background(), output), c, files, Options{Checkpoint: checkpoint})
+	}
+
+	t.Run("resume with the objects left after a failure", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "checkpoint.yaml")
+		applied := []string{}
+		_, err := applyWithCheckpoint(t, newClient(&applied, "second"), files, path, false)
+		require.Error(t, err)
+		assert.Equal(t, []string{"NicClusterPolicy/nic-cluster-policy", "ConfigMap/first"}, applied)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "mellanox.com/v1alpha1/NicClusterPolicy//nic-cluster-policy: sha256:")
+		assert.Contains(t, string(data), "v1/ConfigMap/default/first: sha256:")
+		assert.NotContains(t, string(data), "second")
+
+		applied = []string{}
+		output, err := applyWithCheckpoint(t, newClient(&applied), files, path, true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap/second"}, applied)
+		assert.Contains(t, output.Infos, "    Skipped, unchanged since the checkpoint")
+	})
+
+	t.Run("reapply the objects changed since the checkpoint", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "checkpoint.yaml")
+		applied := []string{}
+		_, err := applyWithCheckpoint(t, newClient(&applied, "second"), files, path, false)
+		require.Error(t, err)
+
+		changed := map[string]string{
+			"10-policy.yaml":     policyManifest,
+			"20-configmaps.yaml": strings.Replace(configMapManifest, "key: value", "key: changed", 1),
+		}
+		applied = []string{}
+		_, err = applyWithCheckpoint(t, newClient(&applied), changed, path, true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap/first", "ConfigMap/second"}, applied)
+	})
+
+	t.Run("apply everything without resume", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "checkpoint.yaml")
+		applied := []string{}
+		_, err := applyWithCheckpoint(t, newClient(&applied), files, path, false)
+		require.NoError(t, err)
+
+		applied = []string{}
+		_, err = applyWithCheckpoint(t, newClient(&applied), files, path, false)
+		require.NoError(t, err)
+		assert.Len(t, applied, 3)
+	})
+
+	t.Run("resume without a checkpoint file", func(t *testing.T) {
+		applied := []string{}
+		_, err := applyWithCheckpoint(t, newClient(&applied), files, filepath.Join(t.TempDir(), "missing.yaml"), true)
+		require.NoError(t, err)
+		assert.Len(t, applied, 3)
+	})
+
+	t.Run("invalid checkpoint file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "checkpoint.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("applied: [\n"), 0644))
+		_, err := OpenCheckpoint(path, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse checkpoint")
+	})
+
+	t.Run("remove the checkpoint", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "checkpoint.yaml")
+		applied := []string{}
+		_, err := applyWithCheckpoint(t, newClient(&applied), files, path, false)
+		require.NoError(t, err)
+		require.FileExists(t, path)
+
+		checkpoint, err := OpenCheckpoint(path, true)
+		require.NoError(t, err)
+		assert.Equal(t, 3, checkpoint.Len())
+		require.NoError(t, checkpoint.Remove())
+		assert.NoFileExists(t, path)
+		require.NoError(t, checkpoint.Remove())
+	})
+}
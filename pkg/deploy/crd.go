@@ -0,0 +1,108 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/ui"
+)
+
+// DefaultCRDTimeout bounds the wait for an applied CustomResourceDefinition to be established
+const DefaultCRDTimeout = 2 * time.Minute
+
+// crdPollInterval is the initial delay between the reads of a CustomResourceDefinition status
+const crdPollInterval = time.Second
+
+// crdGVK is the kind of the CustomResourceDefinitions applied first and waited on
+var crdGVK = schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"}
+
+// isCRD reports whether the object is a CustomResourceDefinition, whatever its version
+func isCRD(obj *unstructured.Unstructured) bool {
+	gvk := obj.GroupVersionKind()
+	return gvk.Group == crdGVK.Group && gvk.Kind == crdGVK.Kind
+}
+
+// crdsFirst moves the CustomResourceDefinitions to the front, so that they are established before their resources
+// are applied, keeping the relative order otherwise
+func crdsFirst(objects []*unstructured.Unstructured) []*unstructured.Unstructured {
+	ordered := make([]*unstructured.Unstructured, 0, len(objects))
+	for _, obj := range objects {
+		if isCRD(obj) {
+			ordered = append(ordered, obj)
+		}
+	}
+	for _, obj := range objects {
+		if !isCRD(obj) {
+			ordered = append(ordered, obj)
+		}
+	}
+	return ordered
+}
+
+// WaitCRDEstablished polls the CustomResourceDefinition until its Established condition is True, within timeout.
+// It fails right away when the API server rejects the names of the CRD.
+func WaitCRDEstablished(ctx context.Context, c client.Client, name string, timeout time.Duration) error {
+	progress := ui.FromContext(ctx).StartProgressContext(ctx, fmt.Sprintf("Waiting for CustomResourceDefinition %s to be established", name))
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := PollUntil(ctx, crdPollInterval, func(ctx context.Context) (bool, error) {
+		crd := &unstructured.Unstructured{}
+		crd.SetGroupVersionKind(crdGVK)
+		if err := c.Get(ctx, client.ObjectKey{Name: name}, crd); err != nil {
+			log.Log.V(1).Info("CustomResourceDefinition not readable yet", "name", name, "error", err.Error())
+			return false, nil
+		}
+
+		conditions, _, _ := unstructured.NestedSlice(crd.Object, "status", "conditions")
+		for _, condition := range conditions {
+			fields, ok := condition.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			switch {
+			case fields["type"] == "Established" && fields["status"] == "True":
+				return true, nil
+			case fields["type"] == "NamesAccepted" && fields["status"] == "False":
+				return false, fmt.Errorf("CustomResourceDefinition %s names are not accepted: %v", name, fields["message"])
+			}
+		}
+		return false, nil
+	})
+	if errors.Is(err, context.DeadlineExceeded) {
+		progress.Fail("Timeout waiting for CustomResourceDefinition")
+		return fmt.Errorf("timeout waiting for CustomResourceDefinition %s to be established: %w", name, err)
+	}
+	if err != nil {
+		progress.Fail("CustomResourceDefinition not established")
+		return err
+	}
+
+	progress.Success(fmt.Sprintf("CustomResourceDefinition %s is established", name))
+	log.Log.Info("CustomResourceDefinition is established", "name", name)
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+const crdManifest = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: nicclusterpolicies.mellanox.com
+`
+
+// newCRDClient returns a fake client that records the applied objects, in a shared list with the CRD reads,
+// and reports the conditions returned by status for the nth read of a CRD
+func newCRDClient(events *[]string, status func(read int) []interface{}) client.Client {
+	reads := 0
+	return fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			u := obj.(*unstructured.Unstructured)
+			*events = append(*events, "apply "+u.GetKind()+"/"+u.GetName())
+			return nil
+		},
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			reads++
+			*events = append(*events, "read "+key.Name)
+			u := obj.(*unstructured.Unstructured)
+			return unstructured.SetNestedSlice(u.Object, status(reads), "status", "conditions")
+		},
+	}).Build()
+}
+
+func TestApplyWaitsForCRDs(t *testing.T) {
+	files := map[string]string{
+		"10-policy.yaml": policyManifest,
+		"90-crd.yaml":    crdManifest,
+	}
+	established := []interface{}{
+		map[string]interface{}{"type": "NamesAccepted", "status": "True"},
+		map[string]interface{}{"type": "Established", "status": "True"},
+	}
+
+	t.Run("apply the CRD first and wait until it is established", func(t *testing.T) {
+		events := []string{}
+		c := newCRDClient(&events, func(read int) []interface{} {
+			if read < 2 {
+				return []interface{}{map[string]interface{}{"type": "Established", "status": "False"}}
+			}
+			return established
+		})
+
+		require.NoError(t, ApplyManifests(context.Background(), c, files))
+		assert.Equal(t, []string{
+			"apply CustomResourceDefinition/nicclusterpolicies.mellanox.com",
+			"read nicclusterpolicies.mellanox.com",
+			"read nicclusterpolicies.mellanox.com",
+			"apply NicClusterPolicy/nic-cluster-policy",
+		}, events)
+	})
+
+	t.Run("fail when the CRD is never established", func(t *testing.T) {
+		events := []string{}
+		c := newCRDClient(&events, func(int) []interface{} { return nil })
+
+		err := ApplyManifestsWithOptions(context.Background(), c, files, Options{CRDTimeout: 50 * time.Millisecond})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, err.Error(), "timeout waiting for CustomResourceDefinition nicclusterpolicies.mellanox.com to be established")
+		assert.NotContains(t, events, "apply NicClusterPolicy/nic-cluster-policy")
+	})
+
+	t.Run("fail right away when the CRD names are not accepted", func(t *testing.T) {
+		events := []string{}
+		c := newCRDClient(&events, func(int) []interface{} {
+			return []interface{}{map[string]interface{}{"type": "NamesAccepted", "status": "False", "message": "plural name conflicts"}}
+		})
+
+		err := ApplyManifests(context.Background(), c, files)
+		require.Error(t, err)
+		assert.EqualError(t, err, "CustomResourceDefinition nicclusterpolicies.mellanox.com names are not accepted: plural name conflicts")
+		assert.Equal(t, []string{"apply CustomResourceDefinition/nicclusterpolicies.mellanox.com", "read nicclusterpolicies.mellanox.com"}, events)
+	})
+
+	t.Run("use the readiness check set for CRDs", func(t *testing.T) {
+		events := []string{}
+		c := newCRDClient(&events, func(int) []interface{} { return nil })
+		opts := Options{ReadinessChecks: map[string]ReadinessCheck{
+			"CustomResourceDefinition": func(ctx context.Context, c client.Client, obj *unstructured.Unstructured) error {
+				return nil
+			},
+		}}
+
+		require.NoError(t, ApplyManifestsWithOptions(context.Background(), c, files, opts))
+		assert.Equal(t, []string{"apply CustomResourceDefinition/nicclusterpolicies.mellanox.com", "apply NicClusterPolicy/nic-cluster-policy"}, events)
+	})
+}
@@ -0,0 +1,692 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/ui"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// FieldOwner is the default field manager used for server-side apply
+const FieldOwner = "l8k"
+
+// ConflictPolicy decides what server-side apply does with fields owned by another field manager
+type ConflictPolicy string
+
+const (
+	// ConflictPolicyForce takes ownership of the conflicting fields
+	ConflictPolicyForce ConflictPolicy = "force"
+	// ConflictPolicyFail fails the apply of an object with conflicting fields
+	ConflictPolicyFail ConflictPolicy = "fail"
+)
+
+// ConflictPolicies lists the supported conflict policies
+var ConflictPolicies = []ConflictPolicy{ConflictPolicyForce, ConflictPolicyFail}
+
+// ReadinessCheck blocks until the applied object is ready or returns an error
+type ReadinessCheck func(ctx context.Context, c client.Client, obj *unstructured.Unstructured) error
+
+// Options tunes how a manifest set is applied
+type Options struct {
+	// PriorityKinds are applied before all other objects, in the given order
+	PriorityKinds []string
+	// FileOrder lists files applied first, in the given order and before PriorityKinds. The other files follow
+	// in the default order. Every entry must name one of the files.
+	FileOrder []string
+	// ReadinessChecks are invoked, by kind, right after an object of that kind is applied
+	ReadinessChecks map[string]ReadinessCheck
+	// ConfirmConflicts applies without forcing ownership and asks via ui.Confirm before
+	// force-taking fields owned by another field manager. Otherwise ownership is always forced.
+	ConfirmConflicts bool
+	// ContinueOnError keeps applying the remaining objects when one fails and returns an *ApplyError
+	// listing every failed object. Otherwise the first failure stops the apply.
+	ContinueOnError bool
+	// FileGlobs restricts the apply to the files whose name matches one of the globs, e.g. "30-*.yaml"
+	FileGlobs []string
+	// Kinds restricts the apply to the objects of the given kinds, written as Kind, Kind.group or group/version/Kind
+	Kinds []string
+	// Exclude skips the files whose name matches an entry as a glob and the objects whose kind matches an entry
+	// as Kind, Kind.group or group/version/Kind. Excluded objects are neither applied nor waited on.
+	Exclude []string
+	// FieldManager is the server-side apply field manager, FieldOwner when empty
+	FieldManager string
+	// ConflictPolicy decides whether conflicting fields are force-taken, ConflictPolicyForce when empty.
+	// It is ignored with ConfirmConflicts.
+	ConflictPolicy ConflictPolicy
+	// Owner, when set, is added to the owner references of the applied objects so that they are garbage-collected
+	// with it. Objects a namespaced owner cannot own, cluster-scoped or in another namespace, are applied without it.
+	Owner *Owner
+	// MaxUnavailable, when set, becomes the rolling update maxUnavailable of the applied DaemonSets,
+	// either a number of pods or a percentage such as "25%"
+	MaxUnavailable *intstr.IntOrString
+	// PropagationPolicy decides how the dependents of deleted objects are deleted, the server default when empty
+	PropagationPolicy metav1.DeletionPropagation
+	// GracePeriodSeconds, when set, overrides the termination grace period of deleted objects
+	GracePeriodSeconds *int64
+	// CRDTimeout bounds the wait for every applied CustomResourceDefinition to be established, DefaultCRDTimeout
+	// when zero. It is not used when ReadinessChecks has a check for the CustomResourceDefinition kind.
+	CRDTimeout time.Duration
+	// Checkpoint, when set, records every object applied and ready, and skips the objects it recorded with the same
+	// manifest, so that an apply failing partway resumes with the objects left
+	Checkpoint *Checkpoint
+	// Applied, when set, is called with every object applied and ready, written as Kind/name
+	Applied func(object string)
+}
+
+// fieldManager returns the field manager to apply with
+func (o Options) fieldManager() string {
+	if o.FieldManager == "" {
+		return FieldOwner
+	}
+	return o.FieldManager
+}
+
+// readinessCheck returns the readiness check of the object, waiting for CustomResourceDefinitions to be
+// established unless another check is set for them
+func (o Options) readinessCheck(obj *unstructured.Unstructured) (ReadinessCheck, bool) {
+	if check, ok := o.ReadinessChecks[obj.GetKind()]; ok {
+		return check, true
+	}
+	if !isCRD(obj) {
+		return nil, false
+	}
+	timeout := o.CRDTimeout
+	if timeout == 0 {
+		timeout = DefaultCRDTimeout
+	}
+	return func(ctx context.Context, c client.Client, obj *unstructured.Unstructured) error {
+		return WaitCRDEstablished(ctx, c, obj.GetName(), timeout)
+	}, true
+}
+
+// patchOptions returns the server-side apply options of a patch, forcing ownership when force is set
+func (o Options) patchOptions(force bool) []client.PatchOption {
+	patchOpts := []client.PatchOption{client.FieldOwner(o.fieldManager())}
+	if force {
+		patchOpts = append(patchOpts, client.ForceOwnership)
+	}
+	return patchOpts
+}
+
+// ObjectError is the failure to apply a single object
+type ObjectError struct {
+	// Object is the Kind/name of the object
+	Object string
+	Err    error
+}
+
+// ApplyError aggregates the objects that failed to apply with ContinueOnError
+type ApplyError struct {
+	Failed []ObjectError
+	Total  int
+}
+
+func (e *ApplyError) Error() string {
+	msgs := make([]string, 0, len(e.Failed))
+	for _, f := range e.Failed {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", f.Object, f.Err))
+	}
+	return fmt.Sprintf("failed to apply %d of %d object(s): %s", len(e.Failed), e.Total, strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the errors of the failed objects
+func (e *ApplyError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, f := range e.Failed {
+		errs = append(errs, f.Err)
+	}
+	return errs
+}
+
+// ForcedOwnership lists the fields of an object whose ownership was force-taken from another field manager
+type ForcedOwnership struct {
+	// Object is the Kind/name of the object
+	Object string
+	Fields []string
+}
+
+// Apply reads Kubernetes manifests from dirPath and applies them to the cluster.
+func Apply(ctx context.Context, c client.Client, dirPath string) error {
+	return ApplyWithOptions(ctx, c, dirPath, Options{})
+}
+
+// ApplyWithOptions reads Kubernetes manifests from dirPath and applies them to the cluster using the given options.
+func ApplyWithOptions(ctx context.Context, c client.Client, dirPath string, opts Options) error {
+	files, err := ReadManifestsDir(dirPath)
+	if err != nil {
+		return err
+	}
+
+	return ApplyManifestsWithOptions(ctx, c, files, opts)
+}
+
+// ApplyManifests applies an in-memory set of manifests (file name -> content) to the cluster.
+func ApplyManifests(ctx context.Context, c client.Client, files map[string]string) error {
+	return ApplyManifestsWithOptions(ctx, c, files, Options{})
+}
+
+// ApplyManifestsWithOptions applies an in-memory set of manifests (file name -> content) to the cluster.
+// CustomResourceDefinitions are applied first and waited on until established. Then the files of FileOrder are applied
+// in the given order, then the other files in file name order with the objects of PriorityKinds first.
+// Only the files and objects selected by FileGlobs and Kinds are applied. Objects previously applied with kubectl
+// client-side apply lose their last-applied-configuration annotation and have their fields handed over to the field manager.
+func ApplyManifestsWithOptions(ctx context.Context, c client.Client, files map[string]string, opts Options) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	uiOutput := ui.FromContext(ctx)
+
+	if opts.Owner != nil {
+		if err := opts.Owner.validate(); err != nil {
+			uiOutput.Error("Invalid owner: %v", err)
+			return err
+		}
+	}
+
+	if err := opts.validateUpdateOptions(); err != nil {
+		uiOutput.Error("Invalid update options: %v", err)
+		return err
+	}
+
+	objects, excluded, err := selectObjects(files, opts)
+	if err != nil {
+		uiOutput.Error("Failed to select manifests: %v", err)
+		return err
+	}
+
+	if len(excluded) > 0 {
+		uiOutput.Info("Excluded %d file(s) and object(s):", len(excluded))
+		for _, name := range excluded {
+			uiOutput.Info("  %s", name)
+		}
+		log.Log.Info("Excluded manifests", "excluded", excluded)
+	}
+	if len(objects) == 0 && (len(opts.FileGlobs) > 0 || len(opts.Kinds) > 0 || len(opts.Exclude) > 0) {
+		uiOutput.Warning("No manifests match the selected files and kinds")
+	}
+
+	if len(objects) > 0 {
+		uiOutput.Info("Applying %d manifest(s)", len(objects))
+	}
+	log.Log.Info("Applying manifests", "count", len(objects))
+
+	var forcedOwnership []ForcedOwnership
+	defer func() {
+		reportForcedOwnership(uiOutput, forcedOwnership)
+	}()
+
+	var failed []ObjectError
+	for i, obj := range objects {
+		object := fmt.Sprintf("%s/%s", obj.GetKind(), obj.GetName())
+		uiOutput.Info("  [%d/%d] Applying %s/%s", i+1, len(objects), obj.GetKind(), obj.GetName())
+		log.Log.Info("Applying object", "kind", obj.GetKind(), "name", obj.GetName(), "version", obj.GetAPIVersion())
+		if opts.Owner != nil && !setOwnerReference(c, obj, *opts.Owner) {
+			uiOutput.Warning("    %s cannot be owned by %s, applying it without owner reference", object, opts.Owner)
+			log.Log.Info("Skipping owner reference", "object", object, "owner", opts.Owner.String(), "namespace", obj.GetNamespace())
+		}
+		if opts.MaxUnavailable != nil {
+			changed, err := setMaxUnavailable(obj, *opts.MaxUnavailable)
+			if err != nil {
+				err = fmt.Errorf("failed to set the update strategy of %s: %w", object, err)
+				uiOutput.Error("    Failed: %v", err)
+				if !opts.ContinueOnError {
+					return err
+				}
+				failed = append(failed, ObjectError{Object: object, Err: err})
+				continue
+			}
+			if changed {
+				log.Log.Info("Set rolling update maxUnavailable", "object", object, "maxUnavailable", opts.MaxUnavailable.String())
+			}
+		}
+
+		// The key and hash are taken before the apply, which replaces obj with the object returned by the server
+		key, hash := checkpointKey(obj), ""
+		if opts.Checkpoint != nil {
+			if opts.Checkpoint.isApplied(obj) {
+				uiOutput.Info("    Skipped, unchanged since the checkpoint")
+				log.Log.Info("Skipping object recorded in the checkpoint", "object", object)
+				continue
+			}
+			if hash, err = manifestHash(obj); err != nil {
+				uiOutput.Error("    Failed: %v", err)
+				if !opts.ContinueOnError {
+					return err
+				}
+				failed = append(failed, ObjectError{Object: object, Err: err})
+				continue
+			}
+		}
+
+		apply := func() error {
+			if !opts.ConfirmConflicts {
+				return c.Patch(ctx, obj, client.Apply, opts.patchOptions(opts.ConflictPolicy != ConflictPolicyFail)...)
+			}
+			forced, err := applyConfirmingConflicts(ctx, c, obj, opts)
+			if forced != nil {
+				forcedOwnership = append(forcedOwnership, *forced)
+			}
+			return err
+		}
+
+		// Apply with retry for Pod kind
+		applyErr := apply()
+		if applyErr != nil && strings.EqualFold(obj.GetKind(), "Pod") && !apierrors.IsConflict(applyErr) {
+			const maxAttempts = 3
+			for attempt := 2; attempt <= maxAttempts && applyErr != nil; attempt++ {
+				uiOutput.Warning("    Retrying (%d/%d)...", attempt, maxAttempts)
+				log.Log.Info("Pod apply failed, retrying", "name", obj.GetName(), "attempt", attempt, "delay", "30s", "error", applyErr.Error())
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(30 * time.Second):
+				}
+				applyErr = apply()
+			}
+		}
+		if applyErr != nil {
+			uiOutput.Error("    Failed: %v", applyErr)
+			if !opts.ContinueOnError {
+				return applyErr
+			}
+			failed = append(failed, ObjectError{Object: object, Err: applyErr})
+			continue
+		}
+
+		// obj now holds the applied object, which still carries the annotation when kubectl apply managed it before
+		migrated, err := migrateLastApplied(ctx, c, obj, opts.fieldManager())
+		if err != nil {
+			err = fmt.Errorf("failed to migrate %s to server-side apply: %w", object, err)
+			uiOutput.Error("    Failed: %v", err)
+			if !opts.ContinueOnError {
+				return err
+			}
+			failed = append(failed, ObjectError{Object: object, Err: err})
+			continue
+		}
+		if migrated {
+			uiOutput.Info("    Migrated %s from kubectl client-side apply", object)
+			log.Log.Info("Removed last-applied-configuration annotation", "object", object, "fieldManager", opts.fieldManager())
+		}
+
+		if check, ok := opts.readinessCheck(obj); ok {
+			log.Log.Info("Waiting for object to be ready", "kind", obj.GetKind(), "name", obj.GetName())
+			if err := check(ctx, c, obj); err != nil {
+				if !opts.ContinueOnError {
+					return err
+				}
+				uiOutput.Error("    Not ready: %v", err)
+				failed = append(failed, ObjectError{Object: object, Err: err})
+				continue
+			}
+		}
+
+		if err := opts.Checkpoint.record(key, hash); err != nil {
+			uiOutput.Warning("    Failed to write the checkpoint: %v", err)
+			log.Log.Error(err, "Failed to write the checkpoint", "object", object)
+		}
+		if opts.Applied != nil {
+			opts.Applied(object)
+		}
+	}
+
+	if opts.ContinueOnError {
+		reportApplySummary(uiOutput, objects, failed)
+	}
+	if len(failed) > 0 {
+		return &ApplyError{Failed: failed, Total: len(objects)}
+	}
+
+	return nil
+}
+
+// ApplyObject applies a single object using kubectl-style server-side apply
+func ApplyObject(ctx context.Context, c client.Client, obj *unstructured.Unstructured) error {
+	return c.Patch(ctx, obj, client.Apply, Options{}.patchOptions(true)...)
+}
+
+// applyConfirmingConflicts applies the object without forcing ownership. On a field manager conflict the user is asked
+// whether to force-take the conflicting fields; the returned ForcedOwnership is set when they were taken.
+func applyConfirmingConflicts(ctx context.Context, c client.Client, obj *unstructured.Unstructured, opts Options) (*ForcedOwnership, error) {
+	err := c.Patch(ctx, obj.DeepCopy(), client.Apply, opts.patchOptions(false)...)
+	if err == nil || !apierrors.IsConflict(err) {
+		return nil, err
+	}
+
+	fields := conflictingFields(err)
+	object := fmt.Sprintf("%s/%s", obj.GetKind(), obj.GetName())
+	log.Log.Info("Server-side apply conflict", "object", object, "fields", fields)
+
+	uiOutput := ui.FromContext(ctx)
+	uiOutput.Warning("    %s has fields owned by another field manager:", object)
+	for _, field := range fields {
+		uiOutput.Warning("      %s", field)
+	}
+	if !uiOutput.Confirm("    Take ownership of the conflicting fields of %s?", object) {
+		return nil, fmt.Errorf("refused to take ownership of conflicting fields of %s: %w", object, err)
+	}
+
+	if err := c.Patch(ctx, obj, client.Apply, opts.patchOptions(true)...); err != nil {
+		return nil, err
+	}
+	return &ForcedOwnership{Object: object, Fields: fields}, nil
+}
+
+// conflictingFields returns the fields, with their current manager, listed in a server-side apply conflict error
+func conflictingFields(err error) []string {
+	status, ok := err.(apierrors.APIStatus)
+	if !ok || status.Status().Details == nil {
+		return nil
+	}
+
+	var fields []string
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		if cause.Message != "" {
+			fields = append(fields, fmt.Sprintf("%s (%s)", cause.Field, cause.Message))
+		} else {
+			fields = append(fields, cause.Field)
+		}
+	}
+	return fields
+}
+
+// reportForcedOwnership summarizes the fields whose ownership was force-taken
+func reportForcedOwnership(uiOutput ui.Output, forced []ForcedOwnership) {
+	if len(forced) == 0 {
+		return
+	}
+
+	uiOutput.Warning("Took ownership of conflicting fields:")
+	for _, f := range forced {
+		uiOutput.Info("  %s: %s", f.Object, strings.Join(f.Fields, ", "))
+	}
+}
+
+// reportApplySummary reports the apply result, listing every object with its result when some failed
+func reportApplySummary(uiOutput ui.Output, objects []*unstructured.Unstructured, failed []ObjectError) {
+	if len(failed) == 0 {
+		uiOutput.Success("Applied %d manifest(s)", len(objects))
+		return
+	}
+
+	failures := make(map[string]error, len(failed))
+	for _, f := range failed {
+		failures[f.Object] = f.Err
+	}
+
+	uiOutput.Error("Applied %d of %d manifest(s):", len(objects)-len(failed), len(objects))
+	for _, obj := range objects {
+		object := fmt.Sprintf("%s/%s", obj.GetKind(), obj.GetName())
+		if err, ok := failures[object]; ok {
+			uiOutput.Info("  FAILED  %s: %v", object, err)
+		} else {
+			uiOutput.Info("  OK      %s", object)
+		}
+	}
+}
+
+// ReadManifestsDir lists YAML files in dirPath (non-recursive) and returns their contents keyed by file name
+func ReadManifestsDir(dirPath string) (map[string]string, error) {
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]string, len(entries))
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		ext := filepath.Ext(e.Name())
+		if ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dirPath, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		files[e.Name()] = string(content)
+	}
+
+	return files, nil
+}
+
+// decodeManifests decodes all documents of all files, in file name order
+func decodeManifests(files map[string]string) ([]*unstructured.Unstructured, error) {
+	fileNames := make([]string, 0, len(files))
+	for name := range files {
+		fileNames = append(fileNames, name)
+	}
+	sort.Strings(fileNames)
+
+	var objects []*unstructured.Unstructured
+	for _, name := range fileNames {
+		parsed, err := ParseManifests([]byte(files[name]))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode manifest from %s: %w", name, err)
+		}
+		for i := range parsed {
+			obj := &parsed[i]
+			// Ensure GVK set for server-side apply
+			gv, err := schema.ParseGroupVersion(obj.GetAPIVersion())
+			if err == nil {
+				obj.SetGroupVersionKind(gv.WithKind(obj.GetKind()))
+			}
+			objects = append(objects, obj)
+		}
+	}
+
+	return objects, nil
+}
+
+// selectFiles returns the files whose name matches one of the globs, all files when no glob is given
+func selectFiles(files map[string]string, globs []string) (map[string]string, error) {
+	if len(globs) == 0 {
+		return files, nil
+	}
+
+	selected := map[string]string{}
+	for name, content := range files {
+		for _, glob := range globs {
+			matched, err := path.Match(glob, path.Base(name))
+			if err != nil {
+				return nil, fmt.Errorf("invalid file glob %q: %w", glob, err)
+			}
+			if matched {
+				selected[name] = content
+				break
+			}
+		}
+	}
+	return selected, nil
+}
+
+// selectObjects decodes the objects of the files selected by opts in apply order, returning the names of the excluded
+// files and objects
+func selectObjects(files map[string]string, opts Options) ([]*unstructured.Unstructured, []string, error) {
+	if err := checkFileOrder(files, opts.FileOrder); err != nil {
+		return nil, nil, err
+	}
+	files, err := selectFiles(files, opts.FileGlobs)
+	if err != nil {
+		return nil, nil, err
+	}
+	files, excluded, err := excludeFiles(files, opts.Exclude)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var objects []*unstructured.Unstructured
+	rest := maps.Clone(files)
+	for _, name := range opts.FileOrder {
+		content, ok := rest[name]
+		if !ok {
+			continue
+		}
+		delete(rest, name)
+		fileObjects, err := decodeManifests(map[string]string{name: content})
+		if err != nil {
+			return nil, nil, err
+		}
+		objects = append(objects, fileObjects...)
+	}
+	restObjects, err := decodeManifests(rest)
+	if err != nil {
+		return nil, nil, err
+	}
+	objects = append(objects, orderByPriority(restObjects, opts.PriorityKinds)...)
+
+	objects = selectKinds(crdsFirst(objects), opts.Kinds)
+	objects, excludedObjects := excludeKinds(objects, opts.Exclude)
+	return objects, append(excluded, excludedObjects...), nil
+}
+
+// checkFileOrder fails when the file order names a missing file or names a file twice
+func checkFileOrder(files map[string]string, fileOrder []string) error {
+	seen := map[string]bool{}
+	for _, name := range fileOrder {
+		if _, ok := files[name]; !ok {
+			return fmt.Errorf("file order entry %q is not one of the manifest files", name)
+		}
+		if seen[name] {
+			return fmt.Errorf("file order entry %q is listed twice", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// excludeFiles removes the files whose name matches one of the patterns, returning the sorted names of the removed files
+func excludeFiles(files map[string]string, patterns []string) (map[string]string, []string, error) {
+	if len(patterns) == 0 {
+		return files, nil, nil
+	}
+
+	kept := map[string]string{}
+	var excluded []string
+	for name, content := range files {
+		matched := false
+		for _, pattern := range patterns {
+			m, err := path.Match(pattern, path.Base(name))
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid exclude pattern %q: %w", pattern, err)
+			}
+			if m {
+				matched = true
+				break
+			}
+		}
+		if matched {
+			excluded = append(excluded, name)
+		} else {
+			kept[name] = content
+		}
+	}
+	sort.Strings(excluded)
+	return kept, excluded, nil
+}
+
+// excludeKinds removes the objects matching one of the kinds, returning the Kind/name of the removed objects
+func excludeKinds(objects []*unstructured.Unstructured, kinds []string) ([]*unstructured.Unstructured, []string) {
+	if len(kinds) == 0 {
+		return objects, nil
+	}
+
+	var kept []*unstructured.Unstructured
+	var excluded []string
+	for _, obj := range objects {
+		if slices.ContainsFunc(kinds, func(kind string) bool { return matchesKind(obj.GroupVersionKind(), kind) }) {
+			excluded = append(excluded, fmt.Sprintf("%s/%s", obj.GetKind(), obj.GetName()))
+		} else {
+			kept = append(kept, obj)
+		}
+	}
+	return kept, excluded
+}
+
+// selectKinds returns the objects matching one of the kinds, all objects when no kind is given
+func selectKinds(objects []*unstructured.Unstructured, kinds []string) []*unstructured.Unstructured {
+	if len(kinds) == 0 {
+		return objects
+	}
+
+	var selected []*unstructured.Unstructured
+	for _, obj := range objects {
+		for _, kind := range kinds {
+			if matchesKind(obj.GroupVersionKind(), kind) {
+				selected = append(selected, obj)
+				break
+			}
+		}
+	}
+	return selected
+}
+
+// matchesKind reports whether gvk matches a kind written as Kind, Kind.group or group/version/Kind
+func matchesKind(gvk schema.GroupVersionKind, kind string) bool {
+	if parts := strings.Split(kind, "/"); len(parts) == 3 {
+		return strings.EqualFold(parts[0], gvk.Group) && parts[1] == gvk.Version && strings.EqualFold(parts[2], gvk.Kind)
+	}
+	if name, group, ok := strings.Cut(kind, "."); ok {
+		return strings.EqualFold(name, gvk.Kind) && strings.EqualFold(group, gvk.Group)
+	}
+	return strings.EqualFold(kind, gvk.Kind)
+}
+
+// orderByPriority moves objects of the priority kinds to the front, keeping the relative order otherwise
+func orderByPriority(objects []*unstructured.Unstructured, priorityKinds []string) []*unstructured.Unstructured {
+	if len(priorityKinds) == 0 {
+		return objects
+	}
+
+	rank := func(obj *unstructured.Unstructured) int {
+		for i, kind := range priorityKinds {
+			if obj.GetKind() == kind {
+				return i
+			}
+		}
+		return len(priorityKinds)
+	}
+
+	ordered := make([]*unstructured.Unstructured, len(objects))
+	copy(ordered, objects)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return rank(ordered[i]) < rank(ordered[j])
+	})
+	return ordered
+}
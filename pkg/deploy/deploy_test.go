@@ -0,0 +1,472 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/ui"
+)
+
+const configMapManifest = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: first
+  namespace: default
+data:
+  key: value
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: second
+  namespace: default
+`
+
+const policyManifest = `apiVersion: mellanox.com/v1alpha1
+kind: NicClusterPolicy
+metadata:
+  name: nic-cluster-policy
+spec: {}
+`
+
+// newRecordingClient returns a fake client that records server-side apply patches,
+// since the fake client does not support apply patches itself.
+func newRecordingClient(applied *[]string) client.Client {
+	return fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			u := obj.(*unstructured.Unstructured)
+			*applied = append(*applied, u.GetKind()+"/"+u.GetName())
+			return nil
+		},
+	}).Build()
+}
+
+func TestApplyManifests(t *testing.T) {
+	t.Run("apply in-memory manifest set", func(t *testing.T) {
+		applied := []string{}
+		c := newRecordingClient(&applied)
+
+		err := ApplyManifests(context.Background(), c, map[string]string{
+			"20-configmaps.yaml": configMapManifest,
+			"10-policy.yaml":     policyManifest,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"NicClusterPolicy/nic-cluster-policy", "ConfigMap/first", "ConfigMap/second"}, applied)
+	})
+
+	t.Run("apply priority kinds first and run readiness checks", func(t *testing.T) {
+		applied := []string{}
+		c := newRecordingClient(&applied)
+
+		checked := []string{}
+		err := ApplyManifestsWithOptions(context.Background(), c, map[string]string{
+			"00-configmaps.yaml": configMapManifest,
+			"10-policy.yaml":     policyManifest,
+		}, Options{
+			PriorityKinds: []string{"NicClusterPolicy"},
+			ReadinessChecks: map[string]ReadinessCheck{
+				"NicClusterPolicy": func(ctx context.Context, c client.Client, obj *unstructured.Unstructured) error {
+					checked = append(checked, obj.GetName())
+					return nil
+				},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"NicClusterPolicy/nic-cluster-policy", "ConfigMap/first", "ConfigMap/second"}, applied)
+		assert.Equal(t, []string{"nic-cluster-policy"}, checked)
+	})
+
+	t.Run("apply files in the declared order", func(t *testing.T) {
+		applied := []string{}
+		c := newRecordingClient(&applied)
+
+		err := ApplyManifestsWithOptions(context.Background(), c, map[string]string{
+			"10-policy.yaml":     policyManifest,
+			"20-configmaps.yaml": configMapManifest,
+			"30-extra.yaml":      "apiVersion: v1\nkind: Secret\nmetadata:\n  name: extra\n",
+			"40-last.yaml":       "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: last\n---\n" + policyManifest,
+		}, Options{
+			PriorityKinds: []string{"NicClusterPolicy"},
+			FileOrder:     []string{"40-last.yaml", "20-configmaps.yaml"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"ConfigMap/last", "NicClusterPolicy/nic-cluster-policy",
+			"ConfigMap/first", "ConfigMap/second",
+			"NicClusterPolicy/nic-cluster-policy", "Secret/extra",
+		}, applied)
+	})
+
+	t.Run("declared order skips filtered files", func(t *testing.T) {
+		applied := []string{}
+		c := newRecordingClient(&applied)
+
+		err := ApplyManifestsWithOptions(context.Background(), c, map[string]string{
+			"10-policy.yaml":     policyManifest,
+			"20-configmaps.yaml": configMapManifest,
+		}, Options{
+			FileOrder: []string{"20-configmaps.yaml", "10-policy.yaml"},
+			Exclude:   []string{"20-*.yaml"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"NicClusterPolicy/nic-cluster-policy"}, applied)
+	})
+
+	t.Run("unknown or duplicate declared file fails before applying anything", func(t *testing.T) {
+		files := map[string]string{
+			"10-policy.yaml":     policyManifest,
+			"20-configmaps.yaml": configMapManifest,
+		}
+		applied := []string{}
+
+		err := ApplyManifestsWithOptions(context.Background(), newRecordingClient(&applied), files, Options{
+			FileOrder: []string{"20-configmaps.yaml", "15-missing.yaml"},
+		})
+		assert.EqualError(t, err, `file order entry "15-missing.yaml" is not one of the manifest files`)
+
+		err = ApplyManifestsWithOptions(context.Background(), newRecordingClient(&applied), files, Options{
+			FileOrder: []string{"20-configmaps.yaml", "20-configmaps.yaml"},
+		})
+		assert.EqualError(t, err, `file order entry "20-configmaps.yaml" is listed twice`)
+		assert.Empty(t, applied)
+	})
+
+	t.Run("invalid manifest fails before applying anything", func(t *testing.T) {
+		applied := []string{}
+		c := newRecordingClient(&applied)
+
+		err := ApplyManifests(context.Background(), c, map[string]string{
+			"10-configmaps.yaml": configMapManifest,
+			"20-broken.yaml":     "kind: [unterminated",
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "20-broken.yaml")
+		assert.Empty(t, applied)
+	})
+}
+
+func TestApply(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "10-configmaps.yaml"), []byte(configMapManifest), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a manifest"), 0644))
+
+	applied := []string{}
+	c := newRecordingClient(&applied)
+
+	require.NoError(t, Apply(context.Background(), c, dir))
+	assert.Equal(t, []string{"ConfigMap/first", "ConfigMap/second"}, applied)
+}
+
+// newConflictingClient returns a fake client rejecting non-forced applies of ConfigMap/first with a field manager conflict
+// and recording the objects applied with forced ownership
+func newConflictingClient(forced *[]string) client.Client {
+	return fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			patchOpts := &client.PatchOptions{}
+			patchOpts.ApplyOptions(opts)
+			if patchOpts.Force != nil && *patchOpts.Force {
+				*forced = append(*forced, obj.GetObjectKind().GroupVersionKind().Kind+"/"+obj.GetName())
+				return nil
+			}
+			if obj.GetName() == "first" {
+				return apierrors.NewApplyConflict([]metav1.StatusCause{{
+					Type:    metav1.CauseTypeFieldManagerConflict,
+					Message: `conflict with "kubectl-edit"`,
+					Field:   ".data.key",
+				}}, "Apply failed with 1 conflict")
+			}
+			return nil
+		},
+	}).Build()
+}
+
+func TestApplyManifestsConfirmConflicts(t *testing.T) {
+	files := map[string]string{"20-configmaps.yaml": configMapManifest}
+
+	t.Run("take ownership when confirmed", func(t *testing.T) {
+		forced := []string{}
+		output := ui.NewRecording()
+		output.ConfirmResponses = []bool{true}
+		ctx := ui.WithOutput(context.Background(), output)
+
+		err := ApplyManifestsWithOptions(ctx, newConflictingClient(&forced), files, Options{ConfirmConflicts: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap/first"}, forced)
+		assert.Equal(t, []string{"    Take ownership of the conflicting fields of ConfigMap/first?"}, output.Confirms)
+		assert.Contains(t, output.Warnings, "Took ownership of conflicting fields:")
+		assert.Contains(t, output.Infos, `  ConfigMap/first: .data.key (conflict with "kubectl-edit")`)
+	})
+
+	t.Run("abort when denied", func(t *testing.T) {
+		forced := []string{}
+		output := ui.NewRecording()
+		output.ConfirmResponses = []bool{false}
+		ctx := ui.WithOutput(context.Background(), output)
+
+		err := ApplyManifestsWithOptions(ctx, newConflictingClient(&forced), files, Options{ConfirmConflicts: true})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "refused to take ownership of conflicting fields of ConfigMap/first")
+		assert.True(t, apierrors.IsConflict(err))
+		assert.Empty(t, forced)
+		assert.NotContains(t, output.Warnings, "Took ownership of conflicting fields:")
+	})
+
+	t.Run("always force ownership by default", func(t *testing.T) {
+		forced := []string{}
+		err := ApplyManifests(context.Background(), newConflictingClient(&forced), files)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap/first", "ConfigMap/second"}, forced)
+	})
+}
+
+func TestApplyManifestsFieldManager(t *testing.T) {
+	files := map[string]string{"20-configmaps.yaml": configMapManifest}
+
+	// newPatchRecordingClient records the field manager and force flag of every apply
+	newPatchRecordingClient := func(managers *[]string, forces *[]bool) client.Client {
+		return fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				patchOpts := &client.PatchOptions{}
+				patchOpts.ApplyOptions(opts)
+				*managers = append(*managers, patchOpts.FieldManager)
+				*forces = append(*forces, patchOpts.Force != nil && *patchOpts.Force)
+				return nil
+			},
+		}).Build()
+	}
+
+	t.Run("default field manager and forced ownership", func(t *testing.T) {
+		managers, forces := []string{}, []bool{}
+		require.NoError(t, ApplyManifests(context.Background(), newPatchRecordingClient(&managers, &forces), files))
+		assert.Equal(t, []string{FieldOwner, FieldOwner}, managers)
+		assert.Equal(t, []bool{true, true}, forces)
+	})
+
+	t.Run("custom field manager", func(t *testing.T) {
+		managers, forces := []string{}, []bool{}
+		err := ApplyManifestsWithOptions(context.Background(), newPatchRecordingClient(&managers, &forces), files, Options{FieldManager: "gitops"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"gitops", "gitops"}, managers)
+		assert.Equal(t, []bool{true, true}, forces)
+	})
+
+	t.Run("fail policy does not force ownership", func(t *testing.T) {
+		managers, forces := []string{}, []bool{}
+		err := ApplyManifestsWithOptions(context.Background(), newPatchRecordingClient(&managers, &forces), files,
+			Options{FieldManager: "gitops", ConflictPolicy: ConflictPolicyFail})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"gitops", "gitops"}, managers)
+		assert.Equal(t, []bool{false, false}, forces)
+	})
+
+	t.Run("fail policy stops on a conflict", func(t *testing.T) {
+		forced := []string{}
+		err := ApplyManifestsWithOptions(context.Background(), newConflictingClient(&forced), files, Options{ConflictPolicy: ConflictPolicyFail})
+		require.Error(t, err)
+		assert.True(t, apierrors.IsConflict(err))
+		assert.Empty(t, forced)
+	})
+}
+
+func TestApplyManifestsContinueOnError(t *testing.T) {
+	files := map[string]string{
+		"10-policy.yaml":     policyManifest,
+		"20-configmaps.yaml": configMapManifest,
+	}
+
+	// newFailingClient rejects applies of ConfigMap/first and records the other applied objects
+	newFailingClient := func(applied *[]string) client.Client {
+		return fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				u := obj.(*unstructured.Unstructured)
+				if u.GetName() == "first" {
+					return apierrors.NewForbidden(schema.GroupResource{Resource: "configmaps"}, "first", errors.New("denied"))
+				}
+				*applied = append(*applied, u.GetKind()+"/"+u.GetName())
+				return nil
+			},
+		}).Build()
+	}
+
+	t.Run("stop at the first failure by default", func(t *testing.T) {
+		applied := []string{}
+		err := ApplyManifests(context.Background(), newFailingClient(&applied), files)
+		require.Error(t, err)
+		assert.True(t, apierrors.IsForbidden(err))
+		assert.Equal(t, []string{"NicClusterPolicy/nic-cluster-policy"}, applied)
+	})
+
+	t.Run("apply the remaining objects and report the failures", func(t *testing.T) {
+		applied := []string{}
+		output := ui.NewRecording()
+		ctx := ui.WithOutput(context.Background(), output)
+
+		err := ApplyManifestsWithOptions(ctx, newFailingClient(&applied), files, Options{ContinueOnError: true})
+		require.Error(t, err)
+		assert.Equal(t, []string{"NicClusterPolicy/nic-cluster-policy", "ConfigMap/second"}, applied)
+
+		var applyErr *ApplyError
+		require.ErrorAs(t, err, &applyErr)
+		require.Len(t, applyErr.Failed, 1)
+		assert.Equal(t, "ConfigMap/first", applyErr.Failed[0].Object)
+		assert.True(t, apierrors.IsForbidden(err))
+		assert.Contains(t, err.Error(), "failed to apply 1 of 3 object(s): ConfigMap/first:")
+
+		assert.Contains(t, output.Errors, "Applied 2 of 3 manifest(s):")
+		assert.Contains(t, output.Infos, "  OK      NicClusterPolicy/nic-cluster-policy")
+		assert.Contains(t, output.Infos, "  OK      ConfigMap/second")
+		assert.Contains(t, output.Infos, "  FAILED  ConfigMap/first: "+applyErr.Failed[0].Err.Error())
+	})
+
+	t.Run("failed readiness checks are reported", func(t *testing.T) {
+		applied := []string{}
+		err := ApplyManifestsWithOptions(context.Background(), newRecordingClient(&applied), files, Options{
+			ContinueOnError: true,
+			ReadinessChecks: map[string]ReadinessCheck{
+				"NicClusterPolicy": func(ctx context.Context, c client.Client, obj *unstructured.Unstructured) error {
+					return errors.New("timed out")
+				},
+			},
+		})
+		require.Error(t, err)
+		assert.Len(t, applied, 3)
+		assert.Contains(t, err.Error(), "NicClusterPolicy/nic-cluster-policy: timed out")
+	})
+}
+
+func TestApplyManifestsSelection(t *testing.T) {
+	const daemonSetManifest = `apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: rdma-device-plugin
+  namespace: default
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: rdma-device-plugin-config
+  namespace: default
+`
+	files := map[string]string{
+		"10-policy.yaml":     policyManifest,
+		"20-configmaps.yaml": configMapManifest,
+		"30-daemonset.yaml":  daemonSetManifest,
+	}
+
+	apply := func(t *testing.T, opts Options) []string {
+		applied := []string{}
+		require.NoError(t, ApplyManifestsWithOptions(context.Background(), newRecordingClient(&applied), files, opts))
+		return applied
+	}
+
+	t.Run("only DaemonSet kinds", func(t *testing.T) {
+		assert.Equal(t, []string{"DaemonSet/rdma-device-plugin"}, apply(t, Options{Kinds: []string{"DaemonSet"}}))
+		assert.Equal(t, []string{"DaemonSet/rdma-device-plugin"}, apply(t, Options{Kinds: []string{"daemonset.apps"}}))
+		assert.Equal(t, []string{"DaemonSet/rdma-device-plugin"}, apply(t, Options{Kinds: []string{"apps/v1/DaemonSet"}}))
+		assert.Empty(t, apply(t, Options{Kinds: []string{"DaemonSet.extensions"}}))
+	})
+
+	t.Run("only files matching a glob", func(t *testing.T) {
+		assert.Equal(t, []string{"DaemonSet/rdma-device-plugin", "ConfigMap/rdma-device-plugin-config"}, apply(t, Options{FileGlobs: []string{"30-*.yaml"}}))
+	})
+
+	t.Run("files and kinds together", func(t *testing.T) {
+		assert.Equal(t, []string{"ConfigMap/first", "ConfigMap/second", "ConfigMap/rdma-device-plugin-config"},
+			apply(t, Options{FileGlobs: []string{"2*", "3*"}, Kinds: []string{"ConfigMap"}}))
+	})
+
+	t.Run("invalid glob", func(t *testing.T) {
+		err := ApplyManifestsWithOptions(context.Background(), newRecordingClient(&[]string{}), files, Options{FileGlobs: []string{"[.yaml"}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid file glob "[.yaml"`)
+	})
+}
+
+func TestApplyManifestsExclude(t *testing.T) {
+	const crdManifest = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: nicclusterpolicies.mellanox.com
+`
+	files := map[string]string{
+		"05-crd.yaml":        crdManifest,
+		"20-configmaps.yaml": configMapManifest,
+	}
+
+	apply := func(t *testing.T, exclude ...string) ([]string, []string, *ui.RecordingOutput) {
+		applied, waited := []string{}, []string{}
+		output := ui.NewRecording()
+		opts := Options{
+			Exclude: exclude,
+			ReadinessChecks: map[string]ReadinessCheck{
+				"CustomResourceDefinition": func(ctx context.Context, c client.Client, obj *unstructured.Unstructured) error {
+					waited = append(waited, obj.GetName())
+					return nil
+				},
+			},
+		}
+		require.NoError(t, ApplyManifestsWithOptions(ui.WithOutput(context.Background(), output), newRecordingClient(&applied), files, opts))
+		return applied, waited, output
+	}
+
+	t.Run("apply and wait on the CRD by default", func(t *testing.T) {
+		applied, waited, _ := apply(t)
+		assert.Equal(t, []string{"CustomResourceDefinition/nicclusterpolicies.mellanox.com", "ConfigMap/first", "ConfigMap/second"}, applied)
+		assert.Equal(t, []string{"nicclusterpolicies.mellanox.com"}, waited)
+	})
+
+	t.Run("exclude the CRD by kind", func(t *testing.T) {
+		applied, waited, output := apply(t, "customresourcedefinition.apiextensions.k8s.io")
+		assert.Equal(t, []string{"ConfigMap/first", "ConfigMap/second"}, applied)
+		assert.Empty(t, waited)
+		assert.Contains(t, output.Infos, "Excluded 1 file(s) and object(s):")
+		assert.Contains(t, output.Infos, "  CustomResourceDefinition/nicclusterpolicies.mellanox.com")
+	})
+
+	t.Run("exclude the CRD file by glob", func(t *testing.T) {
+		applied, waited, output := apply(t, "05-*.yaml")
+		assert.Equal(t, []string{"ConfigMap/first", "ConfigMap/second"}, applied)
+		assert.Empty(t, waited)
+		assert.Contains(t, output.Infos, "  05-crd.yaml")
+	})
+
+	t.Run("exclude by group/version/Kind", func(t *testing.T) {
+		applied, _, _ := apply(t, "/v1/ConfigMap")
+		assert.Equal(t, []string{"CustomResourceDefinition/nicclusterpolicies.mellanox.com"}, applied)
+	})
+
+	t.Run("invalid exclude pattern", func(t *testing.T) {
+		err := ApplyManifestsWithOptions(context.Background(), newRecordingClient(&[]string{}), files, Options{Exclude: []string{"[.yaml"}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid exclude pattern "[.yaml"`)
+	})
+}
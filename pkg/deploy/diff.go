@@ -0,0 +1,305 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	yaml "sigs.k8s.io/yaml"
+)
+
+// DefaultContextLines is the number of unchanged lines shown around each change of a diff
+const DefaultContextLines = 3
+
+// DiffOptions tunes how the differences between manifests and the cluster are rendered
+type DiffOptions struct {
+	// ContextLines is the number of unchanged lines shown around each change, longer unchanged sections are elided
+	ContextLines int
+}
+
+// ObjectDiff is the difference between a rendered object and its live version in the cluster
+type ObjectDiff struct {
+	// Object is the Kind/name of the object
+	Object string
+	// New is set when the object doesn't exist in the cluster yet
+	New bool
+	// Diff is the unified diff from the live to the rendered object, empty when they match
+	Diff string
+}
+
+// Diff compares every object of the manifest set (file name -> content) with its live version in the cluster.
+// Only the fields set by the manifests are compared, so fields defaulted by the cluster don't show up.
+func Diff(ctx context.Context, c client.Client, files map[string]string, opts DiffOptions) ([]ObjectDiff, error) {
+	objects, err := decodeManifests(files)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := make([]ObjectDiff, 0, len(objects))
+	for _, obj := range objects {
+		object := fmt.Sprintf("%s/%s", obj.GetKind(), obj.GetName())
+
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(obj.GroupVersionKind())
+		err := c.Get(ctx, client.ObjectKeyFromObject(obj), live)
+		isNew := apierrors.IsNotFound(err)
+		if err != nil && !isNew {
+			return nil, fmt.Errorf("failed to get %s: %w", object, err)
+		}
+
+		liveYAML := ""
+		if !isNew {
+			data, err := yaml.Marshal(pruneToDesired(live.Object, obj.Object))
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode live %s: %w", object, err)
+			}
+			liveYAML = string(data)
+		}
+		desiredYAML, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", object, err)
+		}
+
+		diffs = append(diffs, ObjectDiff{
+			Object: object,
+			New:    isNew,
+			Diff:   UnifiedDiff("live/"+object, "rendered/"+object, liveYAML, string(desiredYAML), opts.ContextLines),
+		})
+	}
+
+	return diffs, nil
+}
+
+// pruneToDesired keeps the fields of the live object that are set in the desired object
+func pruneToDesired(live, desired map[string]interface{}) map[string]interface{} {
+	pruned := make(map[string]interface{}, len(desired))
+	for key, desiredValue := range desired {
+		liveValue, ok := live[key]
+		if !ok {
+			continue
+		}
+		pruned[key] = pruneValue(liveValue, desiredValue)
+	}
+	return pruned
+}
+
+// pruneValue prunes the live value of a field to the desired one: the fields of objects, also in lists, that the
+// desired value doesn't set are dropped
+func pruneValue(live, desired interface{}) interface{} {
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		if liveMap, ok := live.(map[string]interface{}); ok {
+			return pruneToDesired(liveMap, desiredValue)
+		}
+	case []interface{}:
+		if liveList, ok := live.([]interface{}); ok {
+			return pruneList(liveList, desiredValue)
+		}
+	}
+	return live
+}
+
+// pruneList prunes the elements of the live list to the desired elements they pair with: by name when every desired
+// element is an object with a unique name, e.g. containers, by index otherwise. The live elements without a desired
+// element are kept, after the paired ones.
+func pruneList(live, desired []interface{}) []interface{} {
+	names, named := elementNames(desired)
+	if !named {
+		pruned := make([]interface{}, len(live))
+		for i, liveValue := range live {
+			if i < len(desired) {
+				liveValue = pruneValue(liveValue, desired[i])
+			}
+			pruned[i] = liveValue
+		}
+		return pruned
+	}
+
+	liveNames, _ := elementNames(live)
+	pruned := make([]interface{}, 0, len(live))
+	for i, name := range names {
+		if index := slices.Index(liveNames, name); index != -1 {
+			pruned = append(pruned, pruneValue(live[index], desired[i]))
+		}
+	}
+	for i, liveValue := range live {
+		if !slices.Contains(names, liveNames[i]) {
+			pruned = append(pruned, liveValue)
+		}
+	}
+	return pruned
+}
+
+// elementNames returns the name of every element of the list, empty for the elements that are no named object, and
+// whether every element is an object with a unique name
+func elementNames(list []interface{}) ([]string, bool) {
+	names := make([]string, len(list))
+	named := len(list) > 0
+	for i, element := range list {
+		object, _ := element.(map[string]interface{})
+		name, _ := object["name"].(string)
+		if name == "" || slices.Contains(names[:i], name) {
+			named = false
+		} else {
+			names[i] = name
+		}
+	}
+	return names, named
+}
+
+// diffLine is a line of an edit script: ' ' for unchanged, '-' for removed and '+' for added lines
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// UnifiedDiff renders the line differences between a and b in the unified format, with contextLines
+// unchanged lines around each change. An empty string is returned when a and b are equal.
+func UnifiedDiff(fromName, toName, a, b string, contextLines int) string {
+	if contextLines < 0 {
+		contextLines = 0
+	}
+
+	script := editScript(splitLines(a), splitLines(b))
+
+	var out strings.Builder
+	for start := 0; start < len(script); {
+		// Find the next change, a hunk starts contextLines before it
+		first := start
+		for first < len(script) && script[first].op == ' ' {
+			first++
+		}
+		if first == len(script) {
+			break
+		}
+
+		// Extend the hunk while the unchanged gap to the next change fits in the trailing and leading context
+		last := first
+		for i := first + 1; i < len(script); i++ {
+			if script[i].op == ' ' {
+				continue
+			}
+			if i-last-1 > 2*contextLines {
+				break
+			}
+			last = i
+		}
+
+		hunkStart := max(first-contextLines, start)
+		hunkEnd := min(last+contextLines+1, len(script))
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		writeHunk(&out, script, hunkStart, hunkEnd)
+		start = hunkEnd
+	}
+
+	return out.String()
+}
+
+// writeHunk writes the lines script[start:end] with their hunk header
+func writeHunk(out *strings.Builder, script []diffLine, start, end int) {
+	// Line numbers of the hunk start in a and b
+	aLine, bLine := 1, 1
+	for _, l := range script[:start] {
+		if l.op != '+' {
+			aLine++
+		}
+		if l.op != '-' {
+			bLine++
+		}
+	}
+
+	aCount, bCount := 0, 0
+	for _, l := range script[start:end] {
+		if l.op != '+' {
+			aCount++
+		}
+		if l.op != '-' {
+			bCount++
+		}
+	}
+	// An empty range refers to the line before it
+	if aCount == 0 {
+		aLine--
+	}
+	if bCount == 0 {
+		bLine--
+	}
+
+	fmt.Fprintf(out, "@@ -%d,%d +%d,%d @@\n", aLine, aCount, bLine, bCount)
+	for _, l := range script[start:end] {
+		fmt.Fprintf(out, "%c%s\n", l.op, l.text)
+	}
+}
+
+// editScript returns the shortest edit script turning a into b, based on their longest common subsequence
+func editScript(a, b []string) []diffLine {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	script := make([]diffLine, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			script = append(script, diffLine{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			script = append(script, diffLine{'-', a[i]})
+			i++
+		default:
+			script = append(script, diffLine{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		script = append(script, diffLine{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		script = append(script, diffLine{'+', b[j]})
+	}
+	return script
+}
+
+// splitLines splits s into lines without their line terminators
+func splitLines(s string) []string {
+	s = strings.TrimSuffix(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
@@ -0,0 +1,217 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// numberedLines returns count lines "line 1" to "line count"
+func numberedLines(count int) []string {
+	lines := make([]string, count)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", i+1)
+	}
+	return lines
+}
+
+// contextAround returns the number of unchanged lines before and after the changes of a single-hunk diff
+func contextAround(t *testing.T, diff string) (int, int) {
+	t.Helper()
+	lines := strings.Split(strings.TrimSuffix(diff, "\n"), "\n")
+	require.GreaterOrEqual(t, len(lines), 3)
+	body := lines[3:]
+
+	before := 0
+	for before < len(body) && strings.HasPrefix(body[before], " ") {
+		before++
+	}
+	after := 0
+	for after < len(body) && strings.HasPrefix(body[len(body)-1-after], " ") {
+		after++
+	}
+	return before, after
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	a := numberedLines(20)
+	b := numberedLines(20)
+	b[9] = "line 10 changed"
+	from, to := strings.Join(a, "\n")+"\n", strings.Join(b, "\n")+"\n"
+
+	for _, contextLines := range []int{0, 1, 3, 5} {
+		t.Run(fmt.Sprintf("%d context lines", contextLines), func(t *testing.T) {
+			diff := UnifiedDiff("a", "b", from, to, contextLines)
+			before, after := contextAround(t, diff)
+			assert.Equal(t, contextLines, before)
+			assert.Equal(t, contextLines, after)
+			assert.Contains(t, diff, "-line 10\n+line 10 changed\n")
+			assert.Contains(t, diff, fmt.Sprintf("@@ -%d,%d +%d,%d @@", 10-contextLines, 2*contextLines+1, 10-contextLines, 2*contextLines+1))
+		})
+	}
+
+	t.Run("elide large unchanged sections", func(t *testing.T) {
+		b := numberedLines(20)
+		b[1] = "line 2 changed"
+		b[17] = "line 18 changed"
+
+		diff := UnifiedDiff("a", "b", from, strings.Join(b, "\n")+"\n", 3)
+		assert.Equal(t, 2, strings.Count(diff, "@@ -"))
+		assert.NotContains(t, diff, "line 10")
+		assert.True(t, strings.HasPrefix(diff, "--- a\n+++ b\n@@ -1,5 +1,5 @@\n line 1\n-line 2\n+line 2 changed\n"), diff)
+	})
+
+	t.Run("close changes share a hunk", func(t *testing.T) {
+		b := numberedLines(20)
+		b[5] = "line 6 changed"
+		b[10] = "line 11 changed"
+
+		diff := UnifiedDiff("a", "b", from, strings.Join(b, "\n")+"\n", 3)
+		assert.Equal(t, 1, strings.Count(diff, "@@ -"))
+	})
+
+	t.Run("no diff for equal input", func(t *testing.T) {
+		assert.Empty(t, UnifiedDiff("a", "b", from, from, 3))
+	})
+
+	t.Run("new content", func(t *testing.T) {
+		assert.Equal(t, "--- a\n+++ b\n@@ -0,0 +1,2 @@\n+line 1\n+line 2\n", UnifiedDiff("a", "b", "", "line 1\nline 2\n", 3))
+	})
+}
+
+func TestDiff(t *testing.T) {
+	live := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "first",
+			Namespace:       "default",
+			ResourceVersion: "42",
+			Labels:          map[string]string{"added-by": "cluster"},
+		},
+		Data: map[string]string{"key": "old"},
+	}
+	c := fake.NewClientBuilder().WithObjects(live).Build()
+
+	diffs, err := Diff(context.Background(), c, map[string]string{"20-configmaps.yaml": configMapManifest}, DiffOptions{ContextLines: DefaultContextLines})
+	require.NoError(t, err)
+	require.Len(t, diffs, 2)
+
+	assert.Equal(t, "ConfigMap/first", diffs[0].Object)
+	assert.False(t, diffs[0].New)
+	assert.Contains(t, diffs[0].Diff, "--- live/ConfigMap/first\n+++ rendered/ConfigMap/first\n")
+	assert.Contains(t, diffs[0].Diff, "-  key: old\n+  key: value\n")
+	assert.NotContains(t, diffs[0].Diff, "resourceVersion", "fields not set by the manifest are ignored")
+	assert.NotContains(t, diffs[0].Diff, "added-by")
+
+	assert.Equal(t, "ConfigMap/second", diffs[1].Object)
+	assert.True(t, diffs[1].New)
+	assert.Contains(t, diffs[1].Diff, "+  name: second\n")
+}
+
+func TestPruneToDesired(t *testing.T) {
+	t.Run("prune the elements of a named list by name", func(t *testing.T) {
+		live := map[string]interface{}{"containers": []interface{}{
+			map[string]interface{}{"name": "sidecar", "image": "proxy"},
+			map[string]interface{}{"name": "main", "image": "app", "imagePullPolicy": "IfNotPresent"},
+		}}
+		desired := map[string]interface{}{"containers": []interface{}{
+			map[string]interface{}{"name": "main", "image": "app"},
+		}}
+
+		assert.Equal(t, map[string]interface{}{"containers": []interface{}{
+			map[string]interface{}{"name": "main", "image": "app"},
+			map[string]interface{}{"name": "sidecar", "image": "proxy"},
+		}}, pruneToDesired(live, desired), "the live element without a desired one is kept")
+	})
+
+	t.Run("prune the elements of other lists by index", func(t *testing.T) {
+		live := map[string]interface{}{"rules": []interface{}{
+			map[string]interface{}{"port": int64(80), "protocol": "TCP"},
+			map[string]interface{}{"port": int64(443), "protocol": "TCP"},
+		}}
+		desired := map[string]interface{}{"rules": []interface{}{
+			map[string]interface{}{"port": int64(80)},
+			map[string]interface{}{"port": int64(8443)},
+		}}
+
+		assert.Equal(t, map[string]interface{}{"rules": []interface{}{
+			map[string]interface{}{"port": int64(80)},
+			map[string]interface{}{"port": int64(443)},
+		}}, pruneToDesired(live, desired))
+	})
+
+	t.Run("keep lists of scalars", func(t *testing.T) {
+		live := map[string]interface{}{"args": []interface{}{"--a", "--b"}}
+		assert.Equal(t, live, pruneToDesired(live, map[string]interface{}{"args": []interface{}{"--a"}}))
+	})
+}
+
+func TestDiffListDefaults(t *testing.T) {
+	const podManifest = `apiVersion: v1
+kind: Pod
+metadata:
+  name: test-pod
+  namespace: default
+spec:
+  containers:
+  - name: main
+    image: app:1.0
+    ports:
+    - containerPort: 8080
+`
+	newLive := func(image string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default"},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name:                     "main",
+				Image:                    image,
+				ImagePullPolicy:          corev1.PullIfNotPresent,
+				TerminationMessagePath:   "/dev/termination-log",
+				TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+				Ports:                    []corev1.ContainerPort{{ContainerPort: 8080, Protocol: corev1.ProtocolTCP}},
+			}}},
+		}
+	}
+	files := map[string]string{"50-pod.yaml": podManifest}
+
+	t.Run("no diff for the fields defaulted in a list", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithObjects(newLive("app:1.0")).Build()
+
+		diffs, err := Diff(context.Background(), c, files, DiffOptions{ContextLines: DefaultContextLines})
+		require.NoError(t, err)
+		require.Len(t, diffs, 1)
+		assert.Empty(t, diffs[0].Diff)
+	})
+
+	t.Run("diff a changed field in a list", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithObjects(newLive("app:0.9")).Build()
+
+		diffs, err := Diff(context.Background(), c, files, DiffOptions{ContextLines: DefaultContextLines})
+		require.NoError(t, err)
+		require.Len(t, diffs, 1)
+		assert.Contains(t, diffs[0].Diff, "-  - image: app:0.9\n+  - image: app:1.0\n")
+		assert.NotContains(t, diffs[0].Diff, "imagePullPolicy")
+	})
+}
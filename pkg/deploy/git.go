@@ -0,0 +1,161 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/secrets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// GitOptions configures how manifests are fetched from a Git repository and applied
+type GitOptions struct {
+	// Secrets provides the credentials of http(s) repositories, looked up with secrets.GitCredentials
+	Secrets secrets.Provider
+	// Apply tunes how the fetched manifests are applied
+	Apply Options
+}
+
+// ApplyFromGit fetches the ref (branch, tag or commit) of the Git repository and applies the manifests
+// of the directory at path in the repository. Credentials are read with secrets.EnvProvider.
+func ApplyFromGit(ctx context.Context, c client.Client, repoURL, ref, path string) error {
+	return ApplyFromGitWithOptions(ctx, c, repoURL, ref, path, GitOptions{Secrets: secrets.EnvProvider{}})
+}
+
+// ApplyFromGitWithOptions fetches the ref of the Git repository and applies the manifests of the directory at path
+func ApplyFromGitWithOptions(ctx context.Context, c client.Client, repoURL, ref, path string, opts GitOptions) error {
+	files, err := FetchGitManifests(ctx, repoURL, ref, path, opts.Secrets)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no manifests found in %s at %s of %s", path, ref, repoURL)
+	}
+
+	return ApplyManifestsWithOptions(ctx, c, files, opts.Apply)
+}
+
+// FetchGitManifests returns the manifests (file name -> content) of the directory at path in the ref of the repository
+func FetchGitManifests(ctx context.Context, repoURL, ref, path string, provider secrets.Provider) (map[string]string, error) {
+	if ref == "" {
+		return nil, fmt.Errorf("a Git ref is required to fetch manifests from %s", repoURL)
+	}
+	// git would take them for options, checkout has no -- before a ref
+	if strings.HasPrefix(repoURL, "-") {
+		return nil, fmt.Errorf("invalid Git repository %s: must not start with -", repoURL)
+	}
+	if strings.HasPrefix(ref, "-") {
+		return nil, fmt.Errorf("invalid Git ref %s: must not start with -", ref)
+	}
+	path = filepath.Clean(path)
+	if !filepath.IsLocal(path) && path != "." {
+		return nil, fmt.Errorf("manifests path %s must be inside the repository", path)
+	}
+
+	dir, err := os.MkdirTemp("", "l8k-git-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create checkout directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	env, err := gitAuthEnv(repoURL, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Log.Info("Fetching manifests from Git", "repository", repoURL, "ref", ref, "path", path)
+	if err := runGit(ctx, dir, env, "init", "--quiet"); err != nil {
+		return nil, err
+	}
+	// Servers may refuse fetching a commit by hash, the whole repository is fetched then
+	if err := runGit(ctx, dir, env, "fetch", "--quiet", "--depth", "1", "--", repoURL, ref); err != nil {
+		log.Log.V(1).Info("Shallow fetch of the ref failed, fetching the repository", "ref", ref, "error", err.Error())
+		if err := runGit(ctx, dir, env, "fetch", "--quiet", "--tags", "--", repoURL, "+refs/heads/*:refs/remotes/origin/*"); err != nil {
+			return nil, fmt.Errorf("failed to fetch %s: %w", repoURL, err)
+		}
+		if err := runGit(ctx, dir, env, "checkout", "--quiet", ref); err != nil {
+			return nil, fmt.Errorf("ref %s not found in %s: %w", ref, repoURL, err)
+		}
+	} else if err := runGit(ctx, dir, env, "checkout", "--quiet", "FETCH_HEAD"); err != nil {
+		return nil, err
+	}
+
+	files, err := ReadManifestsDir(filepath.Join(dir, path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifests from %s at %s: %w", path, ref, err)
+	}
+	return files, nil
+}
+
+// gitAuthEnv returns the environment passing the credentials of the repository host to git as an
+// Authorization header, keeping them out of the command line. Only http(s) repositories use credentials.
+// The header is appended to the config entries the environment already passes with GIT_CONFIG_COUNT.
+func gitAuthEnv(repoURL string, provider secrets.Provider) ([]string, error) {
+	env := append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	u, err := url.Parse(repoURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return env, nil
+	}
+	username, password, err := secrets.GitCredentials(provider, u.Host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Git credentials for %s: %w", u.Host, err)
+	}
+	if username == "" && password == "" {
+		return env, nil
+	}
+
+	count := 0
+	if value := os.Getenv("GIT_CONFIG_COUNT"); value != "" {
+		if count, err = strconv.Atoi(value); err != nil || count < 0 {
+			return nil, fmt.Errorf("invalid GIT_CONFIG_COUNT %s in the environment", value)
+		}
+	}
+	env = slices.DeleteFunc(env, func(entry string) bool { return strings.HasPrefix(entry, "GIT_CONFIG_COUNT=") })
+
+	auth := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	return append(env,
+		fmt.Sprintf("GIT_CONFIG_COUNT=%d", count+1),
+		fmt.Sprintf("GIT_CONFIG_KEY_%d=http.extraHeader", count),
+		fmt.Sprintf("GIT_CONFIG_VALUE_%d=Authorization: Basic %s", count, auth),
+	), nil
+}
+
+// runGit runs a git command in dir, returning its error output on failure
+func runGit(ctx context.Context, dir string, env []string, args ...string) error {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = env
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
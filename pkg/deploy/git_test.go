@@ -0,0 +1,170 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/secrets"
+)
+
+// newGitFixture creates a repository with a commit per revision (file path -> content) and returns its
+// path and the hashes of the commits
+func newGitFixture(t *testing.T, revisions ...map[string]string) (string, []string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	git := func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com", "-c", "init.defaultBranch=main"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+
+	git("init", "--quiet")
+	commits := []string{}
+	for i, files := range revisions {
+		for name, content := range files {
+			path := filepath.Join(dir, name)
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+			require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		}
+		git("add", "--all")
+		git("commit", "--quiet", "-m", "revision "+string(rune('1'+i)))
+		commits = append(commits, git("rev-parse", "HEAD"))
+	}
+	return dir, commits
+}
+
+func TestApplyFromGit(t *testing.T) {
+	repo, commits := newGitFixture(t,
+		map[string]string{
+			"clusters/prod/10-policy.yaml": policyManifest,
+			"README.md":                    "manifests",
+		},
+		map[string]string{
+			"clusters/prod/20-configmaps.yaml": configMapManifest,
+		},
+	)
+
+	t.Run("apply the manifests of the branch", func(t *testing.T) {
+		applied := []string{}
+		require.NoError(t, ApplyFromGit(context.Background(), newRecordingClient(&applied), repo, "main", "clusters/prod"))
+		assert.Equal(t, []string{"NicClusterPolicy/nic-cluster-policy", "ConfigMap/first", "ConfigMap/second"}, applied)
+	})
+
+	t.Run("apply the manifests of a pinned commit", func(t *testing.T) {
+		applied := []string{}
+		require.NoError(t, ApplyFromGit(context.Background(), newRecordingClient(&applied), "file://"+repo, commits[0], "clusters/prod"))
+		assert.Equal(t, []string{"NicClusterPolicy/nic-cluster-policy"}, applied)
+	})
+
+	t.Run("apply with options", func(t *testing.T) {
+		applied := []string{}
+		err := ApplyFromGitWithOptions(context.Background(), newRecordingClient(&applied), repo, "main", "clusters/prod", GitOptions{
+			Apply: Options{Kinds: []string{"ConfigMap"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap/first", "ConfigMap/second"}, applied)
+	})
+
+	t.Run("fail on an unknown ref", func(t *testing.T) {
+		applied := []string{}
+		err := ApplyFromGit(context.Background(), newRecordingClient(&applied), repo, "v9.9.9", "clusters/prod")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ref v9.9.9 not found")
+		assert.Empty(t, applied)
+	})
+
+	t.Run("fail on a path without manifests", func(t *testing.T) {
+		applied := []string{}
+		err := ApplyFromGit(context.Background(), newRecordingClient(&applied), repo, "main", ".")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no manifests found in .")
+
+		err = ApplyFromGit(context.Background(), newRecordingClient(&applied), repo, "main", "clusters/staging")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read manifests from clusters/staging")
+		assert.Empty(t, applied)
+	})
+
+	t.Run("reject paths outside the repository", func(t *testing.T) {
+		_, err := FetchGitManifests(context.Background(), repo, "main", "../etc", nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must be inside the repository")
+	})
+
+	t.Run("reject a ref or repository git takes for an option", func(t *testing.T) {
+		_, err := FetchGitManifests(context.Background(), repo, "--upload-pack=touch /tmp/pwned", "clusters/prod", nil)
+		assert.EqualError(t, err, "invalid Git ref --upload-pack=touch /tmp/pwned: must not start with -")
+
+		_, err = FetchGitManifests(context.Background(), "--upload-pack=touch /tmp/pwned", "main", "clusters/prod", nil)
+		assert.EqualError(t, err, "invalid Git repository --upload-pack=touch /tmp/pwned: must not start with -")
+	})
+}
+
+func TestGitAuthEnv(t *testing.T) {
+	provider := secrets.MapProvider{
+		"git/git.example.com/username": "deploy",
+		"git/git.example.com/password": "token",
+	}
+
+	t.Run("pass the credentials of the host as a header", func(t *testing.T) {
+		env, err := gitAuthEnv("https://git.example.com/org/manifests.git", provider)
+		require.NoError(t, err)
+		assert.Contains(t, env, "GIT_CONFIG_KEY_0=http.extraHeader")
+		assert.Contains(t, env, "GIT_CONFIG_VALUE_0=Authorization: Basic "+base64.StdEncoding.EncodeToString([]byte("deploy:token")))
+	})
+
+	t.Run("append the header to the config entries of the environment", func(t *testing.T) {
+		t.Setenv("GIT_CONFIG_COUNT", "1")
+		t.Setenv("GIT_CONFIG_KEY_0", "http.proxy")
+		t.Setenv("GIT_CONFIG_VALUE_0", "http://proxy.example.com:3128")
+
+		env, err := gitAuthEnv("https://git.example.com/org/manifests.git", provider)
+		require.NoError(t, err)
+		assert.Contains(t, env, "GIT_CONFIG_KEY_0=http.proxy")
+		assert.Contains(t, env, "GIT_CONFIG_KEY_1=http.extraHeader")
+		assert.Contains(t, env, "GIT_CONFIG_COUNT=2")
+		assert.NotContains(t, env, "GIT_CONFIG_COUNT=1")
+
+		t.Setenv("GIT_CONFIG_COUNT", "many")
+		_, err = gitAuthEnv("https://git.example.com/org/manifests.git", provider)
+		assert.EqualError(t, err, "invalid GIT_CONFIG_COUNT many in the environment")
+	})
+
+	t.Run("no credentials for other hosts and local repositories", func(t *testing.T) {
+		for _, repoURL := range []string{"https://github.com/org/manifests.git", "file:///srv/manifests", "/srv/manifests", "git@git.example.com:org/manifests.git"} {
+			env, err := gitAuthEnv(repoURL, provider)
+			require.NoError(t, err)
+			assert.NotContains(t, env, "GIT_CONFIG_KEY_0=http.extraHeader", repoURL)
+		}
+	})
+}
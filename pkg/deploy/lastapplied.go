@@ -0,0 +1,84 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/csaupgrade"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// LastAppliedAnnotation is the annotation in which kubectl client-side apply records the applied configuration
+const LastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// csaManagers are the field managers kubectl uses for client-side apply
+var csaManagers = sets.New("kubectl-client-side-apply", "kubectl", "before-first-apply")
+
+// lastAppliedPatch returns the JSON patch that prepares obj, previously managed by kubectl client-side apply, for
+// server-side apply. It removes the last-applied-configuration annotation and hands the fields owned by the
+// client-side apply managers over to fieldManager. It returns nil when obj has no last-applied annotation.
+func lastAppliedPatch(obj *unstructured.Unstructured, fieldManager string) ([]byte, error) {
+	if _, ok := obj.GetAnnotations()[LastAppliedAnnotation]; !ok {
+		return nil, nil
+	}
+
+	ops := []map[string]interface{}{{
+		"op":   "remove",
+		"path": "/metadata/annotations/" + strings.ReplaceAll(LastAppliedAnnotation, "/", "~1"),
+	}}
+
+	upgraded := obj.DeepCopy()
+	if err := csaupgrade.UpgradeManagedFields(upgraded, csaManagers, fieldManager); err != nil {
+		return nil, fmt.Errorf("failed to migrate managed fields: %w", err)
+	}
+	if !reflect.DeepEqual(obj.GetManagedFields(), upgraded.GetManagedFields()) {
+		ops = append(ops, map[string]interface{}{
+			"op":    "replace",
+			"path":  "/metadata/managedFields",
+			"value": upgraded.GetManagedFields(),
+		})
+	}
+	// Replacing the resourceVersion makes the patch fail with a conflict if obj changed since it was read
+	ops = append(ops, map[string]interface{}{
+		"op":    "replace",
+		"path":  "/metadata/resourceVersion",
+		"value": obj.GetResourceVersion(),
+	})
+
+	return json.Marshal(ops)
+}
+
+// migrateLastApplied migrates an applied object away from kubectl client-side apply, see lastAppliedPatch.
+// obj is the object as returned by the server-side apply. It returns whether the object was migrated.
+func migrateLastApplied(ctx context.Context, c client.Client, obj *unstructured.Unstructured, fieldManager string) (bool, error) {
+	patch, err := lastAppliedPatch(obj, fieldManager)
+	if err != nil || patch == nil {
+		return false, err
+	}
+	if err := c.Patch(ctx, obj, client.RawPatch(types.JSONPatchType, patch), client.FieldOwner(fieldManager)); err != nil {
+		return false, fmt.Errorf("failed to remove the %s annotation: %w", LastAppliedAnnotation, err)
+	}
+	return true, nil
+}
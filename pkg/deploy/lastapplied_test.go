@@ -0,0 +1,107 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// newLastAppliedClient returns a fake client holding the given objects. Server-side apply patches are recorded and
+// return the live object, like the API server does; other patches are applied to the fake client.
+func newLastAppliedClient(applied *[]string, objs ...client.Object) client.Client {
+	return fake.NewClientBuilder().WithObjects(objs...).WithInterceptorFuncs(interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			if patch.Type() != types.ApplyPatchType {
+				return c.Patch(ctx, obj, patch, opts...)
+			}
+			*applied = append(*applied, obj.GetName())
+			return client.IgnoreNotFound(c.Get(ctx, client.ObjectKeyFromObject(obj), obj))
+		},
+	}).Build()
+}
+
+func TestApplyManifestsMigratesLastApplied(t *testing.T) {
+	files := map[string]string{"20-configmaps.yaml": configMapManifest}
+
+	t.Run("remove the annotation and hand over the client-side apply fields", func(t *testing.T) {
+		existing := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "first",
+				Namespace: "default",
+				Annotations: map[string]string{
+					LastAppliedAnnotation: `{"apiVersion":"v1","kind":"ConfigMap","data":{"key":"old"}}`,
+					"team":                "networking",
+				},
+				ManagedFields: []metav1.ManagedFieldsEntry{{
+					Manager:    "kubectl-client-side-apply",
+					Operation:  metav1.ManagedFieldsOperationUpdate,
+					APIVersion: "v1",
+					FieldsType: "FieldsV1",
+					FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:data":{"f:key":{}}}`)},
+				}},
+			},
+			Data: map[string]string{"key": "old"},
+		}
+		applied := []string{}
+		c := newLastAppliedClient(&applied, existing)
+		output := ui.NewRecording()
+		ctx := ui.WithOutput(context.Background(), output)
+
+		require.NoError(t, ApplyManifestsWithOptions(ctx, c, files, Options{}))
+		assert.Equal(t, []string{"first", "second"}, applied)
+		assert.Contains(t, output.Infos, "    Migrated ConfigMap/first from kubectl client-side apply")
+		assert.NotContains(t, output.Infos, "    Migrated ConfigMap/second from kubectl client-side apply")
+
+		var migrated corev1.ConfigMap
+		require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(existing), &migrated))
+		assert.Equal(t, map[string]string{"team": "networking"}, migrated.Annotations)
+		require.Len(t, migrated.ManagedFields, 1)
+		assert.Equal(t, FieldOwner, migrated.ManagedFields[0].Manager)
+		assert.Equal(t, metav1.ManagedFieldsOperationApply, migrated.ManagedFields[0].Operation)
+	})
+
+	t.Run("objects without the annotation are left untouched", func(t *testing.T) {
+		existing := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "first", Namespace: "default", Annotations: map[string]string{"team": "networking"}},
+		}
+		applied := []string{}
+		c := newLastAppliedClient(&applied, existing)
+		output := ui.NewRecording()
+		ctx := ui.WithOutput(context.Background(), output)
+
+		require.NoError(t, ApplyManifestsWithOptions(ctx, c, files, Options{FieldManager: "custom"}))
+		assert.Equal(t, []string{"first", "second"}, applied)
+		for _, info := range output.Infos {
+			assert.NotContains(t, info, "Migrated")
+		}
+
+		var current corev1.ConfigMap
+		require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(existing), &current))
+		assert.Equal(t, existing.ResourceVersion, current.ResourceVersion)
+	})
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	yaml "sigs.k8s.io/yaml"
+)
+
+// ParseManifests decodes the objects of a multi-document YAML stream. Documents that are empty or hold only
+// comments are skipped, every other document must be an object with an apiVersion and a kind.
+func ParseManifests(data []byte) ([]unstructured.Unstructured, error) {
+	var objects []unstructured.Unstructured
+	for i, doc := range SplitYAMLDocuments(string(data)) {
+		obj := unstructured.Unstructured{}
+		if err := yaml.Unmarshal([]byte(doc), &obj.Object); err != nil {
+			return nil, fmt.Errorf("document %d: %w", i+1, err)
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+		if obj.GetAPIVersion() == "" || obj.GetKind() == "" {
+			return nil, fmt.Errorf("document %d: apiVersion and kind must be set", i+1)
+		}
+		objects = append(objects, obj)
+	}
+	return objects, nil
+}
+
+// SplitYAMLDocuments splits a YAML stream into its documents, dropping the empty ones.
+// A document starts after a '---' separator line and ends at the next separator or a '...' end marker line;
+// the separators must start at the first column, so indented block scalars such as PEM data are kept intact.
+func SplitYAMLDocuments(s string) []string {
+	var docs []string
+	var cur []string
+	flush := func() {
+		if doc := strings.Join(cur, "\n"); strings.TrimSpace(doc) != "" {
+			docs = append(docs, doc)
+		}
+		cur = nil
+	}
+
+	for _, ln := range strings.Split(s, "\n") {
+		ln = strings.TrimSuffix(ln, "\r")
+		switch {
+		case isDocumentSeparator(ln, "---"):
+			flush()
+			// Content may follow the separator on the same line
+			if rest := strings.TrimSpace(ln[3:]); rest != "" && !strings.HasPrefix(rest, "#") {
+				cur = append(cur, rest)
+			}
+		case isDocumentSeparator(ln, "..."):
+			flush()
+		default:
+			cur = append(cur, ln)
+		}
+	}
+	flush()
+	return docs
+}
+
+// isDocumentSeparator reports whether the line is the marker, alone or followed by whitespace
+func isDocumentSeparator(line, marker string) bool {
+	rest, ok := strings.CutPrefix(line, marker)
+	return ok && (rest == "" || rest[0] == ' ' || rest[0] == '\t')
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestParseManifests(t *testing.T) {
+	names := func(objects []unstructured.Unstructured) []string {
+		result := []string{}
+		for _, obj := range objects {
+			result = append(result, obj.GetKind()+"/"+obj.GetName())
+		}
+		return result
+	}
+
+	t.Run("split multi-document files", func(t *testing.T) {
+		objects, err := ParseManifests([]byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: first\n---\napiVersion: v1\nkind: Secret\nmetadata:\n  name: second\n"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap/first", "Secret/second"}, names(objects))
+	})
+
+	t.Run("skip leading separators and empty documents", func(t *testing.T) {
+		objects, err := ParseManifests([]byte("---\n\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: first\n---\n---\n   \n...\n"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap/first"}, names(objects))
+	})
+
+	t.Run("skip comment-only documents", func(t *testing.T) {
+		objects, err := ParseManifests([]byte("# generated by l8k\n--- # the network\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: first\n---\n# nothing to apply\n"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap/first"}, names(objects))
+	})
+
+	t.Run("parse nothing from an empty file", func(t *testing.T) {
+		objects, err := ParseManifests([]byte("# only a comment\n"))
+		require.NoError(t, err)
+		assert.Empty(t, objects)
+	})
+
+	t.Run("keep dashes inside block scalars", func(t *testing.T) {
+		manifest := "apiVersion: v1\nkind: Secret\nmetadata:\n  name: ca\nstringData:\n  ca.crt: |\n    -----BEGIN CERTIFICATE-----\n    MIIB\n    -----END CERTIFICATE-----\n"
+		objects, err := ParseManifests([]byte(manifest))
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+		data, _, err := unstructured.NestedString(objects[0].Object, "stringData", "ca.crt")
+		require.NoError(t, err)
+		assert.Equal(t, "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n", data)
+	})
+
+	t.Run("handle CRLF line endings", func(t *testing.T) {
+		objects, err := ParseManifests([]byte("apiVersion: v1\r\nkind: ConfigMap\r\nmetadata:\r\n  name: first\r\n---\r\napiVersion: v1\r\nkind: ConfigMap\r\nmetadata:\r\n  name: second\r\n"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap/first", "ConfigMap/second"}, names(objects))
+	})
+
+	t.Run("fail on a document without kind", func(t *testing.T) {
+		_, err := ParseManifests([]byte("apiVersion: v1\nkind: ConfigMap\n---\napiVersion: v1\nmetadata:\n  name: second\n"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "document 2: apiVersion and kind must be set")
+	})
+
+	t.Run("fail on invalid YAML", func(t *testing.T) {
+		_, err := ParseManifests([]byte("apiVersion: v1\nkind: [ConfigMap\n"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "document 1")
+	})
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Owner is the parent object set as owner reference of the applied objects
+type Owner struct {
+	APIVersion string
+	Kind       string
+	Name       string
+	UID        types.UID
+	// Namespace of the owner, empty when the owner is cluster-scoped
+	Namespace string
+}
+
+// validate checks that the owner is fully identified
+func (o Owner) validate() error {
+	if o.APIVersion == "" || o.Kind == "" || o.Name == "" || o.UID == "" {
+		return fmt.Errorf("owner must have an apiVersion, kind, name and uid, got %s", o)
+	}
+	return nil
+}
+
+func (o Owner) String() string {
+	if o.Namespace == "" {
+		return fmt.Sprintf("%s/%s (%s)", o.Kind, o.Name, o.UID)
+	}
+	return fmt.Sprintf("%s/%s/%s (%s)", o.Kind, o.Namespace, o.Name, o.UID)
+}
+
+// reference returns the owner reference pointing to the owner
+func (o Owner) reference() metav1.OwnerReference {
+	return metav1.OwnerReference{
+		APIVersion: o.APIVersion,
+		Kind:       o.Kind,
+		Name:       o.Name,
+		UID:        o.UID,
+	}
+}
+
+// canOwn reports whether the owner can own obj. A namespaced owner only owns namespaced objects of its own
+// namespace; a cluster-scoped owner owns any object. The scope of objects unknown to the REST mapper is
+// guessed from their namespace.
+func (o Owner) canOwn(c client.Client, obj *unstructured.Unstructured) bool {
+	if o.Namespace == "" {
+		return true
+	}
+	namespaced, err := c.IsObjectNamespaced(obj)
+	if err != nil {
+		namespaced = obj.GetNamespace() != ""
+	}
+	return namespaced && obj.GetNamespace() == o.Namespace
+}
+
+// setOwnerReference adds the owner reference of the owner to obj, replacing any existing one with the same uid.
+// It returns false, leaving obj untouched, when the owner cannot own obj.
+func setOwnerReference(c client.Client, obj *unstructured.Unstructured, owner Owner) bool {
+	if !owner.canOwn(c, obj) {
+		return false
+	}
+
+	var refs []metav1.OwnerReference
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID != owner.UID {
+			refs = append(refs, ref)
+		}
+	}
+	obj.SetOwnerReferences(append(refs, owner.reference()))
+	return true
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// newOwnerRecordingClient returns a fake client that records the owner references of the applied objects by Kind/name
+func newOwnerRecordingClient(owners map[string][]metav1.OwnerReference) client.Client {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "mellanox.com", Version: "v1alpha1", Kind: "NicClusterPolicy"}, meta.RESTScopeRoot)
+
+	return fake.NewClientBuilder().WithRESTMapper(mapper).WithInterceptorFuncs(interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			u := obj.(*unstructured.Unstructured)
+			owners[u.GetKind()+"/"+u.GetName()] = u.GetOwnerReferences()
+			return nil
+		},
+	}).Build()
+}
+
+func TestApplyWithOwner(t *testing.T) {
+	files := map[string]string{
+		"10-policy.yaml":     policyManifest,
+		"20-configmaps.yaml": configMapManifest,
+	}
+	ref := metav1.OwnerReference{APIVersion: "example.com/v1", Kind: "Launch", Name: "cluster", UID: "1234"}
+
+	t.Run("namespaced owner owns the objects of its namespace", func(t *testing.T) {
+		owners := map[string][]metav1.OwnerReference{}
+		owner := &Owner{APIVersion: "example.com/v1", Kind: "Launch", Name: "cluster", UID: "1234", Namespace: "default"}
+
+		require.NoError(t, ApplyManifestsWithOptions(context.Background(), newOwnerRecordingClient(owners), files, Options{Owner: owner}))
+		assert.Equal(t, []metav1.OwnerReference{ref}, owners["ConfigMap/first"])
+		assert.Equal(t, []metav1.OwnerReference{ref}, owners["ConfigMap/second"])
+		assert.Contains(t, owners, "NicClusterPolicy/nic-cluster-policy")
+		assert.Empty(t, owners["NicClusterPolicy/nic-cluster-policy"])
+	})
+
+	t.Run("namespaced owner skips objects of other namespaces", func(t *testing.T) {
+		owners := map[string][]metav1.OwnerReference{}
+		owner := &Owner{APIVersion: "example.com/v1", Kind: "Launch", Name: "cluster", UID: "1234", Namespace: "nvidia-network-operator"}
+
+		require.NoError(t, ApplyManifestsWithOptions(context.Background(), newOwnerRecordingClient(owners), files, Options{Owner: owner}))
+		assert.Len(t, owners, 3)
+		for object, refs := range owners {
+			assert.Empty(t, refs, object)
+		}
+	})
+
+	t.Run("cluster-scoped owner owns all objects", func(t *testing.T) {
+		owners := map[string][]metav1.OwnerReference{}
+		owner := &Owner{APIVersion: "example.com/v1", Kind: "Launch", Name: "cluster", UID: "1234"}
+
+		require.NoError(t, ApplyManifestsWithOptions(context.Background(), newOwnerRecordingClient(owners), files, Options{Owner: owner}))
+		assert.Len(t, owners, 3)
+		for object, refs := range owners {
+			assert.Equal(t, []metav1.OwnerReference{ref}, refs, object)
+		}
+	})
+
+	t.Run("existing owner references are kept", func(t *testing.T) {
+		owners := map[string][]metav1.OwnerReference{}
+		owner := &Owner{APIVersion: "example.com/v1", Kind: "Launch", Name: "cluster", UID: "1234"}
+		manifest := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: owned
+  namespace: default
+  ownerReferences:
+  - apiVersion: v1
+    kind: ConfigMap
+    name: parent
+    uid: "5678"
+  - apiVersion: example.com/v1
+    kind: Launch
+    name: previous
+    uid: "1234"
+`
+
+		require.NoError(t, ApplyManifestsWithOptions(context.Background(), newOwnerRecordingClient(owners), map[string]string{"owned.yaml": manifest}, Options{Owner: owner}))
+		assert.Equal(t, []metav1.OwnerReference{
+			{APIVersion: "v1", Kind: "ConfigMap", Name: "parent", UID: "5678"},
+			ref,
+		}, owners["ConfigMap/owned"])
+	})
+
+	t.Run("incomplete owner is rejected", func(t *testing.T) {
+		owners := map[string][]metav1.OwnerReference{}
+		err := ApplyManifestsWithOptions(context.Background(), newOwnerRecordingClient(owners), files, Options{Owner: &Owner{Kind: "Launch", Name: "cluster"}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "owner must have an apiVersion, kind, name and uid")
+		assert.Empty(t, owners)
+	})
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/ui"
+)
+
+// requiredVerbs are the verbs server-side apply needs: create for new objects and patch for existing ones
+var requiredVerbs = []string{"create", "patch"}
+
+// MissingPermission describes a verb the current user is not allowed to perform on a resource
+type MissingPermission struct {
+	Verb      string
+	Group     string
+	Resource  string
+	Namespace string
+}
+
+func (p MissingPermission) String() string {
+	resource := p.Resource
+	if p.Group != "" {
+		resource = fmt.Sprintf("%s.%s", p.Resource, p.Group)
+	}
+	if p.Namespace == "" {
+		return fmt.Sprintf("%s %s (cluster-scoped)", p.Verb, resource)
+	}
+	return fmt.Sprintf("%s %s in namespace %s", p.Verb, resource, p.Namespace)
+}
+
+// CheckPermissions verifies with SelfSubjectAccessReviews that the current user can apply every object
+// of the manifest set (file name -> content). All missing permissions are returned together.
+func CheckPermissions(ctx context.Context, c client.Client, files map[string]string) ([]MissingPermission, error) {
+	return CheckPermissionsWithOptions(ctx, c, files, Options{})
+}
+
+// CheckPermissionsWithOptions verifies that the current user can apply the objects of the manifest set selected by opts
+func CheckPermissionsWithOptions(ctx context.Context, c client.Client, files map[string]string, opts Options) ([]MissingPermission, error) {
+	objects, _, err := selectObjects(files, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	type target struct {
+		gvk       schema.GroupVersionKind
+		namespace string
+	}
+	targets := map[target]struct{}{}
+	for _, obj := range objects {
+		targets[target{gvk: obj.GroupVersionKind(), namespace: obj.GetNamespace()}] = struct{}{}
+	}
+
+	sorted := make([]target, 0, len(targets))
+	for t := range targets {
+		sorted = append(sorted, t)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].gvk.String() != sorted[j].gvk.String() {
+			return sorted[i].gvk.String() < sorted[j].gvk.String()
+		}
+		return sorted[i].namespace < sorted[j].namespace
+	})
+
+	uiOutput := ui.FromContext(ctx)
+	var missing []MissingPermission
+	for _, t := range sorted {
+		resource, err := resourceFor(c, t.gvk)
+		if err != nil {
+			return nil, err
+		}
+		if resource.Empty() {
+			resource, _ = meta.UnsafeGuessKindToResource(t.gvk)
+			uiOutput.Warning("Kind %s is not known to the cluster yet, checking permissions for %s", t.gvk.Kind, resource.GroupResource().String())
+			log.Log.Info("Kind not installed, guessed its resource for the permission check", "kind", t.gvk.String(), "resource", resource.String())
+		}
+
+		for _, verb := range requiredVerbs {
+			review := &authorizationv1.SelfSubjectAccessReview{
+				Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+					ResourceAttributes: &authorizationv1.ResourceAttributes{
+						Verb:      verb,
+						Group:     resource.Group,
+						Version:   resource.Version,
+						Resource:  resource.Resource,
+						Namespace: t.namespace,
+					},
+				},
+			}
+			if err := c.Create(ctx, review); err != nil {
+				return nil, fmt.Errorf("failed to review %s permission for %s: %w", verb, resource.String(), err)
+			}
+
+			if !review.Status.Allowed {
+				log.Log.V(1).Info("Missing permission", "verb", verb, "resource", resource.String(), "namespace", t.namespace, "reason", review.Status.Reason)
+				missing = append(missing, MissingPermission{
+					Verb:      verb,
+					Group:     resource.Group,
+					Resource:  resource.Resource,
+					Namespace: t.namespace,
+				})
+			}
+		}
+	}
+
+	sort.Slice(missing, func(i, j int) bool {
+		return missing[i].String() < missing[j].String()
+	})
+
+	return missing, nil
+}
+
+// resourceFor returns the resource of the kind, or an empty resource when the kind is not installed yet,
+// e.g. a CRD installed by an earlier object of the same deploy
+func resourceFor(c client.Client, gvk schema.GroupVersionKind) (schema.GroupVersionResource, error) {
+	mapping, err := c.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		return schema.GroupVersionResource{}, nil
+	}
+	if err != nil {
+		return schema.GroupVersionResource{}, fmt.Errorf("failed to find resource for %s: %w", gvk.String(), err)
+	}
+	return mapping.Resource, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/ui"
+)
+
+// newAuthorizerClient returns a fake client answering SelfSubjectAccessReviews with the allow function
+func newAuthorizerClient(allow func(attrs *authorizationv1.ResourceAttributes) bool) client.Client {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "mellanox.com", Version: "v1alpha1", Kind: "NicClusterPolicy"}, meta.RESTScopeRoot)
+
+	return fake.NewClientBuilder().WithRESTMapper(mapper).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if review, ok := obj.(*authorizationv1.SelfSubjectAccessReview); ok {
+				review.Status.Allowed = allow(review.Spec.ResourceAttributes)
+				return nil
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}).Build()
+}
+
+func TestCheckPermissions(t *testing.T) {
+	files := map[string]string{
+		"10-policy.yaml":     policyManifest,
+		"20-configmaps.yaml": configMapManifest,
+	}
+
+	t.Run("all permissions granted", func(t *testing.T) {
+		c := newAuthorizerClient(func(attrs *authorizationv1.ResourceAttributes) bool { return true })
+
+		missing, err := CheckPermissions(context.Background(), c, files)
+		require.NoError(t, err)
+		assert.Empty(t, missing)
+	})
+
+	t.Run("report every missing permission", func(t *testing.T) {
+		c := newAuthorizerClient(func(attrs *authorizationv1.ResourceAttributes) bool {
+			if attrs.Resource == "configmaps" {
+				return attrs.Verb == "create"
+			}
+			return false
+		})
+
+		missing, err := CheckPermissions(context.Background(), c, files)
+		require.NoError(t, err)
+		assert.Equal(t, []MissingPermission{
+			{Verb: "create", Group: "mellanox.com", Resource: "nicclusterpolicies"},
+			{Verb: "patch", Resource: "configmaps", Namespace: "default"},
+			{Verb: "patch", Group: "mellanox.com", Resource: "nicclusterpolicies"},
+		}, missing)
+		assert.Equal(t, "patch configmaps in namespace default", missing[1].String())
+		assert.Equal(t, "create nicclusterpolicies.mellanox.com (cluster-scoped)", missing[0].String())
+	})
+
+	t.Run("check the guessed resource of a kind whose CRD is not installed yet", func(t *testing.T) {
+		reviewed := []string{}
+		c := newAuthorizerClient(func(attrs *authorizationv1.ResourceAttributes) bool {
+			reviewed = append(reviewed, attrs.Verb+" "+attrs.Resource+"."+attrs.Group)
+			return attrs.Resource != "ippools"
+		})
+		output := ui.NewRecording()
+
+		ipPool := "apiVersion: nv-ipam.nvidia.com/v1alpha1\nkind: IPPool\nmetadata:\n  name: pool\n  namespace: nvidia-network-operator\n"
+		missing, err := CheckPermissions(ui.WithOutput(context.Background(), output), c, map[string]string{
+			"10-policy.yaml": policyManifest,
+			"20-ippool.yaml": ipPool,
+		})
+		require.NoError(t, err)
+		assert.Contains(t, reviewed, "create ippools.nv-ipam.nvidia.com")
+		assert.Equal(t, []MissingPermission{
+			{Verb: "create", Group: "nv-ipam.nvidia.com", Resource: "ippools", Namespace: "nvidia-network-operator"},
+			{Verb: "patch", Group: "nv-ipam.nvidia.com", Resource: "ippools", Namespace: "nvidia-network-operator"},
+		}, missing)
+		assert.Equal(t, []string{"Kind IPPool is not known to the cluster yet, checking permissions for ippools.nv-ipam.nvidia.com"}, output.Warnings)
+	})
+
+	t.Run("warn about the kinds that are not installed in a stable order", func(t *testing.T) {
+		c := newAuthorizerClient(func(*authorizationv1.ResourceAttributes) bool { return true })
+		files := map[string]string{
+			"20-ippool.yaml":  "apiVersion: nv-ipam.nvidia.com/v1alpha1\nkind: IPPool\nmetadata:\n  name: pool\n  namespace: nvidia-network-operator\n",
+			"30-macvlan.yaml": "apiVersion: mellanox.com/v1alpha1\nkind: MacvlanNetwork\nmetadata:\n  name: macvlan\n",
+			"40-cidr.yaml":    "apiVersion: nv-ipam.nvidia.com/v1alpha1\nkind: CIDRPool\nmetadata:\n  name: cidr\n  namespace: nvidia-network-operator\n",
+		}
+
+		for range 5 {
+			output := ui.NewRecording()
+			_, err := CheckPermissions(ui.WithOutput(context.Background(), output), c, files)
+			require.NoError(t, err)
+			assert.Equal(t, []string{
+				"Kind MacvlanNetwork is not known to the cluster yet, checking permissions for macvlannetworks.mellanox.com",
+				"Kind CIDRPool is not known to the cluster yet, checking permissions for cidrpools.nv-ipam.nvidia.com",
+				"Kind IPPool is not known to the cluster yet, checking permissions for ippools.nv-ipam.nvidia.com",
+			}, output.Warnings)
+		}
+	})
+
+	t.Run("skip excluded objects", func(t *testing.T) {
+		c := newAuthorizerClient(func(attrs *authorizationv1.ResourceAttributes) bool { return attrs.Resource == "configmaps" })
+
+		missing, err := CheckPermissionsWithOptions(context.Background(), c, files, Options{Exclude: []string{"NicClusterPolicy"}})
+		require.NoError(t, err)
+		assert.Empty(t, missing)
+	})
+}
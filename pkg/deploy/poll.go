@@ -0,0 +1,57 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// maxPollInterval caps the backoff between the calls of a PollUntil condition
+const maxPollInterval = 30 * time.Second
+
+// PollCondition reports whether the awaited state is reached. An error stops the polling.
+type PollCondition func(ctx context.Context) (bool, error)
+
+// PollUntil calls condition right away and then after interval, doubling the delay up to maxPollInterval,
+// until it returns true or an error. It returns ctx.Err() when the context is done first. An interval that is not
+// positive is rejected, it would call condition in a busy loop.
+func PollUntil(ctx context.Context, interval time.Duration, condition PollCondition) error {
+	if interval <= 0 {
+		return fmt.Errorf("poll interval %s must be positive", interval)
+	}
+	delay := interval
+	for {
+		done, err := condition(ctx)
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		delay = min(delay*2, max(interval, maxPollInterval))
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollUntil(t *testing.T) {
+	t.Run("return right away when the condition is already true", func(t *testing.T) {
+		calls := 0
+		err := PollUntil(context.Background(), time.Hour, func(context.Context) (bool, error) {
+			calls++
+			return true, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("poll until the condition is true", func(t *testing.T) {
+		calls := 0
+		err := PollUntil(context.Background(), time.Millisecond, func(context.Context) (bool, error) {
+			calls++
+			return calls == 4, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 4, calls)
+	})
+
+	t.Run("back off between the calls", func(t *testing.T) {
+		var calledAt []time.Time
+		err := PollUntil(context.Background(), 10*time.Millisecond, func(context.Context) (bool, error) {
+			calledAt = append(calledAt, time.Now())
+			return len(calledAt) == 3, nil
+		})
+		require.NoError(t, err)
+		require.Len(t, calledAt, 3)
+		assert.GreaterOrEqual(t, calledAt[1].Sub(calledAt[0]), 10*time.Millisecond)
+		assert.GreaterOrEqual(t, calledAt[2].Sub(calledAt[1]), 20*time.Millisecond)
+	})
+
+	t.Run("stop on a condition error", func(t *testing.T) {
+		conditionErr := errors.New("policy in error state")
+		err := PollUntil(context.Background(), time.Millisecond, func(context.Context) (bool, error) {
+			return false, conditionErr
+		})
+		assert.ErrorIs(t, err, conditionErr)
+	})
+
+	t.Run("time out when the condition never becomes true", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		calls := 0
+		err := PollUntil(ctx, 5*time.Millisecond, func(context.Context) (bool, error) {
+			calls++
+			return false, nil
+		})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Greater(t, calls, 1)
+	})
+
+	t.Run("reject an interval that is not positive", func(t *testing.T) {
+		for _, interval := range []time.Duration{0, -time.Second} {
+			calls := 0
+			err := PollUntil(context.Background(), interval, func(context.Context) (bool, error) {
+				calls++
+				return false, nil
+			})
+			assert.EqualError(t, err, fmt.Sprintf("poll interval %s must be positive", interval))
+			assert.Zero(t, calls)
+		}
+	})
+}
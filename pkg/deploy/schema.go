@@ -0,0 +1,195 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	openapierrors "k8s.io/kube-openapi/pkg/validation/errors"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+	"sigs.k8s.io/yaml"
+)
+
+// bundledSchemas are the CRDs of the network operator and the SR-IOV network operator the profiles render
+//
+//go:embed schemas/*.yaml
+var bundledSchemas embed.FS
+
+// SchemaViolation is a field of a rendered object that does not match the schema of its kind
+type SchemaViolation struct {
+	File    string
+	Object  string
+	Field   string
+	Message string
+}
+
+func (v SchemaViolation) String() string {
+	if v.Field == "" {
+		return fmt.Sprintf("%s: %s: %s", v.File, v.Object, v.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s: %s", v.File, v.Object, v.Field, v.Message)
+}
+
+// SchemaValidator validates objects against the OpenAPI schema of their GroupVersionKind. Built-in kinds are
+// validated against their API types, custom resources against the schemas of their CRDs.
+type SchemaValidator struct {
+	schemas map[schema.GroupVersionKind]*spec.Schema
+}
+
+// NewSchemaValidator returns a validator knowing the built-in kinds and the bundled CRDs
+func NewSchemaValidator() (*SchemaValidator, error) {
+	v := &SchemaValidator{schemas: map[schema.GroupVersionKind]*spec.Schema{}}
+
+	entries, err := bundledSchemas.ReadDir("schemas")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundled schemas: %w", err)
+	}
+	for _, entry := range entries {
+		data, err := bundledSchemas.ReadFile(path.Join("schemas", entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bundled schema %s: %w", entry.Name(), err)
+		}
+		if err := v.AddCRD(data); err != nil {
+			return nil, fmt.Errorf("bundled schema %s: %w", entry.Name(), err)
+		}
+	}
+	return v, nil
+}
+
+// crdSchemas holds the fields of a CustomResourceDefinition needed to validate its custom resources
+type crdSchemas struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		Group string `json:"group"`
+		Names struct {
+			Kind string `json:"kind"`
+		} `json:"names"`
+		Versions []struct {
+			Name   string `json:"name"`
+			Schema struct {
+				OpenAPIV3Schema *spec.Schema `json:"openAPIV3Schema"`
+			} `json:"schema"`
+		} `json:"versions"`
+	} `json:"spec"`
+}
+
+// AddCRD adds the schemas of every version of the CustomResourceDefinition manifest
+func (v *SchemaValidator) AddCRD(data []byte) error {
+	for _, doc := range SplitYAMLDocuments(string(data)) {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		crd := crdSchemas{}
+		if err := yaml.Unmarshal([]byte(doc), &crd); err != nil {
+			return fmt.Errorf("failed to parse CRD: %w", err)
+		}
+		if crd.Kind != "CustomResourceDefinition" {
+			return fmt.Errorf("expected a CustomResourceDefinition, got %q", crd.Kind)
+		}
+		for _, version := range crd.Spec.Versions {
+			if version.Schema.OpenAPIV3Schema == nil {
+				continue
+			}
+			gvk := schema.GroupVersionKind{Group: crd.Spec.Group, Version: version.Name, Kind: crd.Spec.Names.Kind}
+			v.schemas[gvk] = version.Schema.OpenAPIV3Schema
+		}
+	}
+	return nil
+}
+
+// Knows reports whether objects of the kind can be validated
+func (v *SchemaValidator) Knows(gvk schema.GroupVersionKind) bool {
+	_, ok := v.schemas[gvk]
+	return ok || clientgoscheme.Scheme.Recognizes(gvk)
+}
+
+// ValidateManifests validates the objects of the files (file name -> content). It returns the violations and the
+// kinds that were skipped because no schema is known for them.
+func (v *SchemaValidator) ValidateManifests(files map[string]string) ([]SchemaViolation, []string, error) {
+	var violations []SchemaViolation
+	skipped := map[string]bool{}
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		objects, err := ParseManifests([]byte(files[name]))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+		for i := range objects {
+			obj := &objects[i]
+			gvk := obj.GroupVersionKind()
+			if !v.Knows(gvk) {
+				skipped[gvk.String()] = true
+				continue
+			}
+			for _, violation := range v.Validate(obj) {
+				violation.File = name
+				violations = append(violations, violation)
+			}
+		}
+	}
+	return violations, slices.Sorted(maps.Keys(skipped)), nil
+}
+
+// Validate returns the violations of the object, which must be of a known kind
+func (v *SchemaValidator) Validate(obj *unstructured.Unstructured) []SchemaViolation {
+	object := fmt.Sprintf("%s/%s", obj.GetKind(), obj.GetName())
+	gvk := obj.GroupVersionKind()
+
+	if crdSchema, ok := v.schemas[gvk]; ok {
+		var violations []SchemaViolation
+		result := validate.NewSchemaValidator(crdSchema, nil, "", strfmt.Default).Validate(obj.Object)
+		for _, err := range result.Errors {
+			violation := SchemaViolation{Object: object, Message: err.Error()}
+			var validationErr *openapierrors.Validation
+			if errors.As(err, &validationErr) {
+				violation.Field = validationErr.Name
+				violation.Message = strings.TrimSpace(strings.TrimPrefix(err.Error(), validationErr.Name+" in body"))
+			}
+			violations = append(violations, violation)
+		}
+		return violations
+	}
+
+	// Built-in kinds are decoded into their API types, mismatching field types fail the decoding
+	typed, err := clientgoscheme.Scheme.New(gvk)
+	if err != nil {
+		return []SchemaViolation{{Object: object, Message: err.Error()}}
+	}
+	data, err := json.Marshal(obj.Object)
+	if err != nil {
+		return []SchemaViolation{{Object: object, Message: err.Error()}}
+	}
+	if err := json.Unmarshal(data, typed); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return []SchemaViolation{{Object: object, Field: typeErr.Field, Message: fmt.Sprintf("must be of type %s, got %s", typeErr.Type, typeErr.Value)}}
+		}
+		return []SchemaViolation{{Object: object, Message: err.Error()}}
+	}
+	return nil
+}
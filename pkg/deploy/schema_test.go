@@ -0,0 +1,113 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validSchemaManifests = `apiVersion: mellanox.com/v1alpha1
+kind: HostDeviceNetwork
+metadata:
+  name: hostdev-net
+spec:
+  networkNamespace: default
+  resourceName: hostdev
+  ipam: |
+    {"type": "nv-ipam"}
+---
+apiVersion: sriovnetwork.openshift.io/v1
+kind: SriovNetworkNodePolicy
+metadata:
+  name: policy
+  namespace: nvidia-network-operator
+spec:
+  numVfs: 8
+  resourceName: sriov_resource
+  nodeSelector:
+    feature.node.kubernetes.io/network-sriov.capable: "true"
+  nicSelector:
+    pfNames: ["ens1f0"]
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: test-pod
+spec:
+  containers:
+  - name: test
+    image: mellanox/rping-test
+`
+
+func TestValidateSchemas(t *testing.T) {
+	validator, err := NewSchemaValidator()
+	require.NoError(t, err)
+
+	t.Run("accept schema-valid manifests", func(t *testing.T) {
+		violations, skipped, err := validator.ValidateManifests(map[string]string{"10-objects.yaml": validSchemaManifests})
+		require.NoError(t, err)
+		assert.Empty(t, violations)
+		assert.Empty(t, skipped)
+	})
+
+	t.Run("report fields of the wrong type", func(t *testing.T) {
+		violations, _, err := validator.ValidateManifests(map[string]string{
+			"10-policy.yaml": `apiVersion: sriovnetwork.openshift.io/v1
+kind: SriovNetworkNodePolicy
+metadata:
+  name: policy
+spec:
+  numVfs: eight
+  resourceName: sriov_resource
+  nodeSelector: {}
+  nicSelector: {}
+`,
+			"20-pod.yaml": `apiVersion: v1
+kind: Pod
+metadata:
+  name: test-pod
+spec:
+  containers:
+  - name: test
+    image: 42
+`,
+		})
+		require.NoError(t, err)
+		require.Len(t, violations, 2)
+		assert.Equal(t, SchemaViolation{File: "10-policy.yaml", Object: "SriovNetworkNodePolicy/policy", Field: "spec.numVfs", Message: `must be of type integer: "string"`}, violations[0])
+		assert.Equal(t, SchemaViolation{File: "20-pod.yaml", Object: "Pod/test-pod", Field: "spec.containers.0.image", Message: "must be of type string, got number"}, violations[1])
+		assert.Equal(t, "20-pod.yaml: Pod/test-pod: spec.containers.0.image: must be of type string, got number", violations[1].String())
+	})
+
+	t.Run("skip kinds without a schema", func(t *testing.T) {
+		violations, skipped, err := validator.ValidateManifests(map[string]string{
+			"10-pool.yaml": "apiVersion: nv-ipam.nvidia.com/v1alpha1\nkind: IPPool\nmetadata:\n  name: pool\nspec:\n  subnet: 192.168.0.0/24\n",
+		})
+		require.NoError(t, err)
+		assert.Empty(t, violations)
+		assert.Equal(t, []string{"nv-ipam.nvidia.com/v1alpha1, Kind=IPPool"}, skipped)
+	})
+
+	t.Run("reject manifests that are not CRDs", func(t *testing.T) {
+		err := validator.AddCRD([]byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: crd\n"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `expected a CustomResourceDefinition, got "ConfigMap"`)
+	})
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/ui"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// PropagationPolicies lists the supported deletion propagation policies
+var PropagationPolicies = []metav1.DeletionPropagation{metav1.DeletePropagationBackground, metav1.DeletePropagationForeground, metav1.DeletePropagationOrphan}
+
+// validateUpdateOptions checks the rollout and deletion options
+func (o Options) validateUpdateOptions() error {
+	if o.MaxUnavailable != nil {
+		if err := validateMaxUnavailable(*o.MaxUnavailable); err != nil {
+			return err
+		}
+	}
+	if o.PropagationPolicy != "" && !slices.Contains(PropagationPolicies, o.PropagationPolicy) {
+		return fmt.Errorf("propagation policy must be one of Background, Foreground, Orphan, got %s", o.PropagationPolicy)
+	}
+	if o.GracePeriodSeconds != nil && *o.GracePeriodSeconds < 0 {
+		return fmt.Errorf("grace period must not be negative, got %d", *o.GracePeriodSeconds)
+	}
+	return nil
+}
+
+// validateMaxUnavailable checks that maxUnavailable is a positive number of pods or a percentage up to 100%
+func validateMaxUnavailable(maxUnavailable intstr.IntOrString) error {
+	if maxUnavailable.Type == intstr.Int {
+		if maxUnavailable.IntVal <= 0 {
+			return fmt.Errorf("maxUnavailable must be positive, got %d", maxUnavailable.IntVal)
+		}
+		return nil
+	}
+
+	percent, err := strconv.Atoi(strings.TrimSuffix(maxUnavailable.StrVal, "%"))
+	if !strings.HasSuffix(maxUnavailable.StrVal, "%") || err != nil || percent <= 0 || percent > 100 {
+		return fmt.Errorf("maxUnavailable must be a number of pods or a percentage between 1%% and 100%%, got %s", maxUnavailable.StrVal)
+	}
+	return nil
+}
+
+// setMaxUnavailable sets the rolling update maxUnavailable of a DaemonSet, leaving other objects untouched.
+// It returns whether obj was changed.
+func setMaxUnavailable(obj *unstructured.Unstructured, maxUnavailable intstr.IntOrString) (bool, error) {
+	gvk := obj.GroupVersionKind()
+	if gvk.Group != "apps" || gvk.Kind != "DaemonSet" {
+		return false, nil
+	}
+
+	var value interface{} = maxUnavailable.StrVal
+	if maxUnavailable.Type == intstr.Int {
+		value = int64(maxUnavailable.IntVal)
+	}
+	if err := unstructured.SetNestedField(obj.Object, "RollingUpdate", "spec", "updateStrategy", "type"); err != nil {
+		return false, err
+	}
+	if err := unstructured.SetNestedField(obj.Object, value, "spec", "updateStrategy", "rollingUpdate", "maxUnavailable"); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// deleteOptions returns the options of the deletion of an object
+func (o Options) deleteOptions() []client.DeleteOption {
+	var deleteOpts []client.DeleteOption
+	if o.PropagationPolicy != "" {
+		deleteOpts = append(deleteOpts, client.PropagationPolicy(o.PropagationPolicy))
+	}
+	if o.GracePeriodSeconds != nil {
+		deleteOpts = append(deleteOpts, client.GracePeriodSeconds(*o.GracePeriodSeconds))
+	}
+	return deleteOpts
+}
+
+// DeleteManifestsWithOptions deletes the objects of an in-memory set of manifests (file name -> content) from the
+// cluster, in the reverse of the apply order. Objects already gone are skipped. The objects are selected like
+// ApplyManifestsWithOptions does and deleted with PropagationPolicy and GracePeriodSeconds.
+func DeleteManifestsWithOptions(ctx context.Context, c client.Client, files map[string]string, opts Options) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	uiOutput := ui.FromContext(ctx)
+
+	if err := opts.validateUpdateOptions(); err != nil {
+		uiOutput.Error("Invalid delete options: %v", err)
+		return err
+	}
+
+	objects, _, err := selectObjects(files, opts)
+	if err != nil {
+		uiOutput.Error("Failed to select manifests: %v", err)
+		return err
+	}
+	slices.Reverse(objects)
+
+	uiOutput.Info("Deleting %d manifest(s)", len(objects))
+	log.Log.Info("Deleting manifests", "count", len(objects), "propagationPolicy", opts.PropagationPolicy)
+
+	for i, obj := range objects {
+		object := fmt.Sprintf("%s/%s", obj.GetKind(), obj.GetName())
+		uiOutput.Info("  [%d/%d] Deleting %s", i+1, len(objects), object)
+		if err := c.Delete(ctx, obj, opts.deleteOptions()...); err != nil {
+			if apierrors.IsNotFound(err) {
+				log.Log.Info("Object already deleted", "object", object)
+				continue
+			}
+			uiOutput.Error("    Failed: %v", err)
+			return fmt.Errorf("failed to delete %s: %w", object, err)
+		}
+	}
+
+	return nil
+}
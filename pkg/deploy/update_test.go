@@ -0,0 +1,199 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+const daemonSetManifest = `apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: agent
+  namespace: default
+spec:
+  updateStrategy:
+    type: OnDelete
+`
+
+// deletion is an object deleted through the fake client with its delete options
+type deletion struct {
+	object string
+	opts   client.DeleteOptions
+}
+
+// newDeleteRecordingClient returns a fake client that records the deleted objects and their delete options,
+// reporting the objects of missing as not found
+func newDeleteRecordingClient(deleted *[]deletion, missing ...string) client.Client {
+	return fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			u := obj.(*unstructured.Unstructured)
+			object := u.GetKind() + "/" + u.GetName()
+			for _, name := range missing {
+				if name == object {
+					return apierrors.NewNotFound(schema.GroupResource{Resource: u.GetKind()}, u.GetName())
+				}
+			}
+			deleteOpts := client.DeleteOptions{}
+			deleteOpts.ApplyOptions(opts)
+			*deleted = append(*deleted, deletion{object: object, opts: deleteOpts})
+			return nil
+		},
+	}).Build()
+}
+
+func TestDeleteManifests(t *testing.T) {
+	files := map[string]string{
+		"10-policy.yaml":     policyManifest,
+		"20-configmaps.yaml": configMapManifest,
+	}
+
+	t.Run("pass the propagation policy and grace period to every delete", func(t *testing.T) {
+		deleted := []deletion{}
+		c := newDeleteRecordingClient(&deleted)
+
+		err := DeleteManifestsWithOptions(context.Background(), c, files, Options{
+			PropagationPolicy:  metav1.DeletePropagationForeground,
+			GracePeriodSeconds: ptr.To(int64(30)),
+		})
+		require.NoError(t, err)
+
+		require.Len(t, deleted, 3)
+		for _, d := range deleted {
+			require.NotNil(t, d.opts.PropagationPolicy, d.object)
+			assert.Equal(t, metav1.DeletePropagationForeground, *d.opts.PropagationPolicy, d.object)
+			assert.Equal(t, ptr.To(int64(30)), d.opts.GracePeriodSeconds, d.object)
+		}
+	})
+
+	t.Run("delete in reverse apply order and skip missing objects", func(t *testing.T) {
+		deleted := []deletion{}
+		c := newDeleteRecordingClient(&deleted, "ConfigMap/second")
+
+		require.NoError(t, DeleteManifestsWithOptions(context.Background(), c, files, Options{}))
+
+		objects := []string{}
+		for _, d := range deleted {
+			objects = append(objects, d.object)
+			assert.Nil(t, d.opts.PropagationPolicy, d.object)
+			assert.Nil(t, d.opts.GracePeriodSeconds, d.object)
+		}
+		assert.Equal(t, []string{"ConfigMap/first", "NicClusterPolicy/nic-cluster-policy"}, objects)
+	})
+
+	t.Run("reject invalid delete options", func(t *testing.T) {
+		deleted := []deletion{}
+		c := newDeleteRecordingClient(&deleted)
+
+		err := DeleteManifestsWithOptions(context.Background(), c, files, Options{PropagationPolicy: "Cascade"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "propagation policy must be one of Background, Foreground, Orphan, got Cascade")
+
+		err = DeleteManifestsWithOptions(context.Background(), c, files, Options{GracePeriodSeconds: ptr.To(int64(-1))})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "grace period must not be negative")
+		assert.Empty(t, deleted)
+	})
+}
+
+func TestApplyMaxUnavailable(t *testing.T) {
+	// apply returns the applied DaemonSet and ConfigMap by name
+	apply := func(t *testing.T, opts Options) map[string]*unstructured.Unstructured {
+		applied := map[string]*unstructured.Unstructured{}
+		c := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				u := obj.(*unstructured.Unstructured)
+				applied[u.GetName()] = u.DeepCopy()
+				return nil
+			},
+		}).Build()
+
+		require.NoError(t, ApplyManifestsWithOptions(context.Background(), c, map[string]string{
+			"10-daemonset.yaml":  daemonSetManifest,
+			"20-configmaps.yaml": configMapManifest,
+		}, opts))
+		return applied
+	}
+	strategy := func(obj *unstructured.Unstructured) map[string]interface{} {
+		updateStrategy, _, _ := unstructured.NestedMap(obj.Object, "spec", "updateStrategy")
+		return updateStrategy
+	}
+
+	t.Run("set the rolling update of the DaemonSets", func(t *testing.T) {
+		applied := apply(t, Options{MaxUnavailable: ptr.To(intstr.FromString("25%"))})
+		assert.Equal(t, map[string]interface{}{
+			"type":          "RollingUpdate",
+			"rollingUpdate": map[string]interface{}{"maxUnavailable": "25%"},
+		}, strategy(applied["agent"]))
+		assert.Nil(t, strategy(applied["first"]))
+
+		applied = apply(t, Options{MaxUnavailable: ptr.To(intstr.FromInt32(2))})
+		assert.Equal(t, map[string]interface{}{
+			"type":          "RollingUpdate",
+			"rollingUpdate": map[string]interface{}{"maxUnavailable": int64(2)},
+		}, strategy(applied["agent"]))
+	})
+
+	t.Run("keep the update strategy without maxUnavailable", func(t *testing.T) {
+		applied := apply(t, Options{})
+		assert.Equal(t, map[string]interface{}{"type": "OnDelete"}, strategy(applied["agent"]))
+	})
+
+	t.Run("apply the other objects when the update strategy cannot be set", func(t *testing.T) {
+		const malformedDaemonSet = "apiVersion: apps/v1\nkind: DaemonSet\nmetadata:\n  name: agent\n  namespace: default\nspec:\n  updateStrategy: OnDelete\n"
+		files := map[string]string{
+			"10-daemonset.yaml":  malformedDaemonSet,
+			"20-configmaps.yaml": configMapManifest,
+		}
+
+		applied := []string{}
+		err := ApplyManifestsWithOptions(context.Background(), newRecordingClient(&applied), files, Options{MaxUnavailable: ptr.To(intstr.FromInt32(1))})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to set the update strategy of DaemonSet/agent")
+		assert.Empty(t, applied)
+
+		err = ApplyManifestsWithOptions(context.Background(), newRecordingClient(&applied), files, Options{MaxUnavailable: ptr.To(intstr.FromInt32(1)), ContinueOnError: true})
+		var applyErr *ApplyError
+		require.ErrorAs(t, err, &applyErr)
+		require.Len(t, applyErr.Failed, 1)
+		assert.Equal(t, "DaemonSet/agent", applyErr.Failed[0].Object)
+		assert.Equal(t, []string{"ConfigMap/first", "ConfigMap/second"}, applied)
+	})
+
+	t.Run("reject invalid maxUnavailable", func(t *testing.T) {
+		for _, maxUnavailable := range []intstr.IntOrString{intstr.FromInt32(0), intstr.FromString("150%"), intstr.FromString("two")} {
+			err := ApplyManifestsWithOptions(context.Background(), fake.NewClientBuilder().Build(), map[string]string{
+				"10-daemonset.yaml": daemonSetManifest,
+			}, Options{MaxUnavailable: &maxUnavailable})
+			require.Error(t, err, maxUnavailable.String())
+			assert.Contains(t, err.Error(), "maxUnavailable must be", maxUnavailable.String())
+		}
+	})
+}
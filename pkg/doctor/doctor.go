@@ -0,0 +1,265 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package doctor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/llm"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	"github.com/nvidia/k8s-launch-kit/pkg/ui"
+)
+
+// apiServerTimeout bounds the request made to reach the API server
+const apiServerTimeout = 10 * time.Second
+
+// Status is the outcome of a check
+type Status string
+
+const (
+	StatusPass Status = "PASS"
+	StatusWarn Status = "WARN"
+	StatusFail Status = "FAIL"
+	StatusSkip Status = "SKIP"
+)
+
+// Result is the outcome of a single prerequisite check
+type Result struct {
+	Name    string
+	Status  Status
+	Message string
+	// Hint tells how to fix a failed or warned check
+	Hint string
+}
+
+// Options are the settings the checks are run against
+type Options struct {
+	Kubeconfig  string
+	ProfilesDir string
+	LLMApiKey   string
+	// LLMVendor is inferred from LLMModel and LLMApiUrl with llm.InferVendor when empty
+	LLMVendor string
+	LLMModel  string
+	LLMApiUrl string
+}
+
+// Run runs every check in order. None of the checks change the cluster or the local files.
+func Run(opts Options) []Result {
+	kubeconfig := CheckKubeconfig(opts.Kubeconfig)
+	return []Result{
+		kubeconfig,
+		CheckAPIServer(opts.Kubeconfig, kubeconfig),
+		CheckProfilesDir(opts.ProfilesDir),
+		CheckLLM(opts.LLMApiKey, opts.LLMVendor, opts.LLMModel, opts.LLMApiUrl),
+	}
+}
+
+// CheckKubeconfig checks that the kubeconfig file exists and can be loaded.
+// An empty path falls back to $KUBECONFIG and ~/.kube/config.
+func CheckKubeconfig(path string) Result {
+	result := Result{Name: "kubeconfig"}
+
+	path = resolveKubeconfig(path)
+	if path == "" {
+		result.Status = StatusFail
+		result.Message = "no kubeconfig found"
+		result.Hint = "pass --kubeconfig or set KUBECONFIG to the kubeconfig of the cluster"
+		return result
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("kubeconfig %s not found", path)
+		result.Hint = "pass --kubeconfig with the path of an existing kubeconfig file"
+		return result
+	}
+
+	if _, err := clientcmd.LoadFromFile(path); err != nil {
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("failed to load kubeconfig %s: %v", path, err)
+		result.Hint = "check that the file is a valid kubeconfig, e.g. with kubectl config view"
+		return result
+	}
+
+	result.Status = StatusPass
+	result.Message = path
+	return result
+}
+
+// CheckAPIServer checks that the API server of the kubeconfig is reachable, skipped when the kubeconfig check failed
+func CheckAPIServer(path string, kubeconfig Result) Result {
+	result := Result{Name: "api-server"}
+
+	if kubeconfig.Status != StatusPass {
+		result.Status = StatusSkip
+		result.Message = "no usable kubeconfig"
+		return result
+	}
+
+	restCfg, err := clientcmd.BuildConfigFromFlags("", resolveKubeconfig(path))
+	if err != nil {
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("failed to build client config: %v", err)
+		result.Hint = "check the current context of the kubeconfig"
+		return result
+	}
+	restCfg.Timeout = apiServerTimeout
+
+	client, err := discovery.NewDiscoveryClientForConfig(restCfg)
+	if err != nil {
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("failed to create discovery client: %v", err)
+		return result
+	}
+
+	version, err := client.ServerVersion()
+	if err != nil {
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("API server %s is unreachable: %v", restCfg.Host, err)
+		result.Hint = "check the network access to the cluster and the credentials of the kubeconfig"
+		return result
+	}
+
+	result.Status = StatusPass
+	result.Message = fmt.Sprintf("%s (Kubernetes %s)", restCfg.Host, version)
+	return result
+}
+
+// CheckProfilesDir checks that the profiles directory exists and contains profiles.
+// oci:// references are pulled at run time and are not checked.
+func CheckProfilesDir(dir string) Result {
+	result := Result{Name: "profiles"}
+
+	if profiles.IsOCIReference(dir) {
+		result.Status = StatusSkip
+		result.Message = fmt.Sprintf("%s is pulled at run time", dir)
+		return result
+	}
+
+	available, err := profiles.LoadProfilesDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("profiles directory %s not found", dir)
+		result.Hint = "run l8k from the directory containing profiles/ or pass --profiles-dir"
+		return result
+	}
+	if err != nil {
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("failed to load profiles from %s: %v", dir, err)
+		result.Hint = fmt.Sprintf("run l8k profiles validate %s", dir)
+		return result
+	}
+	if len(available) == 0 {
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("no profiles found in %s", dir)
+		result.Hint = "pass --profiles-dir with a directory of profiles"
+		return result
+	}
+
+	result.Status = StatusPass
+	result.Message = fmt.Sprintf("%d profile(s) in %s", len(available), dir)
+	return result
+}
+
+// CheckLLM checks the LLM settings. They are only needed for --prompt, so a missing key is a warning.
+// An empty vendor is inferred from the model and the API URL like on a regular run.
+func CheckLLM(apiKey, vendor, model, baseURL string) Result {
+	result := Result{Name: "llm"}
+
+	if vendor == "" {
+		inferred, err := llm.InferVendor(model, baseURL)
+		if err != nil {
+			result.Status = StatusFail
+			result.Message = err.Error()
+			result.Hint = fmt.Sprintf("pass --llm-vendor with one of: %s", strings.Join(llm.Vendors, ", "))
+			return result
+		}
+		vendor = inferred
+	}
+	if !slices.Contains(llm.Vendors, vendor) {
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("unsupported LLM vendor %q", vendor)
+		result.Hint = fmt.Sprintf("pass --llm-vendor with one of: %s", strings.Join(llm.Vendors, ", "))
+		return result
+	}
+
+	if apiKey == "" {
+		result.Status = StatusWarn
+		result.Message = "no LLM API key, --prompt is unavailable"
+		result.Hint = "pass --llm-api-key to select profiles from a prompt"
+		return result
+	}
+
+	result.Status = StatusPass
+	result.Message = fmt.Sprintf("API key set for %s", vendor)
+	return result
+}
+
+// Report prints the results as a table followed by the hints, and reports whether no check failed
+func Report(output ui.Output, results []Result) bool {
+	passed := true
+	rows := make([][]string, 0, len(results))
+	for _, result := range results {
+		if result.Status == StatusFail {
+			passed = false
+		}
+		rows = append(rows, []string{result.Name, string(result.Status), result.Message})
+	}
+	output.Table([]string{"CHECK", "RESULT", "DETAILS"}, rows)
+
+	for _, result := range results {
+		switch {
+		case result.Hint == "":
+		case result.Status == StatusFail:
+			output.Error("%s: %s", result.Name, result.Hint)
+		case result.Status == StatusWarn:
+			output.Warning("%s: %s", result.Name, result.Hint)
+		}
+	}
+
+	return passed
+}
+
+// resolveKubeconfig returns the kubeconfig path used by clients, or "" when there is none
+func resolveKubeconfig(path string) string {
+	if path != "" {
+		return path
+	}
+	if env := os.Getenv(clientcmd.RecommendedConfigPathEnvVar); env != "" {
+		return filepath.SplitList(env)[0]
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		if defaultPath := filepath.Join(home, ".kube", "config"); fileExists(defaultPath) {
+			return defaultPath
+		}
+	}
+	return ""
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
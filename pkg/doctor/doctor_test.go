@@ -0,0 +1,183 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package doctor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/llm"
+	"github.com/nvidia/k8s-launch-kit/pkg/ui"
+)
+
+// writeKubeconfig writes a kubeconfig pointing to server
+func writeKubeconfig(t *testing.T, server string) string {
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	content := `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: ` + server + `
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+current-context: test
+users:
+- name: test
+  user:
+    token: test
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestCheckKubeconfig(t *testing.T) {
+	t.Run("fail when the kubeconfig does not exist", func(t *testing.T) {
+		result := CheckKubeconfig(filepath.Join(t.TempDir(), "missing"))
+		assert.Equal(t, StatusFail, result.Status)
+		assert.Contains(t, result.Message, "not found")
+		assert.Contains(t, result.Hint, "--kubeconfig")
+	})
+
+	t.Run("fail when no kubeconfig is configured", func(t *testing.T) {
+		t.Setenv("KUBECONFIG", "")
+		t.Setenv("HOME", t.TempDir())
+
+		result := CheckKubeconfig("")
+		assert.Equal(t, StatusFail, result.Status)
+		assert.Equal(t, "no kubeconfig found", result.Message)
+	})
+
+	t.Run("fail when the kubeconfig is invalid", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "kubeconfig")
+		require.NoError(t, os.WriteFile(path, []byte("clusters: ["), 0644))
+
+		assert.Equal(t, StatusFail, CheckKubeconfig(path).Status)
+	})
+
+	t.Run("pass with the path from KUBECONFIG", func(t *testing.T) {
+		path := writeKubeconfig(t, "https://127.0.0.1:6443")
+		t.Setenv("KUBECONFIG", path)
+
+		result := CheckKubeconfig("")
+		assert.Equal(t, StatusPass, result.Status)
+		assert.Equal(t, path, result.Message)
+	})
+}
+
+func TestCheckAPIServer(t *testing.T) {
+	t.Run("skip without a usable kubeconfig", func(t *testing.T) {
+		result := CheckAPIServer("", Result{Status: StatusFail})
+		assert.Equal(t, StatusSkip, result.Status)
+	})
+
+	t.Run("pass when the server answers", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"major":"1","minor":"33","gitVersion":"v1.33.0"}`))
+		}))
+		defer server.Close()
+		path := writeKubeconfig(t, server.URL)
+
+		result := CheckAPIServer(path, CheckKubeconfig(path))
+		assert.Equal(t, StatusPass, result.Status)
+		assert.Contains(t, result.Message, "v1.33.0")
+	})
+
+	t.Run("fail when the server is unreachable", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+		path := writeKubeconfig(t, server.URL)
+
+		result := CheckAPIServer(path, CheckKubeconfig(path))
+		assert.Equal(t, StatusFail, result.Status)
+		assert.Contains(t, result.Message, "unreachable")
+	})
+}
+
+func TestCheckProfilesDir(t *testing.T) {
+	t.Run("fail when the directory does not exist", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "profiles")
+
+		result := CheckProfilesDir(dir)
+		assert.Equal(t, StatusFail, result.Status)
+		assert.Contains(t, result.Message, "not found")
+		assert.Contains(t, result.Hint, "--profiles-dir")
+	})
+
+	t.Run("fail when the directory has no profiles", func(t *testing.T) {
+		result := CheckProfilesDir(t.TempDir())
+		assert.Equal(t, StatusFail, result.Status)
+		assert.Contains(t, result.Message, "no profiles found")
+	})
+
+	t.Run("pass with the built-in profiles", func(t *testing.T) {
+		assert.Equal(t, StatusPass, CheckProfilesDir("../../profiles").Status)
+	})
+
+	t.Run("skip oci references", func(t *testing.T) {
+		assert.Equal(t, StatusSkip, CheckProfilesDir("oci://registry.example.com/l8k/profiles:v1").Status)
+	})
+}
+
+func TestCheckLLM(t *testing.T) {
+	assert.Equal(t, StatusWarn, CheckLLM("", llm.VendorOpenAI, "", "").Status)
+	assert.Equal(t, StatusFail, CheckLLM("key", "unknown", "", "").Status)
+	assert.Equal(t, StatusPass, CheckLLM("key", llm.VendorAnthropic, "", "").Status)
+
+	t.Run("infer the vendor like a regular run", func(t *testing.T) {
+		assert.Equal(t, "API key set for anthropic", CheckLLM("key", "", "claude-3-5-sonnet-20241022", "").Message)
+		assert.Equal(t, "API key set for openai-azure", CheckLLM("key", "", "gpt-4o", "").Message)
+		assert.Equal(t, "API key set for openai", CheckLLM("key", "", "gpt-4o", "https://api.openai.com/v1").Message)
+
+		result := CheckLLM("key", "", "claude-distilled-gpt-4", "")
+		assert.Equal(t, StatusFail, result.Status)
+		assert.Contains(t, result.Message, "is ambiguous")
+	})
+}
+
+func TestReport(t *testing.T) {
+	t.Run("missing kubeconfig and profiles dir fail the report", func(t *testing.T) {
+		results := Run(Options{
+			Kubeconfig:  filepath.Join(t.TempDir(), "missing"),
+			ProfilesDir: filepath.Join(t.TempDir(), "profiles"),
+			LLMVendor:   llm.VendorOpenAI,
+		})
+		require.Len(t, results, 4)
+		assert.Equal(t, StatusSkip, results[1].Status)
+
+		output := &ui.RecordingOutput{}
+		assert.False(t, Report(output, results))
+		assert.Len(t, output.Errors, 2)
+		assert.Len(t, output.Warnings, 1)
+	})
+
+	t.Run("warnings do not fail the report", func(t *testing.T) {
+		output := &ui.RecordingOutput{}
+		assert.True(t, Report(output, []Result{CheckLLM("", llm.VendorOpenAI, "", "")}))
+	})
+}
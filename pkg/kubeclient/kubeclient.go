@@ -17,7 +17,12 @@
 package kubeclient
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/discovery"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -36,12 +41,63 @@ func New(kubeconfigPath string) (client.Client, error) {
 		return nil, err
 	}
 
-	// Prepare scheme and client
+	return client.New(restCfg, client.Options{Scheme: newScheme()})
+}
+
+// newScheme registers the types l8k reads and writes
+func newScheme() *runtime.Scheme {
 	scheme := runtime.NewScheme()
 	_ = clientgoscheme.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
 	_ = netop.AddToScheme(scheme)
 	_ = nicop.AddToScheme(scheme)
+	return scheme
+}
+
+// ConnectivityTimeout bounds the API server request of CheckConnectivity
+const ConnectivityTimeout = 10 * time.Second
 
-	return client.New(restCfg, client.Options{Scheme: scheme})
+// CheckConnectivity verifies that the kubeconfig is valid and that its API server answers a /version request
+// within timeout, so that an unusable cluster is reported before any phase starts
+func CheckConnectivity(kubeconfigPath string, timeout time.Duration) error {
+	restCfg, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	if err != nil {
+		return fmt.Errorf("invalid kubeconfig %s: %w", kubeconfigPath, err)
+	}
+	restCfg.Timeout = timeout
+
+	client, err := discovery.NewDiscoveryClientForConfig(restCfg)
+	if err != nil {
+		return fmt.Errorf("invalid kubeconfig %s: %w", kubeconfigPath, err)
+	}
+
+	if _, err := client.ServerVersion(); err != nil {
+		return fmt.Errorf("API server %s is unreachable, check the network access to the cluster and the credentials of the kubeconfig: %w", restCfg.Host, err)
+	}
+	return nil
+}
+
+// clusterIDNamespace is the namespace whose UID identifies a cluster, it exists from the cluster creation on
+const clusterIDNamespace = "kube-system"
+
+// ClusterID returns the UID of the kube-system namespace, which identifies the cluster of c
+func ClusterID(ctx context.Context, c client.Client) (string, error) {
+	namespace := &corev1.Namespace{}
+	if err := c.Get(ctx, client.ObjectKey{Name: clusterIDNamespace}, namespace); err != nil {
+		return "", fmt.Errorf("failed to get namespace %s: %w", clusterIDNamespace, err)
+	}
+	return string(namespace.UID), nil
+}
+
+// ClusterEnvironment returns the value of the key label, or else annotation, of the kube-system namespace, which
+// names the environment of the cluster of c. It is empty when the namespace has neither.
+func ClusterEnvironment(ctx context.Context, c client.Client, key string) (string, error) {
+	namespace := &corev1.Namespace{}
+	if err := c.Get(ctx, client.ObjectKey{Name: clusterIDNamespace}, namespace); err != nil {
+		return "", fmt.Errorf("failed to get namespace %s: %w", clusterIDNamespace, err)
+	}
+	if environment, ok := namespace.Labels[key]; ok {
+		return environment, nil
+	}
+	return namespace.Annotations[key], nil
 }
@@ -0,0 +1,99 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kubeclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeKubeconfig writes a kubeconfig pointing to server
+func writeKubeconfig(t *testing.T, server string) string {
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	content := `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: ` + server + `
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+current-context: test
+users:
+- name: test
+  user:
+    token: test
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestCheckConnectivity(t *testing.T) {
+	t.Run("reachable server", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/version", r.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"major":"1","minor":"33","gitVersion":"v1.33.0"}`))
+		}))
+		defer server.Close()
+
+		assert.NoError(t, CheckConnectivity(writeKubeconfig(t, server.URL), time.Second))
+	})
+
+	t.Run("unreachable server", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		err := CheckConnectivity(writeKubeconfig(t, server.URL), time.Second)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "API server "+server.URL+" is unreachable")
+	})
+
+	t.Run("server not answering in time", func(t *testing.T) {
+		done := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-done
+		}))
+		defer server.Close()
+		defer close(done)
+
+		start := time.Now()
+		err := CheckConnectivity(writeKubeconfig(t, server.URL), 100*time.Millisecond)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is unreachable")
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+
+	t.Run("invalid kubeconfig", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "kubeconfig")
+		require.NoError(t, os.WriteFile(path, []byte("clusters: [unterminated"), 0644))
+
+		err := CheckConnectivity(path, time.Second)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid kubeconfig "+path)
+	})
+}
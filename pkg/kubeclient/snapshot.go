@@ -0,0 +1,118 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kubeclient
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+
+	netop "github.com/Mellanox/network-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/deploy"
+)
+
+// NewFromSnapshot returns a fake client serving the cluster objects recorded in the snapshot file at path, so that
+// discovery runs without a cluster. The snapshot holds YAML documents of objects or lists, e.g. the output of
+// kubectl get nodes,namespaces,nicdevices -A -o yaml. No operator reconciles the snapshot: a NicClusterPolicy
+// created through the client is ready at once.
+func NewFromSnapshot(path string) (client.Client, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cluster snapshot: %w", err)
+	}
+	manifests, err := deploy.ParseManifests(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cluster snapshot %s: %w", path, err)
+	}
+
+	scheme := newScheme()
+	objects := []client.Object{}
+	for _, manifest := range manifests {
+		items := []unstructured.Unstructured{manifest}
+		if manifest.IsList() {
+			list, err := manifest.ToList()
+			if err != nil {
+				return nil, fmt.Errorf("invalid cluster snapshot %s: %w", path, err)
+			}
+			items = list.Items
+		}
+		for _, item := range items {
+			obj, err := snapshotObject(scheme, item)
+			if err != nil {
+				return nil, fmt.Errorf("invalid cluster snapshot %s: %w", path, err)
+			}
+			objects = append(objects, obj)
+		}
+	}
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(snapshotRESTMapper(scheme)).WithObjects(objects...).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			// The API server ignores the namespace of cluster-scoped objects
+			if namespaced, err := c.IsObjectNamespaced(obj); err == nil && !namespaced {
+				obj.SetNamespace("")
+			}
+			if policy, ok := obj.(*netop.NicClusterPolicy); ok {
+				policy.Status.State = netop.StateReady
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}).Build(), nil
+}
+
+// clusterScopedKinds are the kinds of the scheme that are not namespaced
+var clusterScopedKinds = []string{
+	"Node", "Namespace", "PersistentVolume", "StorageClass", "ClusterRole", "ClusterRoleBinding",
+	"NicClusterPolicy", "HostDeviceNetwork", "IPoIBNetwork", "MacvlanNetwork",
+}
+
+// snapshotRESTMapper maps the kinds of scheme with their scope, as the API server of a cluster does
+func snapshotRESTMapper(scheme *runtime.Scheme) meta.RESTMapper {
+	mapper := meta.NewDefaultRESTMapper(scheme.PrioritizedVersionsAllGroups())
+	for gvk := range scheme.AllKnownTypes() {
+		scope := meta.RESTScopeNamespace
+		if slices.Contains(clusterScopedKinds, gvk.Kind) {
+			scope = meta.RESTScopeRoot
+		}
+		mapper.Add(gvk, scope)
+	}
+	return mapper
+}
+
+// snapshotObject converts the recorded object to its type in scheme
+func snapshotObject(scheme *runtime.Scheme, item unstructured.Unstructured) (client.Object, error) {
+	gvk := item.GroupVersionKind()
+	typed, err := scheme.New(gvk)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: kind is not supported", gvk.Kind, item.GetName())
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, typed); err != nil {
+		return nil, fmt.Errorf("%s %s: %w", gvk.Kind, item.GetName(), err)
+	}
+	obj, ok := typed.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("%s %s: kind is not an object", gvk.Kind, item.GetName())
+	}
+	return obj, nil
+}
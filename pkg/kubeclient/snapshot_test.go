@@ -0,0 +1,112 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kubeclient
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	netop "github.com/Mellanox/network-operator/api/v1alpha1"
+	nicop "github.com/Mellanox/nic-configuration-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// testSnapshot records a node, a namespace and a list of NicDevices as written by kubectl get -o yaml
+const testSnapshot = `apiVersion: v1
+kind: Node
+metadata:
+  name: worker-0
+  resourceVersion: "1234"
+  labels:
+    feature.node.kubernetes.io/pci-15b3.present: "true"
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: nvidia-network-operator
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: configuration.net.nvidia.com/v1alpha1
+  kind: NicDevice
+  metadata:
+    name: worker-0-device
+    namespace: nvidia-network-operator
+  status:
+    node: worker-0
+    ports:
+    - pci: "0000:08:00.0"
+      networkInterface: ens1f0
+      rdmaInterface: mlx5_0
+`
+
+func writeSnapshot(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "snapshot.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestNewFromSnapshot(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("serve the recorded objects", func(t *testing.T) {
+		c, err := NewFromSnapshot(writeSnapshot(t, testSnapshot))
+		require.NoError(t, err)
+
+		node := &corev1.Node{}
+		require.NoError(t, c.Get(ctx, client.ObjectKey{Name: "worker-0"}, node))
+		assert.Equal(t, "true", node.Labels["feature.node.kubernetes.io/pci-15b3.present"])
+		require.NoError(t, c.Get(ctx, client.ObjectKey{Name: "nvidia-network-operator"}, &corev1.Namespace{}))
+
+		devices := &nicop.NicDeviceList{}
+		require.NoError(t, c.List(ctx, devices, client.InNamespace("nvidia-network-operator")))
+		require.Len(t, devices.Items, 1)
+		assert.Equal(t, "worker-0", devices.Items[0].Status.Node)
+		assert.Equal(t, "mlx5_0", devices.Items[0].Status.Ports[0].RdmaInterface)
+	})
+
+	t.Run("a created NicClusterPolicy is ready", func(t *testing.T) {
+		c, err := NewFromSnapshot(writeSnapshot(t, testSnapshot))
+		require.NoError(t, err)
+
+		// the policy is cluster-scoped, whatever its namespace
+		require.NoError(t, c.Create(ctx, &netop.NicClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "nic-cluster-policy", Namespace: "nvidia-network-operator"}}))
+		policy := &netop.NicClusterPolicy{}
+		require.NoError(t, c.Get(ctx, client.ObjectKey{Name: "nic-cluster-policy"}, policy))
+		assert.Equal(t, netop.State(netop.StateReady), policy.Status.State)
+	})
+
+	t.Run("reject unsupported kinds", func(t *testing.T) {
+		_, err := NewFromSnapshot(writeSnapshot(t, "apiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: w\n"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Widget w: kind is not supported")
+	})
+
+	t.Run("missing snapshot", func(t *testing.T) {
+		_, err := NewFromSnapshot(filepath.Join(t.TempDir(), "missing.yaml"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read cluster snapshot")
+	})
+}
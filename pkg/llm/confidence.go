@@ -0,0 +1,49 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llm
+
+import "slices"
+
+// Confidence levels of the LLM answers
+const (
+	ConfidenceLow    = "low"
+	ConfidenceMedium = "medium"
+	ConfidenceHigh   = "high"
+)
+
+// ConfidenceLevels lists the confidence levels from the least to the most confident
+var ConfidenceLevels = []string{ConfidenceLow, ConfidenceMedium, ConfidenceHigh}
+
+// DefaultMinConfidence is the confidence required by default: only low confidence answers are rejected
+const DefaultMinConfidence = ConfidenceMedium
+
+// MeetsConfidence reports whether an answer with confidence is accepted with the required minimum.
+// A missing or unknown confidence counts as medium and an empty minimum is DefaultMinConfidence.
+func MeetsConfidence(confidence, minimum string) bool {
+	if minimum == "" {
+		minimum = DefaultMinConfidence
+	}
+	return confidenceRank(confidence) >= confidenceRank(minimum)
+}
+
+// confidenceRank returns the position of confidence in ConfidenceLevels, medium when unknown
+func confidenceRank(confidence string) int {
+	if i := slices.Index(ConfidenceLevels, confidence); i >= 0 {
+		return i
+	}
+	return slices.Index(ConfidenceLevels, ConfidenceMedium)
+}
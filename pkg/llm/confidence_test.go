@@ -0,0 +1,58 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeetsConfidence(t *testing.T) {
+	tests := []struct {
+		minimum  string
+		accepted []string
+		rejected []string
+	}{
+		{minimum: ConfidenceLow, accepted: []string{"low", "medium", "high", ""}},
+		{minimum: ConfidenceMedium, accepted: []string{"medium", "high", "", "unsure"}, rejected: []string{"low"}},
+		{minimum: "", accepted: []string{"medium", "high"}, rejected: []string{"low"}},
+		{minimum: ConfidenceHigh, accepted: []string{"high"}, rejected: []string{"low", "medium", ""}},
+	}
+	for _, tt := range tests {
+		t.Run("minimum "+tt.minimum, func(t *testing.T) {
+			for _, confidence := range tt.accepted {
+				assert.True(t, MeetsConfidence(confidence, tt.minimum), "confidence %q", confidence)
+			}
+			for _, confidence := range tt.rejected {
+				assert.False(t, MeetsConfidence(confidence, tt.minimum), "confidence %q", confidence)
+			}
+		})
+	}
+
+	t.Run("reject the answers below the minimum", func(t *testing.T) {
+		model := &scriptedModel{responses: []string{`{"fabric": "ethernet", "deploymentType": "sriov", "confidence": "medium", "reasoning": "likely"}`}}
+
+		_, err := selectProfile(context.Background(), model, "prompt", Vocabulary{}, ConfidenceHigh)
+		var lowConfidence *ErrLowConfidence
+		require.ErrorAs(t, err, &lowConfidence)
+		assert.Equal(t, ConfidenceMedium, lowConfidence.Confidence)
+		assert.EqualError(t, err, "LLM has medium confidence in the selected profile: likely")
+	})
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+)
+
+// SystemPromptPath is the system prompt file, read from the working directory
+const SystemPromptPath = "system-prompt"
+
+// clusterConfigMarker introduces the cluster configuration at the end of the system prompt
+const clusterConfigMarker = "Cluster configuration:"
+
+// ProfilesContext builds the section of the system prompt listing the fabrics and deployment types
+// supported by the available profiles, so that the prompt follows the profiles shipped at runtime
+func ProfilesContext(available []profiles.Profile) string {
+	fabrics := map[string]bool{}
+	deployments := map[string]bool{}
+	var lines []string
+	for _, profile := range available {
+		requirements := profile.ProfileRequirements
+		fabric := requirements.Fabric
+		if fabric != "" {
+			fabrics[fabric] = true
+		} else {
+			fabric = "any"
+		}
+		deployment := requirements.Deployment
+		if deployment != "" {
+			deployments[deployment] = true
+		} else {
+			deployment = "any"
+		}
+
+		line := fmt.Sprintf("- %s: fabric %s, deploymentType %s", profile.Name, fabric, deployment)
+		if requirements.Multirail != nil {
+			line += fmt.Sprintf(", multirail %t", *requirements.Multirail)
+		}
+		if description := strings.Join(strings.Fields(profile.Description), " "); description != "" {
+			line += ". " + description
+		}
+		lines = append(lines, line)
+	}
+
+	var b strings.Builder
+	b.WriteString("VALID OPTIONS:\n")
+	b.WriteString("Only select a fabric and deploymentType combination supported by one of the available profiles.\n")
+	fmt.Fprintf(&b, "Valid fabrics: %s\n", strings.Join(sortedKeys(fabrics), ", "))
+	fmt.Fprintf(&b, "Valid deployment types: %s\n", strings.Join(sortedKeys(deployments), ", "))
+	b.WriteString("Available profiles:\n")
+	for _, line := range lines {
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// systemPrompt reads the system prompt and inserts the profiles context before the cluster configuration
+func systemPrompt(profilesContext string) (string, error) {
+	data, err := os.ReadFile(SystemPromptPath)
+	if err != nil {
+		return "", err
+	}
+
+	prompt := string(data)
+	if profilesContext == "" {
+		return prompt, nil
+	}
+	if i := strings.LastIndex(prompt, clusterConfigMarker); i >= 0 {
+		return prompt[:i] + profilesContext + "\n" + prompt[i:], nil
+	}
+	return prompt + "\n" + profilesContext, nil
+}
+
+// RenderSystemPrompt returns the system prompt sent to the LLM: the system prompt file with the profiles context,
+// followed by the cluster configuration as JSON
+func RenderSystemPrompt(profilesContext string, clusterConfig config.ClusterConfig) (string, error) {
+	prompt, err := systemPrompt(profilesContext)
+	if err != nil {
+		return "", fmt.Errorf("failed to read system prompt: %w", err)
+	}
+
+	configJSON, err := json.Marshal(clusterConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal cluster config: %w", err)
+	}
+	return fmt.Sprintf("%s\n%s", prompt, string(configJSON)), nil
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llm
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+)
+
+func TestProfilesContext(t *testing.T) {
+	profilesDir := t.TempDir()
+	writeProfile := func(name, manifest string) {
+		require.NoError(t, os.MkdirAll(filepath.Join(profilesDir, name), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(profilesDir, name, "profile.yaml"), []byte(manifest), 0644))
+	}
+	writeProfile("sriov-ethernet-rdma", "name: SR-IOV Ethernet RDMA\nplugin: network-operator\nprofileRequirements:\n  fabric: ethernet\n  deployment: sriov\n")
+
+	t.Run("list the options of the profiles", func(t *testing.T) {
+		available, err := profiles.LoadProfilesDir(profilesDir)
+		require.NoError(t, err)
+
+		generated := ProfilesContext(available)
+		assert.Contains(t, generated, "Valid fabrics: ethernet\n")
+		assert.Contains(t, generated, "Valid deployment types: sriov\n")
+		assert.Contains(t, generated, "- SR-IOV Ethernet RDMA: fabric ethernet, deploymentType sriov\n")
+	})
+
+	t.Run("a newly added profile appears in the context", func(t *testing.T) {
+		writeProfile("dpu-offload", "name: DPU offload\nplugin: network-operator\ndescription: |\n  Offloads the datapath\n  to the DPU.\nprofileRequirements:\n  fabric: infiniband\n  deployment: dpu\n  multirail: true\n")
+
+		available, err := profiles.LoadProfilesDir(profilesDir)
+		require.NoError(t, err)
+
+		generated := ProfilesContext(available)
+		assert.Contains(t, generated, "Valid fabrics: ethernet, infiniband\n")
+		assert.Contains(t, generated, "Valid deployment types: dpu, sriov\n")
+		assert.Contains(t, generated, "- DPU offload: fabric infiniband, deploymentType dpu, multirail true. Offloads the datapath to the DPU.\n")
+	})
+}
+
+func TestSystemPrompt(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile(SystemPromptPath, []byte("SYSTEM: select a profile\n\nCluster configuration:\n"), 0644))
+
+	prompt, err := systemPrompt("VALID OPTIONS:\n")
+	require.NoError(t, err)
+	assert.Equal(t, "SYSTEM: select a profile\n\nVALID OPTIONS:\n\nCluster configuration:\n", prompt)
+
+	prompt, err = systemPrompt("")
+	require.NoError(t, err)
+	assert.Equal(t, "SYSTEM: select a profile\n\nCluster configuration:\n", prompt)
+
+	t.Run("render the prompt with the cluster configuration", func(t *testing.T) {
+		prompt, err := RenderSystemPrompt("VALID OPTIONS:\n", config.ClusterConfig{WorkerNodes: []string{"worker-1"}})
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(prompt, "SYSTEM: select a profile\n\nVALID OPTIONS:\n\nCluster configuration:\n\n{"), prompt)
+		assert.Contains(t, prompt, `"WorkerNodes":["worker-1"]`)
+	})
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llm
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// fixtureModel answers every request with the content of a recorded response file instead of calling a provider
+type fixtureModel struct {
+	path string
+}
+
+// NewFixtureModel returns a model replying with the recorded response stored at path
+func NewFixtureModel(path string) (llms.Model, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("failed to read LLM fixture: %w", err)
+	}
+	return &fixtureModel{path: path}, nil
+}
+
+// GenerateContent returns the recorded response as the only choice
+func (m *fixtureModel) GenerateContent(ctx context.Context, _ []llms.MessageContent, _ ...llms.CallOption) (*llms.ContentResponse, error) {
+	response, err := m.Call(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: response}}}, nil
+}
+
+// Call returns the recorded response
+func (m *fixtureModel) Call(_ context.Context, _ string, _ ...llms.CallOption) (string, error) {
+	data, err := os.ReadFile(m.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read LLM fixture: %w", err)
+	}
+	return string(data), nil
+}
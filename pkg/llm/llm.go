@@ -20,8 +20,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/nvidia/k8s-launch-kit/pkg/config"
 	"github.com/tmc/langchaingo/llms"
@@ -39,107 +41,241 @@ const (
 	VendorGemini      = "gemini"
 )
 
-// createLLM creates an LLM instance based on the vendor configuration.
-func createLLM(llmApiKey string, llmApiUrl string, llmVendor string, llmModel string) (llms.Model, error) {
-	switch llmVendor {
+// Vendors are the supported LLM vendors
+var Vendors = []string{VendorOpenAI, VendorOpenAIAzure, VendorAnthropic, VendorGemini}
+
+// LLMConfig describes how to connect to an LLM provider
+type LLMConfig struct {
+	// Vendor is one of VendorOpenAI, VendorOpenAIAzure, VendorAnthropic or VendorGemini, inferred from the Model
+	// with InferVendor when empty
+	Vendor string
+	APIKey string
+	// BaseURL overrides the vendor API endpoint, required for VendorOpenAIAzure
+	BaseURL string
+	// Model overrides the vendor default model
+	Model string
+	// Timeout bounds every request to the provider, no timeout when zero
+	Timeout time.Duration
+	// Fixture is a file with a recorded response returned instead of calling the provider
+	Fixture string
+	// HTTPClient sends the requests to the provider, the vendor default when nil
+	HTTPClient *http.Client
+	// MinConfidence is the confidence an answer needs to be accepted, DefaultMinConfidence when empty
+	MinConfidence string
+}
+
+// NewClient creates an LLM client for the configured vendor, or replaying the configured fixture
+func NewClient(cfg LLMConfig) (llms.Model, error) {
+	if cfg.Fixture != "" {
+		return NewFixtureModel(cfg.Fixture)
+	}
+
+	if cfg.Vendor == "" {
+		vendor, err := InferVendor(cfg.Model, cfg.BaseURL)
+		if err != nil {
+			return nil, err
+		}
+		log.Log.Info("Inferred the LLM vendor from the model", "vendor", vendor, "model", cfg.Model)
+		cfg.Vendor = vendor
+	}
+
+	model, err := newVendorClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Timeout > 0 {
+		return &timeoutModel{Model: model, timeout: cfg.Timeout}, nil
+	}
+	return model, nil
+}
+
+func newVendorClient(cfg LLMConfig) (llms.Model, error) {
+	switch cfg.Vendor {
 	case VendorOpenAI:
 		options := []openai.Option{
-			openai.WithToken(llmApiKey),
+			openai.WithToken(cfg.APIKey),
+		}
+		if cfg.BaseURL != "" {
+			options = append(options, openai.WithBaseURL(cfg.BaseURL))
 		}
-		if llmApiUrl != "" {
-			options = append(options, openai.WithBaseURL(llmApiUrl))
+		if cfg.Model != "" {
+			options = append(options, openai.WithModel(cfg.Model))
 		}
-		if llmModel != "" {
-			options = append(options, openai.WithModel(llmModel))
+		if cfg.HTTPClient != nil {
+			options = append(options, openai.WithHTTPClient(cfg.HTTPClient))
 		}
 		return openai.New(options...)
 
 	case VendorOpenAIAzure:
 		options := []openai.Option{
 			openai.WithAPIType(openai.APITypeAzure),
-			openai.WithToken(llmApiKey),
-			openai.WithBaseURL(llmApiUrl),
-			openai.WithModel(llmModel),
-			openai.WithEmbeddingModel(llmModel),
+			openai.WithToken(cfg.APIKey),
+			openai.WithBaseURL(cfg.BaseURL),
+			openai.WithModel(cfg.Model),
+			openai.WithEmbeddingModel(cfg.Model),
 			//openai.WithAPIVersion("2025-02-01-preview"),
 		}
+		if cfg.HTTPClient != nil {
+			options = append(options, openai.WithHTTPClient(cfg.HTTPClient))
+		}
 		return openai.New(options...)
 
 	case VendorAnthropic:
 		options := []anthropic.Option{
-			anthropic.WithToken(llmApiKey),
+			anthropic.WithToken(cfg.APIKey),
+		}
+		if cfg.BaseURL != "" {
+			options = append(options, anthropic.WithBaseURL(cfg.BaseURL))
 		}
-		if llmApiUrl != "" {
-			options = append(options, anthropic.WithBaseURL(llmApiUrl))
+		if cfg.Model != "" {
+			options = append(options, anthropic.WithModel(cfg.Model))
 		}
-		if llmModel != "" {
-			options = append(options, anthropic.WithModel(llmModel))
+		if cfg.HTTPClient != nil {
+			options = append(options, anthropic.WithHTTPClient(cfg.HTTPClient))
 		}
 		return anthropic.New(options...)
 
 	case VendorGemini:
 		options := []googleai.Option{
-			googleai.WithAPIKey(llmApiKey),
+			googleai.WithAPIKey(cfg.APIKey),
 		}
-		if llmModel != "" {
-			options = append(options, googleai.WithDefaultModel(llmModel))
+		if cfg.Model != "" {
+			options = append(options, googleai.WithDefaultModel(cfg.Model))
+		}
+		if cfg.HTTPClient != nil {
+			options = append(options, googleai.WithHTTPClient(cfg.HTTPClient))
 		}
 		return googleai.New(context.Background(), options...)
 
 	default:
 		return nil, fmt.Errorf("unsupported LLM vendor: %s. Supported vendors: %s, %s, %s, %s",
-			llmVendor, VendorOpenAI, VendorOpenAIAzure, VendorAnthropic, VendorGemini)
+			cfg.Vendor, VendorOpenAI, VendorOpenAIAzure, VendorAnthropic, VendorGemini)
 	}
 }
 
+// timeoutModel bounds every call of the wrapped model with a timeout
+type timeoutModel struct {
+	llms.Model
+	timeout time.Duration
+}
+
+// GenerateContent calls the wrapped model with a context bounded by the timeout
+func (m *timeoutModel) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+	return m.Model.GenerateContent(ctx, messages, options...)
+}
+
+// Call calls the wrapped model with a context bounded by the timeout
+func (m *timeoutModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+	return m.Model.Call(ctx, prompt, options...)
+}
+
+// ErrLowConfidence is returned when the LLM confidence in the profile it selected for the prompt is below the minimum
+type ErrLowConfidence struct {
+	// Profile is the full LLM response, with the possibly partial profile selection
+	Profile map[string]string
+	// Confidence is the confidence of the LLM in the response
+	Confidence string
+	// Reasoning is the explanation of the LLM
+	Reasoning string
+}
+
+func (e *ErrLowConfidence) Error() string {
+	return fmt.Sprintf("LLM has %s confidence in the selected profile: %s", e.Confidence, e.Reasoning)
+}
+
 func SelectPrompt(promptPath string, config config.ClusterConfig, llmApiKey string, llmApiUrl string, llmVendor string) (map[string]string, error) {
-	return SelectPromptWithModel(promptPath, config, llmApiKey, llmApiUrl, llmVendor, "")
+	return SelectPromptWithModel(promptPath, config, "", llmApiKey, llmApiUrl, llmVendor, "")
 }
 
-func SelectPromptWithModel(promptPath string, config config.ClusterConfig, llmApiKey string, llmApiUrl string, llmVendor string, llmModel string) (map[string]string, error) {
-	llm, err := createLLM(llmApiKey, llmApiUrl, llmVendor, llmModel)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create LLM client: %w", err)
-	}
+// SelectPromptWithModel asks the LLM to select the profile for the prompt. A non-empty profilesContext,
+// see ProfilesContext, is added to the system prompt.
+func SelectPromptWithModel(promptPath string, config config.ClusterConfig, profilesContext string, llmApiKey string, llmApiUrl string, llmVendor string, llmModel string) (map[string]string, error) {
+	return SelectPromptWithConfig(promptPath, config, profilesContext, LLMConfig{Vendor: llmVendor, APIKey: llmApiKey, BaseURL: llmApiUrl, Model: llmModel})
+}
 
-	data, err := os.ReadFile("system-prompt")
+// SelectPromptWithConfig asks the LLM described by cfg to select the profile for the prompt
+func SelectPromptWithConfig(promptPath string, config config.ClusterConfig, profilesContext string, cfg LLMConfig) (map[string]string, error) {
+	data, err := os.ReadFile(promptPath)
 	if err != nil {
 		return nil, err
 	}
+	return SelectPromptTextWithConfig(context.Background(), string(data), config, profilesContext, cfg)
+}
 
-	prompt := string(data)
+// SelectPromptTextWithConfig asks the LLM for the profile matching the user prompt text, within ctx
+func SelectPromptTextWithConfig(ctx context.Context, userPrompt string, config config.ClusterConfig, profilesContext string, cfg LLMConfig) (map[string]string, error) {
+	return SelectPromptTextWithVocabulary(ctx, userPrompt, config, profilesContext, Vocabulary{}, cfg)
+}
 
-	configJson, err := json.Marshal(config)
+// SelectPromptTextWithVocabulary asks the LLM for the profile matching the user prompt text, within ctx.
+// When the response selects options outside the vocabulary, the LLM is asked up to MaxCorrections times to correct it.
+func SelectPromptTextWithVocabulary(ctx context.Context, userPrompt string, config config.ClusterConfig, profilesContext string, vocabulary Vocabulary, cfg LLMConfig) (map[string]string, error) {
+	llm, err := NewClient(cfg)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to create LLM client: %w", err)
 	}
-	prompt = fmt.Sprintf("%s\n%s\nUSER:", prompt, string(configJson))
 
-	data, err = os.ReadFile(promptPath)
+	prompt, err := RenderSystemPrompt(profilesContext, config)
 	if err != nil {
 		return nil, err
 	}
-	prompt = fmt.Sprintf("%s\n%s", prompt, string(data))
+	prompt = fmt.Sprintf("%s\nUSER:", prompt)
 
-	log.Log.V(1).Info("User prompt", "prompt", string(data))
+	prompt = fmt.Sprintf("%s\n%s", prompt, userPrompt)
 
-	response, err := llms.GenerateFromSinglePrompt(context.Background(), llm, prompt, llms.WithTemperature(0.5))
-	if err != nil {
-		return nil, err
-	}
+	log.Log.V(1).Info("User prompt", "prompt", userPrompt)
 
-	log.Log.V(1).Info("LLM Response", "response", response)
+	return selectProfile(ctx, llm, prompt, vocabulary, cfg.MinConfidence)
+}
 
-	// Strip markdown code blocks if present
-	response = trimMarkdownJSON(response)
+// selectProfile sends the prompt to the model and parses the profile it selects, asking for corrections of the
+// options outside the vocabulary. Answers below minConfidence are returned as ErrLowConfidence.
+func selectProfile(ctx context.Context, llm llms.Model, prompt string, vocabulary Vocabulary, minConfidence string) (map[string]string, error) {
+	messages := []llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, prompt)}
+	for corrections := 0; ; corrections++ {
+		response, err := llm.GenerateContent(ctx, messages, llms.WithTemperature(0.5))
+		if err != nil {
+			return nil, err
+		}
+		if len(response.Choices) == 0 {
+			return nil, fmt.Errorf("no response from LLM")
+		}
+		answer := response.Choices[0].Content
 
-	jsonResponse := make(map[string]string)
-	err = json.Unmarshal([]byte(response), &jsonResponse)
-	if err != nil {
-		return nil, err
-	}
+		log.Log.V(1).Info("LLM Response", "response", answer)
 
-	return jsonResponse, nil
+		// Strip markdown code blocks if present
+		answer = trimMarkdownJSON(answer)
+
+		jsonResponse := make(map[string]string)
+		err = json.Unmarshal([]byte(answer), &jsonResponse)
+		if err != nil {
+			return nil, err
+		}
+		if err := normalizeBooleans(jsonResponse); err != nil {
+			return nil, err
+		}
+		if !MeetsConfidence(jsonResponse["confidence"], minConfidence) {
+			return nil, &ErrLowConfidence{Profile: jsonResponse, Confidence: jsonResponse["confidence"], Reasoning: jsonResponse["reasoning"]}
+		}
+
+		problems := vocabulary.Check(jsonResponse)
+		if problems == nil {
+			return jsonResponse, nil
+		}
+		if corrections >= MaxCorrections {
+			return nil, fmt.Errorf("LLM selected options that no profile supports: %w", problems)
+		}
+		log.Log.Info("LLM selected unsupported options, asking for a correction", "problems", problems.Error())
+		messages = append(messages,
+			llms.TextParts(llms.ChatMessageTypeAI, answer),
+			llms.TextParts(llms.ChatMessageTypeHuman, correction(problems)))
+	}
 }
 
 // trimMarkdownJSON removes markdown code block formatting from JSON responses.
@@ -174,16 +310,21 @@ type ChatSession struct {
 	lastResponse  string
 }
 
-// NewChatSession creates a new interactive chat session
-func NewChatSession(clusterConfig config.ClusterConfig, llmApiKey, llmApiUrl, llmVendor, llmModel string) (*ChatSession, error) {
-	llm, err := createLLM(llmApiKey, llmApiUrl, llmVendor, llmModel)
+// NewChatSession creates a new interactive chat session, adding a non-empty profilesContext to the system prompt
+func NewChatSession(clusterConfig config.ClusterConfig, profilesContext, llmApiKey, llmApiUrl, llmVendor, llmModel string) (*ChatSession, error) {
+	return NewChatSessionWithConfig(clusterConfig, profilesContext, LLMConfig{Vendor: llmVendor, APIKey: llmApiKey, BaseURL: llmApiUrl, Model: llmModel})
+}
+
+// NewChatSessionWithConfig creates a new interactive chat session with the LLM described by cfg
+func NewChatSessionWithConfig(clusterConfig config.ClusterConfig, profilesContext string, cfg LLMConfig) (*ChatSession, error) {
+	llm, err := NewClient(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create LLM client: %w", err)
 	}
 
-	data, err := os.ReadFile("system-prompt")
+	prompt, err := RenderSystemPrompt(profilesContext, clusterConfig)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read system prompt: %w", err)
+		return nil, err
 	}
 
 	configJSON, err := json.Marshal(clusterConfig)
@@ -191,18 +332,22 @@ func NewChatSession(clusterConfig config.ClusterConfig, llmApiKey, llmApiUrl, ll
 		return nil, fmt.Errorf("failed to marshal cluster config: %w", err)
 	}
 
-	systemPrompt := fmt.Sprintf("%s\n%s", string(data), string(configJSON))
-
 	return &ChatSession{
 		llm:           llm,
 		messages:      []llms.MessageContent{},
-		systemPrompt:  systemPrompt,
+		systemPrompt:  prompt,
 		clusterConfig: string(configJSON),
 	}, nil
 }
 
 // SendMessage sends a user message and returns the LLM response
 func (c *ChatSession) SendMessage(ctx context.Context, userMessage string) (string, error) {
+	return c.SendMessageStreaming(ctx, userMessage, nil)
+}
+
+// SendMessageStreaming sends a user message and returns the LLM response, passing the partial response
+// to onChunk as it is generated when onChunk is not nil. Models that don't stream only return the response.
+func (c *ChatSession) SendMessageStreaming(ctx context.Context, userMessage string, onChunk func(chunk string)) (string, error) {
 	// Add user message to history
 	c.messages = append(c.messages, llms.TextParts(llms.ChatMessageTypeHuman, userMessage))
 
@@ -214,8 +359,16 @@ func (c *ChatSession) SendMessage(ctx context.Context, userMessage string) (stri
 
 	log.Log.V(1).Info("Sending message to LLM", "userMessage", userMessage)
 
+	options := []llms.CallOption{llms.WithTemperature(0.5)}
+	if onChunk != nil {
+		options = append(options, llms.WithStreamingFunc(func(_ context.Context, chunk []byte) error {
+			onChunk(string(chunk))
+			return nil
+		}))
+	}
+
 	// Generate response
-	response, err := c.llm.GenerateContent(ctx, allMessages, llms.WithTemperature(0.5))
+	response, err := c.llm.GenerateContent(ctx, allMessages, options...)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate response: %w", err)
 	}
@@ -261,11 +414,44 @@ func (c *ChatSession) ExtractProfile() (map[string]string, error) {
 		return nil, fmt.Errorf("failed to parse profile JSON: %w", err)
 	}
 
-	// Convert all values to strings
+	// Convert all values to strings, a null value is left empty
 	jsonResponse := make(map[string]string)
 	for k, v := range rawResponse {
-		jsonResponse[k] = fmt.Sprintf("%v", v)
+		if v != nil {
+			jsonResponse[k] = fmt.Sprintf("%v", v)
+		} else {
+			jsonResponse[k] = ""
+		}
 	}
 
+	if err := normalizeBooleans(jsonResponse); err != nil {
+		return nil, err
+	}
 	return jsonResponse, nil
 }
+
+// booleanFields are the fields of the LLM response holding a boolean
+var booleanFields = []string{"multirail", "spectrumX", "ai"}
+
+// booleanValues maps the boolean variants models emit, lowercased, to "true" or "false"
+var booleanValues = map[string]string{
+	"true": "true", "yes": "true", "y": "true", "1": "true", "on": "true",
+	"false": "false", "no": "false", "n": "false", "0": "false", "off": "false",
+}
+
+// normalizeBooleans rewrites the booleanFields of the response to "true" or "false". An empty field is left empty,
+// a value that is neither a true nor a false variant, e.g. "maybe", is an error.
+func normalizeBooleans(response map[string]string) error {
+	for _, field := range booleanFields {
+		value, ok := response[field]
+		if !ok || strings.TrimSpace(value) == "" {
+			continue
+		}
+		normalized, ok := booleanValues[strings.ToLower(strings.TrimSpace(value))]
+		if !ok {
+			return fmt.Errorf("LLM returned an ambiguous value for %s: %q, expected true or false", field, value)
+		}
+		response[field] = normalized
+	}
+	return nil
+}
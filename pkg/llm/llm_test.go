@@ -17,63 +17,135 @@
 package llm
 
 import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/ui"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
 )
 
-func TestCreateLLM_OpenAI(t *testing.T) {
-	llm, err := createLLM("test-api-key", "", VendorOpenAI, "gpt-4")
-	require.NoError(t, err)
-	assert.NotNil(t, llm)
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  LLMConfig
+	}{
+		{name: "OpenAI", cfg: LLMConfig{Vendor: VendorOpenAI, APIKey: "test-api-key", Model: "gpt-4"}},
+		{name: "OpenAI with base URL", cfg: LLMConfig{Vendor: VendorOpenAI, APIKey: "test-api-key", BaseURL: "https://custom.openai.example.com", Model: "gpt-4"}},
+		{name: "OpenAI Azure", cfg: LLMConfig{Vendor: VendorOpenAIAzure, APIKey: "test-api-key", BaseURL: "https://azure.openai.example.com", Model: "gpt-4"}},
+		{name: "Anthropic", cfg: LLMConfig{Vendor: VendorAnthropic, APIKey: "test-api-key", Model: "claude-3-5-sonnet-20241022"}},
+		{name: "Anthropic with base URL", cfg: LLMConfig{Vendor: VendorAnthropic, APIKey: "test-api-key", BaseURL: "https://custom.anthropic.example.com", Model: "claude-3-5-sonnet-20241022"}},
+		{name: "Gemini", cfg: LLMConfig{Vendor: VendorGemini, APIKey: "test-api-key", Model: "gemini-pro"}},
+		{name: "Gemini with default model", cfg: LLMConfig{Vendor: VendorGemini, APIKey: "test-api-key"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			llm, err := NewClient(tt.cfg)
+			require.NoError(t, err)
+			assert.NotNil(t, llm)
+
+			withTimeout := tt.cfg
+			withTimeout.Timeout = time.Minute
+			llm, err = NewClient(withTimeout)
+			require.NoError(t, err)
+			assert.IsType(t, &timeoutModel{}, llm)
+		})
+	}
 }
 
-func TestCreateLLM_OpenAIWithBaseURL(t *testing.T) {
-	llm, err := createLLM("test-api-key", "https://custom.openai.example.com", VendorOpenAI, "gpt-4")
-	require.NoError(t, err)
-	assert.NotNil(t, llm)
+func TestNewClient_UnsupportedVendor(t *testing.T) {
+	llm, err := NewClient(LLMConfig{Vendor: "unsupported-vendor", APIKey: "test-api-key"})
+	require.Error(t, err)
+	assert.Nil(t, llm)
+	assert.Contains(t, err.Error(), "unsupported LLM vendor: unsupported-vendor")
+	assert.Contains(t, err.Error(), VendorOpenAI)
+	assert.Contains(t, err.Error(), VendorOpenAIAzure)
+	assert.Contains(t, err.Error(), VendorAnthropic)
+	assert.Contains(t, err.Error(), VendorGemini)
 }
 
-func TestCreateLLM_OpenAIAzure(t *testing.T) {
-	llm, err := createLLM("test-api-key", "https://azure.openai.example.com", VendorOpenAIAzure, "gpt-4")
-	require.NoError(t, err)
-	assert.NotNil(t, llm)
+// slowModel blocks until the context is done
+type slowModel struct{}
+
+func (slowModel) GenerateContent(ctx context.Context, _ []llms.MessageContent, _ ...llms.CallOption) (*llms.ContentResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
 }
 
-func TestCreateLLM_Anthropic(t *testing.T) {
-	llm, err := createLLM("test-api-key", "", VendorAnthropic, "claude-3-5-sonnet-20241022")
-	require.NoError(t, err)
-	assert.NotNil(t, llm)
+func (slowModel) Call(ctx context.Context, _ string, _ ...llms.CallOption) (string, error) {
+	<-ctx.Done()
+	return "", ctx.Err()
 }
 
-func TestCreateLLM_AnthropicWithBaseURL(t *testing.T) {
-	llm, err := createLLM("test-api-key", "https://custom.anthropic.example.com", VendorAnthropic, "claude-3-5-sonnet-20241022")
-	require.NoError(t, err)
-	assert.NotNil(t, llm)
+func TestTimeoutModel(t *testing.T) {
+	model := &timeoutModel{Model: slowModel{}, timeout: 10 * time.Millisecond}
+
+	_, err := model.GenerateContent(context.Background(), nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	_, err = model.Call(context.Background(), "prompt")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
 }
 
-func TestCreateLLM_Gemini(t *testing.T) {
-	llm, err := createLLM("test-api-key", "", VendorGemini, "gemini-pro")
-	require.NoError(t, err)
-	assert.NotNil(t, llm)
+// streamingModel streams its chunks to the streaming function before returning the whole response
+type streamingModel struct {
+	chunks []string
 }
 
-func TestCreateLLM_GeminiWithDefaultModel(t *testing.T) {
-	llm, err := createLLM("test-api-key", "", VendorGemini, "")
-	require.NoError(t, err)
-	assert.NotNil(t, llm)
+func (m streamingModel) GenerateContent(ctx context.Context, _ []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	opts := &llms.CallOptions{}
+	for _, option := range options {
+		option(opts)
+	}
+
+	var content strings.Builder
+	for _, chunk := range m.chunks {
+		if opts.StreamingFunc != nil {
+			if err := opts.StreamingFunc(ctx, []byte(chunk)); err != nil {
+				return nil, err
+			}
+		}
+		content.WriteString(chunk)
+	}
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: content.String()}}}, nil
 }
 
-func TestCreateLLM_UnsupportedVendor(t *testing.T) {
-	llm, err := createLLM("test-api-key", "", "unsupported-vendor", "")
-	require.Error(t, err)
-	assert.Nil(t, llm)
-	assert.Contains(t, err.Error(), "unsupported LLM vendor: unsupported-vendor")
-	assert.Contains(t, err.Error(), VendorOpenAI)
-	assert.Contains(t, err.Error(), VendorOpenAIAzure)
-	assert.Contains(t, err.Error(), VendorAnthropic)
-	assert.Contains(t, err.Error(), VendorGemini)
+func (m streamingModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func TestChatSession_SendMessageStreaming(t *testing.T) {
+	chunks := []string{"I recommend ", "SR-IOV:\n", `{"fabric": "ethernet", `, `"deploymentType": "sriov", "confidence": "high"}`}
+	session := &ChatSession{llm: streamingModel{chunks: chunks}}
+
+	t.Run("stream the chunks to the UI", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		output := ui.NewWithWriter(buf)
+
+		response, err := session.SendMessageStreaming(context.Background(), "which profile?", output.Stream)
+		require.NoError(t, err)
+		assert.Equal(t, strings.Join(chunks, ""), buf.String())
+		assert.Equal(t, buf.String(), response)
+
+		profile, err := session.ExtractProfile()
+		require.NoError(t, err)
+		assert.Equal(t, "ethernet", profile["fabric"])
+		assert.Equal(t, "sriov", profile["deploymentType"])
+	})
+
+	t.Run("buffer the response without a chunk handler", func(t *testing.T) {
+		response, err := session.SendMessage(context.Background(), "and again?")
+		require.NoError(t, err)
+		assert.Equal(t, strings.Join(chunks, ""), response)
+		assert.Len(t, session.messages, 4)
+	})
 }
 
 func TestVendorConstants(t *testing.T) {
@@ -162,6 +234,43 @@ This should work.`,
 	assert.Equal(t, "high", profile["confidence"])
 }
 
+func TestChatSession_ExtractProfile_LenientBooleanValues(t *testing.T) {
+	extract := func(multirail string) (map[string]string, error) {
+		session := &ChatSession{
+			lastResponse: `{"fabric": "ethernet", "deploymentType": "sriov", "multirail": ` + multirail +
+				`, "spectrumX": "false", "ai": "false", "confidence": "high", "reasoning": "Test reasoning"}`,
+		}
+		return session.ExtractProfile()
+	}
+
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{`"yes"`, "true"},
+		{`"Yes"`, "true"},
+		{`"no"`, "false"},
+		{`"1"`, "true"},
+		{`"0"`, "false"},
+		{`1`, "true"},
+		{`0`, "false"},
+		{`null`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			profile, err := extract(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, profile["multirail"])
+		})
+	}
+
+	t.Run("reject an ambiguous value", func(t *testing.T) {
+		_, err := extract(`"maybe"`)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `ambiguous value for multirail: "maybe"`)
+	})
+}
+
 func TestChatSession_ExtractProfile_NoJSON(t *testing.T) {
 	session := &ChatSession{
 		lastResponse: "This is just text without any JSON",
@@ -186,3 +295,59 @@ func TestInteractivePromptSuffix(t *testing.T) {
 	assert.Contains(t, InteractivePromptSuffix, "generate")
 	assert.Contains(t, InteractivePromptSuffix, "question")
 }
+
+func TestFixture(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile(SystemPromptPath, []byte("SYSTEM: select a profile\n\nCluster configuration:\n"), 0644))
+	require.NoError(t, os.WriteFile("prompt.txt", []byte("I need SR-IOV with RDMA on ethernet"), 0644))
+	require.NoError(t, os.WriteFile("response.json", []byte("```json\n"+`{"fabric": "ethernet", "deploymentType": "sriov", "multirail": "true", "confidence": "high", "reasoning": "recorded"}`+"\n```\n"), 0644))
+
+	cfg := LLMConfig{Vendor: "unused", Fixture: "response.json"}
+
+	t.Run("select a profile from the recorded response", func(t *testing.T) {
+		profile, err := SelectPromptWithConfig("prompt.txt", config.ClusterConfig{}, "", cfg)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"fabric":         "ethernet",
+			"deploymentType": "sriov",
+			"multirail":      "true",
+			"confidence":     "high",
+			"reasoning":      "recorded",
+		}, profile)
+	})
+
+	t.Run("chat session replies with the recorded response", func(t *testing.T) {
+		session, err := NewChatSessionWithConfig(config.ClusterConfig{}, "", cfg)
+		require.NoError(t, err)
+
+		_, err = session.SendMessage(context.Background(), "which profile should I use?")
+		require.NoError(t, err)
+		profile, err := session.ExtractProfile()
+		require.NoError(t, err)
+		assert.Equal(t, "sriov", profile["deploymentType"])
+		assert.Equal(t, "recorded", profile["reasoning"])
+	})
+
+	t.Run("low confidence returns the reasoning and partial profile", func(t *testing.T) {
+		require.NoError(t, os.WriteFile("low.json", []byte(`{"fabric": "ethernet", "deploymentType": "", "confidence": "low", "reasoning": "the prompt does not name the deployment type"}`), 0644))
+
+		profile, err := SelectPromptWithConfig("prompt.txt", config.ClusterConfig{}, "", LLMConfig{Fixture: "low.json"})
+		assert.Nil(t, profile)
+		var lowConfidence *ErrLowConfidence
+		require.ErrorAs(t, err, &lowConfidence)
+		assert.Equal(t, "the prompt does not name the deployment type", lowConfidence.Reasoning)
+		assert.Equal(t, map[string]string{
+			"fabric":         "ethernet",
+			"deploymentType": "",
+			"confidence":     "low",
+			"reasoning":      "the prompt does not name the deployment type",
+		}, lowConfidence.Profile)
+		assert.EqualError(t, err, "LLM has low confidence in the selected profile: the prompt does not name the deployment type")
+	})
+
+	t.Run("missing fixture", func(t *testing.T) {
+		_, err := NewClient(LLMConfig{Fixture: "missing.json"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read LLM fixture")
+	})
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ParseProfileJSON parses a profile given as a JSON object with the keys of an LLM response, e.g.
+// {"fabric": "ethernet", "deploymentType": "sriov", "multirail": false}, as the LLM response it stands for.
+// The booleans are normalized like the answers of the LLM and the options are checked against the vocabulary.
+func ParseProfileJSON(data string, vocabulary Vocabulary) (map[string]string, error) {
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(data), &fields); err != nil {
+		return nil, fmt.Errorf("invalid profile JSON: %w", err)
+	}
+
+	profile := make(map[string]string, len(fields))
+	for key, value := range fields {
+		switch value := value.(type) {
+		case string:
+			profile[key] = value
+		case bool, float64:
+			profile[key] = fmt.Sprint(value)
+		case nil:
+			profile[key] = ""
+		default:
+			return nil, fmt.Errorf("invalid profile JSON: %s must be a string, a boolean or a number", key)
+		}
+	}
+
+	for _, field := range booleanFields {
+		value := strings.TrimSpace(profile[field])
+		if value == "" {
+			continue
+		}
+		normalized, ok := booleanValues[strings.ToLower(value)]
+		if !ok {
+			return nil, fmt.Errorf("invalid profile JSON: %s must be true or false, got %q", field, profile[field])
+		}
+		profile[field] = normalized
+	}
+
+	if err := vocabulary.Check(profile); err != nil {
+		return nil, fmt.Errorf("invalid profile JSON: %w", err)
+	}
+	return profile, nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProfileJSON(t *testing.T) {
+	vocabulary := Vocabulary{Fabrics: []string{"ethernet", "infiniband"}, Deployments: []string{"host_device", "sriov"}}
+
+	t.Run("parse a valid profile", func(t *testing.T) {
+		profile, err := ParseProfileJSON(`{"fabric": "ethernet", "deploymentType": "sriov", "multirail": true, "ai": "no"}`, vocabulary)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"fabric": "ethernet", "deploymentType": "sriov", "multirail": "true", "ai": "false"}, profile)
+	})
+
+	t.Run("reject invalid JSON", func(t *testing.T) {
+		_, err := ParseProfileJSON(`{"fabric": "ethernet",`, vocabulary)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid profile JSON")
+	})
+
+	t.Run("reject a nested value", func(t *testing.T) {
+		_, err := ParseProfileJSON(`{"fabric": {"name": "ethernet"}}`, vocabulary)
+		assert.EqualError(t, err, "invalid profile JSON: fabric must be a string, a boolean or a number")
+	})
+
+	t.Run("reject an ambiguous boolean", func(t *testing.T) {
+		_, err := ParseProfileJSON(`{"fabric": "ethernet", "multirail": "maybe"}`, vocabulary)
+		assert.EqualError(t, err, `invalid profile JSON: multirail must be true or false, got "maybe"`)
+	})
+
+	t.Run("reject the options outside the vocabulary", func(t *testing.T) {
+		_, err := ParseProfileJSON(`{"fabric": "ethernet", "deploymentType": "macvlan"}`, vocabulary)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `deploymentType "macvlan" is not supported`)
+	})
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llm
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// DefaultVendor serves the models of no known family, Azure OpenAI deployments may have any name. It also serves the
+// OpenAI models unless the API URL is the OpenAI API.
+const DefaultVendor = VendorOpenAIAzure
+
+// modelFamily matches the tokens of the model names of a vendor, e.g. claude in claude-3-5-sonnet
+type modelFamily struct {
+	vendor  string
+	matches func(token string) bool
+}
+
+var openAIReasoningModel = regexp.MustCompile(`^o[0-9]`)
+
+// modelFamilies lists the model families the vendor is inferred from
+var modelFamilies = []modelFamily{
+	{vendor: VendorOpenAI, matches: func(token string) bool {
+		return strings.HasPrefix(token, "gpt") || strings.HasPrefix(token, "chatgpt") || openAIReasoningModel.MatchString(token)
+	}},
+	{vendor: VendorAnthropic, matches: func(token string) bool { return strings.HasPrefix(token, "claude") }},
+	{vendor: VendorGemini, matches: func(token string) bool { return strings.HasPrefix(token, "gemini") }},
+}
+
+// endpointVendors are the vendors of the known API hosts
+var endpointVendors = map[string]string{
+	"api.openai.com":                    VendorOpenAI,
+	"api.anthropic.com":                 VendorAnthropic,
+	"generativelanguage.googleapis.com": VendorGemini,
+}
+
+// InferVendor returns the vendor the model name and the API URL point to, e.g. VendorAnthropic for
+// claude-3-5-sonnet or anthropic/claude-3-5-sonnet. The OpenAI models are served by DefaultVendor, like a model
+// of no known family, unless baseURL is the OpenAI API. A model naming several families, or pointing to another
+// vendor than the API URL, is ambiguous and an error.
+func InferVendor(model, baseURL string) (string, error) {
+	tokens := strings.FieldsFunc(strings.ToLower(model), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var vendors []string
+	for _, family := range modelFamilies {
+		if slices.ContainsFunc(tokens, family.matches) {
+			vendors = append(vendors, family.vendor)
+		}
+	}
+	if len(vendors) > 1 {
+		return "", fmt.Errorf("model %q is ambiguous, it may be served by %s: set the LLM vendor", model, strings.Join(vendors, " or "))
+	}
+
+	endpoint := endpointVendor(baseURL)
+	switch {
+	case len(vendors) == 0 && endpoint == "":
+		return DefaultVendor, nil
+	case len(vendors) == 0:
+		return endpoint, nil
+	case vendors[0] == VendorOpenAI && endpoint == "":
+		return DefaultVendor, nil
+	case vendors[0] == VendorOpenAI && (endpoint == VendorOpenAI || endpoint == VendorOpenAIAzure):
+		return endpoint, nil
+	case endpoint == "" || endpoint == vendors[0]:
+		return vendors[0], nil
+	default:
+		return "", fmt.Errorf("model %q is served by %s, but the API URL %s is served by %s: set the LLM vendor", model, vendors[0], baseURL, endpoint)
+	}
+}
+
+// endpointVendor returns the vendor of the API URL, VendorOpenAIAzure for an Azure endpoint such as
+// https://example.openai.azure.com, empty when the URL is unset or of no known vendor
+func endpointVendor(baseURL string) string {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return ""
+	}
+	host := strings.ToLower(parsed.Hostname())
+	if strings.HasSuffix(host, ".azure.com") {
+		return VendorOpenAIAzure
+	}
+	return endpointVendors[host]
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms/anthropic"
+)
+
+func TestInferVendor(t *testing.T) {
+	tests := []struct {
+		model    string
+		baseURL  string
+		expected string
+	}{
+		{model: "gpt-4o", expected: DefaultVendor},
+		{model: "gpt-4o", baseURL: "https://llm.example.com/v1", expected: DefaultVendor},
+		{model: "GPT-4.1-mini", baseURL: "https://api.openai.com/v1", expected: VendorOpenAI},
+		{model: "chatgpt-4o-latest", baseURL: "https://api.openai.com/v1", expected: VendorOpenAI},
+		{model: "o3-mini", baseURL: "https://api.openai.com/v1", expected: VendorOpenAI},
+		{model: "gpt-4o", baseURL: "https://example.openai.azure.com", expected: VendorOpenAIAzure},
+		{model: "my-deployment", baseURL: "https://api.anthropic.com", expected: VendorAnthropic},
+		{model: "claude-3-5-sonnet-20241022", expected: VendorAnthropic},
+		{model: "claude-3-5-sonnet-20241022", baseURL: "https://api.anthropic.com", expected: VendorAnthropic},
+		{model: "anthropic/claude-sonnet-4", expected: VendorAnthropic},
+		{model: "gemini-1.5-pro", expected: VendorGemini},
+		{model: "models/gemini-2.0-flash", expected: VendorGemini},
+		{model: "my-deployment", expected: DefaultVendor},
+		{model: "", expected: DefaultVendor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.model+tt.baseURL, func(t *testing.T) {
+			vendor, err := InferVendor(tt.model, tt.baseURL)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, vendor)
+		})
+	}
+
+	t.Run("ambiguous model", func(t *testing.T) {
+		_, err := InferVendor("claude-distilled-gpt-4", "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `model "claude-distilled-gpt-4" is ambiguous, it may be served by openai or anthropic`)
+	})
+
+	t.Run("model and API URL of different vendors", func(t *testing.T) {
+		_, err := InferVendor("claude-3-5-sonnet-20241022", "https://api.openai.com/v1")
+		assert.EqualError(t, err, `model "claude-3-5-sonnet-20241022" is served by anthropic, but the API URL https://api.openai.com/v1 is served by openai: set the LLM vendor`)
+
+		_, err = InferVendor("gpt-4o", "https://generativelanguage.googleapis.com")
+		assert.ErrorContains(t, err, "is served by gemini")
+	})
+}
+
+func TestNewClient_InferVendor(t *testing.T) {
+	t.Run("infer the vendor from the model", func(t *testing.T) {
+		llm, err := NewClient(LLMConfig{APIKey: "test-api-key", Model: "claude-3-5-sonnet-20241022"})
+		require.NoError(t, err)
+		assert.IsType(t, &anthropic.LLM{}, llm)
+	})
+
+	t.Run("ambiguous model", func(t *testing.T) {
+		llm, err := NewClient(LLMConfig{APIKey: "test-api-key", Model: "gemini-claude"})
+		require.Error(t, err)
+		assert.Nil(t, llm)
+		assert.Contains(t, err.Error(), "is ambiguous")
+	})
+}
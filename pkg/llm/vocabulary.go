@@ -0,0 +1,85 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llm
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+)
+
+// MaxCorrections bounds the re-prompts sent when the LLM selects options that no profile supports
+const MaxCorrections = 1
+
+// Vocabulary lists the fabrics and deployment types supported by the available profiles.
+// An empty list accepts any value, as does a profile without that requirement.
+type Vocabulary struct {
+	Fabrics     []string
+	Deployments []string
+}
+
+// NewVocabulary returns the vocabulary of the available profiles
+func NewVocabulary(available []profiles.Profile) Vocabulary {
+	fabrics := map[string]bool{}
+	deployments := map[string]bool{}
+	anyFabric, anyDeployment := len(available) == 0, len(available) == 0
+	for _, profile := range available {
+		if fabric := profile.ProfileRequirements.Fabric; fabric != "" {
+			fabrics[fabric] = true
+		} else {
+			anyFabric = true
+		}
+		if deployment := profile.ProfileRequirements.Deployment; deployment != "" {
+			deployments[deployment] = true
+		} else {
+			anyDeployment = true
+		}
+	}
+
+	vocabulary := Vocabulary{}
+	if !anyFabric {
+		vocabulary.Fabrics = sortedKeys(fabrics)
+	}
+	if !anyDeployment {
+		vocabulary.Deployments = sortedKeys(deployments)
+	}
+	return vocabulary
+}
+
+// Check returns an error describing the fabric and deployment type of the LLM response that no profile supports.
+// Missing values are not checked.
+func (v Vocabulary) Check(response map[string]string) error {
+	problems := []string{}
+	if fabric := response["fabric"]; fabric != "" && len(v.Fabrics) > 0 && !slices.Contains(v.Fabrics, fabric) {
+		problems = append(problems, fmt.Sprintf("fabric %q is not supported, expected one of: %s", fabric, strings.Join(v.Fabrics, ", ")))
+	}
+	if deployment := response["deploymentType"]; deployment != "" && len(v.Deployments) > 0 && !slices.Contains(v.Deployments, deployment) {
+		problems = append(problems, fmt.Sprintf("deploymentType %q is not supported, expected one of: %s", deployment, strings.Join(v.Deployments, ", ")))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// correction is the message asking the LLM to fix the problems found by Check
+func correction(problems error) string {
+	return fmt.Sprintf("Your answer selects options that no available profile supports: %v. "+
+		"Answer again with the same JSON object, using only the valid options.", problems)
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llm
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+)
+
+// scriptedModel answers the requests with its responses in turn, recording the messages of every request
+type scriptedModel struct {
+	responses []string
+	requests  [][]llms.MessageContent
+}
+
+func (m *scriptedModel) GenerateContent(_ context.Context, messages []llms.MessageContent, _ ...llms.CallOption) (*llms.ContentResponse, error) {
+	if len(m.requests) >= len(m.responses) {
+		return nil, fmt.Errorf("unexpected request %d", len(m.requests)+1)
+	}
+	m.requests = append(m.requests, messages)
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: m.responses[len(m.requests)-1]}}}, nil
+}
+
+func (m *scriptedModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func TestNewVocabulary(t *testing.T) {
+	profile := func(fabric, deployment string) profiles.Profile {
+		return profiles.Profile{ProfileRequirements: profiles.ProfileRequirements{Fabric: fabric, Deployment: deployment}}
+	}
+
+	t.Run("collect the options of the profiles", func(t *testing.T) {
+		vocabulary := NewVocabulary([]profiles.Profile{profile("infiniband", "sriov"), profile("ethernet", "sriov"), profile("ethernet", "host_device")})
+		assert.Equal(t, Vocabulary{Fabrics: []string{"ethernet", "infiniband"}, Deployments: []string{"host_device", "sriov"}}, vocabulary)
+	})
+
+	t.Run("accept any value of a requirement a profile leaves unset", func(t *testing.T) {
+		vocabulary := NewVocabulary([]profiles.Profile{profile("ethernet", "sriov"), profile("", "host_device")})
+		assert.Empty(t, vocabulary.Fabrics)
+		assert.NoError(t, vocabulary.Check(map[string]string{"fabric": "roce", "deploymentType": "sriov"}))
+	})
+}
+
+func TestSelectProfileCorrections(t *testing.T) {
+	vocabulary := Vocabulary{Fabrics: []string{"ethernet", "infiniband"}, Deployments: []string{"host_device", "sriov"}}
+	invalid := `{"fabric": "roce", "deploymentType": "sriov", "confidence": "high"}`
+	valid := `{"fabric": "ethernet", "deploymentType": "sriov", "confidence": "high"}`
+
+	t.Run("accept a valid answer without correction", func(t *testing.T) {
+		model := &scriptedModel{responses: []string{valid}}
+
+		profile, err := selectProfile(context.Background(), model, "prompt", vocabulary, "")
+		require.NoError(t, err)
+		assert.Equal(t, "ethernet", profile["fabric"])
+		assert.Len(t, model.requests, 1)
+	})
+
+	t.Run("ask for a correction of an invalid fabric", func(t *testing.T) {
+		model := &scriptedModel{responses: []string{"```json\n" + invalid + "\n```", valid}}
+
+		profile, err := selectProfile(context.Background(), model, "prompt", vocabulary, "")
+		require.NoError(t, err)
+		assert.Equal(t, "ethernet", profile["fabric"])
+		assert.Equal(t, "sriov", profile["deploymentType"])
+
+		require.Len(t, model.requests, 2)
+		correction := model.requests[1]
+		require.Len(t, correction, 3)
+		assert.Equal(t, llms.ChatMessageTypeAI, correction[1].Role)
+		assert.Equal(t, llms.TextContent{Text: invalid}, correction[1].Parts[0])
+		assert.Equal(t, llms.ChatMessageTypeHuman, correction[2].Role)
+		assert.Contains(t, correction[2].Parts[0].(llms.TextContent).Text, `fabric "roce" is not supported, expected one of: ethernet, infiniband`)
+	})
+
+	t.Run("stop after the bounded corrections", func(t *testing.T) {
+		model := &scriptedModel{responses: []string{invalid, invalid, valid}}
+
+		_, err := selectProfile(context.Background(), model, "prompt", vocabulary, "")
+		require.Error(t, err)
+		assert.EqualError(t, err, `LLM selected options that no profile supports: fabric "roce" is not supported, expected one of: ethernet, infiniband`)
+		assert.Len(t, model.requests, MaxCorrections+1)
+	})
+
+	t.Run("report every unsupported option", func(t *testing.T) {
+		err := vocabulary.Check(map[string]string{"fabric": "roce", "deploymentType": "dpu"})
+		assert.EqualError(t, err, `fabric "roce" is not supported, expected one of: ethernet, infiniband; deploymentType "dpu" is not supported, expected one of: host_device, sriov`)
+	})
+
+	t.Run("leave low confidence answers to the caller", func(t *testing.T) {
+		model := &scriptedModel{responses: []string{`{"fabric": "roce", "confidence": "low", "reasoning": "unclear"}`}}
+
+		_, err := selectProfile(context.Background(), model, "prompt", vocabulary, "")
+		var lowConfidence *ErrLowConfidence
+		require.ErrorAs(t, err, &lowConfidence)
+		assert.Len(t, model.requests, 1)
+	})
+}
@@ -19,7 +19,9 @@ package log
 import (
 	"flag"
 	"os"
+	"sync"
 
+	"github.com/go-logr/logr"
 	"github.com/go-logr/zapr"
 	zzap "go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -32,12 +34,14 @@ const (
 )
 
 var (
+	// mu guards the state below and Options.Level, the logger may be set up concurrently, e.g. by tests
+	mu             sync.Mutex
 	logFile        *os.File
 	logFileSet     bool
 	loggingEnabled bool
 )
 
-// Options stores controller-runtime (zap) log config
+// Options stores controller-runtime (zap) log config, it must not be modified concurrently with the functions of the package
 var Options = &zap.Options{
 	Development: true,
 	// we dont log with panic level, so this essentially
@@ -61,6 +65,8 @@ func SetLogFile(path string) error {
 	if err != nil {
 		return err
 	}
+	mu.Lock()
+	defer mu.Unlock()
 	logFile = file
 	logFileSet = true
 	return nil
@@ -68,11 +74,15 @@ func SetLogFile(path string) error {
 
 // SetLoggingEnabled controls whether logging is enabled or disabled
 func SetLoggingEnabled(enabled bool) {
+	mu.Lock()
+	defer mu.Unlock()
 	loggingEnabled = enabled
 }
 
 // IsEnabled returns whether logging is currently enabled
 func IsEnabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
 	return loggingEnabled
 }
 
@@ -80,10 +90,13 @@ func IsEnabled() bool {
 // this should be called once Options have been initialized
 // either by parsing flags or directly modifying Options.
 func InitLog() {
+	mu.Lock()
+	defer mu.Unlock()
+
 	if !loggingEnabled {
 		// Disable logging by setting level to panic (effectively disables all logs)
 		Options.Level = zzap.NewAtomicLevelAt(zapcore.PanicLevel)
-		log.SetLogger(zap.New(zap.UseFlagOptions(Options)))
+		setLogger(zap.New(zap.UseFlagOptions(Options)))
 		return
 	}
 
@@ -106,7 +119,7 @@ func InitLog() {
 		writeSyncer := zapcore.AddSync(logFile)
 		core := zapcore.NewCore(encoder, writeSyncer, Options.Level)
 		logger := zzap.New(core, zzap.AddCaller(), zzap.AddStacktrace(zapcore.DPanicLevel))
-		log.SetLogger(zapr.NewLogger(logger))
+		setLogger(zapr.NewLogger(logger))
 		return
 	}
 
@@ -129,7 +142,12 @@ func InitLog() {
 	writeSyncer := zapcore.AddSync(os.Stderr)
 	core := zapcore.NewCore(encoder, writeSyncer, Options.Level)
 	logger := zzap.New(core, zzap.AddCaller(), zzap.AddStacktrace(zapcore.DPanicLevel))
-	log.SetLogger(zapr.NewLogger(logger))
+	setLogger(zapr.NewLogger(logger))
+}
+
+// setLogger sets the global controller-runtime logger, teeing its entries to the sinks added with Tee
+func setLogger(logger logr.Logger) {
+	log.SetLogger(logr.New(teeSink{primary: logger.GetSink()}))
 }
 
 // SetLogLevel sets current logging level to the provided lvl
@@ -139,6 +157,8 @@ func SetLogLevel(lvl string) error {
 		return err
 	}
 
+	mu.Lock()
+	defer mu.Unlock()
 	currLevel := Options.Level.(zzap.AtomicLevel).Level()
 
 	if newLevel != currLevel {
@@ -150,5 +170,7 @@ func SetLogLevel(lvl string) error {
 
 // GetLogLevel returns the current logging level
 func GetLogLevel() string {
+	mu.Lock()
+	defer mu.Unlock()
 	return Options.Level.(zzap.AtomicLevel).Level().String()
 }
@@ -0,0 +1,61 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	zzap "go.uber.org/zap"
+)
+
+func TestConcurrentSetup(t *testing.T) {
+	level := Options.Level
+	t.Cleanup(func() {
+		Options.Level = level
+		SetLoggingEnabled(false)
+		logFile, logFileSet = nil, false
+	})
+	Options.Level = zzap.NewAtomicLevel()
+
+	logPath := filepath.Join(t.TempDir(), "l8k.log")
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			SetLoggingEnabled(i%2 == 0)
+			if i%4 == 0 {
+				assert.NoError(t, SetLogFile(logPath))
+			}
+			InitLog()
+			assert.NoError(t, SetLogLevel([]string{"debug", "info"}[i%2]))
+			_ = GetLogLevel()
+			_ = IsEnabled()
+		}()
+	}
+	wg.Wait()
+
+	SetLoggingEnabled(true)
+	InitLog()
+	require.NoError(t, SetLogLevel("debug"))
+	assert.True(t, IsEnabled())
+	assert.Equal(t, "debug", GetLogLevel())
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/go-logr/logr"
+)
+
+// tees holds the sinks added with Tee
+var tees = &teeRegistry{}
+
+type teeRegistry struct {
+	mu    sync.RWMutex
+	sinks []*logr.LogSink
+}
+
+// Tee sends the entries of the global controller-runtime logger to sink as well, until the returned function is called
+func Tee(sink logr.LogSink) (remove func()) {
+	entry := &sink
+	tees.mu.Lock()
+	tees.sinks = append(tees.sinks, entry)
+	tees.mu.Unlock()
+
+	return func() {
+		tees.mu.Lock()
+		defer tees.mu.Unlock()
+		tees.sinks = slices.DeleteFunc(tees.sinks, func(s *logr.LogSink) bool { return s == entry })
+	}
+}
+
+// forEach calls fn with every added sink, with the names and values of the logger applied
+func (r *teeRegistry) forEach(names []string, values []interface{}, fn func(logr.LogSink)) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, entry := range r.sinks {
+		sink := *entry
+		for _, name := range names {
+			sink = sink.WithName(name)
+		}
+		if len(values) > 0 {
+			sink = sink.WithValues(values...)
+		}
+		fn(sink)
+	}
+}
+
+// teeSink writes to the sink of the global logger and to the sinks added with Tee
+type teeSink struct {
+	primary logr.LogSink
+	names   []string
+	values  []interface{}
+}
+
+func (s teeSink) Init(info logr.RuntimeInfo) {
+	s.primary.Init(info)
+}
+
+func (s teeSink) Enabled(level int) bool {
+	if s.primary.Enabled(level) {
+		return true
+	}
+	enabled := false
+	tees.forEach(nil, nil, func(sink logr.LogSink) { enabled = enabled || sink.Enabled(level) })
+	return enabled
+}
+
+func (s teeSink) Info(level int, msg string, keysAndValues ...interface{}) {
+	if s.primary.Enabled(level) {
+		s.primary.Info(level, msg, keysAndValues...)
+	}
+	tees.forEach(s.names, s.values, func(sink logr.LogSink) {
+		if sink.Enabled(level) {
+			sink.Info(level, msg, keysAndValues...)
+		}
+	})
+}
+
+func (s teeSink) Error(err error, msg string, keysAndValues ...interface{}) {
+	s.primary.Error(err, msg, keysAndValues...)
+	tees.forEach(s.names, s.values, func(sink logr.LogSink) { sink.Error(err, msg, keysAndValues...) })
+}
+
+func (s teeSink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	s.primary = s.primary.WithValues(keysAndValues...)
+	s.values = append(slices.Clip(s.values), keysAndValues...)
+	return s
+}
+
+func (s teeSink) WithName(name string) logr.LogSink {
+	s.primary = s.primary.WithName(name)
+	s.names = append(slices.Clip(s.names), name)
+	return s
+}
+
+// WithCallDepth keeps the caller reported by the global logger pointing at the logging code
+func (s teeSink) WithCallDepth(depth int) logr.LogSink {
+	if withCallDepth, ok := s.primary.(logr.CallDepthLogSink); ok {
+		s.primary = withCallDepth.WithCallDepth(depth)
+	}
+	return s
+}
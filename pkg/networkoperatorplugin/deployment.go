@@ -0,0 +1,84 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/deploy"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// deploymentResources are the resources specific to each deployment type: the kinds of its device policies and
+// networks, and the NicClusterPolicy components of its device plugin
+var deploymentResources = map[string]struct {
+	kinds      []string
+	components []string
+}{
+	"sriov":       {kinds: []string{"SriovNetworkNodePolicy", "SriovNetwork", "SriovIBNetwork"}},
+	"host_device": {kinds: []string{"HostDeviceNetwork"}, components: []string{"sriovDevicePlugin"}},
+	"rdma_shared": {kinds: []string{"IPoIBNetwork", "MacvlanNetwork"}, components: []string{"rdmaSharedDevicePlugin"}},
+}
+
+// ValidateDeploymentResources checks that the rendered files (file name -> content) of a profile declaring the
+// given deployment type contain no resources specific to another deployment type, which indicates a profile
+// shipping the templates of another deployment type
+func ValidateDeploymentResources(deployment string, files map[string]string) error {
+	if _, ok := deploymentResources[deployment]; !ok {
+		return nil
+	}
+
+	mismatches := []string{}
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		objects, err := deploy.ParseManifests([]byte(files[name]))
+		if err != nil {
+			// Invalid YAML is reported when the manifests are decoded for deployment
+			continue
+		}
+		for i := range objects {
+			obj := &objects[i]
+
+			for _, other := range slices.Sorted(maps.Keys(deploymentResources)) {
+				if other == deployment {
+					continue
+				}
+				resources := deploymentResources[other]
+				for _, kind := range resources.kinds {
+					if obj.GetKind() == kind {
+						mismatches = append(mismatches, fmt.Sprintf("%s renders %s %s of deployment type %s", name, kind, objectName(obj), other))
+					}
+				}
+				if obj.GetKind() != "NicClusterPolicy" {
+					continue
+				}
+				for _, component := range resources.components {
+					if _, found, _ := unstructured.NestedFieldNoCopy(obj.Object, "spec", component); found {
+						mismatches = append(mismatches, fmt.Sprintf("%s enables the %s of deployment type %s", name, component, other))
+					}
+				}
+			}
+		}
+	}
+
+	if len(mismatches) > 0 {
+		return fmt.Errorf("resources do not match deployment type %s: %s", deployment, strings.Join(mismatches, "; "))
+	}
+	return nil
+}
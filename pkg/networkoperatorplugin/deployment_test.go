@@ -0,0 +1,68 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateDeploymentResources(t *testing.T) {
+	t.Run("built-in profiles match their deployment type", func(t *testing.T) {
+		available, err := profiles.LoadProfilesDir(profilesDir)
+		require.NoError(t, err)
+		require.NotEmpty(t, available)
+
+		for _, profile := range available {
+			_, err := (&NetworkOperatorPlugin{}).GenerateProfileDeploymentFiles(&profile, newTestConfig())
+			assert.NoError(t, err, profile.Name)
+		}
+	})
+
+	t.Run("reject a profile shipping the templates of another deployment type", func(t *testing.T) {
+		hostdevDir := filepath.Join(profilesDir, "host-device-rdma")
+		profile := &profiles.Profile{
+			Name:                "Misauthored SR-IOV",
+			ProfileRequirements: profiles.ProfileRequirements{Deployment: "sriov"},
+			Templates: []string{
+				filepath.Join(hostdevDir, "10-nicclusterpolicy.yaml"),
+				filepath.Join(hostdevDir, "30-hostdevicenetwork.yaml"),
+			},
+		}
+
+		_, err := (&NetworkOperatorPlugin{}).GenerateProfileDeploymentFiles(profile, newTestConfig())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "profile Misauthored SR-IOV: resources do not match deployment type sriov")
+		assert.Contains(t, err.Error(), "10-nicclusterpolicy.yaml enables the sriovDevicePlugin of deployment type host_device")
+		assert.Contains(t, err.Error(), "30-hostdevicenetwork.yaml renders HostDeviceNetwork hostdev-network of deployment type host_device")
+	})
+
+	t.Run("ignore unknown deployment types and shared resources", func(t *testing.T) {
+		files := map[string]string{"a.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\n"}
+		assert.NoError(t, ValidateDeploymentResources("sriov", files))
+
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "net.yaml"), []byte("apiVersion: mellanox.com/v1alpha1\nkind: HostDeviceNetwork\nmetadata:\n  name: net\n"), 0644))
+		_, err := (&NetworkOperatorPlugin{}).GenerateProfileDeploymentFiles(&profiles.Profile{Name: "custom", ProfileRequirements: profiles.ProfileRequirements{Deployment: "custom"}, Templates: []string{filepath.Join(dir, "net.yaml")}}, newTestConfig())
+		assert.NoError(t, err)
+	})
+}
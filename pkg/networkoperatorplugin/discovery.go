@@ -26,6 +26,8 @@ import (
 	netop "github.com/Mellanox/network-operator/api/v1alpha1"
 	nicop "github.com/Mellanox/nic-configuration-operator/api/v1alpha1"
 	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/deploy"
+	"github.com/nvidia/k8s-launch-kit/pkg/plugin"
 	"github.com/nvidia/k8s-launch-kit/pkg/ui"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -33,7 +35,34 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
-func (p *NetworkOperatorPlugin) DiscoverClusterConfig(ctx context.Context, c client.Client, defaultConfig *config.LaunchKubernetesConfig) error {
+// discoveryProbe determines part of the cluster config once the discovery profile is deployed
+type discoveryProbe struct {
+	name string
+	// required probes abort the discovery when they fail
+	required bool
+	// impact describes what the discovered config may be missing when the probe fails
+	impact string
+	run    func(ctx context.Context, c client.Client, cfg *config.LaunchKubernetesConfig) error
+}
+
+// discoveryProbes are run in order after the discovery profile is deployed
+var discoveryProbes = []discoveryProbe{
+	{
+		name:   "nic-configuration-daemon",
+		impact: "the devices of nodes with a daemon pod that is not ready may be missing",
+		run: func(ctx context.Context, c client.Client, cfg *config.LaunchKubernetesConfig) error {
+			// Ensure all pods from the nic-configuration-daemon DaemonSet are Ready
+			return checkDaemonSetPodsReady(ctx, c, cfg.NetworkOperator.Namespace, "nic-configuration-daemon")
+		},
+	},
+	{
+		name:     "nic-devices",
+		required: true,
+		run:      discoverNicDevices,
+	},
+}
+
+func (p *NetworkOperatorPlugin) DiscoverClusterConfig(ctx context.Context, c client.Client, defaultConfig *config.LaunchKubernetesConfig) (*plugin.DiscoveryResult, error) {
 	uiOutput := ui.FromContext(ctx)
 
 	// Ensure a NicClusterPolicy exists (error if any already exists, else create one)
@@ -47,12 +76,12 @@ func (p *NetworkOperatorPlugin) DiscoverClusterConfig(ctx context.Context, c cli
 				Operator: &netop.ImageSpec{
 					Repository: defaultConfig.NetworkOperator.Repository,
 					Image:      "nic-configuration-operator",
-					Version:    defaultConfig.NetworkOperator.ComponentVersion,
+					Version:    defaultConfig.NetworkOperator.ImageVersion("nic-configuration-operator", defaultConfig.NetworkOperator.ComponentVersion),
 				},
 				ConfigurationDaemon: &netop.ImageSpec{
 					Repository: defaultConfig.NetworkOperator.Repository,
 					Image:      "nic-configuration-operator-daemon",
-					Version:    defaultConfig.NetworkOperator.ComponentVersion,
+					Version:    defaultConfig.NetworkOperator.ImageVersion("nic-configuration-operator-daemon", defaultConfig.NetworkOperator.ComponentVersion),
 				},
 			},
 		},
@@ -62,7 +91,7 @@ func (p *NetworkOperatorPlugin) DiscoverClusterConfig(ctx context.Context, c cli
 	log.Log.Info("Deploying a thin NicClusterPolicy for cluster config discovery")
 
 	if err := EnsureNicClusterPolicy(ctx, c, policy); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Always attempt cleanup of the NicClusterPolicy at the end of discovery
@@ -74,30 +103,49 @@ func (p *NetworkOperatorPlugin) DiscoverClusterConfig(ctx context.Context, c cli
 		}
 	}()
 
-	// After creation, list pods in the target namespace and ensure all pods
-	// from the nic-configuration-daemon DaemonSet are Ready
-	if err := checkDaemonSetPodsReady(ctx, c, defaultConfig.NetworkOperator.Namespace, "nic-configuration-daemon"); err != nil {
-		return err
+	return runDiscoveryProbes(ctx, c, defaultConfig, discoveryProbes)
+}
+
+// runDiscoveryProbes runs the probes in order. A failed required probe aborts the discovery,
+// the other failures are recorded in the result and the remaining probes still run.
+func runDiscoveryProbes(ctx context.Context, c client.Client, cfg *config.LaunchKubernetesConfig, probes []discoveryProbe) (*plugin.DiscoveryResult, error) {
+	result := &plugin.DiscoveryResult{}
+	for _, probe := range probes {
+		log.Log.V(1).Info("Running discovery probe", "probe", probe.name)
+		err := probe.run(ctx, c, cfg)
+		if err == nil {
+			continue
+		}
+		if probe.required {
+			return nil, fmt.Errorf("discovery probe %s failed: %w", probe.name, err)
+		}
+		log.Log.Error(err, "Discovery probe failed, continuing with partial results", "probe", probe.name)
+		result.Failures = append(result.Failures, plugin.ProbeFailure{Probe: probe.name, Err: err, Impact: probe.impact})
 	}
+	return result, nil
+}
+
+// discoverNicDevices builds the PFs, worker nodes and node capabilities from the NicDevice statuses
+func discoverNicDevices(ctx context.Context, c client.Client, cfg *config.LaunchKubernetesConfig) error {
+	namespace := cfg.NetworkOperator.Namespace
 
-	// Get NicDevice resources and build ClusterConfig.NvidiaNICs from their statuses
 	devices := &nicop.NicDeviceList{}
-	if err := c.List(ctx, devices, client.InNamespace(defaultConfig.NetworkOperator.Namespace)); err != nil {
+	if err := c.List(ctx, devices, client.InNamespace(namespace)); err != nil {
 		return err
 	}
 	if len(devices.Items) == 0 {
-		log.Log.Info("No NicDevice resources found yet; waiting for discovery", "namespace", defaultConfig.NetworkOperator.Namespace)
-		if err := waitNicDevicesDiscovered(ctx, c, defaultConfig.NetworkOperator.Namespace); err != nil {
+		log.Log.Info("No NicDevice resources found yet; waiting for discovery", "namespace", namespace)
+		if err := waitNicDevicesDiscovered(ctx, c, namespace); err != nil {
 			return err
 		}
 		// re-list after wait
-		if err := c.List(ctx, devices, client.InNamespace(defaultConfig.NetworkOperator.Namespace)); err != nil {
+		if err := c.List(ctx, devices, client.InNamespace(namespace)); err != nil {
 			return err
 		}
 		log.Log.Info("NicDevice resources discovered", "count", len(devices.Items))
 	}
 
-	buildClusterConfigFromNicDevices(devices.Items, defaultConfig.ClusterConfig)
+	buildClusterConfigFromNicDevices(devices.Items, cfg.ClusterConfig)
 
 	return nil
 }
@@ -145,7 +193,7 @@ func isPodReady(pod *corev1.Pod) bool {
 // waitNicDevicesDiscovered polls until one or more NicDevice objects exist in the given namespace.
 func waitNicDevicesDiscovered(parentCtx context.Context, c client.Client, namespace string) error {
 	uiOutput := ui.FromContext(parentCtx)
-	progress := uiOutput.StartProgress("Discovering network devices (timeout: 5 min)")
+	progress := uiOutput.StartProgressContext(parentCtx, "Discovering network devices (timeout: 5 min)")
 
 	// Use a bounded timeout if none supplied
 	ctx := parentCtx
@@ -155,26 +203,26 @@ func waitNicDevicesDiscovered(parentCtx context.Context, c client.Client, namesp
 		defer cancel()
 	}
 
-	ticker := time.NewTicker(5 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		list := &nicop.NicDeviceList{}
-		if err := c.List(ctx, list, client.InNamespace(namespace)); err == nil {
-			if len(list.Items) > 0 {
-				progress.Success(fmt.Sprintf("Found %d device(s)", len(list.Items)))
-				return nil
-			}
-		}
-
-		select {
-		case <-ctx.Done():
-			progress.Fail("Timeout waiting for devices")
-			return fmt.Errorf("timeout waiting for NicDevice resources in namespace %q", namespace)
-		case <-ticker.C:
+	found := 0
+	polls := 0
+	err := deploy.PollUntil(ctx, 5*time.Second, func(ctx context.Context) (bool, error) {
+		if polls++; polls > 1 {
 			progress.Update("Still waiting for device discovery...")
 		}
+		list := &nicop.NicDeviceList{}
+		if err := c.List(ctx, list, client.InNamespace(namespace)); err != nil {
+			return false, nil
+		}
+		found = len(list.Items)
+		return found > 0, nil
+	})
+	if err != nil {
+		progress.Fail("Timeout waiting for devices")
+		return fmt.Errorf("timeout waiting for NicDevice resources in namespace %q", namespace)
 	}
+
+	progress.Success(fmt.Sprintf("Found %d device(s)", found))
+	return nil
 }
 
 // buildClusterConfigFromNicDevices constructs ClusterConfig.NvidiaNICs based on NicDevice statuses.
@@ -201,6 +249,7 @@ func buildClusterConfigFromNicDevices(devices []nicop.NicDevice, cluster *config
 				PciAddress:       p.PCI,
 				NetworkInterface: p.NetworkInterface,
 				Traffic:          "east-west", // TODO fix
+				NicType:          d.Status.Type,
 			}] = struct{}{}
 		}
 
@@ -214,6 +263,7 @@ func buildClusterConfigFromNicDevices(devices []nicop.NicDevice, cluster *config
 	}
 
 	slices.Sort(cluster.WorkerNodes)
+	cluster.Capabilities.Nodes.Count = len(cluster.WorkerNodes)
 
 	for pf := range pfs {
 		cluster.PFs = append(cluster.PFs, pf)
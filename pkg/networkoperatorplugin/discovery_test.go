@@ -0,0 +1,140 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	nicop "github.com/Mellanox/nic-configuration-operator/api/v1alpha1"
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+const discoveryNamespace = "nvidia-network-operator"
+
+// newDiscoveryClient returns a fake client serving one NicDevice, failing the List calls of the kinds in failures
+func newDiscoveryClient(t *testing.T, failures map[string]error) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, nicop.AddToScheme(scheme))
+
+	device := &nicop.NicDevice{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-0-device", Namespace: discoveryNamespace},
+		Status: nicop.NicDeviceStatus{
+			Node: "worker-0",
+			Type: "101d",
+			Ports: []nicop.NicDevicePortSpec{
+				{PCI: "0000:08:00.1", NetworkInterface: "ens1f1", RdmaInterface: "mlx5_1"},
+				{PCI: "0000:08:00.0", NetworkInterface: "ens1f0", RdmaInterface: "mlx5_0"},
+			},
+		},
+	}
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(device).WithInterceptorFuncs(interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			switch list.(type) {
+			case *corev1.PodList:
+				if err, ok := failures["pods"]; ok {
+					return err
+				}
+			case *nicop.NicDeviceList:
+				if err, ok := failures["nicdevices"]; ok {
+					return err
+				}
+			}
+			return c.List(ctx, list, opts...)
+		},
+	}).Build()
+}
+
+func newDiscoveryConfig() *config.LaunchKubernetesConfig {
+	return &config.LaunchKubernetesConfig{
+		NetworkOperator: &config.NetworkOperatorConfig{Namespace: discoveryNamespace},
+		ClusterConfig: &config.ClusterConfig{
+			Capabilities: &config.ClusterCapabilities{Nodes: &config.NodesCapabilities{}},
+		},
+	}
+}
+
+func TestRunDiscoveryProbes(t *testing.T) {
+	t.Run("keep the discovered devices when the daemon readiness probe fails", func(t *testing.T) {
+		cfg := newDiscoveryConfig()
+		c := newDiscoveryClient(t, map[string]error{"pods": errors.New("pods is forbidden")})
+
+		result, err := runDiscoveryProbes(context.Background(), c, cfg, discoveryProbes)
+		require.NoError(t, err)
+
+		require.Len(t, result.Failures, 1)
+		assert.Equal(t, "nic-configuration-daemon", result.Failures[0].Probe)
+		assert.Equal(t, []string{
+			"probe nic-configuration-daemon failed: pods is forbidden, the devices of nodes with a daemon pod that is not ready may be missing",
+		}, result.Warnings())
+
+		assert.Equal(t, []string{"worker-0"}, cfg.ClusterConfig.WorkerNodes)
+		assert.Equal(t, []config.PFConfig{
+			{RdmaDevice: "mlx5_0", PciAddress: "0000:08:00.0", NetworkInterface: "ens1f0", Traffic: "east-west", NicType: "101d"},
+			{RdmaDevice: "mlx5_1", PciAddress: "0000:08:00.1", NetworkInterface: "ens1f1", Traffic: "east-west", NicType: "101d"},
+		}, cfg.ClusterConfig.PFs)
+		assert.True(t, cfg.ClusterConfig.Capabilities.Nodes.Rdma)
+		assert.True(t, cfg.ClusterConfig.Capabilities.Nodes.Sriov)
+		assert.Equal(t, 1, cfg.ClusterConfig.Capabilities.Nodes.Count)
+	})
+
+	t.Run("abort when a required probe fails", func(t *testing.T) {
+		cfg := newDiscoveryConfig()
+		c := newDiscoveryClient(t, map[string]error{
+			"pods":       errors.New("pods is forbidden"),
+			"nicdevices": errors.New("nicdevices is forbidden"),
+		})
+
+		result, err := runDiscoveryProbes(context.Background(), c, cfg, discoveryProbes)
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "discovery probe nic-devices failed: nicdevices is forbidden", err.Error())
+		assert.Empty(t, cfg.ClusterConfig.PFs)
+	})
+
+	t.Run("run every probe after an optional failure", func(t *testing.T) {
+		var ran []string
+		probe := func(name string, required bool, err error) discoveryProbe {
+			return discoveryProbe{name: name, required: required, impact: name + " unknown", run: func(context.Context, client.Client, *config.LaunchKubernetesConfig) error {
+				ran = append(ran, name)
+				return err
+			}}
+		}
+
+		result, err := runDiscoveryProbes(context.Background(), nil, newDiscoveryConfig(), []discoveryProbe{
+			probe("first", false, errors.New("timeout")),
+			probe("second", true, nil),
+			probe("third", false, errors.New("forbidden")),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"first", "second", "third"}, ran)
+		assert.Equal(t, []string{"probe first failed: timeout, first unknown", "probe third failed: forbidden, third unknown"}, result.Warnings())
+	})
+}
@@ -18,10 +18,12 @@ package networkoperatorplugin
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	netop "github.com/Mellanox/network-operator/api/v1alpha1"
+	"github.com/nvidia/k8s-launch-kit/pkg/deploy"
 	"github.com/nvidia/k8s-launch-kit/pkg/ui"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -50,7 +52,7 @@ func EnsureNicClusterPolicy(ctx context.Context, c client.Client, policy *netop.
 // WaitNicClusterPolicyReady polls NicClusterPolicy until Status.State is ready or error, with a timeout.
 func WaitNicClusterPolicyReady(parentCtx context.Context, c client.Client, name string) error {
 	uiOutput := ui.FromContext(parentCtx)
-	progress := uiOutput.StartProgress("Waiting for NIC Cluster Policy to become ready")
+	progress := uiOutput.StartProgressContext(parentCtx, "Waiting for NIC Cluster Policy to become ready")
 
 	// Use a bounded timeout if none supplied
 	ctx := parentCtx
@@ -60,36 +62,35 @@ func WaitNicClusterPolicyReady(parentCtx context.Context, c client.Client, name
 		defer cancel()
 	}
 
-	ticker := time.NewTicker(3 * time.Second)
-	defer ticker.Stop()
-
-	for {
+	err := deploy.PollUntil(ctx, 3*time.Second, func(ctx context.Context) (bool, error) {
 		// Try to get by name (cluster-scoped)
 		policy := &netop.NicClusterPolicy{}
-		if err := c.Get(ctx, client.ObjectKey{Name: name}, policy); err == nil {
-			switch policy.Status.State {
-			case netop.StateReady:
-				progress.Success("NIC Cluster Policy is ready")
-				log.Log.Info("NicClusterPolicy is ready")
-				return nil
-			case netop.StateError:
-				progress.Fail(fmt.Sprintf("Policy error: %s", policy.Status.Reason))
-				return fmt.Errorf("NicClusterPolicy in error state: %s", policy.Status.Reason)
-			default:
-				if policy.Status.State != "" {
-					progress.Update(fmt.Sprintf("Current state: %s", policy.Status.State))
-				}
-			}
+		if err := c.Get(ctx, client.ObjectKey{Name: name}, policy); err != nil {
+			return false, nil
 		}
-
-		select {
-		case <-ctx.Done():
-			progress.Fail("Timeout waiting for policy")
-			return fmt.Errorf("timeout waiting for NicClusterPolicy %q to become ready", name)
-		case <-ticker.C:
-			// continue
+		switch policy.Status.State {
+		case netop.StateReady:
+			return true, nil
+		case netop.StateError:
+			progress.Fail(fmt.Sprintf("Policy error: %s", policy.Status.Reason))
+			return false, fmt.Errorf("NicClusterPolicy in error state: %s", policy.Status.Reason)
+		case "":
+		default:
+			progress.Update(fmt.Sprintf("Current state: %s", policy.Status.State))
 		}
+		return false, nil
+	})
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		progress.Fail("Timeout waiting for policy")
+		return fmt.Errorf("timeout waiting for NicClusterPolicy %q to become ready", name)
+	}
+	if err != nil {
+		return err
 	}
+
+	progress.Success("NIC Cluster Policy is ready")
+	log.Log.Info("NicClusterPolicy is ready")
+	return nil
 }
 
 // DeleteNicClusterPolicy deletes the NicClusterPolicy by name, ignoring NotFound errors.
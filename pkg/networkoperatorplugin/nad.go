@@ -0,0 +1,68 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/deploy"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const networkAttachmentDefinitionKind = "NetworkAttachmentDefinition"
+
+// ValidateNetworkAttachmentDefinitions checks that the CNI config embedded as a JSON string in the spec.config
+// of every NetworkAttachmentDefinition of the rendered manifest is a well-formed JSON object
+func ValidateNetworkAttachmentDefinitions(rendered string) error {
+	objects, err := deploy.ParseManifests([]byte(rendered))
+	if err != nil {
+		// Invalid YAML is reported when the manifests are decoded for deployment
+		return nil
+	}
+	for i := range objects {
+		obj := &objects[i]
+		if obj.GetKind() != networkAttachmentDefinitionKind {
+			continue
+		}
+
+		cniConfig, found, err := unstructured.NestedString(obj.Object, "spec", "config")
+		if err != nil {
+			return fmt.Errorf("%s %s: spec.config must be a string: %w", networkAttachmentDefinitionKind, objectName(obj), err)
+		}
+		// Without a config the CNI config is read from a file on the node
+		if !found || strings.TrimSpace(cniConfig) == "" {
+			continue
+		}
+
+		var parsed map[string]interface{}
+		if err := json.Unmarshal([]byte(cniConfig), &parsed); err != nil {
+			return fmt.Errorf("%s %s: invalid JSON in spec.config: %w", networkAttachmentDefinitionKind, objectName(obj), err)
+		}
+	}
+
+	return nil
+}
+
+// objectName returns namespace/name, or name for objects without a namespace
+func objectName(obj *unstructured.Unstructured) string {
+	if obj.GetNamespace() == "" {
+		return obj.GetName()
+	}
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const nadTemplate = `apiVersion: k8s.cni.cncf.io/v1
+kind: NetworkAttachmentDefinition
+metadata:
+  name: {{.Hostdev.NetworkName}}
+  namespace: default
+spec:
+  config: '{"cniVersion": "0.3.1", "type": "host-device", "ipam": {"type": "nv-ipam", "poolName": "{{.NvIpam.PoolName}}"}}'
+`
+
+func TestValidateNetworkAttachmentDefinitions(t *testing.T) {
+	t.Run("well-formed config", func(t *testing.T) {
+		assert.NoError(t, ValidateNetworkAttachmentDefinitions(`apiVersion: k8s.cni.cncf.io/v1
+kind: NetworkAttachmentDefinition
+metadata:
+  name: hostdev-network
+spec:
+  config: |
+    {
+      "cniVersion": "0.3.1",
+      "type": "host-device"
+    }
+`))
+	})
+
+	t.Run("missing config", func(t *testing.T) {
+		assert.NoError(t, ValidateNetworkAttachmentDefinitions("apiVersion: k8s.cni.cncf.io/v1\nkind: NetworkAttachmentDefinition\nmetadata:\n  name: from-file\n"))
+	})
+
+	t.Run("other kinds are ignored", func(t *testing.T) {
+		assert.NoError(t, ValidateNetworkAttachmentDefinitions("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\ndata:\n  config: '{'\n"))
+	})
+
+	t.Run("malformed config", func(t *testing.T) {
+		err := ValidateNetworkAttachmentDefinitions(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+---
+apiVersion: k8s.cni.cncf.io/v1
+kind: NetworkAttachmentDefinition
+metadata:
+  name: hostdev-network
+  namespace: default
+spec:
+  config: '{"cniVersion": "0.3.1", "type": "host-device",}'
+`)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "NetworkAttachmentDefinition default/hostdev-network: invalid JSON in spec.config")
+	})
+
+	t.Run("config that is not an object", func(t *testing.T) {
+		err := ValidateNetworkAttachmentDefinitions("apiVersion: k8s.cni.cncf.io/v1\nkind: NetworkAttachmentDefinition\nmetadata:\n  name: list\nspec:\n  config: '[]'\n")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "NetworkAttachmentDefinition list: invalid JSON in spec.config")
+	})
+}
+
+func TestGenerateValidatesNetworkAttachmentDefinitions(t *testing.T) {
+	generate := func(t *testing.T, poolName string) (map[string]string, error) {
+		dir := t.TempDir()
+		templatePath := filepath.Join(dir, "60-nad.yaml")
+		require.NoError(t, os.WriteFile(templatePath, []byte(nadTemplate), 0644))
+
+		cfg := newTestConfig()
+		cfg.NvIpam.PoolName = poolName
+		profile := &profiles.Profile{Name: "NAD", Templates: []string{templatePath}}
+		return (&NetworkOperatorPlugin{}).GenerateProfileDeploymentFiles(profile, cfg)
+	}
+
+	t.Run("valid rendered config", func(t *testing.T) {
+		files, err := generate(t, "nv-ipam-pool")
+		require.NoError(t, err)
+		assert.Contains(t, files["60-nad.yaml"], `"poolName": "nv-ipam-pool"`)
+	})
+
+	t.Run("value breaking the rendered JSON", func(t *testing.T) {
+		_, err := generate(t, `pool"`)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "60-nad.yaml renders an invalid manifest")
+		assert.Contains(t, err.Error(), "invalid JSON in spec.config")
+	})
+}
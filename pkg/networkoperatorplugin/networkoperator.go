@@ -52,6 +52,7 @@ func (p *NetworkOperatorPlugin) BuildProfileFromOptions(options options.Options,
 	profile.Multirail = options.Multirail
 	profile.SpectrumX = options.SpectrumX
 	profile.Ai = options.Ai
+	profile.Set = options.ProfileFlagsSet
 
 	log.Log.V(1).Info("Built profile for plugin", "plugin", p.GetName(), "profile", profile)
 	return nil
@@ -63,6 +64,11 @@ func (p *NetworkOperatorPlugin) BuildProfileFromLLMResponse(llmResponse map[stri
 	profile.Multirail = llmResponse["multirail"] == "true"
 	profile.SpectrumX = llmResponse["spectrumX"] == "true"
 	profile.Ai = llmResponse["ai"] == "true"
+	for _, key := range config.ProfileFlags {
+		if _, ok := llmResponse[key]; ok {
+			profile.Set = append(profile.Set, key)
+		}
+	}
 
 	log.Log.V(1).Info("Built profile for plugin", "plugin", p.GetName(), "profile", profile)
 	return nil
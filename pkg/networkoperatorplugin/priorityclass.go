@@ -0,0 +1,157 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/deploy"
+)
+
+// priorityClassTemplate renders the PriorityClass of networkOperator.daemonSets.priorityClass, shared by the profiles
+//
+//go:embed templates/priorityclass.yaml
+var priorityClassTemplate string
+
+// PriorityClassFile is the generated file holding the PriorityClass, sorted before the files of the profiles
+const PriorityClassFile = "05-priorityclass.yaml"
+
+// priorityClassKinds are the apps/v1 kinds whose pod template takes a priority class
+var priorityClassKinds = []string{"DaemonSet", "Deployment", "StatefulSet"}
+
+// applyPriorityClass sets the priority class of networkOperator.daemonSets on the rendered workloads, adding the
+// PriorityClass when networkOperator.daemonSets.priorityClass defines it. It fails when no rendered object can
+// take the priority class rather than ignoring the setting.
+func applyPriorityClass(files map[string]string, cfg *config.LaunchKubernetesConfig) error {
+	name := cfg.NetworkOperator.PriorityClassName()
+	workloads, err := setPriorityClassName(files, name)
+	if err != nil {
+		return err
+	}
+	if workloads == 0 {
+		return fmt.Errorf("networkOperator.daemonSets.priorityClassName %s is set, but no rendered %s takes it: "+
+			"the NicClusterPolicy has no priority class field, the network operator sets the priority class of the DaemonSets it creates",
+			name, strings.Join(priorityClassKinds, ", "))
+	}
+
+	if cfg.NetworkOperator.DaemonSets.PriorityClass == nil {
+		return nil
+	}
+	tmpl, err := template.New(PriorityClassFile).Funcs(TemplateFuncs).Parse(priorityClassTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse the PriorityClass template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, cfg); err != nil {
+		return fmt.Errorf("failed to render the PriorityClass: %w", err)
+	}
+	files[PriorityClassFile] = buf.String()
+	return nil
+}
+
+// setPriorityClassName sets priorityClassName on the pod template of the workloads of the rendered files that don't
+// set one, returning the number of workloads. Only the updated documents are written again, in their key order and
+// without their comments.
+func setPriorityClassName(files map[string]string, priorityClassName string) (int, error) {
+	workloads := 0
+	for name, content := range files {
+		docs := deploy.SplitYAMLDocuments(content)
+		changed := false
+		for i, doc := range docs {
+			updated, workload, err := setDocumentPriorityClassName(doc, priorityClassName)
+			if err != nil {
+				return 0, fmt.Errorf("failed to set the priority class of %s: document %d: %w", name, i+1, err)
+			}
+			if workload {
+				workloads++
+			}
+			if updated != "" {
+				docs[i] = updated
+				changed = true
+			}
+		}
+		if changed {
+			for i := range docs {
+				if !strings.HasSuffix(docs[i], "\n") {
+					docs[i] += "\n"
+				}
+			}
+			files[name] = strings.Join(docs, "---\n")
+		}
+	}
+	return workloads, nil
+}
+
+// setDocumentPriorityClassName returns the document with the priority class set, empty when it is not a workload
+// or already sets one. workload reports whether the document is a workload.
+func setDocumentPriorityClassName(doc, priorityClassName string) (updated string, workload bool, err error) {
+	var obj yaml.MapSlice
+	if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+		return "", false, err
+	}
+	kind, _ := mapValue(obj, "kind").(string)
+	if mapValue(obj, "apiVersion") != "apps/v1" || !slices.Contains(priorityClassKinds, kind) {
+		return "", false, nil
+	}
+
+	spec, ok := mapValue(obj, "spec").(yaml.MapSlice)
+	if !ok {
+		return "", true, fmt.Errorf("%s has no spec", kind)
+	}
+	template, ok := mapValue(spec, "template").(yaml.MapSlice)
+	if !ok {
+		return "", true, fmt.Errorf("%s has no spec.template", kind)
+	}
+	podSpec, ok := mapValue(template, "spec").(yaml.MapSlice)
+	if !ok {
+		return "", true, fmt.Errorf("%s has no spec.template.spec", kind)
+	}
+	if mapValue(podSpec, "priorityClassName") != nil {
+		return "", true, nil
+	}
+
+	// The nested mappings share their items with obj
+	for i := range template {
+		if template[i].Key == "spec" {
+			template[i].Value = append(podSpec, yaml.MapItem{Key: "priorityClassName", Value: priorityClassName})
+		}
+	}
+
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		return "", true, err
+	}
+	return string(data), true, nil
+}
+
+// mapValue returns the value of the key, nil when it is missing
+func mapValue(mapping yaml.MapSlice, key string) interface{} {
+	for _, item := range mapping {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return nil
+}
@@ -21,17 +21,78 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"text/template"
 
 	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/plugin"
 	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	"gopkg.in/yaml.v2"
 )
 
-// templateFuncs provides helper functions for Go templates
-var templateFuncs = template.FuncMap{
+// TemplateFuncs provides helper functions for Go templates
+var TemplateFuncs = template.FuncMap{
 	"add": func(a, b int) int { return a + b },
 	"sub": func(a, b int) int { return a - b },
 	"gt":  func(a, b int) bool { return a > b },
+
+	"containerResources": containerResources,
+	"configMapData":      configMapData,
+}
+
+// configMapData renders the config section at the dotted path, e.g. sriov, as a ConfigMap data entry named
+// <path>.yaml holding the section in YAML, indented by indent spaces, so that the ConfigMap follows the config
+func configMapData(indent int, cfg *config.LaunchKubernetesConfig, path string) (string, error) {
+	section, err := config.Section(cfg, path)
+	if err != nil {
+		return "", err
+	}
+	data, err := yaml.Marshal(section)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal config section %s: %w", path, err)
+	}
+
+	pad := strings.Repeat(" ", indent)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s%s.yaml: |", pad, path)
+	for _, line := range strings.Split(strings.TrimSuffix(string(data), "\n"), "\n") {
+		fmt.Fprintf(&b, "\n%s  %s", pad, line)
+	}
+	return b.String(), nil
+}
+
+// containerResources renders the containerResources field of a NicClusterPolicy component, indented by indent spaces,
+// for the configured containers among names. Nothing is rendered when none of the containers is configured.
+func containerResources(indent int, resources []config.ContainerResourcesConfig, names ...string) string {
+	pad := strings.Repeat(" ", indent)
+
+	var b strings.Builder
+	for _, container := range resources {
+		if !slices.Contains(names, container.Name) {
+			continue
+		}
+		if b.Len() == 0 {
+			fmt.Fprintf(&b, "\n%scontainerResources:", pad)
+		}
+		fmt.Fprintf(&b, "\n%s  - name: %s", pad, container.Name)
+		writeResourceValues(&b, pad+"    ", "requests", container.Requests)
+		writeResourceValues(&b, pad+"    ", "limits", container.Limits)
+	}
+	return b.String()
+}
+
+func writeResourceValues(b *strings.Builder, pad, field string, values config.ResourceValues) {
+	if values.IsEmpty() {
+		return
+	}
+	fmt.Fprintf(b, "\n%s%s:", pad, field)
+	if values.CPU != "" {
+		fmt.Fprintf(b, "\n%s  cpu: %q", pad, values.CPU)
+	}
+	if values.Memory != "" {
+		fmt.Fprintf(b, "\n%s  memory: %q", pad, values.Memory)
+	}
 }
 
 // ProcessTemplate processes a Go template file with the given config
@@ -43,7 +104,7 @@ func ProcessTemplate(templatePath string, config *config.LaunchKubernetesConfig)
 	}
 
 	// Parse the template with helper functions
-	tmpl, err := template.New(filepath.Base(templatePath)).Funcs(templateFuncs).Parse(string(templateContent))
+	tmpl, err := template.New(filepath.Base(templatePath)).Funcs(TemplateFuncs).Parse(string(templateContent))
 	if err != nil {
 		return "", fmt.Errorf("failed to parse template %s: %w", templatePath, err)
 	}
@@ -58,18 +119,51 @@ func ProcessTemplate(templatePath string, config *config.LaunchKubernetesConfig)
 	return buf.String(), nil
 }
 
-// ProcessProfileTemplates processes all template files in a profile directory
-func (p *NetworkOperatorPlugin) GenerateProfileDeploymentFiles(profile *profiles.Profile, config *config.LaunchKubernetesConfig) (map[string]string, error) {
+// RenderProfileTemplates renders every template of the profile, carrying on past the failed ones. It returns the files
+// of the templates that rendered and the failures of the others.
+func RenderProfileTemplates(profile *profiles.Profile, config *config.LaunchKubernetesConfig) (map[string]string, []plugin.TemplateFailure) {
 	results := make(map[string]string)
+	var failures []plugin.TemplateFailure
+	// Templates render the names with the configured prefix and suffix
+	config = config.WithObjectNames()
 
 	for _, templatePath := range profile.Templates {
 		processed, err := ProcessTemplate(templatePath, config)
 		if err != nil {
-			return nil, fmt.Errorf("failed to process template %s: %w", templatePath, err)
+			failures = append(failures, plugin.TemplateFailure{Template: templatePath, Err: fmt.Errorf("failed to process template %s: %w", templatePath, err)})
+			continue
+		}
+		if err := ValidateNetworkAttachmentDefinitions(processed); err != nil {
+			failures = append(failures, plugin.TemplateFailure{Template: templatePath, Err: fmt.Errorf("template %s renders an invalid manifest: %w", templatePath, err)})
+			continue
 		}
 
+		// Templates that render nothing with this config, e.g. the Spectrum-X QoS settings, produce no file
+		if strings.TrimSpace(processed) == "" {
+			continue
+		}
 		results[filepath.Base(templatePath)] = processed
 	}
+	return results, failures
+}
+
+// GenerateProfileDeploymentFiles renders the templates of the profile, failing with a plugin.RenderError listing
+// every template that failed to render
+func (p *NetworkOperatorPlugin) GenerateProfileDeploymentFiles(profile *profiles.Profile, config *config.LaunchKubernetesConfig) (map[string]string, error) {
+	results, failures := RenderProfileTemplates(profile, config)
+	if len(failures) > 0 {
+		return nil, &plugin.RenderError{Rendered: results, Failures: failures}
+	}
+
+	if config.NetworkOperator.PriorityClassName() != "" {
+		if err := applyPriorityClass(results, config); err != nil {
+			return nil, fmt.Errorf("profile %s: %w", profile.Name, err)
+		}
+	}
+
+	if err := ValidateDeploymentResources(profile.ProfileRequirements.Deployment, results); err != nil {
+		return nil, fmt.Errorf("profile %s: %w", profile.Name, err)
+	}
 
 	return results, nil
 }
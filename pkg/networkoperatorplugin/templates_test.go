@@ -0,0 +1,777 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package networkoperatorplugin
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/deploy"
+	"github.com/nvidia/k8s-launch-kit/pkg/plugin"
+	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+const profilesDir = "../../profiles"
+
+// newTestConfig returns a config with two east-west PFs similar to l8k-config.yaml
+func newTestConfig() *config.LaunchKubernetesConfig {
+	return &config.LaunchKubernetesConfig{
+		NetworkOperator: &config.NetworkOperatorConfig{
+			Version:          "v25.10.0",
+			ComponentVersion: "network-operator-v25.10.0",
+			Repository:       "nvcr.io/nvidia/mellanox",
+			Namespace:        "nvidia-network-operator",
+		},
+		DOCADriver: &config.DOCADriverConfig{
+			Version: "doca3.2.0-25.10-1.2.8.0-1",
+		},
+		NvIpam: &config.NvIpamConfig{
+			PoolName: "nv-ipam-pool",
+			Subnets: []config.NvIpamSubnetConfig{
+				{Subnet: "192.168.2.0/24", Gateway: "192.168.2.1"},
+				{Subnet: "192.168.3.0/24", Gateway: "192.168.3.1"},
+			},
+		},
+		Sriov: &config.SriovConfig{
+			EthernetMtu:   9000,
+			InfinibandMtu: 4000,
+			NumVfs:        8,
+			Priority:      90,
+			ResourceName:  "sriov_resource",
+			NetworkName:   "sriov_network",
+		},
+		Hostdev: &config.HostdevConfig{
+			ResourceName: "hostdev-resource",
+			NetworkName:  "hostdev-network",
+		},
+		RdmaShared: &config.RdmaSharedConfig{
+			ResourceName: "rdma_shared_resource",
+			HcaMax:       63,
+		},
+		Ipoib:   &config.IpoibConfig{NetworkName: "ipoib-network"},
+		Macvlan: &config.MacvlanConfig{NetworkName: "macvlan-network"},
+		Profile: &config.Profile{
+			Fabric:     "ethernet",
+			Deployment: "sriov",
+			Multirail:  true,
+		},
+		ClusterConfig: &config.ClusterConfig{
+			Capabilities: &config.ClusterCapabilities{
+				Nodes: &config.NodesCapabilities{Sriov: true, Rdma: true},
+			},
+			PFs: []config.PFConfig{
+				{RdmaDevice: "mlx5_0", PciAddress: "0000:08:00.0", NetworkInterface: "ens1f0", Traffic: "east-west"},
+				{RdmaDevice: "mlx5_1", PciAddress: "0000:08:00.1", NetworkInterface: "ens1f1", Traffic: "east-west"},
+			},
+			WorkerNodes:  []string{"worker-0"},
+			NodeSelector: map[string]string{"feature.node.kubernetes.io/pci-15b3.present": "true"},
+		},
+	}
+}
+
+// parseObjects decodes the documents of a rendered template
+func parseObjects(rendered string) ([]*unstructured.Unstructured, error) {
+	var objects []*unstructured.Unstructured
+	for _, doc := range deploy.SplitYAMLDocuments(rendered) {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal([]byte(doc), &obj.Object); err != nil {
+			return nil, err
+		}
+		objects = append(objects, obj)
+	}
+	return objects, nil
+}
+
+func TestSriovPolicyVfConfiguration(t *testing.T) {
+	policyTemplate := filepath.Join(profilesDir, "sriov-ethernet-rdma", "30-sriovnetworknodepolicy.yaml")
+
+	t.Run("full VF range is equivalent to the numVfs shorthand", func(t *testing.T) {
+		shorthand, err := ProcessTemplate(policyTemplate, newTestConfig())
+		require.NoError(t, err)
+
+		rangeConfig := newTestConfig()
+		rangeConfig.Sriov.NumVfs = 4
+		rangeConfig.Sriov.Devices = []config.SriovDeviceConfig{
+			{PciAddress: "0000:08:00.0", VfRange: "0-7"},
+			{PciAddress: "0000:08:00.1", NumVfs: 8},
+		}
+		withRange, err := ProcessTemplate(policyTemplate, rangeConfig)
+		require.NoError(t, err)
+
+		assert.Equal(t, shorthand, withRange)
+		assert.NotContains(t, withRange, "pfNames")
+	})
+
+	t.Run("partial VF range selects the VFs on the PF", func(t *testing.T) {
+		cfg := newTestConfig()
+		cfg.Sriov.Devices = []config.SriovDeviceConfig{
+			{PciAddress: "0000:08:00.1", NumVfs: 16, VfRange: "4-11"},
+		}
+
+		rendered, err := ProcessTemplate(policyTemplate, cfg)
+		require.NoError(t, err)
+
+		assert.Contains(t, rendered, `- "ens1f1#4-11"`)
+		assert.Contains(t, rendered, "numVfs: 16")
+		assert.Contains(t, rendered, "numVfs: 8")
+		assert.NotContains(t, rendered, "ens1f0#")
+	})
+}
+
+func TestSriovPolicyLinkType(t *testing.T) {
+	// The SriovNetworkNodePolicy CRD only accepts eth, ETH, ib and IB
+	for profile, linkType := range map[string]string{"sriov-ethernet-rdma": "eth", "sriov-ib-rdma": "IB"} {
+		for _, multirail := range []bool{true, false} {
+			cfg := newTestConfig()
+			cfg.Profile.Multirail = multirail
+
+			rendered, err := ProcessTemplate(filepath.Join(profilesDir, profile, "30-sriovnetworknodepolicy.yaml"), cfg)
+			require.NoError(t, err, profile)
+			objects, err := parseObjects(rendered)
+			require.NoError(t, err, profile)
+			require.NotEmpty(t, objects, profile)
+			for _, obj := range objects {
+				value, _, err := unstructured.NestedString(obj.Object, "spec", "linkType")
+				require.NoError(t, err)
+				assert.Equal(t, linkType, value, profile)
+			}
+		}
+	}
+}
+
+func TestSriovPools(t *testing.T) {
+	newPoolsConfig := func(multirail bool) *config.LaunchKubernetesConfig {
+		cfg := newTestConfig()
+		cfg.Profile.Multirail = multirail
+		cfg.Sriov.ResourceName = ""
+		cfg.Sriov.NetworkName = ""
+		cfg.Sriov.Pools = []config.SriovPoolConfig{
+			{Name: "compute", ResourceName: "compute_resource", NetworkName: "compute_network", PciAddresses: []string{"0000:08:00.0"}},
+			{Name: "storage", ResourceName: "storage_resource", NetworkName: "storage_network", NumVfs: 4, PciAddresses: []string{"0000:08:00.1"}},
+		}
+		return cfg
+	}
+
+	t.Run("render a policy, network and pod per pool", func(t *testing.T) {
+		cfg := newPoolsConfig(false)
+		require.NoError(t, config.ValidateClusterConfig(cfg, "sriov-ethernet-rdma"))
+
+		policies, err := ProcessTemplate(filepath.Join(profilesDir, "sriov-ethernet-rdma", "30-sriovnetworknodepolicy.yaml"), cfg)
+		require.NoError(t, err)
+		networks, err := ProcessTemplate(filepath.Join(profilesDir, "sriov-ethernet-rdma", "40-sriovnetwork.yaml"), cfg)
+		require.NoError(t, err)
+		pods, err := ProcessTemplate(filepath.Join(profilesDir, "sriov-ethernet-rdma", "50-pod.yaml"), cfg)
+		require.NoError(t, err)
+
+		policyObjects, err := parseObjects(policies)
+		require.NoError(t, err)
+		require.Len(t, policyObjects, 2)
+		assert.Equal(t, "ethernet-sriov-compute", policyObjects[0].GetName())
+		assert.Equal(t, "ethernet-sriov-storage", policyObjects[1].GetName())
+		assert.Contains(t, policies, "resourceName: compute_resource")
+		assert.Contains(t, policies, "resourceName: storage_resource")
+		assert.Contains(t, policies, `- "0000:08:00.1"`)
+		assert.Contains(t, policies, "numVfs: 8")
+		assert.Contains(t, policies, "numVfs: 4")
+
+		networkObjects, err := parseObjects(networks)
+		require.NoError(t, err)
+		require.Len(t, networkObjects, 2)
+		assert.Equal(t, "compute_network", networkObjects[0].GetName())
+		assert.Equal(t, "storage_network", networkObjects[1].GetName())
+
+		podObjects, err := parseObjects(pods)
+		require.NoError(t, err)
+		require.Len(t, podObjects, 2)
+		assert.Equal(t, "sriov-test-pod-compute", podObjects[0].GetName())
+		assert.Contains(t, pods, "nvidia.com/storage_resource: '1'")
+	})
+
+	t.Run("multirail uses the pool of each PF", func(t *testing.T) {
+		cfg := newPoolsConfig(true)
+
+		networks, err := ProcessTemplate(filepath.Join(profilesDir, "sriov-ib-rdma", "40-sriovibnetwork.yaml"), cfg)
+		require.NoError(t, err)
+
+		networkObjects, err := parseObjects(networks)
+		require.NoError(t, err)
+		require.Len(t, networkObjects, 2)
+		assert.Equal(t, "compute_network-a", networkObjects[0].GetName())
+		assert.Equal(t, "storage_network-b", networkObjects[1].GetName())
+		assert.Contains(t, networks, "resourceName: storage_resource-b")
+	})
+}
+
+func TestContainerResources(t *testing.T) {
+	t.Run("render the configured containers of every profile", func(t *testing.T) {
+		cfg := newTestConfig()
+		cfg.NetworkOperator.ContainerResources = []config.ContainerResourcesConfig{
+			{Name: "mofed-container", Requests: config.ResourceValues{CPU: "500m", Memory: "1Gi"}, Limits: config.ResourceValues{Memory: "4Gi"}},
+			{Name: "nv-ipam-controller", Limits: config.ResourceValues{CPU: "1"}},
+			{Name: "kube-multus", Requests: config.ResourceValues{Memory: "128Mi"}},
+		}
+
+		rendered, err := ProcessTemplate(filepath.Join(profilesDir, "sriov-ethernet-rdma", "10-nicclusterpolicy.yaml"), cfg)
+		require.NoError(t, err)
+		objects, err := parseObjects(rendered)
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+
+		ofed, found, err := unstructured.NestedSlice(objects[0].Object, "spec", "ofedDriver", "containerResources")
+		require.NoError(t, err)
+		require.True(t, found)
+		assert.Equal(t, []interface{}{map[string]interface{}{
+			"name":     "mofed-container",
+			"requests": map[string]interface{}{"cpu": "500m", "memory": "1Gi"},
+			"limits":   map[string]interface{}{"memory": "4Gi"},
+		}}, ofed)
+
+		ipam, _, err := unstructured.NestedSlice(objects[0].Object, "spec", "nvIpam", "containerResources")
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{map[string]interface{}{
+			"name":   "nv-ipam-controller",
+			"limits": map[string]interface{}{"cpu": "1"},
+		}}, ipam)
+
+		multus, _, err := unstructured.NestedSlice(objects[0].Object, "spec", "secondaryNetwork", "multus", "containerResources")
+		require.NoError(t, err)
+		assert.Len(t, multus, 1)
+
+		_, found, err = unstructured.NestedSlice(objects[0].Object, "spec", "secondaryNetwork", "cniPlugins", "containerResources")
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("render every known container in a profile", func(t *testing.T) {
+		for _, name := range config.ContainerNames {
+			cfg := newTestConfig()
+			cfg.NetworkOperator.ContainerResources = []config.ContainerResourcesConfig{{Name: name, Limits: config.ResourceValues{Memory: "1Gi"}}}
+
+			rendered := false
+			for _, profile := range []string{"host-device-rdma", "ipoib-rdma-shared", "macvlan-rdma-shared", "sriov-ethernet-rdma", "sriov-ib-rdma"} {
+				policy, err := ProcessTemplate(filepath.Join(profilesDir, profile, "10-nicclusterpolicy.yaml"), cfg)
+				require.NoError(t, err, profile)
+				rendered = rendered || strings.Contains(policy, "- name: "+name)
+			}
+			assert.True(t, rendered, name)
+		}
+	})
+
+	t.Run("render nothing without configured resources", func(t *testing.T) {
+		for _, profile := range []string{"host-device-rdma", "ipoib-rdma-shared", "macvlan-rdma-shared", "sriov-ethernet-rdma", "sriov-ib-rdma"} {
+			rendered, err := ProcessTemplate(filepath.Join(profilesDir, profile, "10-nicclusterpolicy.yaml"), newTestConfig())
+			require.NoError(t, err, profile)
+			assert.NotContains(t, rendered, "containerResources", profile)
+			_, err = parseObjects(rendered)
+			require.NoError(t, err, profile)
+		}
+	})
+}
+
+func TestDaemonSetScheduling(t *testing.T) {
+	tolerationSeconds := int64(300)
+	cfg := newTestConfig()
+	cfg.NetworkOperator.DaemonSets = &config.DaemonSetsConfig{
+		NodeSelector: map[string]string{
+			"feature.node.kubernetes.io/pci-15b3.present": "true",
+			"node-role.kubernetes.io/worker":              "",
+		},
+		Tolerations: []config.TolerationConfig{
+			{Key: "nvidia.com/gpu", Operator: "Exists", Effect: "NoSchedule"},
+			{Key: "dedicated", Value: "networking", Effect: "NoExecute", TolerationSeconds: &tolerationSeconds},
+		},
+	}
+
+	for _, profile := range []string{"host-device-rdma", "ipoib-rdma-shared", "macvlan-rdma-shared", "sriov-ethernet-rdma", "sriov-ib-rdma"} {
+		t.Run(profile, func(t *testing.T) {
+			rendered, err := ProcessTemplate(filepath.Join(profilesDir, profile, "10-nicclusterpolicy.yaml"), cfg)
+			require.NoError(t, err)
+			objects, err := parseObjects(rendered)
+			require.NoError(t, err)
+			require.Len(t, objects, 1)
+
+			terms, found, err := unstructured.NestedSlice(objects[0].Object, "spec", "nodeAffinity", "requiredDuringSchedulingIgnoredDuringExecution", "nodeSelectorTerms")
+			require.NoError(t, err)
+			require.True(t, found)
+			assert.Equal(t, []interface{}{map[string]interface{}{"matchExpressions": []interface{}{
+				map[string]interface{}{"key": "feature.node.kubernetes.io/pci-15b3.present", "operator": "In", "values": []interface{}{"true"}},
+				map[string]interface{}{"key": "node-role.kubernetes.io/worker", "operator": "Exists"},
+			}}}, terms)
+
+			tolerations, found, err := unstructured.NestedSlice(objects[0].Object, "spec", "tolerations")
+			require.NoError(t, err)
+			require.True(t, found)
+			assert.Equal(t, []interface{}{
+				map[string]interface{}{"key": "nvidia.com/gpu", "operator": "Exists", "effect": "NoSchedule"},
+				map[string]interface{}{"key": "dedicated", "operator": "Equal", "value": "networking", "effect": "NoExecute", "tolerationSeconds": float64(300)},
+			}, tolerations)
+		})
+	}
+
+	t.Run("render nothing without scheduling config", func(t *testing.T) {
+		rendered, err := ProcessTemplate(filepath.Join(profilesDir, "sriov-ethernet-rdma", "10-nicclusterpolicy.yaml"), newTestConfig())
+		require.NoError(t, err)
+		assert.NotContains(t, rendered, "nodeAffinity")
+		assert.NotContains(t, rendered, "tolerations")
+	})
+}
+
+func TestPriorityClass(t *testing.T) {
+	available, err := profiles.LoadProfilesDir(profilesDir)
+	require.NoError(t, err)
+	shipped := func(t *testing.T, dir string) profiles.Profile {
+		t.Helper()
+		for _, profile := range available {
+			if filepath.Base(profile.Dir) == dir {
+				return profile
+			}
+		}
+		t.Fatalf("profile %s not found", dir)
+		return profiles.Profile{}
+	}
+
+	// withDaemonSets returns the shipped profile with an extra template rendering DaemonSets
+	withDaemonSets := func(t *testing.T, dir string) *profiles.Profile {
+		t.Helper()
+		templatePath := filepath.Join(t.TempDir(), "60-daemonsets.yaml")
+		require.NoError(t, os.WriteFile(templatePath, []byte(`# plumbing DaemonSets
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: plumbing
+spec:
+  template:
+    spec:
+      containers:
+      - name: plumbing
+        image: plumbing
+---
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: own-priority
+spec:
+  template:
+    spec:
+      priorityClassName: system-cluster-critical
+      containers:
+      - name: own-priority
+        image: own-priority
+---
+# kept as rendered
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: plumbing
+`), 0644))
+		profile := shipped(t, dir)
+		profile.Templates = append(slices.Clone(profile.Templates), templatePath)
+		return &profile
+	}
+
+	t.Run("fail when the shipped profile has nothing to take the priority class", func(t *testing.T) {
+		cfg := newTestConfig()
+		cfg.NetworkOperator.DaemonSets = &config.DaemonSetsConfig{PriorityClassName: "system-node-critical"}
+		profile := shipped(t, "sriov-ethernet-rdma")
+		_, err := (&NetworkOperatorPlugin{}).GenerateProfileDeploymentFiles(&profile, cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no rendered DaemonSet, Deployment, StatefulSet takes it")
+		assert.Contains(t, err.Error(), "the NicClusterPolicy has no priority class field")
+	})
+
+	t.Run("set priorityClassName on the rendered DaemonSets", func(t *testing.T) {
+		cfg := newTestConfig()
+		cfg.NetworkOperator.DaemonSets = &config.DaemonSetsConfig{PriorityClassName: "system-node-critical"}
+		files, err := (&NetworkOperatorPlugin{}).GenerateProfileDeploymentFiles(withDaemonSets(t, "sriov-ethernet-rdma"), cfg)
+		require.NoError(t, err)
+		assert.NotContains(t, files, PriorityClassFile, "an existing PriorityClass is not generated")
+		assert.Contains(t, files, "10-nicclusterpolicy.yaml")
+
+		objects, err := parseObjects(files["60-daemonsets.yaml"])
+		require.NoError(t, err)
+		require.Len(t, objects, 3)
+		priorityClasses := []string{}
+		for _, obj := range objects[:2] {
+			name, _, err := unstructured.NestedString(obj.Object, "spec", "template", "spec", "priorityClassName")
+			require.NoError(t, err)
+			priorityClasses = append(priorityClasses, name)
+		}
+		assert.Equal(t, []string{"system-node-critical", "system-cluster-critical"}, priorityClasses)
+		assert.Contains(t, files["60-daemonsets.yaml"], "# kept as rendered\napiVersion: v1\nkind: ConfigMap\n")
+	})
+
+	t.Run("generate the PriorityClass when requested", func(t *testing.T) {
+		cfg := newTestConfig()
+		cfg.NetworkOperator.DaemonSets = &config.DaemonSetsConfig{
+			PriorityClassName: "network-critical",
+			PriorityClass:     &config.PriorityClassConfig{Value: 1000000, Description: "NVIDIA network plumbing"},
+		}
+
+		for _, dir := range []string{"host-device-rdma", "ipoib-rdma-shared", "macvlan-rdma-shared", "sriov-ethernet-rdma", "sriov-ib-rdma"} {
+			t.Run(dir, func(t *testing.T) {
+				files, err := (&NetworkOperatorPlugin{}).GenerateProfileDeploymentFiles(withDaemonSets(t, dir), cfg)
+				require.NoError(t, err)
+				objects, err := parseObjects(files[PriorityClassFile])
+				require.NoError(t, err)
+				require.Len(t, objects, 1)
+				assert.Equal(t, "PriorityClass", objects[0].GetKind())
+				assert.Equal(t, "network-critical", objects[0].GetName())
+				assert.Equal(t, float64(1000000), objects[0].Object["value"])
+				assert.Equal(t, "NVIDIA network plumbing", objects[0].Object["description"])
+				assert.Contains(t, files["60-daemonsets.yaml"], "priorityClassName: network-critical")
+			})
+		}
+	})
+}
+
+func TestImageDigests(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("0f", 32)
+	cfg := newTestConfig()
+	cfg.NetworkOperator.ImageDigests = map[string]string{"doca-driver": digest, "multus-cni": digest}
+
+	rendered, err := ProcessTemplate(filepath.Join(profilesDir, "sriov-ethernet-rdma", "10-nicclusterpolicy.yaml"), cfg)
+	require.NoError(t, err)
+	objects, err := parseObjects(rendered)
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+
+	for _, fields := range [][]string{{"spec", "ofedDriver"}, {"spec", "secondaryNetwork", "multus"}} {
+		version, _, err := unstructured.NestedString(objects[0].Object, append(fields, "version")...)
+		require.NoError(t, err)
+		assert.Equal(t, digest, version, fields)
+	}
+
+	version, _, err := unstructured.NestedString(objects[0].Object, "spec", "secondaryNetwork", "cniPlugins", "version")
+	require.NoError(t, err)
+	assert.Equal(t, cfg.NetworkOperator.ComponentVersion, version)
+}
+
+func TestImageDigestsOfEveryImage(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("0f", 32)
+	for _, image := range config.ImageNames {
+		cfg := newTestConfig()
+		cfg.NetworkOperator.ImageDigests = map[string]string{image: digest}
+
+		pinned := false
+		for _, profile := range []string{"host-device-rdma", "ipoib-rdma-shared", "macvlan-rdma-shared", "sriov-ethernet-rdma", "sriov-ib-rdma"} {
+			rendered, err := ProcessTemplate(filepath.Join(profilesDir, profile, "10-nicclusterpolicy.yaml"), cfg)
+			require.NoError(t, err, profile)
+			pinned = pinned || strings.Contains(rendered, digest)
+		}
+		assert.True(t, pinned, image)
+	}
+}
+
+func TestValidateBuiltinProfiles(t *testing.T) {
+	results, err := profiles.ValidateProfilesDir(profilesDir, TemplateFuncs)
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	for _, result := range results {
+		assert.True(t, result.Passed(), "%s: %v", result.Dir, result.Errors)
+	}
+}
+
+func TestBuiltinProfilesMatchSchemas(t *testing.T) {
+	validator, err := deploy.NewSchemaValidator()
+	require.NoError(t, err)
+
+	available, err := profiles.LoadProfilesDir(profilesDir)
+	require.NoError(t, err)
+	for _, profile := range available {
+		t.Run(filepath.Base(profile.Dir), func(t *testing.T) {
+			files, err := (&NetworkOperatorPlugin{}).GenerateProfileDeploymentFiles(&profile, newTestConfig())
+			require.NoError(t, err)
+
+			violations, _, err := validator.ValidateManifests(files)
+			require.NoError(t, err)
+			assert.Empty(t, violations)
+		})
+	}
+}
+
+func TestComponentNamespaces(t *testing.T) {
+	profile := func(t *testing.T) *profiles.Profile {
+		available, err := profiles.LoadProfilesDir(profilesDir)
+		require.NoError(t, err)
+		for _, p := range available {
+			if filepath.Base(p.Dir) == "sriov-ethernet-rdma" {
+				return &p
+			}
+		}
+		t.Fatal("sriov-ethernet-rdma profile not found")
+		return nil
+	}
+
+	// namespacesByKind returns the distinct namespaces of the rendered objects of every kind
+	namespacesByKind := func(t *testing.T, cfg *config.LaunchKubernetesConfig) map[string][]string {
+		files, err := (&NetworkOperatorPlugin{}).GenerateProfileDeploymentFiles(profile(t), cfg)
+		require.NoError(t, err)
+		namespaces := map[string][]string{}
+		for _, content := range files {
+			objects, err := parseObjects(content)
+			require.NoError(t, err)
+			for _, obj := range objects {
+				if !slices.Contains(namespaces[obj.GetKind()], obj.GetNamespace()) {
+					namespaces[obj.GetKind()] = append(namespaces[obj.GetKind()], obj.GetNamespace())
+				}
+			}
+		}
+		return namespaces
+	}
+
+	t.Run("render the components into their own namespaces", func(t *testing.T) {
+		cfg := newTestConfig()
+		cfg.NetworkOperator.Namespaces = map[string]string{"sriov": "sriov-network-operator"}
+
+		namespaces := namespacesByKind(t, cfg)
+		assert.Equal(t, []string{"nvidia-network-operator"}, namespaces["IPPool"])
+		assert.Equal(t, []string{"sriov-network-operator"}, namespaces["SriovNetworkNodePolicy"])
+		assert.Equal(t, []string{"sriov-network-operator"}, namespaces["SriovNetwork"])
+	})
+
+	t.Run("default to the operator namespace", func(t *testing.T) {
+		namespaces := namespacesByKind(t, newTestConfig())
+		assert.Equal(t, []string{"nvidia-network-operator"}, namespaces["IPPool"])
+		assert.Equal(t, []string{"nvidia-network-operator"}, namespaces["SriovNetworkNodePolicy"])
+	})
+}
+
+func TestObjectNamePrefixAndSuffix(t *testing.T) {
+	available, err := profiles.LoadProfilesDir(profilesDir)
+	require.NoError(t, err)
+
+	cfg := newTestConfig()
+	cfg.NetworkOperator.NamePrefix = "team-a-"
+	cfg.NetworkOperator.NameSuffix = "-v2"
+	cfg.Sriov.NetworkName = "sriov-network"
+
+	for _, profile := range available {
+		t.Run(filepath.Base(profile.Dir), func(t *testing.T) {
+			files, err := (&NetworkOperatorPlugin{}).GenerateProfileDeploymentFiles(&profile, cfg)
+			require.NoError(t, err)
+
+			var networks []string
+			for _, content := range files {
+				objects, err := parseObjects(content)
+				require.NoError(t, err)
+				for _, obj := range objects {
+					name := obj.GetName()
+					assert.Empty(t, validation.IsDNS1123Subdomain(name), "%s %s", obj.GetKind(), name)
+					if obj.GetKind() == "NicClusterPolicy" {
+						continue
+					}
+					assert.True(t, strings.HasPrefix(name, "team-a-"), "%s %s", obj.GetKind(), name)
+					assert.Contains(t, name, "-v2", "%s %s", obj.GetKind(), name)
+					if network, ok := obj.GetAnnotations()["k8s.v1.cni.cncf.io/networks"]; ok {
+						networks = append(networks, network)
+					}
+				}
+			}
+			for _, network := range networks {
+				assert.True(t, strings.HasPrefix(network, "team-a-"), network)
+			}
+		})
+	}
+
+	assert.Equal(t, "sriov-network", cfg.Sriov.NetworkName)
+}
+
+func TestRoceQos(t *testing.T) {
+	available, err := profiles.LoadProfilesDir(profilesDir)
+	require.NoError(t, err)
+
+	newSpectrumXConfig := func() *config.LaunchKubernetesConfig {
+		cfg := newTestConfig()
+		cfg.Profile.SpectrumX = true
+		cfg.ClusterConfig.PFs[0].NicType = "101d"
+		cfg.ClusterConfig.PFs[1].NicType = "101d"
+		cfg.ClusterConfig.PFs = append(cfg.ClusterConfig.PFs, config.PFConfig{RdmaDevice: "mlx5_2", PciAddress: "0000:09:00.0", NetworkInterface: "ens2f0", Traffic: "east-west", NicType: "a2dc"})
+		cfg.NvIpam.Subnets = append(cfg.NvIpam.Subnets, config.NvIpamSubnetConfig{Subnet: "192.168.4.0/24", Gateway: "192.168.4.1"})
+		return cfg
+	}
+
+	for _, tt := range []struct {
+		dir    string
+		numVfs float64
+	}{
+		{dir: "sriov-ethernet-rdma", numVfs: 8},
+		{dir: "macvlan-rdma-shared", numVfs: 0},
+	} {
+		index := slices.IndexFunc(available, func(p profiles.Profile) bool { return filepath.Base(p.Dir) == tt.dir })
+		require.NotEqual(t, -1, index, tt.dir)
+		profile := available[index]
+
+		t.Run(tt.dir+" renders a NicConfigurationTemplate per NIC type with Spectrum-X", func(t *testing.T) {
+			cfg := newSpectrumXConfig()
+			cfg.Qos = &config.QosConfig{Trust: "pfc", PfcPriorities: []int{3, 4}}
+
+			files, err := (&NetworkOperatorPlugin{}).GenerateProfileDeploymentFiles(&profile, cfg)
+			require.NoError(t, err)
+			objects, err := parseObjects(files["15-roce-qos.yaml"])
+			require.NoError(t, err)
+			require.Len(t, objects, 2)
+
+			for i, nicType := range []string{"101d", "a2dc"} {
+				obj := objects[i]
+				assert.Equal(t, "configuration.net.nvidia.com/v1alpha1", obj.GetAPIVersion())
+				assert.Equal(t, "NicConfigurationTemplate", obj.GetKind())
+				assert.Equal(t, "nvidia-network-operator", obj.GetNamespace())
+				selected, _, _ := unstructured.NestedString(obj.Object, "spec", "nicSelector", "nicType")
+				assert.Equal(t, nicType, selected)
+
+				numVfs, _, _ := unstructured.NestedFieldNoCopy(obj.Object, "spec", "template", "numVfs")
+				assert.EqualValues(t, tt.numVfs, numVfs)
+				linkType, _, _ := unstructured.NestedString(obj.Object, "spec", "template", "linkType")
+				assert.Equal(t, "Ethernet", linkType)
+				enabled, _, _ := unstructured.NestedBool(obj.Object, "spec", "template", "roceOptimized", "enabled")
+				assert.True(t, enabled)
+				qos, _, err := unstructured.NestedStringMap(obj.Object, "spec", "template", "roceOptimized", "qos")
+				require.NoError(t, err)
+				assert.Equal(t, map[string]string{"trust": "pfc", "pfc": "0,0,0,1,1,0,0,0"}, qos)
+			}
+			pciAddresses, _, _ := unstructured.NestedStringSlice(objects[0].Object, "spec", "nicSelector", "pciAddresses")
+			assert.Equal(t, []string{"0000:08:00.0", "0000:08:00.1"}, pciAddresses)
+			assert.Equal(t, "roce-qos-b", objects[1].GetName())
+
+			assert.Contains(t, files["10-nicclusterpolicy.yaml"], "nicConfigurationOperator:")
+		})
+
+		t.Run(tt.dir+" applies the default QoS without a qos section", func(t *testing.T) {
+			files, err := (&NetworkOperatorPlugin{}).GenerateProfileDeploymentFiles(&profile, newSpectrumXConfig())
+			require.NoError(t, err)
+			objects, err := parseObjects(files["15-roce-qos.yaml"])
+			require.NoError(t, err)
+			require.Len(t, objects, 2)
+
+			qos, _, err := unstructured.NestedStringMap(objects[0].Object, "spec", "template", "roceOptimized", "qos")
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"trust": "dscp", "pfc": "0,0,0,1,0,0,0,0"}, qos)
+		})
+
+		t.Run(tt.dir+" requires the NIC types of the PFs", func(t *testing.T) {
+			cfg := newSpectrumXConfig()
+			cfg.ClusterConfig.PFs[1].NicType = ""
+
+			_, err := (&NetworkOperatorPlugin{}).GenerateProfileDeploymentFiles(&profile, cfg)
+			assert.ErrorContains(t, err, "clusterConfig.pfs[1] 0000:08:00.1 has no nicType")
+		})
+
+		t.Run(tt.dir+" renders no QoS file without Spectrum-X", func(t *testing.T) {
+			cfg := newTestConfig()
+			cfg.Qos = &config.QosConfig{Trust: "dscp", PfcPriorities: []int{3}}
+
+			files, err := (&NetworkOperatorPlugin{}).GenerateProfileDeploymentFiles(&profile, cfg)
+			require.NoError(t, err)
+			assert.NotContains(t, files, "15-roce-qos.yaml")
+			assert.NotContains(t, files["10-nicclusterpolicy.yaml"], "nicConfigurationOperator:")
+		})
+	}
+}
+
+func TestConfigMapData(t *testing.T) {
+	render := func(t *testing.T, cfg *config.LaunchKubernetesConfig, path string) (string, error) {
+		templatePath := filepath.Join(t.TempDir(), "20-configmap.yaml")
+		template := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: l8k-config\ndata:\n{{ configMapData 2 . \"" + path + "\" }}\n"
+		require.NoError(t, os.WriteFile(templatePath, []byte(template), 0644))
+		return ProcessTemplate(templatePath, cfg)
+	}
+
+	t.Run("embed the SR-IOV config", func(t *testing.T) {
+		cfg := newTestConfig()
+		cfg.Sriov.NumVfs = 16
+		rendered, err := render(t, cfg, "sriov")
+		require.NoError(t, err)
+
+		objects, err := parseObjects(rendered)
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+		data, found, err := unstructured.NestedString(objects[0].Object, "data", "sriov.yaml")
+		require.NoError(t, err)
+		require.True(t, found)
+
+		var sriov config.SriovConfig
+		require.NoError(t, yaml.Unmarshal([]byte(data), &sriov))
+		assert.Equal(t, *cfg.Sriov, sriov, "the data follows the config")
+	})
+
+	t.Run("embed a nested field", func(t *testing.T) {
+		rendered, err := render(t, newTestConfig(), "sriov.numVfs")
+		require.NoError(t, err)
+		assert.Contains(t, rendered, "  sriov.numVfs.yaml: |\n    8\n")
+	})
+
+	t.Run("reject an unknown section", func(t *testing.T) {
+		_, err := render(t, newTestConfig(), "sriov.unknown")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "config has no section sriov.unknown")
+	})
+}
+
+func TestRenderProfileTemplates(t *testing.T) {
+	dir := t.TempDir()
+	templates := map[string]string{
+		"10-namespace.yaml": "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: {{.NetworkOperator.Namespace}}\n",
+		"20-broken.yaml":    "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.NetworkOperator.Missing}}\n",
+		"30-empty.yaml":     "{{- if false }}\nkind: ConfigMap\n{{- end }}\n",
+		"40-network.yaml":   "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Sriov.NetworkName}}\n",
+	}
+	profile := &profiles.Profile{Name: "mixed"}
+	for _, name := range []string{"10-namespace.yaml", "20-broken.yaml", "30-empty.yaml", "40-network.yaml"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(templates[name]), 0644))
+		profile.Templates = append(profile.Templates, filepath.Join(dir, name))
+	}
+
+	t.Run("render the templates past a failed one", func(t *testing.T) {
+		rendered, failures := RenderProfileTemplates(profile, newTestConfig())
+		assert.ElementsMatch(t, []string{"10-namespace.yaml", "40-network.yaml"}, slices.Collect(maps.Keys(rendered)))
+		require.Len(t, failures, 1)
+		assert.Equal(t, filepath.Join(dir, "20-broken.yaml"), failures[0].Template)
+		assert.Contains(t, failures[0].Err.Error(), "can't evaluate field Missing")
+	})
+
+	t.Run("fail with every failed template", func(t *testing.T) {
+		broken := *profile
+		broken.Templates = append([]string{filepath.Join(dir, "missing.yaml")}, profile.Templates...)
+		_, err := (&NetworkOperatorPlugin{}).GenerateProfileDeploymentFiles(&broken, newTestConfig())
+
+		var renderErr *plugin.RenderError
+		require.ErrorAs(t, err, &renderErr)
+		assert.Len(t, renderErr.Failures, 2)
+		assert.Contains(t, renderErr.Rendered, "40-network.yaml")
+		assert.Contains(t, err.Error(), "2 templates failed to render")
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("report a single failure as is", func(t *testing.T) {
+		_, err := (&NetworkOperatorPlugin{}).GenerateProfileDeploymentFiles(profile, newTestConfig())
+		require.Error(t, err)
+		assert.True(t, strings.HasPrefix(err.Error(), "failed to process template "+filepath.Join(dir, "20-broken.yaml")), err.Error())
+	})
+}
@@ -16,35 +16,101 @@
 
 package options
 
+import "time"
+
 // Options holds all the configuration parameters for the application
 type Options struct {
+	// Version is the l8k version, reported in the generated files
+	Version string
+
 	// Logging
 	LogLevel string
 	LogFile  string // Path to log file (optional)
 
+	SupportBundle string // Path of a tarball with the redacted artifacts and logs of the run, written when it ends
+
+	// Timeouts, no limit when zero. The phase timeouts are nested in the Timeout of the whole run.
+	Timeout          time.Duration // Bounds every workflow run
+	DiscoveryTimeout time.Duration // Bounds the cluster discovery
+	LLMTimeout       time.Duration // Bounds the AI-assisted profile selection, every response in interactive mode
+	DeployTimeout    time.Duration // Bounds the deployment, including the permission check
+
 	// Phase 1: Cluster Discovery
-	UserConfig            string // Path to user-provided config (skips discovery)
-	DiscoverClusterConfig bool   // Whether to discover cluster config
-	SaveClusterConfig     string // Path to save discovered config
+	UserConfig            string   // Path to user-provided config (skips discovery)
+	ConfigFromSecret      string   // <secret|configmap>/<namespace>/<name>[:<key>] holding the config (skips discovery)
+	RunSpec               string   // Path to a manifest holding both the config and the profile intent (skips discovery)
+	TemplateConfig        bool     // Render the user config as a Go template against the discovered config
+	DiscoverClusterConfig bool     // Whether to discover cluster config
+	DiscoverySnapshot     string   // File with recorded cluster objects the discovery reads instead of the cluster (optional)
+	SaveClusterConfig     string   // Path to save discovered config
+	PrintDiscovery        string   // Format, yaml or json, of the discovered capabilities printed to stdout (optional)
+	Set                   []string // path=value overrides of the loaded config fields, applied in order
+	Annotations           []string // key=value annotations added to every generated object, overriding the config ones
+	ExplainConfig         bool     // Print the source of every resolved config field
 
 	// Phase 2: Deployment Generation
-	Fabric              string // Fabric type to deploy
-	DeploymentType      string // Deployment type to deploy
-	Multirail           bool   // Whether to deploy with multirail
-	SpectrumX           bool   // Whether to deploy with Spectrum X
-	Ai                  bool   // Whether to deploy with AI
-	Prompt              string // Path to file with a prompt to use for LLM-assisted profile generation
-	SaveDeploymentFiles string // Directory to save generated files
-
-	LLMApiKey      string // API key for the LLM API
-	LLMApiUrl      string // API URL for the LLM API
-	LLMVendor      string // Vendor of the LLM API
-	LLMModel       string // Model name for the LLM API
-	LLMInteractive bool   // Enable interactive chat mode
-
-	EnabledPlugins []string // Enabled plugins
+	Fabric              string   // Fabric type to deploy
+	DeploymentType      string   // Deployment type to deploy, comma-separated to deploy several profiles together
+	Multirail           bool     // Whether to deploy with multirail
+	SpectrumX           bool     // Whether to deploy with Spectrum X
+	Ai                  bool     // Whether to deploy with AI
+	ProfileFlagsSet     []string // The config.ProfileFlags of Multirail, SpectrumX and Ai given on the command line
+	Prompt              string   // Path to file with a prompt to use for LLM-assisted profile generation
+	SaveDeploymentFiles string   // Directory to save generated files
+	OutputFormat        string   // How the generated files are written: files, stream to stdout or argocd
+	ArgoCDRepoURL       string   // Git repository the Argo CD Applications sync from
+	ArgoCDPath          string   // Path in the repository of the SaveDeploymentFiles directory
+	ArgoCDRevision      string   // Repository revision the Argo CD Applications track
+	AllowEmptyProfile   bool     // Warn instead of failing when a profile renders no files
+	NoClean             bool     // Keep user files in the output directory, overwriting only generated files
+	ProvenanceHeader    bool     // Prepend the profile, l8k version and config hash to every generated file
+	CanonicalOutput     bool     // Sort the keys and normalize the indentation of the rendered YAML documents
+	Changed             bool     // Only regenerate the profiles whose inputs changed since the files were last saved
+	SchemaValidate      bool     // Validate the rendered objects against the OpenAPI schemas of their kinds
+	SummaryDoc          string   // Markdown file summarizing the profiles, settings and objects to deploy (optional)
+	WhichPlugins        bool     // Print the plugins configured by the options and exit
+
+	LLMApiKey         string // API key for the LLM API
+	LLMApiUrl         string // API URL for the LLM API
+	LLMVendor         string // Vendor of the LLM API
+	LLMModel          string // Model name for the LLM API
+	LLMInteractive    bool   // Enable interactive chat mode
+	LLMFixture        string // File with a recorded LLM response used instead of calling the provider
+	ProfileJSON       string // Profile as the JSON object of an LLM response, used without calling the LLM
+	MinConfidence     string // Confidence, low, medium or high, an LLM answer needs to be accepted
+	PrintSystemPrompt bool   // Print the redacted system prompt sent to the LLM instead of selecting a profile
+	CABundle          string // PEM file with the CAs trusted, besides the system roots, by the LLM and registry clients
+
+	EnabledPlugins  []string // Enabled plugins
+	ProfilesDirs    []string // Directories or oci:// references with the deployment profiles, earlier ones win (defaults to profiles.ProfilesDir)
+	Environment     string   // Environment overlay of the profile to render (optional)
+	FallbackProfile string   // Profile used when no profile matches the requirements (optional)
+	ForceProfile    string   // Profile rendered without matching it against the requirements (optional)
+	ProfileCache    string   // File caching the profile matched for the capabilities and requirements (optional)
 
 	// Phase 3: Cluster Deployment
-	Deploy     bool   // Whether to deploy to cluster
-	Kubeconfig string // Path to kubeconfig for discovery and deployment
+	Deploy               bool     // Whether to deploy to cluster
+	NoDeployFiles        bool     // Deploy the rendered files from memory without saving them
+	Checkpoint           string   // File recording the objects applied, removed once the deployment completes (optional)
+	Resume               bool     // Skip the objects of the Checkpoint applied and unchanged since
+	ClusterMatch         string   // What to do when the cluster deployed to is not the one the config was discovered from: warn, fail or ignore
+	EnvironmentKey       string   // Label or annotation of the kube-system namespace naming the environment of the cluster, unchecked when empty
+	Yes                  bool     // Deploy to production clusters without asking for a confirmation
+	Kubeconfig           string   // Path to kubeconfig for discovery and deployment
+	Kubeconfigs          []string // Kubeconfigs of the clusters to deploy to instead of Kubeconfig (optional)
+	ValidateConnectivity bool     // Check that the API server is reachable before the workflow starts
+	ConfirmConflicts     bool     // Ask before force-taking fields owned by another field manager
+	FieldManager         string   // Server-side apply field manager
+	ConflictPolicy       string   // What to do with fields owned by another field manager: fail or force
+	ContinueOnError      bool     // Apply the remaining objects when one fails and report all failures
+	ApplyFiles           []string // Only apply the generated files matching these globs
+	ApplyKinds           []string // Only apply the objects of these kinds
+	Exclude              []string // Skip the generated files matching these globs and the objects of these kinds
+	Diff                 bool     // Show the differences between the generated files and the cluster
+	ContextLines         int      // Unchanged lines shown around each change of the diff
+	DiffExitCode         bool     // Fail with app.ErrDrift when the diff finds differences
+
+	// Watch mode
+	Watch      bool   // Re-run generation and deployment whenever the user config changes
+	HealthAddr string // Address of the /healthz and /readyz endpoints in watch mode
 }
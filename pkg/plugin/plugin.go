@@ -18,8 +18,14 @@ package plugin
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/pflag"
 
 	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/deploy"
 	"github.com/nvidia/k8s-launch-kit/pkg/options"
 	"github.com/nvidia/k8s-launch-kit/pkg/profiles"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -27,7 +33,8 @@ import (
 
 // Plugin defines the interface to implement for tool support for the Launch Kit.
 // To integrate a new tool, implement this interface and add the plugin to the plugins map in the main package.
-// CLI flags and config type should be defined in the tool, not in the plugin.
+// CLI flags and config type should be defined in the tool, not in the plugin. Options specific to the plugin can be
+// contributed as flags by implementing FlagRegistrar.
 // Configuration templates should be stored in the tool's directory. Additional make targets can be used for template provisioning.
 type Plugin interface {
 	// GetName returns the name of the plugin. The name will be used to enable / disable plugins and match profiles.
@@ -43,10 +50,132 @@ type Plugin interface {
 	// GetSystemPromptAddendum returns the addendum to the system prompt, specific to the plugin. The addendum will be used to add additional context to the system prompt.
 	GetSystemPromptAddendum() (string, error)
 	// DiscoverClusterConfig discovers the plugin-specific part of the cluster configuration and adds it to the given LaunchKubernetesConfig.
-	// Should not reassign defaultConfig.ClusterConfig, only edit it.
-	DiscoverClusterConfig(ctx context.Context, kubeClient client.Client, defaultConfig *config.LaunchKubernetesConfig) error
+	// Should not reassign defaultConfig.ClusterConfig, only edit it. Probes that fail without preventing the discovery are
+	// reported in the returned DiscoveryResult, the parts of the config they determine are left at their defaults.
+	DiscoverClusterConfig(ctx context.Context, kubeClient client.Client, defaultConfig *config.LaunchKubernetesConfig) (*DiscoveryResult, error)
 	// GenerateProfileDeploymentFiles generates the deployment files for the profile.
 	GenerateProfileDeploymentFiles(profile *profiles.Profile, config *config.LaunchKubernetesConfig) (map[string]string, error)
-	// DeployProfile deploys the profile to the cluster.
-	DeployProfile(ctx context.Context, profile *profiles.Profile, kubeClient client.Client, manifestsDir string) error
+	// DeployProfile deploys the rendered manifests (file name -> content) of the profile to the cluster.
+	// opts carry the apply settings chosen by the user, the plugin may add its own ordering and readiness checks.
+	DeployProfile(ctx context.Context, profile *profiles.Profile, kubeClient client.Client, manifests map[string]string, opts deploy.Options) error
+}
+
+// PostRenderer is optionally implemented by plugins to post-process the rendered files of their profiles,
+// e.g. to inject sidecars or labels. It is called after generation, before the files are saved or deployed.
+type PostRenderer interface {
+	// PostRender returns the final files (file name -> content) for the rendered files. An error aborts the generation.
+	PostRender(files map[string]string) (map[string]string, error)
+}
+
+// FlagRegistrar is optionally implemented by plugins to contribute their own CLI flags. RegisterFlags is called at
+// startup, before the command line is parsed, and binds the flags to the plugin.
+type FlagRegistrar interface {
+	// RegisterFlags defines the flags of the plugin in fs
+	RegisterFlags(fs *pflag.FlagSet)
+}
+
+// RegisterFlags adds the flags of the plugins implementing FlagRegistrar to fs, in plugin name order.
+// A flag whose name or shorthand is already defined, by the tool or another plugin, is an error.
+func RegisterFlags(fs *pflag.FlagSet, plugins ...Plugin) error {
+	sorted := append([]Plugin(nil), plugins...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].GetName() < sorted[j].GetName() })
+
+	for _, p := range sorted {
+		registrar, ok := p.(FlagRegistrar)
+		if !ok {
+			continue
+		}
+		pluginFlags, err := pluginFlagSet(p.GetName(), registrar)
+		if err != nil {
+			return err
+		}
+
+		var conflicts []string
+		pluginFlags.VisitAll(func(f *pflag.Flag) {
+			if fs.Lookup(f.Name) != nil {
+				conflicts = append(conflicts, "--"+f.Name)
+			} else if f.Shorthand != "" && fs.ShorthandLookup(f.Shorthand) != nil {
+				conflicts = append(conflicts, "-"+f.Shorthand)
+			}
+		})
+		if len(conflicts) > 0 {
+			return fmt.Errorf("plugin %s defines flags that are already defined: %v", p.GetName(), conflicts)
+		}
+		fs.AddFlagSet(pluginFlags)
+	}
+	return nil
+}
+
+// pluginFlagSet returns the flags the plugin registers, pflag panics when the plugin defines a flag twice
+func pluginFlagSet(name string, registrar FlagRegistrar) (fs *pflag.FlagSet, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("plugin %s failed to register its flags: %v", name, r)
+		}
+	}()
+
+	fs = pflag.NewFlagSet(name, pflag.ContinueOnError)
+	registrar.RegisterFlags(fs)
+	return fs, nil
+}
+
+// ProbeFailure is a discovery probe that failed without aborting the discovery
+type ProbeFailure struct {
+	Probe string
+	Err   error
+	// Impact describes what the discovered config may be missing
+	Impact string
+}
+
+// DiscoveryResult reports the probes that failed during a discovery that still completed
+type DiscoveryResult struct {
+	Failures []ProbeFailure
+}
+
+// Warnings describes every failed probe and its impact
+func (r *DiscoveryResult) Warnings() []string {
+	if r == nil {
+		return nil
+	}
+
+	warnings := make([]string, 0, len(r.Failures))
+	for _, f := range r.Failures {
+		warnings = append(warnings, fmt.Sprintf("probe %s failed: %v, %s", f.Probe, f.Err, f.Impact))
+	}
+	return warnings
+}
+
+// TemplateFailure is a template of a profile that failed to render
+type TemplateFailure struct {
+	// Template is the path of the template
+	Template string
+	Err      error
+}
+
+// RenderError is returned by GenerateProfileDeploymentFiles when templates of the profile failed to render. It lists
+// every failure along with the files of the templates that rendered, as all templates are rendered before failing.
+type RenderError struct {
+	// Rendered holds the files (file name -> content) of the templates that rendered
+	Rendered map[string]string
+	Failures []TemplateFailure
+}
+
+func (e *RenderError) Error() string {
+	if len(e.Failures) == 1 {
+		return e.Failures[0].Err.Error()
+	}
+	messages := make([]string, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		messages = append(messages, f.Err.Error())
+	}
+	return fmt.Sprintf("%d templates failed to render: %s", len(e.Failures), strings.Join(messages, "; "))
+}
+
+// Unwrap returns the errors of the failures
+func (e *RenderError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		errs = append(errs, f.Err)
+	}
+	return errs
 }
@@ -0,0 +1,118 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flagPlugin is a plugin registering flags, the other methods of Plugin are not called
+type flagPlugin struct {
+	Plugin
+	name     string
+	register func(fs *pflag.FlagSet)
+}
+
+func (p *flagPlugin) GetName() string {
+	return p.name
+}
+
+func (p *flagPlugin) RegisterFlags(fs *pflag.FlagSet) {
+	p.register(fs)
+}
+
+// plainPlugin is a plugin without flags
+type plainPlugin struct {
+	Plugin
+}
+
+func (plainPlugin) GetName() string {
+	return "plain"
+}
+
+func TestRegisterFlags(t *testing.T) {
+	newFlagSet := func() *pflag.FlagSet {
+		fs := pflag.NewFlagSet("l8k", pflag.ContinueOnError)
+		fs.String("fabric", "", "Fabric type")
+		fs.BoolP("help", "h", false, "Help")
+		return fs
+	}
+
+	t.Run("register the distinct flags of every plugin", func(t *testing.T) {
+		var mtu int
+		var mode string
+		first := &flagPlugin{name: "first", register: func(fs *pflag.FlagSet) {
+			fs.IntVar(&mtu, "first-mtu", 1500, "MTU of the first plugin")
+		}}
+		second := &flagPlugin{name: "second", register: func(fs *pflag.FlagSet) {
+			fs.StringVarP(&mode, "second-mode", "m", "auto", "Mode of the second plugin")
+		}}
+
+		fs := newFlagSet()
+		require.NoError(t, RegisterFlags(fs, second, plainPlugin{}, first))
+		require.NoError(t, fs.Parse([]string{"--first-mtu", "9000", "-m", "manual", "--fabric", "ethernet"}))
+		assert.Equal(t, 9000, mtu)
+		assert.Equal(t, "manual", mode)
+	})
+
+	t.Run("flag defined by the tool", func(t *testing.T) {
+		conflicting := &flagPlugin{name: "conflicting", register: func(fs *pflag.FlagSet) {
+			fs.String("fabric", "", "Fabric of the plugin")
+		}}
+
+		err := RegisterFlags(newFlagSet(), conflicting)
+		require.Error(t, err)
+		assert.Equal(t, "plugin conflicting defines flags that are already defined: [--fabric]", err.Error())
+	})
+
+	t.Run("flag defined by another plugin", func(t *testing.T) {
+		register := func(fs *pflag.FlagSet) {
+			fs.Bool("shared", false, "Flag of both plugins")
+		}
+		fs := newFlagSet()
+
+		err := RegisterFlags(fs, &flagPlugin{name: "second", register: register}, &flagPlugin{name: "first", register: register})
+		require.Error(t, err)
+		assert.Equal(t, "plugin second defines flags that are already defined: [--shared]", err.Error())
+		assert.NotNil(t, fs.Lookup("shared"), "the flag of the first plugin is registered")
+	})
+
+	t.Run("shorthand defined by the tool", func(t *testing.T) {
+		conflicting := &flagPlugin{name: "conflicting", register: func(fs *pflag.FlagSet) {
+			fs.BoolP("hosts", "h", false, "Hosts of the plugin")
+		}}
+
+		err := RegisterFlags(newFlagSet(), conflicting)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "[-h]")
+	})
+
+	t.Run("flag defined twice by a plugin", func(t *testing.T) {
+		twice := &flagPlugin{name: "twice", register: func(fs *pflag.FlagSet) {
+			fs.Bool("again", false, "First definition")
+			fs.Bool("again", false, "Second definition")
+		}}
+
+		err := RegisterFlags(newFlagSet(), twice)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "plugin twice failed to register its flags")
+	})
+}
@@ -0,0 +1,169 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package profiles
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"gopkg.in/yaml.v2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// SelectionCache remembers the profile matched for a selection fingerprint, the hash of the cluster capabilities,
+// the profile requirements and the plugin, so that unchanged runs skip matching the profiles directory.
+// It is persisted to a file and dropped when the profile manifests of the directory change.
+type SelectionCache struct {
+	path        string
+	profilesDir string
+	state       selectionCacheFile
+	changed     bool
+}
+
+// selectionCacheFile is the persisted form of a SelectionCache
+type selectionCacheFile struct {
+	// ProfilesHash is the hash of the profile manifests the selections were matched against
+	ProfilesHash string `yaml:"profilesHash"`
+	// Selections maps a selection fingerprint to the directory name of the matched profile
+	Selections map[string]string `yaml:"selections"`
+}
+
+// OpenSelectionCache reads the selection cache persisted at path for profilesDir. A missing cache file, or one
+// recorded for other profile manifests, opens an empty cache.
+func OpenSelectionCache(path, profilesDir string) (*SelectionCache, error) {
+	profilesHash, err := profilesDirHash(profilesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	cache := &SelectionCache{
+		path:        path,
+		profilesDir: profilesDir,
+		state:       selectionCacheFile{ProfilesHash: profilesHash, Selections: map[string]string{}},
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cache, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profile cache %s: %w", path, err)
+	}
+	var state selectionCacheFile
+	if err := yaml.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse profile cache %s: %w", path, err)
+	}
+	if state.ProfilesHash != profilesHash {
+		log.Log.Info("Profiles changed, dropping the profile cache", "path", path, "profilesDir", profilesDir)
+		cache.changed = len(state.Selections) > 0
+		return cache, nil
+	}
+	if state.Selections != nil {
+		cache.state.Selections = state.Selections
+	}
+	return cache, nil
+}
+
+// FindApplicableProfile returns the profile cached for the selection, or finds it like FindApplicableProfileInDir
+// and caches it. cached reports whether the profile came from the cache.
+func (c *SelectionCache) FindApplicableProfile(requirements *config.Profile, capabilities *config.ClusterCapabilities, pluginName string) (profile *Profile, cached bool, err error) {
+	fingerprint, err := selectionFingerprint(requirements, capabilities, pluginName)
+	if err != nil {
+		return nil, false, err
+	}
+	if name, ok := c.state.Selections[fingerprint]; ok {
+		profile, err := loadProfile(c.profilesDir, name)
+		// The profile manifests are unchanged, a cached profile that no longer loads or matches is a miss
+		if err == nil && profile.Plugin == pluginName {
+			if valid, _ := profile.Validate(requirements, capabilities); valid {
+				log.Log.Info("Using cached profile selection", "profile", profile.Name, "fingerprint", fingerprint)
+				return profile, true, nil
+			}
+		}
+		delete(c.state.Selections, fingerprint)
+		c.changed = true
+	}
+
+	profile, err = FindApplicableProfileInDir(c.profilesDir, requirements, capabilities, pluginName)
+	if err != nil {
+		return nil, false, err
+	}
+	c.state.Selections[fingerprint] = filepath.Base(profile.Dir)
+	c.changed = true
+	return profile, false, nil
+}
+
+// Save persists the cache when it changed since it was opened, it does nothing on a nil cache
+func (c *SelectionCache) Save() error {
+	if c == nil || !c.changed {
+		return nil
+	}
+
+	data, err := yaml.Marshal(c.state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal profile cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("failed to create profile cache directory: %w", err)
+	}
+	if err := os.WriteFile(c.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write profile cache %s: %w", c.path, err)
+	}
+	c.changed = false
+	return nil
+}
+
+// selectionFingerprint returns the hash of what a profile is matched on
+func selectionFingerprint(requirements *config.Profile, capabilities *config.ClusterCapabilities, pluginName string) (string, error) {
+	data, err := yaml.Marshal(struct {
+		Plugin       string                      `yaml:"plugin"`
+		Requirements *config.Profile             `yaml:"requirements"`
+		Capabilities *config.ClusterCapabilities `yaml:"capabilities"`
+	}{pluginName, requirements, capabilities})
+	if err != nil {
+		return "", fmt.Errorf("failed to fingerprint the profile selection: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// profilesDirHash returns the hash of the profile manifests of profilesDir, by profile directory name
+func profilesDirHash(profilesDir string) (string, error) {
+	entries, err := os.ReadDir(profilesDir)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(profilesDir, entry.Name(), "profile.yaml"))
+		if err != nil {
+			return "", fmt.Errorf("failed to hash profile %s: %w", entry.Name(), err)
+		}
+		fmt.Fprintf(hash, "%s %d\n", entry.Name(), len(data))
+		hash.Write(data)
+	}
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil)), nil
+}
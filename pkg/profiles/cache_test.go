@@ -0,0 +1,110 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package profiles
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+)
+
+func TestSelectionCache(t *testing.T) {
+	const rdmaManifest = "name: RDMA\nplugin: network-operator\nprofileRequirements:\n  deployment: rdma_shared\n"
+	const sriovManifest = "name: SR-IOV\nplugin: network-operator\nprofileRequirements:\n  deployment: sriov\n"
+
+	requirements := &config.Profile{Fabric: "ethernet", Deployment: "sriov"}
+	capabilities := &config.ClusterCapabilities{Nodes: &config.NodesCapabilities{Sriov: true, Rdma: true, Count: 2}}
+
+	setup := func(t *testing.T) (string, string) {
+		profilesDir := t.TempDir()
+		writeProfileFiles(t, profilesDir, "10-rdma", map[string]string{"profile.yaml": rdmaManifest})
+		writeProfileFiles(t, profilesDir, "20-sriov", map[string]string{"profile.yaml": sriovManifest})
+		return profilesDir, filepath.Join(t.TempDir(), "cache", "profiles.yaml")
+	}
+
+	find := func(t *testing.T, cachePath, profilesDir string, requirements *config.Profile) (*Profile, bool) {
+		t.Helper()
+		cache, err := OpenSelectionCache(cachePath, profilesDir)
+		require.NoError(t, err)
+		profile, cached, err := cache.FindApplicableProfile(requirements, capabilities, "network-operator")
+		require.NoError(t, err)
+		require.NoError(t, cache.Save())
+		return profile, cached
+	}
+
+	t.Run("reuse the cached profile", func(t *testing.T) {
+		profilesDir, cachePath := setup(t)
+
+		profile, cached := find(t, cachePath, profilesDir, requirements)
+		assert.False(t, cached)
+		assert.Equal(t, "SR-IOV", profile.Name)
+		assert.FileExists(t, cachePath)
+
+		profile, cached = find(t, cachePath, profilesDir, requirements)
+		assert.True(t, cached)
+		assert.Equal(t, "SR-IOV", profile.Name)
+		assert.Equal(t, filepath.Join(profilesDir, "20-sriov"), profile.Dir)
+	})
+
+	t.Run("other requirements are not cached", func(t *testing.T) {
+		profilesDir, cachePath := setup(t)
+		find(t, cachePath, profilesDir, requirements)
+
+		profile, cached := find(t, cachePath, profilesDir, &config.Profile{Fabric: "ethernet", Deployment: "rdma_shared"})
+		assert.False(t, cached)
+		assert.Equal(t, "RDMA", profile.Name)
+	})
+
+	t.Run("drop the cache when a profile changes", func(t *testing.T) {
+		profilesDir, cachePath := setup(t)
+		find(t, cachePath, profilesDir, requirements)
+
+		changed := sriovManifest + "description: tuned SR-IOV\n"
+		require.NoError(t, os.WriteFile(filepath.Join(profilesDir, "20-sriov", "profile.yaml"), []byte(changed), 0644))
+		profile, cached := find(t, cachePath, profilesDir, requirements)
+		assert.False(t, cached)
+		assert.Equal(t, "tuned SR-IOV", profile.Description)
+
+		_, cached = find(t, cachePath, profilesDir, requirements)
+		assert.True(t, cached)
+	})
+
+	t.Run("drop the cache when a profile is added", func(t *testing.T) {
+		profilesDir, cachePath := setup(t)
+		find(t, cachePath, profilesDir, requirements)
+
+		writeProfileFiles(t, profilesDir, "01-sriov", map[string]string{"profile.yaml": "name: New SR-IOV\nplugin: network-operator\nprofileRequirements:\n  deployment: sriov\n"})
+		profile, cached := find(t, cachePath, profilesDir, requirements)
+		assert.False(t, cached)
+		assert.Equal(t, "New SR-IOV", profile.Name)
+	})
+
+	t.Run("invalid cache file", func(t *testing.T) {
+		profilesDir, cachePath := setup(t)
+		require.NoError(t, os.MkdirAll(filepath.Dir(cachePath), 0755))
+		require.NoError(t, os.WriteFile(cachePath, []byte("selections: [\n"), 0644))
+
+		_, err := OpenSelectionCache(cachePath, profilesDir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse profile cache")
+	})
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package profiles
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// EnvironmentsDir is the profile subdirectory holding the environment overlays
+const EnvironmentsDir = "environments"
+
+// Environments returns the names of the environment overlays available in the profile
+func (p *Profile) Environments() ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(p.Dir, EnvironmentsDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	environments := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			environments = append(environments, entry.Name())
+		}
+	}
+	return environments, nil
+}
+
+// ApplyEnvironment merges the templates of the environment overlay over the base templates of the profile.
+// An overlay template replaces the base template with the same file name, other overlay templates are added.
+// Only .yaml and .yml files of the overlay are used as templates.
+func (p *Profile) ApplyEnvironment(environment string) error {
+	environments, err := p.Environments()
+	if err != nil {
+		return fmt.Errorf("failed to list environments of profile %s: %w", p.Name, err)
+	}
+	if !slices.Contains(environments, environment) {
+		if len(environments) == 0 {
+			return fmt.Errorf("unknown environment %q: profile %s has no environments", environment, p.Name)
+		}
+		return fmt.Errorf("unknown environment %q for profile %s, available environments: %s", environment, p.Name, strings.Join(environments, ", "))
+	}
+
+	overlayDir := filepath.Join(p.Dir, EnvironmentsDir, environment)
+	entries, err := os.ReadDir(overlayDir)
+	if err != nil {
+		return fmt.Errorf("failed to read environment %s of profile %s: %w", environment, p.Name, err)
+	}
+
+	overlays := map[string]string{}
+	for _, entry := range entries {
+		if !entry.IsDir() && isTemplateFile(entry.Name()) {
+			overlays[entry.Name()] = filepath.Join(overlayDir, entry.Name())
+		}
+	}
+
+	for i, template := range p.Templates {
+		if overlay, ok := overlays[filepath.Base(template)]; ok {
+			p.Templates[i] = overlay
+			delete(overlays, filepath.Base(template))
+		}
+	}
+
+	added := make([]string, 0, len(overlays))
+	for _, overlay := range overlays {
+		added = append(added, overlay)
+	}
+	slices.Sort(added)
+	p.Templates = append(p.Templates, added...)
+
+	return nil
+}
+
+func isTemplateFile(name string) bool {
+	ext := filepath.Ext(name)
+	return ext == ".yaml" || ext == ".yml"
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package profiles
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyEnvironment(t *testing.T) {
+	dir := writeProfileFiles(t, t.TempDir(), "sriov", map[string]string{
+		"profile.yaml":                     testProfileManifest,
+		"10-policy.yaml":                   "base",
+		"environments/prod/10-policy.yaml": "prod",
+		"environments/prod/90-extra.yaml":  "extra",
+		"environments/dev/README.md":       "dev notes",
+	})
+	newProfile := func() *Profile {
+		profile := &Profile{Name: "sriov", Templates: []string{"10-policy.yaml"}}
+		profile.UpdateManifestsPaths(dir)
+		return profile
+	}
+
+	environments, err := newProfile().Environments()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dev", "prod"}, environments)
+
+	t.Run("overlay replaces and adds templates", func(t *testing.T) {
+		profile := newProfile()
+		require.NoError(t, profile.ApplyEnvironment("prod"))
+		assert.Equal(t, []string{
+			filepath.Join(dir, "environments", "prod", "10-policy.yaml"),
+			filepath.Join(dir, "environments", "prod", "90-extra.yaml"),
+		}, profile.Templates)
+	})
+
+	t.Run("unknown environment lists the available ones", func(t *testing.T) {
+		err := newProfile().ApplyEnvironment("staging")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown environment "staging" for profile sriov, available environments: dev, prod`)
+	})
+
+	t.Run("profile without environments", func(t *testing.T) {
+		profile := &Profile{Name: "plain", Dir: t.TempDir()}
+		err := profile.ApplyEnvironment("prod")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "profile plain has no environments")
+	})
+}
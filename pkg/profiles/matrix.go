@@ -0,0 +1,71 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package profiles
+
+import "strings"
+
+// Unconstrained marks a matrix cell the profile places no requirement on
+const Unconstrained = "any"
+
+// matrixHeaders are the columns of the compatibility matrix
+var matrixHeaders = []string{"PROFILE", "PLUGIN", "FABRIC", "DEPLOYMENT", "MULTIRAIL", "SPECTRUM-X", "AI", "SRIOV", "RDMA", "IB"}
+
+// CompatibilityMatrix lists, per profile, the profile requirements and cluster capabilities it supports
+type CompatibilityMatrix struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// BuildCompatibilityMatrix derives the compatibility matrix of the profiles from their profile.yaml.
+// Constrained fields hold the required value, the others are Unconstrained.
+func BuildCompatibilityMatrix(available []Profile) CompatibilityMatrix {
+	matrix := CompatibilityMatrix{Headers: matrixHeaders}
+	for _, p := range available {
+		requirements := p.ProfileRequirements
+		matrix.Rows = append(matrix.Rows, []string{
+			p.Name,
+			p.Plugin,
+			stringCell(requirements.Fabric),
+			stringCell(requirements.Deployment),
+			boolCell(requirements.Multirail),
+			boolCell(requirements.SpectrumX),
+			boolCell(requirements.Ai),
+			boolCell(p.NodeCapabilities.Sriov),
+			boolCell(p.NodeCapabilities.Rdma),
+			boolCell(p.NodeCapabilities.Ib),
+		})
+	}
+	return matrix
+}
+
+func stringCell(value string) string {
+	if strings.TrimSpace(value) == "" {
+		return Unconstrained
+	}
+	return value
+}
+
+func boolCell(value *bool) string {
+	switch {
+	case value == nil:
+		return Unconstrained
+	case *value:
+		return "yes"
+	default:
+		return "no"
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package profiles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCompatibilityMatrix(t *testing.T) {
+	yes, no := true, false
+	matrix := BuildCompatibilityMatrix([]Profile{
+		{
+			Name:                "SR-IOV Infiniband RDMA",
+			Plugin:              "network-operator",
+			ProfileRequirements: ProfileRequirements{Fabric: "infiniband", Deployment: "sriov"},
+			NodeCapabilities:    NodeCapabilities{Rdma: &yes, Ib: &yes},
+		},
+		{
+			Name:                "Host device RDMA",
+			Plugin:              "network-operator",
+			ProfileRequirements: ProfileRequirements{Deployment: "host_device", Multirail: &no},
+			NodeCapabilities:    NodeCapabilities{Rdma: &yes},
+		},
+	})
+
+	assert.Equal(t, []string{"PROFILE", "PLUGIN", "FABRIC", "DEPLOYMENT", "MULTIRAIL", "SPECTRUM-X", "AI", "SRIOV", "RDMA", "IB"}, matrix.Headers)
+	require.Len(t, matrix.Rows, 2)
+	assert.Equal(t, []string{"SR-IOV Infiniband RDMA", "network-operator", "infiniband", "sriov", "any", "any", "any", "any", "yes", "yes"}, matrix.Rows[0])
+	assert.Equal(t, []string{"Host device RDMA", "network-operator", "any", "host_device", "no", "any", "any", "any", "yes", "any"}, matrix.Rows[1])
+
+	t.Run("rows of the bundled profiles", func(t *testing.T) {
+		available, err := LoadProfilesDir("../../profiles")
+		require.NoError(t, err)
+
+		matrix := BuildCompatibilityMatrix(available)
+		require.Len(t, matrix.Rows, len(available))
+		for i, row := range matrix.Rows {
+			assert.Equal(t, available[i].Name, row[0])
+			assert.Len(t, row, len(matrix.Headers))
+		}
+	})
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package profiles
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// MergeProfilesDirs copies the profiles of dirs into a new temporary directory and returns it. Directories are
+// searched in order: a profile whose directory or name is provided by an earlier directory is shadowed.
+// The caller removes the returned directory.
+func MergeProfilesDirs(dirs []string) (string, error) {
+	merged, err := os.MkdirTemp("", "l8k-profiles-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create profiles directory: %w", err)
+	}
+
+	// Directory and profile names -> the directory providing them
+	providers := map[string]string{}
+	for _, dir := range dirs {
+		available, err := LoadProfilesDir(dir)
+		if err != nil {
+			os.RemoveAll(merged)
+			return "", fmt.Errorf("failed to load profiles from %s: %w", dir, err)
+		}
+		for _, profile := range available {
+			name := filepath.Base(profile.Dir)
+			if provider, ok := providers[name]; ok {
+				log.Log.Info("Profile shadowed", "profile", name, "profilesDir", dir, "by", provider)
+				continue
+			}
+			if provider, ok := providers[profile.Name]; ok {
+				log.Log.Info("Profile shadowed", "profile", profile.Name, "profilesDir", dir, "by", provider)
+				continue
+			}
+			if err := copyDir(profile.Dir, filepath.Join(merged, name)); err != nil {
+				os.RemoveAll(merged)
+				return "", fmt.Errorf("failed to copy profile %s: %w", profile.Dir, err)
+			}
+			providers[name] = dir
+			providers[profile.Name] = dir
+		}
+	}
+	return merged, nil
+}
+
+// copyDir copies the directories and files under src to dst
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if entry.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, info.Mode().Perm())
+	})
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package profiles
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeProfile writes a profile manifest and its files in the dirName directory of profilesDir
+func writeProfile(t *testing.T, profilesDir, dirName, manifest string, files map[string]string) {
+	t.Helper()
+	files["profile.yaml"] = manifest
+	for name, content := range files {
+		path := filepath.Join(profilesDir, dirName, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestMergeProfilesDirs(t *testing.T) {
+	teamDir, orgDir := t.TempDir(), t.TempDir()
+	writeProfile(t, orgDir, "sriov-ethernet-rdma", "name: SR-IOV\nplugin: network-operator\ntemplates:\n  - 30-network.yaml\n", map[string]string{"30-network.yaml": "org"})
+	writeProfile(t, orgDir, "host-device-rdma", "name: Host device\nplugin: network-operator\n", map[string]string{})
+	writeProfile(t, orgDir, "macvlan", "name: Macvlan\nplugin: network-operator\n", map[string]string{})
+	writeProfile(t, teamDir, "sriov-ethernet-rdma", "name: Team SR-IOV\nplugin: network-operator\ntemplates:\n  - 30-network.yaml\n", map[string]string{
+		"30-network.yaml":                   "team",
+		"environments/prod/30-network.yaml": "team prod",
+	})
+	writeProfile(t, teamDir, "team-macvlan", "name: Macvlan\nplugin: network-operator\n", map[string]string{})
+
+	merged, err := MergeProfilesDirs([]string{teamDir, orgDir})
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(merged) })
+
+	available, err := LoadProfilesDir(merged)
+	require.NoError(t, err)
+	names := []string{}
+	for _, profile := range available {
+		names = append(names, filepath.Base(profile.Dir)+"="+profile.Name)
+	}
+	assert.Equal(t, []string{"host-device-rdma=Host device", "sriov-ethernet-rdma=Team SR-IOV", "team-macvlan=Macvlan"}, names,
+		"earlier directories shadow profiles with the same directory or name")
+
+	t.Run("copy every file of the winning profile", func(t *testing.T) {
+		data, err := os.ReadFile(filepath.Join(merged, "sriov-ethernet-rdma", "30-network.yaml"))
+		require.NoError(t, err)
+		assert.Equal(t, "team", string(data))
+		data, err = os.ReadFile(filepath.Join(merged, "sriov-ethernet-rdma", "environments", "prod", "30-network.yaml"))
+		require.NoError(t, err)
+		assert.Equal(t, "team prod", string(data))
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		_, err := MergeProfilesDirs([]string{teamDir, filepath.Join(orgDir, "missing")})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to load profiles from")
+	})
+}
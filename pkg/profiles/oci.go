@@ -0,0 +1,400 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package profiles
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/secrets"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// OCIScheme prefixes profile references that are pulled from an OCI registry
+const OCIScheme = "oci://"
+
+// Media types accepted for the profile bundle layer
+const (
+	ProfileBundleMediaType = "application/vnd.nvidia.l8k.profiles.v1.tar+gzip"
+	ociLayerMediaType      = "application/vnd.oci.image.layer.v1.tar+gzip"
+	ociManifestMediaType   = "application/vnd.oci.image.manifest.v1+json"
+)
+
+// MaxOCIBlobSize bounds the manifests and layers read from a registry, and the files unpacked from a profile bundle.
+// Profile bundles hold a few templates, a larger read is a registry error or a decompression bomb.
+const MaxOCIBlobSize = 64 << 20
+
+// OCIOptions configures how profile bundles are pulled from a registry
+type OCIOptions struct {
+	// PlainHTTP talks to the registry over http instead of https
+	PlainHTTP bool
+	// Secrets provides the registry credentials, looked up with secrets.RegistryCredentials
+	Secrets secrets.Provider
+	// HTTPClient is used for registry requests, http.DefaultClient when nil
+	HTTPClient *http.Client
+}
+
+// OCIReference is a parsed oci://registry/repository[:tag|@digest] reference
+type OCIReference struct {
+	Registry   string
+	Repository string
+	// Reference is the tag or digest of the manifest
+	Reference string
+}
+
+type ociDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+type ociManifest struct {
+	MediaType string          `json:"mediaType"`
+	Layers    []ociDescriptor `json:"layers"`
+}
+
+// IsOCIReference reports whether the profiles location is an oci:// reference
+func IsOCIReference(location string) bool {
+	return strings.HasPrefix(location, OCIScheme)
+}
+
+// ParseOCIReference parses an oci://registry/repository[:tag|@digest] reference, the tag defaults to latest
+func ParseOCIReference(ref string) (*OCIReference, error) {
+	if !IsOCIReference(ref) {
+		return nil, fmt.Errorf("invalid OCI reference %q: must start with %s", ref, OCIScheme)
+	}
+
+	registry, name, found := strings.Cut(strings.TrimPrefix(ref, OCIScheme), "/")
+	if !found || registry == "" || name == "" {
+		return nil, fmt.Errorf("invalid OCI reference %q: expected %sregistry/repository[:tag]", ref, OCIScheme)
+	}
+
+	parsed := &OCIReference{Registry: registry, Repository: name, Reference: "latest"}
+	// The digest of repository:tag@digest wins over the tag, the tag is only informative then
+	repository, digest, hasDigest := strings.Cut(name, "@")
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		parsed.Repository, parsed.Reference = repository[:i], repository[i+1:]
+	} else {
+		parsed.Repository = repository
+	}
+	if hasDigest {
+		parsed.Reference = digest
+	}
+
+	if parsed.Repository == "" || parsed.Reference == "" {
+		return nil, fmt.Errorf("invalid OCI reference %q: empty repository or tag", ref)
+	}
+
+	return parsed, nil
+}
+
+// String returns the reference in its oci:// form
+func (r *OCIReference) String() string {
+	separator := ":"
+	if strings.HasPrefix(r.Reference, "sha256:") {
+		separator = "@"
+	}
+	return OCIScheme + r.Registry + "/" + r.Repository + separator + r.Reference
+}
+
+// PullOCIProfiles pulls the profile bundle referenced by ref and unpacks it into a new temporary directory.
+// The returned directory can be passed to FindApplicableProfileInDir; the caller is responsible for removing it.
+// A bundle with profile.yaml at its root is unpacked as a single profile named after the repository.
+func PullOCIProfiles(ctx context.Context, ref string, opts OCIOptions) (string, error) {
+	parsed, err := ParseOCIReference(ref)
+	if err != nil {
+		return "", err
+	}
+
+	log.Log.Info("Pulling profiles bundle", "reference", parsed.String())
+
+	puller := &ociPuller{ref: parsed, opts: opts}
+	if puller.opts.HTTPClient == nil {
+		puller.opts.HTTPClient = http.DefaultClient
+	}
+
+	manifest, err := puller.fetchManifest(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch manifest for %s: %w", parsed, err)
+	}
+
+	var layer *ociDescriptor
+	for i := range manifest.Layers {
+		if manifest.Layers[i].MediaType == ProfileBundleMediaType || manifest.Layers[i].MediaType == ociLayerMediaType {
+			layer = &manifest.Layers[i]
+			break
+		}
+	}
+	if layer == nil {
+		return "", fmt.Errorf("manifest for %s has no %s layer", parsed, ProfileBundleMediaType)
+	}
+
+	blob, err := puller.fetchBlob(ctx, layer.Digest)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch profiles bundle %s: %w", layer.Digest, err)
+	}
+
+	dir, err := os.MkdirTemp("", "l8k-profiles-")
+	if err != nil {
+		return "", err
+	}
+
+	if err := unpackProfilesBundle(blob, dir, path.Base(parsed.Repository)); err != nil {
+		os.RemoveAll(dir)
+		return "", fmt.Errorf("failed to unpack profiles bundle %s: %w", parsed, err)
+	}
+
+	return dir, nil
+}
+
+type ociPuller struct {
+	ref   *OCIReference
+	opts  OCIOptions
+	token string
+}
+
+func (p *ociPuller) url(kind, reference string) string {
+	scheme := "https"
+	if p.opts.PlainHTTP {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s/v2/%s/%s/%s", scheme, p.ref.Registry, p.ref.Repository, kind, reference)
+}
+
+func (p *ociPuller) fetchManifest(ctx context.Context) (*ociManifest, error) {
+	data, err := p.get(ctx, p.url("manifests", p.ref.Reference), ociManifestMediaType)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &ociManifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+func (p *ociPuller) fetchBlob(ctx context.Context, digest string) ([]byte, error) {
+	data, err := p.get(ctx, p.url("blobs", digest), "")
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	if actual := "sha256:" + hex.EncodeToString(sum[:]); actual != digest {
+		return nil, fmt.Errorf("digest mismatch: expected %s, got %s", digest, actual)
+	}
+	return data, nil
+}
+
+// get performs a registry GET request, authenticating once if the registry asks for it
+func (p *ociPuller) get(ctx context.Context, target, accept string) ([]byte, error) {
+	resp, err := p.do(ctx, target, accept)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		if err := p.authenticate(ctx, challenge); err != nil {
+			return nil, err
+		}
+		if resp, err = p.do(ctx, target, accept); err != nil {
+			return nil, err
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: unexpected status %s", target, resp.Status)
+	}
+	return readLimited(resp.Body, MaxOCIBlobSize)
+}
+
+// readLimited reads r to the end, failing when it holds more than limit bytes
+func readLimited(r io.Reader, limit int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("content exceeds the maximum size of %d bytes", limit)
+	}
+	return data, nil
+}
+
+func (p *ociPuller) do(ctx context.Context, target, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	} else if username, password, err := secrets.RegistryCredentials(p.opts.Secrets, p.ref.Registry); err != nil {
+		return nil, err
+	} else if username != "" || password != "" {
+		req.SetBasicAuth(username, password)
+	}
+
+	return p.opts.HTTPClient.Do(req)
+}
+
+// authenticate obtains a bearer token for the repository as described by the registry's auth challenge
+func (p *ociPuller) authenticate(ctx context.Context, challenge string) error {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return fmt.Errorf("registry %s rejected the credentials", p.ref.Registry)
+	}
+
+	attributes := map[string]string{}
+	for _, param := range strings.Split(params, ",") {
+		if key, value, found := strings.Cut(strings.TrimSpace(param), "="); found {
+			attributes[key] = strings.Trim(value, `"`)
+		}
+	}
+	if attributes["realm"] == "" {
+		return fmt.Errorf("registry %s sent an auth challenge without realm", p.ref.Registry)
+	}
+
+	query := url.Values{}
+	if service := attributes["service"]; service != "" {
+		query.Set("service", service)
+	}
+	query.Set("scope", fmt.Sprintf("repository:%s:pull", p.ref.Repository))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, attributes["realm"]+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	username, password, err := secrets.RegistryCredentials(p.opts.Secrets, p.ref.Registry)
+	if err != nil {
+		return err
+	}
+	if username != "" || password != "" {
+		req.SetBasicAuth(username, password)
+	}
+
+	resp, err := p.opts.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get token for %s: unexpected status %s", p.ref.Registry, resp.Status)
+	}
+
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return fmt.Errorf("failed to parse token response: %w", err)
+	}
+
+	p.token = token.Token
+	if p.token == "" {
+		p.token = token.AccessToken
+	}
+	if p.token == "" {
+		return fmt.Errorf("registry %s returned an empty token", p.ref.Registry)
+	}
+	return nil
+}
+
+// unpackProfilesBundle extracts a gzipped tarball into dir
+func unpackProfilesBundle(blob []byte, dir, singleProfileName string) error {
+	entries, err := readTarGz(blob)
+	if err != nil {
+		return err
+	}
+
+	// A bundle holding a single profile is placed in its own subdirectory so that
+	// dir keeps the profiles directory layout expected by the matching logic
+	if _, ok := entries["profile.yaml"]; ok {
+		dir = filepath.Join(dir, singleProfileName)
+	}
+
+	for name, content := range entries {
+		target := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, content, 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readTarGz returns the regular files of a gzipped tarball keyed by their cleaned relative path
+func readTarGz(blob []byte) (map[string][]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(blob))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	entries := map[string][]byte{}
+	reader := tar.NewReader(gz)
+	remaining := int64(MaxOCIBlobSize)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := filepath.Clean(header.Name)
+		if !filepath.IsLocal(name) {
+			return nil, fmt.Errorf("bundle entry %q escapes the profiles directory", header.Name)
+		}
+
+		content, err := readLimited(reader, remaining)
+		if err != nil {
+			return nil, fmt.Errorf("bundle entry %q: %w", header.Name, err)
+		}
+		remaining -= int64(len(content))
+		entries[name] = content
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,207 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package profiles
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+	"github.com/nvidia/k8s-launch-kit/pkg/secrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testProfileManifest = `name: oci-sriov
+plugin: network-operator
+description: SR-IOV profile distributed as an OCI artifact
+profileRequirements:
+  fabric: ethernet
+  deployment: sriov
+deploymentGuide: guide.md
+templates:
+- 10-policy.yaml
+`
+
+// buildBundle returns a gzipped tarball with the given files
+func buildBundle(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+// newFakeRegistry serves bundle as the only layer of repository:tag, requiring a bearer token
+// obtained with the given basic auth credentials
+func newFakeRegistry(t *testing.T, repository, tag string, bundle []byte, username, password string) *httptest.Server {
+	t.Helper()
+
+	sum := sha256.Sum256(bundle)
+	digest := "sha256:" + hex.EncodeToString(sum[:])
+	manifest, err := json.Marshal(ociManifest{
+		MediaType: ociManifestMediaType,
+		Layers:    []ociDescriptor{{MediaType: ProfileBundleMediaType, Digest: digest, Size: int64(len(bundle))}},
+	})
+	require.NoError(t, err)
+
+	const token = "test-token"
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			user, pass, ok := r.BasicAuth()
+			if !ok || user != username || pass != password {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			assert.Equal(t, fmt.Sprintf("repository:%s:pull", repository), r.URL.Query().Get("scope"))
+			_ = json.NewEncoder(w).Encode(map[string]string{"token": token})
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="fake"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case fmt.Sprintf("/v2/%s/manifests/%s", repository, tag):
+			w.Header().Set("Content-Type", ociManifestMediaType)
+			_, _ = w.Write(manifest)
+		case fmt.Sprintf("/v2/%s/blobs/%s", repository, digest):
+			_, _ = w.Write(bundle)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestParseOCIReference(t *testing.T) {
+	tests := []struct {
+		ref      string
+		expected OCIReference
+	}{
+		{"oci://nvcr.io/nvidia/l8k-profiles:v1.0", OCIReference{"nvcr.io", "nvidia/l8k-profiles", "v1.0"}},
+		{"oci://localhost:5000/profiles", OCIReference{"localhost:5000", "profiles", "latest"}},
+		{"oci://ghcr.io/org/profiles@sha256:abc", OCIReference{"ghcr.io", "org/profiles", "sha256:abc"}},
+		{"oci://ghcr.io/org/profiles:v1.0@sha256:abc", OCIReference{"ghcr.io", "org/profiles", "sha256:abc"}},
+		{"oci://localhost:5000/profiles:v1.0@sha256:abc", OCIReference{"localhost:5000", "profiles", "sha256:abc"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			parsed, err := ParseOCIReference(tt.ref)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, *parsed)
+		})
+	}
+
+	_, err := ParseOCIReference("nvcr.io/nvidia/profiles")
+	assert.Error(t, err)
+	_, err = ParseOCIReference("oci://nvcr.io")
+	assert.Error(t, err)
+}
+
+func TestPullOCIProfiles(t *testing.T) {
+	bundle := buildBundle(t, map[string]string{
+		"profile.yaml":   testProfileManifest,
+		"guide.md":       "# Guide",
+		"10-policy.yaml": "kind: NicClusterPolicy",
+	})
+	server := newFakeRegistry(t, "nvidia/sriov", "v1", bundle, "robot", "secret")
+	registry := strings.TrimPrefix(server.URL, "http://")
+
+	t.Run("pull and match profile", func(t *testing.T) {
+		dir, err := PullOCIProfiles(context.Background(), "oci://"+registry+"/nvidia/sriov:v1", OCIOptions{
+			PlainHTTP: true,
+			Secrets: secrets.MapProvider{
+				"registry/" + registry + "/username": "robot",
+				"registry/" + registry + "/password": "secret",
+			},
+		})
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		profile, err := FindApplicableProfileInDir(dir,
+			&config.Profile{Fabric: "ethernet", Deployment: "sriov"},
+			&config.ClusterCapabilities{},
+			"network-operator")
+		require.NoError(t, err)
+		assert.Equal(t, "oci-sriov", profile.Name)
+		assert.Equal(t, []string{filepath.Join(dir, "sriov", "10-policy.yaml")}, profile.Templates)
+
+		data, err := os.ReadFile(profile.Templates[0])
+		require.NoError(t, err)
+		assert.Equal(t, "kind: NicClusterPolicy", string(data))
+	})
+
+	t.Run("wrong credentials", func(t *testing.T) {
+		_, err := PullOCIProfiles(context.Background(), "oci://"+registry+"/nvidia/sriov:v1", OCIOptions{PlainHTTP: true})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get token")
+	})
+
+	t.Run("unknown tag", func(t *testing.T) {
+		_, err := PullOCIProfiles(context.Background(), "oci://"+registry+"/nvidia/sriov:v2", OCIOptions{
+			PlainHTTP: true,
+			Secrets: secrets.MapProvider{
+				"registry/" + registry + "/username": "robot",
+				"registry/" + registry + "/password": "secret",
+			},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "404")
+	})
+}
+
+func TestReadLimited(t *testing.T) {
+	data, err := readLimited(strings.NewReader("12345"), 5)
+	require.NoError(t, err)
+	assert.Equal(t, "12345", string(data))
+
+	_, err = readLimited(strings.NewReader("123456"), 5)
+	assert.EqualError(t, err, "content exceeds the maximum size of 5 bytes")
+}
+
+func TestUnpackProfilesBundleRejectsEscapingPaths(t *testing.T) {
+	bundle := buildBundle(t, map[string]string{"../evil.yaml": "kind: Pod"})
+	err := unpackProfilesBundle(bundle, t.TempDir(), "profiles")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "escapes")
+}
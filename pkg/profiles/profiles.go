@@ -21,6 +21,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/nvidia/k8s-launch-kit/pkg/config"
 	"gopkg.in/yaml.v2"
@@ -33,6 +35,8 @@ type ProfileRequirements struct {
 	Multirail  *bool  `yaml:"multirail"`
 	SpectrumX  *bool  `yaml:"spectrumX"`
 	Ai         *bool  `yaml:"ai"`
+	// MinNodes is the minimum number of NIC-equipped nodes the profile needs, unconstrained when zero
+	MinNodes int `yaml:"minNodes"`
 }
 
 type NodeCapabilities struct {
@@ -42,20 +46,35 @@ type NodeCapabilities struct {
 }
 
 type Profile struct {
-	Name                string
-	Plugin              string
-	Description         string
+	Name                string              `yaml:"name"`
+	Plugin              string              `yaml:"plugin"`
+	Description         string              `yaml:"description"`
 	ProfileRequirements ProfileRequirements `yaml:"profileRequirements"`
 	NodeCapabilities    NodeCapabilities    `yaml:"nodeCapabilities"`
-	DeploymentGuide     string
-	Templates           []string
+	DeploymentGuide     string              `yaml:"deploymentGuide"`
+	Templates           []string            `yaml:"templates"`
+	// ApplyOrder lists templates whose rendered files are applied first, in the given order
+	ApplyOrder []string `yaml:"applyOrder"`
+	// RequiredPlugins lists the plugins, besides Plugin, that must be enabled to generate the profile
+	RequiredPlugins []string `yaml:"requiredPlugins"`
+	// Dir is the directory the profile was loaded from
+	Dir string `yaml:"-"`
 }
 
 const ProfilesDir = "profiles"
 
+// ErrNoApplicableProfile is returned when no profile matches the requirements and capabilities
+var ErrNoApplicableProfile = errors.New("no applicable profile found")
+
+// FindApplicableProfile finds the first profile in ProfilesDir matching the requirements and capabilities
 func FindApplicableProfile(requirements *config.Profile, capabilities *config.ClusterCapabilities, pluginName string) (*Profile, error) {
-	log.Log.Info("Finding applicable profile", "requirements", requirements)
-	entries, err := os.ReadDir(ProfilesDir)
+	return FindApplicableProfileInDir(ProfilesDir, requirements, capabilities, pluginName)
+}
+
+// FindApplicableProfileInDir finds the first profile in profilesDir matching the requirements and capabilities
+func FindApplicableProfileInDir(profilesDir string, requirements *config.Profile, capabilities *config.ClusterCapabilities, pluginName string) (*Profile, error) {
+	log.Log.Info("Finding applicable profile", "requirements", requirements, "profilesDir", profilesDir)
+	entries, err := os.ReadDir(profilesDir)
 	if err != nil {
 		return nil, err
 	}
@@ -66,7 +85,7 @@ func FindApplicableProfile(requirements *config.Profile, capabilities *config.Cl
 
 	for _, entry := range entries {
 		if entry.IsDir() {
-			profileManifest := filepath.Join(ProfilesDir, entry.Name(), "profile.yaml")
+			profileManifest := filepath.Join(profilesDir, entry.Name(), "profile.yaml")
 			profileData, err := os.ReadFile(profileManifest)
 			if err != nil {
 				log.Log.Error(err, "failed to read profile manifest", "profileManifest", profileManifest)
@@ -84,7 +103,7 @@ func FindApplicableProfile(requirements *config.Profile, capabilities *config.Cl
 			valid, reason := profile.Validate(requirements, capabilities)
 			if valid {
 				log.Log.V(1).Info("Found applicable profile", "profile", profile)
-				profile.UpdateManifestsPaths(filepath.Join(ProfilesDir, entry.Name()))
+				profile.UpdateManifestsPaths(filepath.Join(profilesDir, entry.Name()))
 				return profile, nil
 			} else {
 				errorMessages = append(errorMessages, fmt.Sprintf("profile %s is not applicable: %s", entry.Name(), reason))
@@ -97,7 +116,132 @@ func FindApplicableProfile(requirements *config.Profile, capabilities *config.Cl
 		log.Log.Error(errors.New(errorMessage), "errorMessage")
 	}
 
-	return nil, errors.New("no applicable profile found")
+	return nil, ErrNoApplicableProfile
+}
+
+// Matching returns every profile in ProfilesDir matching the requirements and capabilities
+func Matching(requirements *config.Profile, capabilities *config.ClusterCapabilities) ([]*Profile, error) {
+	return MatchingInDir(ProfilesDir, requirements, capabilities)
+}
+
+// MatchingInDir returns every profile in profilesDir, of any plugin, that Validate accepts for the requirements and
+// capabilities, in directory name order. The profiles are returned as in their profile.yaml with only Dir set, their
+// templates and deployment guide staying relative to Dir. Missing capabilities match no node capability.
+func MatchingInDir(profilesDir string, requirements *config.Profile, capabilities *config.ClusterCapabilities) ([]*Profile, error) {
+	if capabilities == nil || capabilities.Nodes == nil {
+		capabilities = &config.ClusterCapabilities{Nodes: &config.NodesCapabilities{}}
+	}
+
+	entries, err := os.ReadDir(profilesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	matching := []*Profile{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		profile, err := readProfileManifest(profilesDir, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		if valid, _ := profile.Validate(requirements, capabilities); valid {
+			matching = append(matching, profile)
+		}
+	}
+	return matching, nil
+}
+
+// FindFallbackProfile returns the profile of pluginName in profilesDir whose directory or name is name.
+// The profile must pass schema validation, as it is used without checking its requirements.
+func FindFallbackProfile(profilesDir, name, pluginName string) (*Profile, error) {
+	return findNamedProfile(profilesDir, name, pluginName, "fallback")
+}
+
+// FindForcedProfile returns the profile of pluginName in profilesDir whose directory or name is name.
+// Like a fallback profile, it must pass schema validation as its requirements are not matched.
+func FindForcedProfile(profilesDir, name, pluginName string) (*Profile, error) {
+	return findNamedProfile(profilesDir, name, pluginName, "forced")
+}
+
+// findNamedProfile looks up a profile by directory or name, kind describes the profile in errors
+func findNamedProfile(profilesDir, name, pluginName, kind string) (*Profile, error) {
+	available, err := LoadProfilesDir(profilesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range available {
+		profile := &available[i]
+		if profile.Plugin != pluginName || (filepath.Base(profile.Dir) != name && profile.Name != name) {
+			continue
+		}
+		if result := ValidateProfileSchema(profile.Dir); !result.Passed() {
+			return nil, fmt.Errorf("%s profile %s is invalid: %s", kind, name, strings.Join(result.Errors, "; "))
+		}
+		return profile, nil
+	}
+
+	return nil, fmt.Errorf("%s profile %s not found in %s for plugin %s", kind, name, profilesDir, pluginName)
+}
+
+// MissingPlugins returns the required plugins of the profile that are not in enabled
+func (p *Profile) MissingPlugins(enabled []string) []string {
+	missing := []string{}
+	for _, name := range p.RequiredPlugins {
+		if !slices.Contains(enabled, name) {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// LoadProfilesDir loads the manifests of all profiles in profilesDir, in directory name order
+func LoadProfilesDir(profilesDir string) ([]Profile, error) {
+	entries, err := os.ReadDir(profilesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var profiles []Profile
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		profile, err := loadProfile(profilesDir, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, *profile)
+	}
+
+	return profiles, nil
+}
+
+// loadProfile loads the manifest of the profile in the name directory of profilesDir
+func loadProfile(profilesDir, name string) (*Profile, error) {
+	profile, err := readProfileManifest(profilesDir, name)
+	if err != nil {
+		return nil, err
+	}
+	profile.UpdateManifestsPaths(profile.Dir)
+	return profile, nil
+}
+
+// readProfileManifest reads the manifest of the profile in the name directory of profilesDir, setting only its Dir
+func readProfileManifest(profilesDir, name string) (*Profile, error) {
+	profileManifest := filepath.Join(profilesDir, name, "profile.yaml")
+	profileData, err := os.ReadFile(profileManifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profile manifest %s: %w", profileManifest, err)
+	}
+	profile := &Profile{}
+	if err := yaml.Unmarshal(profileData, profile); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal profile manifest %s: %w", profileManifest, err)
+	}
+	profile.Dir = filepath.Join(profilesDir, name)
+	return profile, nil
 }
 
 func (p *Profile) Validate(requirements *config.Profile, capabilities *config.ClusterCapabilities) (bool, string) {
@@ -133,6 +277,16 @@ func (p *Profile) Validate(requirements *config.Profile, capabilities *config.Cl
 		}
 	}
 
+	if p.ProfileRequirements.MinNodes > 0 {
+		nodes := 0
+		if capabilities != nil && capabilities.Nodes != nil {
+			nodes = capabilities.Nodes.Count
+		}
+		if nodes < p.ProfileRequirements.MinNodes {
+			return false, fmt.Sprintf("profile requires at least %d NIC-equipped node(s), cluster has %d", p.ProfileRequirements.MinNodes, nodes)
+		}
+	}
+
 	if p.NodeCapabilities.Sriov != nil && *p.NodeCapabilities.Sriov != capabilities.Nodes.Sriov {
 		return false, fmt.Sprintf("cluster sriov capability does not match profile requirements: %t", *p.NodeCapabilities.Sriov)
 	}
@@ -146,8 +300,18 @@ func (p *Profile) Validate(requirements *config.Profile, capabilities *config.Cl
 	return true, ""
 }
 
+// ApplyOrderFiles returns the names of the rendered files of the ApplyOrder templates
+func (p *Profile) ApplyOrderFiles() []string {
+	files := make([]string, 0, len(p.ApplyOrder))
+	for _, template := range p.ApplyOrder {
+		files = append(files, filepath.Base(template))
+	}
+	return files
+}
+
 // UpdateManifestsPaths appends the directory path to the templates and deployment guide
 func (p *Profile) UpdateManifestsPaths(dirPath string) {
+	p.Dir = dirPath
 	for i := range p.Templates {
 		p.Templates[i] = filepath.Join(dirPath, p.Templates[i])
 	}
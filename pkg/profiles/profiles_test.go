@@ -0,0 +1,106 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package profiles
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nvidia/k8s-launch-kit/pkg/config"
+)
+
+func TestValidateMinNodes(t *testing.T) {
+	profile := &Profile{Name: "multirail", ProfileRequirements: ProfileRequirements{MinNodes: 2}}
+	requirements := &config.Profile{Fabric: "ethernet", Deployment: "sriov"}
+	capabilities := func(count int) *config.ClusterCapabilities {
+		return &config.ClusterCapabilities{Nodes: &config.NodesCapabilities{Count: count}}
+	}
+
+	t.Run("enough nodes", func(t *testing.T) {
+		for _, count := range []int{2, 3} {
+			ok, reason := profile.Validate(requirements, capabilities(count))
+			assert.True(t, ok, reason)
+			assert.Empty(t, reason)
+		}
+	})
+
+	t.Run("not enough nodes", func(t *testing.T) {
+		ok, reason := profile.Validate(requirements, capabilities(1))
+		assert.False(t, ok)
+		assert.Equal(t, "profile requires at least 2 NIC-equipped node(s), cluster has 1", reason)
+	})
+
+	t.Run("node count not discovered", func(t *testing.T) {
+		ok, reason := profile.Validate(requirements, &config.ClusterCapabilities{})
+		assert.False(t, ok)
+		assert.Equal(t, "profile requires at least 2 NIC-equipped node(s), cluster has 0", reason)
+	})
+
+	t.Run("unconstrained profile", func(t *testing.T) {
+		ok, _ := (&Profile{Name: "any"}).Validate(requirements, capabilities(0))
+		assert.True(t, ok)
+	})
+}
+
+func TestMissingPlugins(t *testing.T) {
+	profile := &Profile{Name: "storage", Plugin: "network-operator", RequiredPlugins: []string{"network-operator", "gpu-operator"}}
+
+	assert.Equal(t, []string{"gpu-operator"}, profile.MissingPlugins([]string{"network-operator"}))
+	assert.Empty(t, profile.MissingPlugins([]string{"gpu-operator", "network-operator"}))
+	assert.Empty(t, (&Profile{Name: "any"}).MissingPlugins(nil))
+}
+
+func TestMatching(t *testing.T) {
+	capabilities := func(nodes config.NodesCapabilities) *config.ClusterCapabilities {
+		return &config.ClusterCapabilities{Nodes: &nodes}
+	}
+	names := func(t *testing.T, requirements *config.Profile, capabilities *config.ClusterCapabilities) []string {
+		t.Helper()
+		matching, err := MatchingInDir("../../profiles", requirements, capabilities)
+		require.NoError(t, err)
+		names := []string{}
+		for _, profile := range matching {
+			names = append(names, filepath.Base(profile.Dir))
+		}
+		return names
+	}
+
+	t.Run("match the requirements and capabilities", func(t *testing.T) {
+		assert.Equal(t, []string{"sriov-ethernet-rdma"}, names(t, &config.Profile{Fabric: "ethernet", Deployment: "sriov"}, capabilities(config.NodesCapabilities{Sriov: true, Rdma: true})))
+		assert.Equal(t, []string{"sriov-ib-rdma"}, names(t, &config.Profile{Fabric: "infiniband", Deployment: "sriov"}, capabilities(config.NodesCapabilities{Sriov: true, Rdma: true, Ib: true})))
+		assert.Equal(t, []string{"host-device-rdma"}, names(t, &config.Profile{Fabric: "infiniband", Deployment: "host_device"}, capabilities(config.NodesCapabilities{Rdma: true, Ib: true})))
+	})
+
+	t.Run("match no profile without the capabilities", func(t *testing.T) {
+		assert.Empty(t, names(t, &config.Profile{Fabric: "infiniband", Deployment: "sriov"}, capabilities(config.NodesCapabilities{Sriov: true, Rdma: true})))
+		assert.Empty(t, names(t, &config.Profile{Fabric: "ethernet", Deployment: "sriov"}, nil))
+	})
+
+	t.Run("keep the paths of the manifest", func(t *testing.T) {
+		matching, err := MatchingInDir("../../profiles", &config.Profile{Fabric: "ethernet", Deployment: "rdma_shared"}, capabilities(config.NodesCapabilities{Rdma: true}))
+		require.NoError(t, err)
+		require.Len(t, matching, 1)
+		assert.Equal(t, filepath.Join("../../profiles", "macvlan-rdma-shared"), matching[0].Dir)
+		require.NotEmpty(t, matching[0].Templates)
+		for _, template := range matching[0].Templates {
+			assert.NotContains(t, template, "macvlan-rdma-shared")
+		}
+	})
+}
@@ -0,0 +1,212 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package profiles
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v2"
+)
+
+const profileManifestName = "profile.yaml"
+
+var (
+	supportedFabrics     = []string{"ethernet", "infiniband"}
+	supportedDeployments = []string{"sriov", "rdma_shared", "host_device"}
+)
+
+// ValidationResult holds the problems found in a single profile
+type ValidationResult struct {
+	// Profile is the profile name, or the directory name when profile.yaml can't be read
+	Profile string
+	Dir     string
+	// Errors make the profile unusable
+	Errors []string
+	// Warnings don't prevent the profile from being used
+	Warnings []string
+}
+
+// Passed reports whether the profile has no errors
+func (r *ValidationResult) Passed() bool {
+	return len(r.Errors) == 0
+}
+
+func (r *ValidationResult) errorf(format string, args ...interface{}) {
+	r.Errors = append(r.Errors, fmt.Sprintf(format, args...))
+}
+
+func (r *ValidationResult) warnf(format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// ValidateProfilesDir validates every profile in dir. If dir contains a profile.yaml itself, it is validated as a single profile.
+// Templates are parsed with funcs, which must match the functions available when the profile is rendered.
+func ValidateProfilesDir(dir string, funcs template.FuncMap) ([]ValidationResult, error) {
+	if _, err := os.Stat(filepath.Join(dir, profileManifestName)); err == nil {
+		return []ValidationResult{ValidateProfile(dir, funcs)}, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []ValidationResult{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			results = append(results, ValidateProfile(filepath.Join(dir, entry.Name()), funcs))
+		}
+	}
+
+	if len(results) == 0 {
+		return nil, fmt.Errorf("no profiles found in %s", dir)
+	}
+
+	return results, nil
+}
+
+// ValidateProfile checks the profile.yaml schema in dir, that every referenced template exists and parses, and warns
+// about a missing deployment guide and the files the profile doesn't reference
+func ValidateProfile(dir string, funcs template.FuncMap) ValidationResult {
+	result := ValidationResult{Profile: filepath.Base(dir), Dir: dir}
+
+	profile := validateManifest(dir, &result)
+	if profile == nil {
+		return result
+	}
+
+	referenced := map[string]bool{profileManifestName: true}
+
+	if profile.DeploymentGuide == "" {
+		result.errorf("deploymentGuide is required")
+	} else {
+		referenced[filepath.Clean(profile.DeploymentGuide)] = true
+		if _, err := os.Stat(filepath.Join(dir, profile.DeploymentGuide)); err != nil {
+			result.warnf("deployment guide %s not found", profile.DeploymentGuide)
+		}
+	}
+
+	if len(profile.Templates) == 0 {
+		result.errorf("at least one template is required")
+	}
+	for _, name := range profile.Templates {
+		referenced[filepath.Clean(name)] = true
+
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			result.errorf("template %s not found", name)
+			continue
+		}
+		if _, err := template.New(filepath.Base(name)).Funcs(funcs).Parse(string(content)); err != nil {
+			result.errorf("template %s does not parse: %v", name, err)
+		}
+	}
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if referenced[rel] {
+			return nil
+		}
+		if isEnvironmentTemplate(rel) {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			if _, err := template.New(filepath.Base(rel)).Funcs(funcs).Parse(string(content)); err != nil {
+				result.errorf("template %s does not parse: %v", rel, err)
+			}
+			return nil
+		}
+		result.warnf("file %s is not referenced by the profile", rel)
+		return nil
+	})
+	if err != nil {
+		result.errorf("failed to list profile files: %v", err)
+	}
+
+	return result
+}
+
+// ValidateProfileSchema checks only the profile.yaml schema in dir, without looking at the files it references
+func ValidateProfileSchema(dir string) ValidationResult {
+	result := ValidationResult{Profile: filepath.Base(dir), Dir: dir}
+	validateManifest(dir, &result)
+	return result
+}
+
+// validateManifest reads and checks profile.yaml in dir. It returns nil when the manifest can't be decoded.
+func validateManifest(dir string, result *ValidationResult) *Profile {
+	data, err := os.ReadFile(filepath.Join(dir, profileManifestName))
+	if err != nil {
+		result.errorf("failed to read %s: %v", profileManifestName, err)
+		return nil
+	}
+
+	profile := &Profile{}
+	if err := yaml.UnmarshalStrict(data, profile); err != nil {
+		result.errorf("invalid %s: %v", profileManifestName, err)
+		return nil
+	}
+
+	if profile.Name != "" {
+		result.Profile = profile.Name
+	} else {
+		result.errorf("name is required")
+	}
+	if profile.Plugin == "" {
+		result.errorf("plugin is required")
+	}
+	if fabric := profile.ProfileRequirements.Fabric; fabric != "" && !slices.Contains(supportedFabrics, fabric) {
+		result.errorf("profileRequirements.fabric %q must be one of %v", fabric, supportedFabrics)
+	}
+	if deployment := profile.ProfileRequirements.Deployment; deployment != "" && !slices.Contains(supportedDeployments, deployment) {
+		result.errorf("profileRequirements.deployment %q must be one of %v", deployment, supportedDeployments)
+	}
+	if minNodes := profile.ProfileRequirements.MinNodes; minNodes < 0 {
+		result.errorf("profileRequirements.minNodes %d must not be negative", minNodes)
+	}
+	for _, name := range profile.RequiredPlugins {
+		if name == "" {
+			result.errorf("requiredPlugins entries must not be empty")
+		}
+	}
+	for _, name := range profile.ApplyOrder {
+		if !slices.Contains(profile.Templates, name) {
+			result.errorf("applyOrder entry %s is not a template of the profile", name)
+		}
+	}
+
+	return profile
+}
+
+// isEnvironmentTemplate reports whether the profile-relative path is a template of an environment overlay
+func isEnvironmentTemplate(rel string) bool {
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	return len(parts) == 3 && parts[0] == EnvironmentsDir && isTemplateFile(parts[2])
+}
@@ -0,0 +1,174 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package profiles
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeProfileFiles writes the files of a profile into dir/name
+func writeProfileFiles(t *testing.T, dir, name string, files map[string]string) string {
+	t.Helper()
+
+	profileDir := filepath.Join(dir, name)
+	for file, content := range files {
+		path := filepath.Join(profileDir, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return profileDir
+}
+
+func TestValidateProfilesDir(t *testing.T) {
+	funcs := template.FuncMap{"add": func(a, b int) int { return a + b }}
+	dir := t.TempDir()
+
+	writeProfileFiles(t, dir, "good", map[string]string{
+		"profile.yaml":   testProfileManifest,
+		"guide.md":       "# Guide",
+		"10-policy.yaml": "mtu: {{ add .Mtu 0 }}",
+	})
+	writeProfileFiles(t, dir, "good-with-environments", map[string]string{
+		"profile.yaml":                     testProfileManifest,
+		"guide.md":                         "# Guide",
+		"10-policy.yaml":                   "kind: NicClusterPolicy",
+		"environments/prod/10-policy.yaml": "mtu: {{ add .Mtu 0 }}",
+	})
+	writeProfileFiles(t, dir, "missing-template", map[string]string{
+		"profile.yaml": testProfileManifest,
+		"guide.md":     "# Guide",
+		"notes.txt":    "leftover",
+	})
+
+	results, err := ValidateProfilesDir(dir, funcs)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	for _, good := range results[:2] {
+		assert.Equal(t, "oci-sriov", good.Profile)
+		assert.True(t, good.Passed(), good.Dir)
+		assert.Empty(t, good.Errors)
+		assert.Empty(t, good.Warnings)
+	}
+	assert.Equal(t, filepath.Join(dir, "good"), results[0].Dir)
+
+	broken := results[2]
+	assert.False(t, broken.Passed())
+	assert.Equal(t, []string{"template 10-policy.yaml not found"}, broken.Errors)
+	assert.Equal(t, []string{"file notes.txt is not referenced by the profile"}, broken.Warnings)
+}
+
+func TestValidateProfile(t *testing.T) {
+	t.Run("single profile directory", func(t *testing.T) {
+		dir := writeProfileFiles(t, t.TempDir(), "good", map[string]string{
+			"profile.yaml":   testProfileManifest,
+			"guide.md":       "# Guide",
+			"10-policy.yaml": "kind: NicClusterPolicy",
+		})
+
+		results, err := ValidateProfilesDir(dir, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.True(t, results[0].Passed())
+	})
+
+	t.Run("template does not parse", func(t *testing.T) {
+		dir := writeProfileFiles(t, t.TempDir(), "bad-template", map[string]string{
+			"profile.yaml":   testProfileManifest,
+			"guide.md":       "# Guide",
+			"10-policy.yaml": "mtu: {{ unknown .Mtu }}",
+		})
+
+		result := ValidateProfile(dir, nil)
+		require.Len(t, result.Errors, 1)
+		assert.Contains(t, result.Errors[0], "template 10-policy.yaml does not parse")
+	})
+
+	t.Run("unknown and missing fields", func(t *testing.T) {
+		dir := writeProfileFiles(t, t.TempDir(), "bad-schema", map[string]string{
+			"profile.yaml": "name: test\ntemplate:\n- 10-policy.yaml\n",
+		})
+
+		result := ValidateProfile(dir, nil)
+		assert.Equal(t, "bad-schema", result.Profile)
+		require.Len(t, result.Errors, 1)
+		assert.Contains(t, result.Errors[0], "field template not found")
+	})
+
+	t.Run("unsupported requirements", func(t *testing.T) {
+		dir := writeProfileFiles(t, t.TempDir(), "bad-requirements", map[string]string{
+			"profile.yaml": "name: test\nplugin: network-operator\nprofileRequirements:\n  fabric: roce\n",
+		})
+
+		result := ValidateProfile(dir, nil)
+		assert.Equal(t, []string{
+			`profileRequirements.fabric "roce" must be one of [ethernet infiniband]`,
+			"deploymentGuide is required",
+			"at least one template is required",
+		}, result.Errors)
+	})
+
+	t.Run("negative minimum node count", func(t *testing.T) {
+		dir := writeProfileFiles(t, t.TempDir(), "bad-min-nodes", map[string]string{
+			"profile.yaml":   "name: test\nplugin: network-operator\nprofileRequirements:\n  minNodes: -1\ndeploymentGuide: guide.md\ntemplates:\n- 10-policy.yaml\n",
+			"guide.md":       "# Guide",
+			"10-policy.yaml": "kind: NicClusterPolicy",
+		})
+
+		result := ValidateProfile(dir, nil)
+		assert.Equal(t, []string{"profileRequirements.minNodes -1 must not be negative"}, result.Errors)
+	})
+
+	t.Run("apply order entry is not a template", func(t *testing.T) {
+		dir := writeProfileFiles(t, t.TempDir(), "bad-apply-order", map[string]string{
+			"profile.yaml":   testProfileManifest + "applyOrder:\n- 10-policy.yaml\n- 20-network.yaml\n",
+			"guide.md":       "# Guide",
+			"10-policy.yaml": "kind: NicClusterPolicy",
+		})
+
+		result := ValidateProfile(dir, nil)
+		assert.Equal(t, []string{"applyOrder entry 20-network.yaml is not a template of the profile"}, result.Errors)
+	})
+
+	t.Run("missing deployment guide", func(t *testing.T) {
+		dir := writeProfileFiles(t, t.TempDir(), "no-guide", map[string]string{
+			"profile.yaml":   testProfileManifest,
+			"10-policy.yaml": "kind: NicClusterPolicy",
+		})
+
+		result := ValidateProfile(dir, nil)
+		assert.True(t, result.Passed(), result.Errors)
+		assert.Equal(t, []string{"deployment guide guide.md not found"}, result.Warnings)
+	})
+
+	t.Run("empty required plugin", func(t *testing.T) {
+		dir := writeProfileFiles(t, t.TempDir(), "bad-required-plugins", map[string]string{
+			"profile.yaml":   testProfileManifest + "requiredPlugins:\n- \"\"\n",
+			"guide.md":       "# Guide",
+			"10-policy.yaml": "kind: NicClusterPolicy",
+		})
+
+		result := ValidateProfile(dir, nil)
+		assert.Equal(t, []string{"requiredPlugins entries must not be empty"}, result.Errors)
+	})
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package secrets
+
+import (
+	"errors"
+	"os"
+	"strings"
+)
+
+// ErrNotFound is returned when a provider has no value for the requested secret
+var ErrNotFound = errors.New("secret not found")
+
+// Provider resolves named secrets such as registry credentials
+type Provider interface {
+	Get(name string) (string, error)
+}
+
+// EnvPrefix is prepended to secret names looked up by EnvProvider
+const EnvPrefix = "L8K_"
+
+// EnvProvider resolves secrets from environment variables,
+// e.g. registry/ghcr.io/username is read from L8K_REGISTRY_GHCR_IO_USERNAME
+type EnvProvider struct{}
+
+// Get returns the value of the environment variable for the secret name
+func (EnvProvider) Get(name string) (string, error) {
+	value, ok := os.LookupEnv(EnvVar(name))
+	if !ok {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
+
+// EnvVar returns the environment variable EnvProvider reads the secret name from
+func EnvVar(name string) string {
+	return EnvPrefix + strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+// MapProvider resolves secrets from an in-memory map
+type MapProvider map[string]string
+
+// Get returns the map value for the secret name
+func (m MapProvider) Get(name string) (string, error) {
+	value, ok := m[name]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
+
+// RegistryCredentials returns the username and password stored for an OCI registry host.
+// Both are empty when the provider holds no credentials for the host.
+func RegistryCredentials(p Provider, host string) (string, string, error) {
+	return credentials(p, "registry/"+host)
+}
+
+// GitCredentials returns the username and password stored for a Git server host.
+// Both are empty when the provider holds no credentials for the host.
+func GitCredentials(p Provider, host string) (string, string, error) {
+	return credentials(p, "git/"+host)
+}
+
+func credentials(p Provider, prefix string) (string, string, error) {
+	if p == nil {
+		return "", "", nil
+	}
+
+	username, err := p.Get(prefix + "/username")
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return "", "", err
+	}
+	password, err := p.Get(prefix + "/password")
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return "", "", err
+	}
+
+	return username, password, nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package secrets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvProvider(t *testing.T) {
+	assert.Equal(t, "L8K_REGISTRY_GHCR_IO_USERNAME", EnvVar("registry/ghcr.io/username"))
+
+	t.Setenv("L8K_REGISTRY_GHCR_IO_USERNAME", "robot")
+
+	value, err := EnvProvider{}.Get("registry/ghcr.io/username")
+	require.NoError(t, err)
+	assert.Equal(t, "robot", value)
+
+	_, err = EnvProvider{}.Get("registry/ghcr.io/password")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestRegistryCredentials(t *testing.T) {
+	provider := MapProvider{
+		"registry/nvcr.io/username": "$oauthtoken",
+		"registry/nvcr.io/password": "secret",
+	}
+
+	username, password, err := RegistryCredentials(provider, "nvcr.io")
+	require.NoError(t, err)
+	assert.Equal(t, "$oauthtoken", username)
+	assert.Equal(t, "secret", password)
+
+	username, password, err = RegistryCredentials(provider, "ghcr.io")
+	require.NoError(t, err)
+	assert.Empty(t, username)
+	assert.Empty(t, password)
+}
+
+func TestGitCredentials(t *testing.T) {
+	provider := MapProvider{
+		"git/github.com/username": "x-access-token",
+		"git/github.com/password": "token",
+	}
+
+	username, password, err := GitCredentials(provider, "github.com")
+	require.NoError(t, err)
+	assert.Equal(t, "x-access-token", username)
+	assert.Equal(t, "token", password)
+
+	username, password, err = GitCredentials(provider, "gitlab.com")
+	require.NoError(t, err)
+	assert.Empty(t, username)
+	assert.Empty(t, password)
+}
@@ -17,6 +17,7 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
@@ -25,35 +26,72 @@ import (
 // spinnerChars are the characters used for the spinner animation
 var spinnerChars = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
 
+// ProgressOptions tunes the progress indicators of StandardOutput
+type ProgressOptions struct {
+	// ElapsedThreshold is the time after which the terminal spinner shows the elapsed time
+	ElapsedThreshold time.Duration
+	// FormatElapsed formats the elapsed time shown next to the progress message
+	FormatElapsed func(time.Duration) string
+	// UpdateInterval is how often the elapsed time is printed when the output is not a terminal, e.g. in CI logs.
+	// Periodic updates are disabled when zero.
+	UpdateInterval time.Duration
+}
+
+// DefaultProgressOptions returns the progress options used by StandardOutput unless set otherwise
+func DefaultProgressOptions() ProgressOptions {
+	return ProgressOptions{
+		ElapsedThreshold: 30 * time.Second,
+		FormatElapsed:    formatDuration,
+		UpdateInterval:   30 * time.Second,
+	}
+}
+
 // standardProgress implements Progress for terminal progress indicators
 type standardProgress struct {
 	output    *StandardOutput
+	opts      ProgressOptions
 	message   string
 	done      chan bool
 	mu        sync.Mutex
 	spinIndex int
-	startTime time.Time
-	stopped   bool
+	// now returns the current time, time.Now carries a monotonic clock reading so the elapsed time
+	// isn't affected by wall clock changes
+	now        func() time.Time
+	startTime  time.Time
+	lastUpdate time.Duration
+	stopped    bool
 }
 
-// newProgress creates a new progress indicator
-func newProgress(output *StandardOutput, message string) Progress {
-	p := &standardProgress{
+// newProgress creates a new progress indicator, reported as cancelled when ctx is done before it completes
+func newProgress(ctx context.Context, output *StandardOutput, message string) Progress {
+	p := newStandardProgress(output, message, time.Now)
+
+	// Start the spinner in a goroutine
+	go p.spin(ctx)
+
+	return p
+}
+
+// newStandardProgress creates a progress indicator without starting its spinner
+func newStandardProgress(output *StandardOutput, message string, now func() time.Time) *standardProgress {
+	opts := output.progressOptions
+	if opts.FormatElapsed == nil {
+		opts.FormatElapsed = formatDuration
+	}
+
+	return &standardProgress{
 		output:    output,
+		opts:      opts,
 		message:   message,
 		done:      make(chan bool),
-		startTime: time.Now(),
+		now:       now,
+		startTime: now(),
 		stopped:   false,
 	}
-
-	// Start the spinner in a goroutine
-	go p.spin()
-
-	return p
 }
 
-// spin runs the spinner animation
-func (p *standardProgress) spin() {
+// spin runs the spinner animation until the progress is stopped or ctx is done
+func (p *standardProgress) spin(ctx context.Context) {
 	ticker := time.NewTicker(100 * time.Millisecond)
 	defer ticker.Stop()
 
@@ -61,28 +99,46 @@ func (p *standardProgress) spin() {
 		select {
 		case <-p.done:
 			return
+		case <-ctx.Done():
+			p.cancel()
+			return
 		case <-ticker.C:
-			p.mu.Lock()
-			if p.stopped {
-				p.mu.Unlock()
+			if !p.tick() {
 				return
 			}
+		}
+	}
+}
 
-			elapsed := time.Since(p.startTime)
-			var timeStr string
-			if elapsed > 30*time.Second {
-				// Show elapsed time for long operations
-				timeStr = fmt.Sprintf(" (%s)", formatDuration(elapsed))
-			}
+// tick redraws the spinner, or prints a periodic update when the output is not a terminal.
+// It returns false once the progress is stopped.
+func (p *standardProgress) tick() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 
-			if p.output.isTTY {
-				// Use carriage return and clear line to update same line
-				fmt.Fprintf(p.output.writer, "\r\033[K%s %s%s", spinnerChars[p.spinIndex], p.message, timeStr)
-				p.spinIndex = (p.spinIndex + 1) % len(spinnerChars)
-			}
-			p.mu.Unlock()
+	if p.stopped {
+		return false
+	}
+
+	elapsed := p.now().Sub(p.startTime)
+	if p.output.isTTY {
+		var timeStr string
+		if elapsed >= p.opts.ElapsedThreshold {
+			// Show elapsed time for long operations
+			timeStr = fmt.Sprintf(" (%s)", p.opts.FormatElapsed(elapsed))
 		}
+
+		// Use carriage return and clear line to update same line
+		fmt.Fprintf(p.output.writer, "\r\033[K%s %s%s", spinnerChars[p.spinIndex], p.message, timeStr)
+		p.spinIndex = (p.spinIndex + 1) % len(spinnerChars)
+		return true
+	}
+
+	if p.opts.UpdateInterval > 0 && elapsed-p.lastUpdate >= p.opts.UpdateInterval {
+		p.lastUpdate = elapsed
+		fmt.Fprintf(p.output.writer, "  %s (%s elapsed)\n", p.message, p.opts.FormatElapsed(elapsed))
 	}
+	return true
 }
 
 // Update changes the progress message
@@ -104,27 +160,31 @@ func (p *standardProgress) Update(message string) {
 
 // Success marks the progress as successful
 func (p *standardProgress) Success(message string) {
-	p.stop()
-
-	symbol := "✓"
-	if p.output.colorEnabled {
-		fmt.Fprintf(p.output.writer, "\r\033[K\033[32m%s\033[0m %s\n", symbol, message)
-	} else {
-		if p.output.isTTY {
-			fmt.Fprintf(p.output.writer, "\r\033[K%s %s\n", symbol, message)
-		} else {
-			fmt.Fprintf(p.output.writer, "%s %s\n", symbol, message)
-		}
-	}
+	p.finish("✓", "32", message)
 }
 
 // Fail marks the progress as failed
 func (p *standardProgress) Fail(message string) {
-	p.stop()
+	p.finish("✗", "31", message)
+}
+
+// cancel stops the progress when its context is done
+func (p *standardProgress) cancel() {
+	p.mu.Lock()
+	message := p.message
+	p.mu.Unlock()
+
+	p.finish("✗", "31", message+": cancelled")
+}
+
+// finish stops the progress and prints its result with the symbol in color, unless it is already stopped
+func (p *standardProgress) finish(symbol, color, message string) {
+	if !p.stop() {
+		return
+	}
 
-	symbol := "✗"
 	if p.output.colorEnabled {
-		fmt.Fprintf(p.output.writer, "\r\033[K\033[31m%s\033[0m %s\n", symbol, message)
+		fmt.Fprintf(p.output.writer, "\r\033[K\033[%sm%s\033[0m %s\n", color, symbol, message)
 	} else {
 		if p.output.isTTY {
 			fmt.Fprintf(p.output.writer, "\r\033[K%s %s\n", symbol, message)
@@ -134,13 +194,13 @@ func (p *standardProgress) Fail(message string) {
 	}
 }
 
-// stop stops the progress indicator
-func (p *standardProgress) stop() {
+// stop stops the progress indicator, it returns false when it was already stopped
+func (p *standardProgress) stop() bool {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	if p.stopped {
-		return
+		return false
 	}
 
 	p.stopped = true
@@ -151,6 +211,7 @@ func (p *standardProgress) stop() {
 		// Move to beginning of line and clear
 		fmt.Fprintf(p.output.writer, "\r\033[K")
 	}
+	return true
 }
 
 // formatDuration formats a duration in a human-readable way
@@ -0,0 +1,166 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ui
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is a settable time source for progress indicators
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func TestProgressElapsed(t *testing.T) {
+	newOutput := func(isTTY bool, opts ProgressOptions) (*StandardOutput, *bytes.Buffer) {
+		buf := &bytes.Buffer{}
+		return &StandardOutput{writer: buf, isTTY: isTTY, progressOptions: opts}, buf
+	}
+
+	t.Run("show elapsed time after the configured threshold", func(t *testing.T) {
+		output, buf := newOutput(true, ProgressOptions{ElapsedThreshold: 5 * time.Second})
+		clock := &fakeClock{now: time.Now()}
+		p := newStandardProgress(output, "Waiting for pods", clock.Now)
+
+		clock.now = clock.now.Add(4 * time.Second)
+		assert.True(t, p.tick())
+		assert.NotContains(t, buf.String(), "(4s)")
+
+		clock.now = clock.now.Add(2 * time.Second)
+		assert.True(t, p.tick())
+		assert.True(t, strings.HasSuffix(buf.String(), "Waiting for pods (6s)"), buf.String())
+	})
+
+	t.Run("custom elapsed format", func(t *testing.T) {
+		output, buf := newOutput(true, ProgressOptions{FormatElapsed: func(d time.Duration) string { return d.String() }})
+		clock := &fakeClock{now: time.Now()}
+		p := newStandardProgress(output, "Waiting", clock.Now)
+
+		clock.now = clock.now.Add(1500 * time.Millisecond)
+		p.tick()
+		assert.True(t, strings.HasSuffix(buf.String(), "Waiting (1.5s)"), buf.String())
+	})
+
+	t.Run("periodic updates when not a terminal", func(t *testing.T) {
+		output, buf := newOutput(false, ProgressOptions{ElapsedThreshold: time.Hour, UpdateInterval: 10 * time.Second})
+		clock := &fakeClock{now: time.Now()}
+		p := newStandardProgress(output, "Waiting for pods", clock.Now)
+
+		clock.now = clock.now.Add(5 * time.Second)
+		p.tick()
+		assert.Empty(t, buf.String())
+
+		clock.now = clock.now.Add(5 * time.Second)
+		p.tick()
+		clock.now = clock.now.Add(time.Second)
+		p.tick()
+		clock.now = clock.now.Add(64 * time.Second)
+		p.tick()
+		assert.Equal(t, "  Waiting for pods (10s elapsed)\n  Waiting for pods (1m15s elapsed)\n", buf.String())
+	})
+
+	t.Run("stopped progress doesn't tick", func(t *testing.T) {
+		output, buf := newOutput(false, DefaultProgressOptions())
+		p := newStandardProgress(output, "Waiting", time.Now)
+		p.Success("Done")
+		assert.False(t, p.tick())
+		assert.Equal(t, "✓ Done\n", buf.String())
+	})
+}
+
+// syncBuffer is a buffer safe to write from the spinner goroutine while the test reads it
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestProgressCancellation(t *testing.T) {
+	t.Run("stop and report the cancellation when the context is done", func(t *testing.T) {
+		buf := &syncBuffer{}
+		output := &StandardOutput{writer: buf, progressOptions: DefaultProgressOptions()}
+		ctx, cancel := context.WithCancel(context.Background())
+		p := output.StartProgressContext(ctx, "Waiting for pods").(*standardProgress)
+
+		cancel()
+		require.Eventually(t, func() bool { return buf.String() == "✗ Waiting for pods: cancelled\n" }, time.Second, time.Millisecond, buf.String())
+		assert.False(t, p.tick(), "the spinner is stopped")
+
+		p.Fail("Waiting for pods failed: context canceled")
+		assert.Equal(t, "✗ Waiting for pods: cancelled\n", buf.String(), "the result of a cancelled progress is not printed")
+	})
+
+	t.Run("report the current message", func(t *testing.T) {
+		buf := &syncBuffer{}
+		output := &StandardOutput{writer: buf, progressOptions: DefaultProgressOptions()}
+		ctx, cancel := context.WithCancel(context.Background())
+		p := output.StartProgressContext(ctx, "Waiting")
+
+		p.Update("Waiting for pods")
+		cancel()
+		require.Eventually(t, func() bool { return strings.HasSuffix(buf.String(), "✗ Waiting for pods: cancelled\n") }, time.Second, time.Millisecond, buf.String())
+	})
+
+	t.Run("a completed progress is not cancelled", func(t *testing.T) {
+		buf := &syncBuffer{}
+		output := &StandardOutput{writer: buf, progressOptions: DefaultProgressOptions()}
+		ctx, cancel := context.WithCancel(context.Background())
+		p := output.StartProgressContext(ctx, "Waiting")
+
+		p.Success("Done")
+		cancel()
+		time.Sleep(10 * time.Millisecond)
+		assert.Equal(t, "✓ Done\n", buf.String())
+	})
+
+	t.Run("record the cancellation", func(t *testing.T) {
+		output := NewRecording()
+		ctx, cancel := context.WithCancel(context.Background())
+		output.StartProgressContext(ctx, "Waiting")
+		done := output.StartProgressContext(ctx, "Pulling")
+		done.Success("Pulled")
+
+		cancel()
+		require.Eventually(t, func() bool {
+			output.Progresses[0].mu.Lock()
+			defer output.Progresses[0].mu.Unlock()
+			return output.Progresses[0].Cancelled
+		}, time.Second, time.Millisecond)
+		assert.False(t, output.Progresses[1].Cancelled)
+	})
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// RecordingOutput implements Output by capturing every call, for use in tests
+type RecordingOutput struct {
+	mu sync.Mutex
+
+	Infos      []string
+	Successes  []string
+	Warnings   []string
+	Errors     []string
+	Headers    []string
+	Sections   []string
+	Tables     []RecordedTable
+	Progresses []*RecordingProgress
+	// Confirms are the questions asked with Confirm
+	Confirms []string
+	// ConfirmResponses are returned by Confirm in order, further questions are refused
+	ConfirmResponses []bool
+	// Selects are the questions asked with Select
+	Selects []string
+	// SelectResponses are returned by Select in order, further questions get no choice
+	SelectResponses []int
+	// Streams are the chunks of text passed to Stream
+	Streams []string
+}
+
+// RecordedTable is a table captured by RecordingOutput
+type RecordedTable struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// RecordingProgress implements Progress by capturing every call, for use in tests
+type RecordingProgress struct {
+	mu sync.Mutex
+
+	// Message is the message the progress was started with
+	Message string
+	Updates []string
+	// Result is the message passed to Success or Fail
+	Result string
+	// Succeeded and Failed report how the progress was completed
+	Succeeded bool
+	Failed    bool
+	// Cancelled reports that the context of StartProgressContext was done before the progress completed
+	Cancelled bool
+}
+
+// NewRecording creates an output handler that records all output
+func NewRecording() *RecordingOutput {
+	return &RecordingOutput{}
+}
+
+// Info records an informational message
+func (o *RecordingOutput) Info(format string, args ...interface{}) {
+	o.record(&o.Infos, format, args...)
+}
+
+// Success records a success message
+func (o *RecordingOutput) Success(format string, args ...interface{}) {
+	o.record(&o.Successes, format, args...)
+}
+
+// Warning records a warning message
+func (o *RecordingOutput) Warning(format string, args ...interface{}) {
+	o.record(&o.Warnings, format, args...)
+}
+
+// Error records an error message
+func (o *RecordingOutput) Error(format string, args ...interface{}) {
+	o.record(&o.Errors, format, args...)
+}
+
+// StartProgress records and returns a new progress
+func (o *RecordingOutput) StartProgress(message string) Progress {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	progress := &RecordingProgress{Message: message}
+	o.Progresses = append(o.Progresses, progress)
+	return progress
+}
+
+// StartProgressContext records and returns a new progress, marked as cancelled when ctx is done before it completes
+func (o *RecordingOutput) StartProgressContext(ctx context.Context, message string) Progress {
+	progress := o.StartProgress(message).(*RecordingProgress)
+	context.AfterFunc(ctx, func() {
+		progress.mu.Lock()
+		defer progress.mu.Unlock()
+		if !progress.Succeeded && !progress.Failed {
+			progress.Cancelled = true
+		}
+	})
+	return progress
+}
+
+// Header records a header banner
+func (o *RecordingOutput) Header(text string) {
+	o.record(&o.Headers, "%s", text)
+}
+
+// Section records a section header
+func (o *RecordingOutput) Section(text string) {
+	o.record(&o.Sections, "%s", text)
+}
+
+// Table records a table
+func (o *RecordingOutput) Table(headers []string, rows [][]string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.Tables = append(o.Tables, RecordedTable{Headers: headers, Rows: rows})
+}
+
+// Confirm records the question and returns the next configured response
+func (o *RecordingOutput) Confirm(format string, args ...interface{}) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.Confirms = append(o.Confirms, fmt.Sprintf(format, args...))
+	if len(o.ConfirmResponses) == 0 {
+		return false
+	}
+
+	response := o.ConfirmResponses[0]
+	o.ConfirmResponses = o.ConfirmResponses[1:]
+	return response
+}
+
+// Select records the question and returns the next configured response
+func (o *RecordingOutput) Select(question string, choices []string) int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.Selects = append(o.Selects, question)
+	if len(o.SelectResponses) == 0 {
+		return -1
+	}
+
+	response := o.SelectResponses[0]
+	o.SelectResponses = o.SelectResponses[1:]
+	return response
+}
+
+// Stream records a chunk of streamed text
+func (o *RecordingOutput) Stream(text string) {
+	o.record(&o.Streams, "%s", text)
+}
+
+func (o *RecordingOutput) record(messages *[]string, format string, args ...interface{}) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	*messages = append(*messages, fmt.Sprintf(format, args...))
+}
+
+// Update records a progress message change
+func (p *RecordingProgress) Update(message string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.Updates = append(p.Updates, message)
+}
+
+// Success records the successful completion of the progress
+func (p *RecordingProgress) Success(message string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.Result = message
+	p.Succeeded = true
+}
+
+// Fail records the failure of the progress
+func (p *RecordingProgress) Fail(message string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.Result = message
+	p.Failed = true
+}
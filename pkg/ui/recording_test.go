@@ -0,0 +1,59 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ui
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordingOutput(t *testing.T) {
+	recording := NewRecording()
+	var output Output = recording
+
+	output.Section("Cluster Deployment")
+	output.Success("Applied %d manifest(s)", 3)
+	output.Error("Deployment failed: %v", "timeout")
+
+	progress := FromContext(WithOutput(context.Background(), output)).StartProgress("Waiting for NicClusterPolicy")
+	progress.Update("Still waiting")
+	progress.Fail("NicClusterPolicy not ready")
+
+	assert.Equal(t, []string{"Cluster Deployment"}, recording.Sections)
+	assert.Equal(t, []string{"Applied 3 manifest(s)"}, recording.Successes)
+	assert.Equal(t, []string{"Deployment failed: timeout"}, recording.Errors)
+	assert.Empty(t, recording.Warnings)
+
+	require.Len(t, recording.Progresses, 1)
+	assert.Equal(t, "Waiting for NicClusterPolicy", recording.Progresses[0].Message)
+	assert.Equal(t, []string{"Still waiting"}, recording.Progresses[0].Updates)
+	assert.True(t, recording.Progresses[0].Failed)
+	assert.False(t, recording.Progresses[0].Succeeded)
+	assert.Equal(t, "NicClusterPolicy not ready", recording.Progresses[0].Result)
+}
+
+func TestRecordingConfirm(t *testing.T) {
+	recording := NewRecording()
+	recording.ConfirmResponses = []bool{true}
+
+	assert.True(t, recording.Confirm("first?"))
+	assert.False(t, recording.Confirm("second?"))
+	assert.Equal(t, []string{"first?", "second?"}, recording.Confirms)
+}
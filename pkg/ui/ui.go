@@ -17,10 +17,14 @@
 package ui
 
 import (
+	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
+	"text/tabwriter"
 
 	"golang.org/x/term"
 )
@@ -37,10 +41,21 @@ type Output interface {
 	Error(format string, args ...interface{})
 	// StartProgress starts a progress indicator for a long-running operation
 	StartProgress(message string) Progress
+	// StartProgressContext starts a progress indicator that stops and reports the operation as cancelled when ctx is done
+	StartProgressContext(ctx context.Context, message string) Progress
 	// Header displays a header banner
 	Header(text string)
 	// Section displays a section header
 	Section(text string)
+	// Table displays rows aligned in columns under the given headers
+	Table(headers []string, rows [][]string)
+	// Confirm asks a yes/no question and reports whether it was answered yes.
+	// Without an interactive input the question is refused.
+	Confirm(format string, args ...interface{}) bool
+	// Select asks to pick one of the choices and returns its index, or -1 without an interactive input
+	Select(question string, choices []string) int
+	// Stream displays text as it arrives, without adding a newline
+	Stream(text string)
 }
 
 // Progress represents a long-running operation with progress updates
@@ -55,16 +70,28 @@ type Progress interface {
 
 // StandardOutput implements Output for standard terminal output
 type StandardOutput struct {
-	writer       io.Writer
-	isTTY        bool
-	colorEnabled bool
+	writer          io.Writer
+	reader          *bufio.Reader
+	isTTY           bool
+	colorEnabled    bool
+	progressOptions ProgressOptions
 }
 
-// New creates a standard output handler writing to stdout
+// New creates a standard output handler writing to stdout, reading confirmations from stdin when it is a terminal
 func New() Output {
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		return NewWithIO(os.Stdin, os.Stdout)
+	}
 	return NewWithWriter(os.Stdout)
 }
 
+// NewWithIO creates a standard output handler with a custom writer, reading confirmations from r
+func NewWithIO(r io.Reader, w io.Writer) Output {
+	o := NewWithWriter(w).(*StandardOutput)
+	o.reader = bufio.NewReader(r)
+	return o
+}
+
 // NewWithWriter creates a standard output handler with a custom writer
 func NewWithWriter(w io.Writer) Output {
 	isTTY := false
@@ -73,12 +100,18 @@ func NewWithWriter(w io.Writer) Output {
 	}
 
 	return &StandardOutput{
-		writer:       w,
-		isTTY:        isTTY,
-		colorEnabled: isTTY, // Enable colors only for TTY
+		writer:          w,
+		isTTY:           isTTY,
+		colorEnabled:    isTTY, // Enable colors only for TTY
+		progressOptions: DefaultProgressOptions(),
 	}
 }
 
+// SetProgressOptions changes the options of the progress indicators started afterwards
+func (o *StandardOutput) SetProgressOptions(opts ProgressOptions) {
+	o.progressOptions = opts
+}
+
 // NewSilent creates a silent output handler that discards all output
 func NewSilent() Output {
 	return NewWithWriter(io.Discard)
@@ -127,7 +160,12 @@ func (o *StandardOutput) Error(format string, args ...interface{}) {
 
 // StartProgress starts a progress indicator
 func (o *StandardOutput) StartProgress(message string) Progress {
-	return newProgress(o, message)
+	return newProgress(context.Background(), o, message)
+}
+
+// StartProgressContext starts a progress indicator stopped when ctx is done
+func (o *StandardOutput) StartProgressContext(ctx context.Context, message string) Progress {
+	return newProgress(ctx, o, message)
 }
 
 // Header displays a header banner
@@ -157,3 +195,69 @@ func (o *StandardOutput) Section(text string) {
 	// Underline with dashes
 	fmt.Fprintf(o.writer, "%s\n\n", strings.Repeat("─", len(text)))
 }
+
+// Table displays rows aligned in columns under the given headers
+func (o *StandardOutput) Table(headers []string, rows [][]string) {
+	w := tabwriter.NewWriter(o.writer, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+
+	w.Flush()
+}
+
+// Stream displays text as it arrives, without adding a newline
+func (o *StandardOutput) Stream(text string) {
+	fmt.Fprint(o.writer, text)
+}
+
+// Select lists the numbered choices and reads the number of the chosen one, asking again until the answer
+// is valid. It returns -1 when there is no interactive input or the input ends.
+func (o *StandardOutput) Select(question string, choices []string) int {
+	if o.reader == nil {
+		fmt.Fprintf(o.writer, "%s: no choice (non-interactive)\n", question)
+		return -1
+	}
+
+	fmt.Fprintf(o.writer, "%s\n", question)
+	for i, choice := range choices {
+		fmt.Fprintf(o.writer, "  %d) %s\n", i+1, choice)
+	}
+	for {
+		fmt.Fprintf(o.writer, "Enter a number [1-%d]: ", len(choices))
+		answer, err := o.reader.ReadString('\n')
+		if n, convErr := strconv.Atoi(strings.TrimSpace(answer)); convErr == nil && n >= 1 && n <= len(choices) {
+			return n - 1
+		}
+		if err != nil {
+			fmt.Fprintln(o.writer)
+			return -1
+		}
+		fmt.Fprintf(o.writer, "%q is not a valid choice\n", strings.TrimSpace(answer))
+	}
+}
+
+// Confirm asks a yes/no question, refusing when there is no interactive input
+func (o *StandardOutput) Confirm(format string, args ...interface{}) bool {
+	question := fmt.Sprintf(format, args...)
+	if o.reader == nil {
+		fmt.Fprintf(o.writer, "%s [y/N]: N (non-interactive)\n", question)
+		return false
+	}
+
+	fmt.Fprintf(o.writer, "%s [y/N]: ", question)
+	answer, err := o.reader.ReadString('\n')
+	if err != nil && answer == "" {
+		fmt.Fprintln(o.writer)
+		return false
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 NVIDIA CORPORATION & AFFILIATES
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ui
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfirm(t *testing.T) {
+	t.Run("refuse without interactive input", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		assert.False(t, NewWithWriter(buf).Confirm("Take ownership of %s?", "ConfigMap/first"))
+		assert.Equal(t, "Take ownership of ConfigMap/first? [y/N]: N (non-interactive)\n", buf.String())
+	})
+
+	t.Run("read the answer", func(t *testing.T) {
+		for answer, expected := range map[string]bool{"y\n": true, "Yes\n": true, "n\n": false, "\n": false, "": false} {
+			assert.Equal(t, expected, NewWithIO(strings.NewReader(answer), io.Discard).Confirm("Continue?"), "answer %q", answer)
+		}
+	})
+}
+
+func TestStream(t *testing.T) {
+	buf := &bytes.Buffer{}
+	output := NewWithWriter(buf)
+
+	output.Stream("Assistant: ")
+	output.Stream("partial")
+	output.Stream(" answer")
+	assert.Equal(t, "Assistant: partial answer", buf.String())
+}
+
+func TestSelect(t *testing.T) {
+	choices := []string{"SR-IOV (sriov-ethernet-rdma)", "Host device (host-device-rdma)"}
+
+	t.Run("give no choice without interactive input", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		assert.Equal(t, -1, NewWithWriter(buf).Select("Select the profile", choices))
+		assert.Equal(t, "Select the profile: no choice (non-interactive)\n", buf.String())
+	})
+
+	t.Run("read the number of the choice", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		assert.Equal(t, 1, NewWithIO(strings.NewReader("2\n"), buf).Select("Select the profile", choices))
+		assert.Equal(t, "Select the profile\n  1) SR-IOV (sriov-ethernet-rdma)\n  2) Host device (host-device-rdma)\nEnter a number [1-2]: ", buf.String())
+	})
+
+	t.Run("ask again after an invalid answer", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		assert.Equal(t, 0, NewWithIO(strings.NewReader("3\nsriov\n 1 \n"), buf).Select("Select the profile", choices))
+		assert.Contains(t, buf.String(), `"3" is not a valid choice`)
+		assert.Contains(t, buf.String(), `"sriov" is not a valid choice`)
+	})
+
+	t.Run("give no choice when the input ends", func(t *testing.T) {
+		assert.Equal(t, -1, NewWithIO(strings.NewReader("x"), io.Discard).Select("Select the profile", choices))
+		assert.Equal(t, 1, NewWithIO(strings.NewReader("2"), io.Discard).Select("Select the profile", choices))
+	})
+}